@@ -43,7 +43,7 @@ func init() {
 }
 
 func handleAnnouncements(verbose bool, beforePath, afterPath, repo string) error {
-	runner := spinners.NewRunner(spinners.RunnerOptions{Verbose: verbose})
+	runner := spinners.NewRunner(runnerOptions(verbose))
 
 	// Validate repository name
 	if repo != "Saltbox" && repo != "Sandbox" {
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/saltyorg/sb-go/internal/apt"
+	"github.com/saltyorg/sb-go/internal/executor"
+	"github.com/saltyorg/sb-go/internal/motd"
+	"github.com/saltyorg/sb-go/internal/spinners"
+	"github.com/saltyorg/sb-go/internal/styles"
+
+	"github.com/spf13/cobra"
+)
+
+// aptCmd represents the apt command
+var aptCmd = &cobra.Command{
+	Use:   "apt",
+	Short: "Manage system packages",
+	Long:  `Manage system packages`,
+}
+
+// aptUpgradeCmd represents the apt upgrade command
+var aptUpgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Upgrade system packages",
+	Long: `Upgrade system packages.
+
+Updates the apt package cache and upgrades all installed packages,
+keeping any modified configuration files. Reports afterwards whether
+the upgrade requires a reboot; use --reboot to reboot automatically.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		reboot, _ := cmd.Flags().GetBool("reboot")
+		return handleAptUpgrade(cmd.Context(), verbose, reboot)
+	},
+}
+
+func handleAptUpgrade(ctx context.Context, verbose, reboot bool) error {
+	runner := spinners.NewRunner(runnerOptions(verbose))
+	if err := runner.Run(ctx, spinners.TaskSpec{
+		Running:      "Upgrading system packages",
+		Success:      "System packages upgraded",
+		Failure:      "System package upgrade",
+		ChildDisplay: spinners.RetainChildTasks,
+	}, func(ctx context.Context, task *spinners.Task) error {
+		if err := task.RunStreaming(ctx, spinners.TaskSpec{Running: "Updating apt package cache"}, func(taskCtx context.Context) error {
+			updateCache := apt.UpdatePackageLists(taskCtx, verbose)
+			return updateCache()
+		}); err != nil {
+			return fmt.Errorf("error updating apt cache: %w", err)
+		}
+
+		if err := task.RunStreaming(ctx, spinners.TaskSpec{Running: "Upgrading packages"}, func(taskCtx context.Context) error {
+			upgrade := apt.UpgradePackages(taskCtx, verbose)
+			return upgrade()
+		}); err != nil {
+			return fmt.Errorf("error upgrading packages: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	required, packages := motd.RebootRequired()
+	if !required {
+		fmt.Println(styles.SuccessStyle.Render("No reboot required"))
+		return nil
+	}
+
+	message := "Reboot required"
+	if len(packages) > 0 {
+		message += " by " + strings.Join(packages, ", ")
+	}
+	fmt.Println(styles.WarningStyle.Render(message))
+
+	if !reboot {
+		fmt.Println(styles.DimStyle.Render("Run 'sb apt upgrade --reboot' or reboot manually to finish the upgrade"))
+		return nil
+	}
+
+	fmt.Println("Rebooting...")
+	result, err := executor.Run(ctx, "systemctl",
+		executor.WithArgs("reboot"),
+		executor.WithOutputMode(executor.OutputModeCapture))
+	if err != nil {
+		return result.FormatError("reboot")
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(aptCmd)
+	aptCmd.AddCommand(aptUpgradeCmd)
+	aptCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
+	aptUpgradeCmd.Flags().Bool("reboot", false, "Reboot automatically if the upgrade requires it")
+}
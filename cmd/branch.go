@@ -34,7 +34,7 @@ func init() {
 }
 
 func changeBranch(ctx context.Context, branchName string) error {
-	runner := spinners.NewRunner(spinners.RunnerOptions{})
+	runner := spinners.NewRunner(runnerOptions(false))
 
 	saltboxUser, err := utils.GetSaltboxUser()
 	if err != nil {
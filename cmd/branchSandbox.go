@@ -31,7 +31,7 @@ func init() {
 }
 
 func changeSandboxBranch(ctx context.Context, branchName string) error {
-	runner := spinners.NewRunner(spinners.RunnerOptions{})
+	runner := spinners.NewRunner(runnerOptions(false))
 
 	saltboxUser, err := utils.GetSaltboxUser()
 	if err != nil {
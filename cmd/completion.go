@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,16 +15,21 @@ import (
 
 // completionCmd represents the completion command
 var completionCmd = &cobra.Command{
-	Use:    "completion",
-	Hidden: true,
-	Short:  "Install shell completion for sb",
-	Args:   cobra.NoArgs,
+	Use:   "completion",
+	Short: "Install or print shell completion for sb",
+	Args:  cobra.NoArgs,
 	Long: `Install shell completion scripts for sb.
 
-This command installs completion scripts system-wide on Ubuntu.
-Supported shells: bash, zsh
+The bash and zsh subcommands install completion scripts system-wide on Ubuntu.
+After installation, restart your shell or source the completion file.
 
-After installation, restart your shell or source the completion file.`,
+The script subcommand prints the completion script for bash, zsh, fish or
+powershell to stdout instead, e.g.:
+
+  sb completion script fish > ~/.config/fish/completions/sb.fish
+
+Completions cover the services of sb logs, the containers of
+sb docker stop/restart --ignore and the tags of sb install.`,
 }
 
 // bashCompletionCmd installs bash completion
@@ -53,10 +59,50 @@ After installation, restart your shell or run:
 	},
 }
 
+// completionShells are the shells sb completion script can generate a script for
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+// scriptCompletionCmd prints a completion script to stdout
+var scriptCompletionCmd = &cobra.Command{
+	Use:       "script <shell>",
+	Short:     "Print the completion script for a shell",
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: completionShells,
+	Long: `Prints the completion script for bash, zsh, fish or powershell to stdout.
+
+The script asks sb for completions at runtime, so services, containers and
+tags are always current.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return writeCompletionScript(cmd.OutOrStdout(), args[0], filepath.Base(os.Args[0]))
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(completionCmd)
 	completionCmd.AddCommand(bashCompletionCmd)
 	completionCmd.AddCommand(zshCompletionCmd)
+	completionCmd.AddCommand(scriptCompletionCmd)
+}
+
+// writeCompletionScript writes Cobra's dynamic completion script for shellName to w
+func writeCompletionScript(w io.Writer, shellName, cmdName string) error {
+	// Generate the script for the name sb was invoked as, like the installed scripts
+	originalUse := rootCmd.Use
+	rootCmd.Use = cmdName
+	defer func() { rootCmd.Use = originalUse }()
+
+	switch shellName {
+	case "bash":
+		return rootCmd.GenBashCompletionV2(w, true)
+	case "zsh":
+		return rootCmd.GenZshCompletion(w)
+	case "fish":
+		return rootCmd.GenFishCompletion(w, true)
+	case "powershell":
+		return rootCmd.GenPowerShellCompletionWithDesc(w)
+	default:
+		return fmt.Errorf("unsupported shell %q (valid shells: %s)", shellName, strings.Join(completionShells, ", "))
+	}
 }
 
 // getAllBinaryNames returns the binary name plus all symlinks pointing to it
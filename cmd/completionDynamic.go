@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/moby/moby/client"
+	"github.com/spf13/cobra"
+)
+
+// completionTimeout bounds the discovery done for a single completion request,
+// so a slow systemd or Docker daemon doesn't hang the shell
+const completionTimeout = 5 * time.Second
+
+// completeServiceNames completes the service argument of sb logs with the
+// managed systemd services that sb logs lists
+func completeServiceNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), completionTimeout)
+	defer cancel()
+
+	services, err := listLogServices(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	names := make([]string, len(services))
+	for i, service := range services {
+		names[i] = service.Name
+	}
+	return filterCompletions(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeContainerNames completes comma-separated container names for flags such as --ignore
+func completeContainerNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ctx, cancel := context.WithTimeout(cmd.Context(), completionTimeout)
+	defer cancel()
+
+	cli, err := client.New(client.FromEnv)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	defer func() { _ = cli.Close() }()
+
+	containers, err := cli.ContainerList(ctx, client.ContainerListOptions{All: true})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	names := make([]string, len(containers.Items))
+	for i, c := range containers.Items {
+		names[i] = containerDisplayName(c.ID, c.Names)
+	}
+	return filterCompletions(names, toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// completeInstallTags completes the tags of sb install, leaving out tags that were already given
+func completeInstallTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	tags, err := loadCompletionTags(cmd.Context())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var given []string
+	for _, arg := range args {
+		given = append(given, strings.Split(arg, ",")...)
+	}
+	tags = slices.DeleteFunc(tags, func(tag string) bool { return slices.Contains(given, tag) })
+	return filterCompletions(tags, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeSkipTags completes comma-separated tags for the --skip-tags flag of sb install
+func completeSkipTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	tags, err := loadCompletionTags(cmd.Context())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return filterCompletions(tags, toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// filterCompletions returns the candidates matching the last element of a
+// comma-separated toComplete, prefixed with the elements before it. Candidates
+// that are already in the list are left out, and duplicates are dropped.
+func filterCompletions(candidates []string, toComplete string) []string {
+	prefix, current := "", toComplete
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix, current = toComplete[:i+1], toComplete[i+1:]
+	}
+	listed := strings.Split(strings.TrimSuffix(prefix, ","), ",")
+
+	var matches []string
+	for _, candidate := range candidates {
+		if !strings.HasPrefix(candidate, current) || slices.Contains(listed, candidate) {
+			continue
+		}
+		if completion := prefix + candidate; !slices.Contains(matches, completion) {
+			matches = append(matches, completion)
+		}
+	}
+	slices.Sort(matches)
+	return matches
+}
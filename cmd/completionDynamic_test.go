@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFilterCompletions(t *testing.T) {
+	candidates := []string{"sonarr", "radarr", "sabnzbd", "sonarr", "plex"}
+
+	tests := []struct {
+		name       string
+		toComplete string
+		want       []string
+	}{
+		{"empty", "", []string{"plex", "radarr", "sabnzbd", "sonarr"}},
+		{"prefix", "s", []string{"sabnzbd", "sonarr"}},
+		{"no match", "x", nil},
+		{"after comma", "plex,s", []string{"plex,sabnzbd", "plex,sonarr"}},
+		{"skips listed", "sonarr,plex,", []string{"sonarr,plex,radarr", "sonarr,plex,sabnzbd"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterCompletions(candidates, tt.toComplete)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("filterCompletions(%q) = %v, want %v", tt.toComplete, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteCompletionScript(t *testing.T) {
+	for _, shell := range completionShells {
+		t.Run(shell, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeCompletionScript(&buf, shell, "sb2"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(buf.String(), "sb2") {
+				t.Errorf("%s script doesn't mention the command name", shell)
+			}
+		})
+	}
+
+	if rootCmd.Use != "sb" {
+		t.Errorf("rootCmd.Use = %q, want it restored to sb", rootCmd.Use)
+	}
+	if err := writeCompletionScript(&bytes.Buffer{}, "tcsh", "sb"); err == nil {
+		t.Error("expected an error for an unsupported shell")
+	}
+}
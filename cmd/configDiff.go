@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/saltyorg/sb-go/internal/constants"
+	"github.com/saltyorg/sb-go/internal/setup"
+	"github.com/saltyorg/sb-go/internal/styles"
+
+	"github.com/spf13/cobra"
+)
+
+var configDiffCmd = &cobra.Command{
+	Use:   "diff [file]",
+	Short: "Show settings that differ from the upstream defaults",
+	Long: "Compare Saltbox configuration files with their upstream .default templates.\n" +
+		"Lists settings that are new in the defaults and settings that are no longer used.\n" +
+		"Values are not compared, so your own settings are never reported as differences.",
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: configFileNames(),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		diffs, err := setup.DiffDefaultConfigFiles(constants.SaltboxRepoPath)
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 1 {
+			file, err := lookupConfigFile(args[0])
+			if err != nil {
+				return err
+			}
+			diffs = filterConfigDiffs(diffs, file.path)
+			if len(diffs) == 0 {
+				return fmt.Errorf("%s does not exist or has no default template", file.path)
+			}
+		}
+
+		fmt.Print(formatConfigDiffs(diffs))
+		return nil
+	},
+}
+
+// filterConfigDiffs returns the diffs of the config file at path
+func filterConfigDiffs(diffs []setup.ConfigDiff, path string) []setup.ConfigDiff {
+	var filtered []setup.ConfigDiff
+	for _, diff := range diffs {
+		if diff.Path == path {
+			filtered = append(filtered, diff)
+		}
+	}
+	return filtered
+}
+
+// formatConfigDiffs renders the diffs with new settings prefixed by + and unused settings by -
+func formatConfigDiffs(diffs []setup.ConfigDiff) string {
+	var b strings.Builder
+	for _, diff := range diffs {
+		name := filepath.Base(diff.Path)
+		if diff.InSync() {
+			b.WriteString(styles.DimStyle.Render(name+": in sync with defaults") + "\n")
+			continue
+		}
+
+		b.WriteString(styles.HeaderStyle.Render(name+":") + "\n")
+		for _, key := range diff.Added {
+			b.WriteString(styles.SuccessStyle.Render(formatConfigKey("+", key)) + "\n")
+		}
+		for _, key := range diff.Removed {
+			b.WriteString(styles.WarningStyle.Render(formatConfigKey("-", key)) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// formatConfigKey renders a setting as a single line, or as an indented block for nested values
+func formatConfigKey(marker string, key setup.ConfigKey) string {
+	if !strings.Contains(key.Value, "\n") {
+		return fmt.Sprintf("  %s %s: %s", marker, key.Path, key.Value)
+	}
+	indented := strings.ReplaceAll(key.Value, "\n", "\n      ")
+	return fmt.Sprintf("  %s %s:\n      %s", marker, key.Path, indented)
+}
+
+func init() {
+	configManageCmd.AddCommand(configDiffCmd)
+}
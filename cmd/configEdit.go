@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/saltyorg/sb-go/internal/config"
+	"github.com/saltyorg/sb-go/internal/constants"
+	"github.com/saltyorg/sb-go/internal/executor"
+	"github.com/saltyorg/sb-go/internal/spinners"
+	"github.com/saltyorg/sb-go/internal/tty"
+	"github.com/saltyorg/sb-go/internal/utils"
+	"github.com/saltyorg/sb-go/internal/validate"
+
+	"github.com/spf13/cobra"
+)
+
+// configFile is a Saltbox configuration file that can be edited with sb config edit
+type configFile struct {
+	name string
+	path string
+}
+
+// configFiles lists the editable configuration files by the name used on the command line
+var configFiles = []configFile{
+	{name: "accounts", path: constants.SaltboxAccountsConfigPath},
+	{name: "settings", path: constants.SaltboxSettingsConfigPath},
+	{name: "adv_settings", path: constants.SaltboxAdvancedSettingsConfigPath},
+	{name: "backup_config", path: constants.SaltboxBackupConfigPath},
+	{name: "hetzner_vlan", path: constants.SaltboxHetznerVLANConfigPath},
+	{name: "inventory", path: constants.SaltboxInventoryConfigPath},
+	{name: "motd", path: constants.SaltboxMOTDConfigPath},
+}
+
+// configManageCmd groups commands operating on Saltbox configuration files
+var configManageCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage Saltbox configuration files",
+}
+
+var configEditCmd = &cobra.Command{
+	Use:       "edit <file>",
+	Short:     "Edit a Saltbox configuration file and validate it on save",
+	Long:      "Open a Saltbox configuration file in $EDITOR ($VISUAL, or nano if neither is set) and validate it when the editor exits.\nInvalid changes are only kept after confirmation.\n\nFiles: " + strings.Join(configFileNames(), ", "),
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: configFileNames(),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := lookupConfigFile(args[0])
+		if err != nil {
+			return err
+		}
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		return editConfigFile(cmd.Context(), file, verbose)
+	},
+}
+
+// configFileNames returns the names accepted by sb config edit
+func configFileNames() []string {
+	names := make([]string, len(configFiles))
+	for i, file := range configFiles {
+		names[i] = file.name
+	}
+	return names
+}
+
+// lookupConfigFile returns the configuration file with the given name.
+// The file name itself (e.g. settings.yml) is accepted too.
+func lookupConfigFile(name string) (configFile, error) {
+	i := slices.IndexFunc(configFiles, func(file configFile) bool {
+		return file.name == name || filepath.Base(file.path) == name
+	})
+	if i < 0 {
+		return configFile{}, fmt.Errorf("unknown configuration file %q (valid files: %s)", name, strings.Join(configFileNames(), ", "))
+	}
+	file := configFiles[i]
+	// The MOTD config can be moved with motd_config in the sb config
+	if file.name == "motd" {
+		file.path = config.SB().MOTDConfig
+	}
+	return file, nil
+}
+
+// editConfigFile runs the edit-validate loop for a configuration file.
+// If validation fails the user can reopen the editor, keep the invalid file, or revert to the original content.
+func editConfigFile(ctx context.Context, file configFile, verbose bool) error {
+	original, err := os.ReadFile(file.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("configuration file does not yet exist: %s", file.path)
+		}
+		return fmt.Errorf("failed to read %s: %w", file.path, err)
+	}
+
+	for {
+		if err := runEditorAsInvokingUser(ctx, file.path); err != nil {
+			return err
+		}
+
+		edited, err := os.ReadFile(file.path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file.path, err)
+		}
+		if bytes.Equal(edited, original) {
+			fmt.Println("No changes made")
+			return nil
+		}
+
+		validationErr := validateConfigFile(ctx, file, verbose)
+		if validationErr == nil {
+			return nil
+		}
+
+		reopen, err := tty.Confirm(fmt.Sprintf("%s is invalid. Reopen the editor to fix it?", filepath.Base(file.path)), false)
+		if err != nil {
+			return err
+		}
+		if reopen {
+			continue
+		}
+
+		keep, err := tty.Confirm("Keep the invalid changes anyway?", false)
+		if err != nil {
+			return err
+		}
+		if keep {
+			fmt.Printf("Kept invalid changes in %s\n", file.path)
+			return validationErr
+		}
+
+		// WriteFile truncates the existing file, so its ownership and permissions are preserved
+		if err := os.WriteFile(file.path, original, 0644); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", file.path, err)
+		}
+		fmt.Printf("Reverted %s to its original content\n", file.path)
+		return nil
+	}
+}
+
+// validateConfigFile runs the Saltbox validators against a single configuration file
+func validateConfigFile(ctx context.Context, file configFile, verbose bool) error {
+	name := filepath.Base(file.path)
+	runner := spinners.NewRunner(runnerOptions(verbose))
+	return runner.Run(ctx, spinners.TaskSpec{
+		Running:      fmt.Sprintf("Validating %s", name),
+		Success:      fmt.Sprintf("%s is valid", name),
+		Failure:      fmt.Sprintf("Validation of %s", name),
+		ChildDisplay: spinners.RetainChildTasks,
+	}, func(ctx context.Context, task *spinners.Task) error {
+		return validate.SaltboxConfig(ctx, task, file.path, verbose)
+	})
+}
+
+// runEditorAsInvokingUser opens path in the user's editor.
+// sb runs as root, so the editor is started as the user who invoked sudo to
+// keep their editor configuration and leave file ownership untouched.
+func runEditorAsInvokingUser(ctx context.Context, path string) error {
+	editor, err := resolveEditor()
+	if err != nil {
+		return err
+	}
+	var invokingUser string
+	if os.Geteuid() == 0 {
+		u, err := utils.LookupInvokingUser()
+		switch {
+		case errors.Is(err, utils.ErrNoInvokingUser):
+			fmt.Println("Warning: sb was run as root without sudo, so the editor runs as root")
+		case err != nil:
+			return err
+		default:
+			invokingUser = u.Name
+		}
+	}
+	command := invokingUserCommand(append(editor, path), os.Geteuid(), invokingUser)
+
+	if _, err := executor.Run(ctx, command[0],
+		executor.WithArgs(command[1:]...),
+		executor.WithOutputMode(executor.OutputModeInteractive),
+	); err != nil {
+		return fmt.Errorf("error opening editor: %w", err)
+	}
+	return nil
+}
+
+// invokingUserCommand wraps command in sudo -u when running as root on behalf of another user
+func invokingUserCommand(command []string, euid int, sudoUser string) []string {
+	if euid != 0 || sudoUser == "" || sudoUser == "root" {
+		return command
+	}
+	return append([]string{"sudo", "-H", "-u", sudoUser, "--"}, command...)
+}
+
+func init() {
+	rootCmd.AddCommand(configManageCmd)
+	configManageCmd.AddCommand(configEditCmd)
+	configEditCmd.Flags().BoolP("verbose", "v", false, "Enable verbose output")
+}
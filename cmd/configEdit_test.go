@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/saltyorg/sb-go/internal/constants"
+)
+
+func TestLookupConfigFile(t *testing.T) {
+	for _, name := range []string{"settings", "settings.yml"} {
+		file, err := lookupConfigFile(name)
+		if err != nil {
+			t.Fatalf("lookupConfigFile(%q) unexpected error: %v", name, err)
+		}
+		if file.path != constants.SaltboxSettingsConfigPath {
+			t.Errorf("lookupConfigFile(%q) path = %s, want %s", name, file.path, constants.SaltboxSettingsConfigPath)
+		}
+	}
+
+	if _, err := lookupConfigFile("docker"); err == nil {
+		t.Error("expected error for unknown configuration file")
+	}
+}
+
+func TestResolveEditor(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"vim", "nano"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir)
+
+	tests := []struct {
+		name   string
+		editor string
+		visual string
+		want   []string
+	}{
+		{name: "editor", editor: "vim -n", visual: "nano", want: []string{filepath.Join(dir, "vim"), "-n"}},
+		{name: "visual", visual: "vim", want: []string{filepath.Join(dir, "vim")}},
+		{name: "fallback", want: []string{filepath.Join(dir, "nano")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("EDITOR", tt.editor)
+			t.Setenv("VISUAL", tt.visual)
+
+			got, err := resolveEditor()
+			if err != nil {
+				t.Fatalf("resolveEditor() unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("resolveEditor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Setenv("EDITOR", "emacs")
+	if _, err := resolveEditor(); err == nil {
+		t.Error("expected error for editor missing from PATH")
+	}
+}
+
+func TestInvokingUserCommand(t *testing.T) {
+	command := []string{"/usr/bin/nano", "/srv/git/saltbox/settings.yml"}
+
+	got := invokingUserCommand(command, 0, "seed")
+	want := []string{"sudo", "-H", "-u", "seed", "--", "/usr/bin/nano", "/srv/git/saltbox/settings.yml"}
+	if !slices.Equal(got, want) {
+		t.Errorf("invokingUserCommand() as root = %q, want %q", got, want)
+	}
+
+	for _, tt := range []struct {
+		euid     int
+		sudoUser string
+	}{{0, ""}, {0, "root"}, {1000, "seed"}} {
+		if got := invokingUserCommand(command, tt.euid, tt.sudoUser); !slices.Equal(got, command) {
+			t.Errorf("invokingUserCommand(euid=%d, SUDO_USER=%q) = %q, want unchanged", tt.euid, tt.sudoUser, got)
+		}
+	}
+}
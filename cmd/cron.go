@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/motd"
+	"github.com/saltyorg/sb-go/internal/styles"
+	"github.com/saltyorg/sb-go/internal/systemd"
+
+	"github.com/aquasecurity/table"
+	"github.com/spf13/cobra"
+)
+
+// cronCmd represents the cron command
+var cronCmd = &cobra.Command{
+	Use:   "cron",
+	Short: "Inspect Saltbox scheduled tasks",
+}
+
+// cronListCmd represents the cron list command
+var cronListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the systemd timers of Saltbox managed services",
+	Long: `Lists the systemd timers that trigger Saltbox managed services, such as
+backups and cache cleanups, with their next and last run times.
+
+Timers that are past their next run are marked overdue, and timers whose
+service failed on its last run are marked failed. Extra service prefixes
+from the systemd section of motd.yml are included, as with sb logs.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		defer cancel()
+
+		var prefixes []string
+		if systemdCfg := motd.LoadSystemdConfig(); systemdCfg != nil {
+			prefixes = systemdCfg.LogPrefixes
+		}
+
+		timers, err := systemd.GetFilteredTimers(ctx, systemd.FiltersWithPrefixes(prefixes))
+		if err != nil {
+			return err
+		}
+		if len(timers) == 0 {
+			fmt.Println("No Saltbox managed timers found.")
+			return nil
+		}
+
+		sort.Slice(timers, func(i, j int) bool {
+			return timers[i].Name < timers[j].Name
+		})
+
+		now := time.Now()
+		t := table.New(cmd.OutOrStdout())
+		t.SetHeaders("Timer", "Service", "Next", "Left", "Last", "Passed", "Status")
+		t.SetHeaderStyle(table.StyleBold)
+		t.SetAlignment(table.AlignLeft, table.AlignLeft, table.AlignLeft, table.AlignLeft, table.AlignLeft, table.AlignLeft, table.AlignLeft)
+		t.SetBorders(true)
+		t.SetRowLines(true)
+		t.SetDividers(table.UnicodeRoundedDividers)
+		t.SetLineStyle(table.StyleBlue)
+		t.SetPadding(1)
+
+		for _, timer := range timers {
+			next, left := formatTimerTime(timer.Next, now)
+			last, passed := formatTimerTime(timer.Last, now)
+			t.AddRow(timer.Name, timer.Service, next, left, last, passed, timerStatus(timer, now))
+		}
+		t.Render()
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cronCmd)
+	cronCmd.AddCommand(cronListCmd)
+}
+
+// formatTimerTime renders a timer timestamp and its distance from now, or n/a for unset timestamps
+func formatTimerTime(at, now time.Time) (string, string) {
+	if at.IsZero() {
+		return "n/a", "n/a"
+	}
+	d := at.Sub(now)
+	if d < 0 {
+		d = -d
+	}
+	return at.Format("2006-01-02 15:04:05"), systemd.FormatDuration(d)
+}
+
+// timerStatus returns the colored status of a timer, with failures taking precedence over overdue runs
+func timerStatus(timer systemd.TimerInfo, now time.Time) string {
+	switch {
+	case timer.Failed():
+		return styles.ErrorStyle.Render("failed")
+	case timer.Overdue(now):
+		return styles.WarningStyle.Render("overdue")
+	case timer.Next.IsZero():
+		return styles.WarningStyle.Render("not scheduled")
+	default:
+		return styles.SuccessStyle.Render("ok")
+	}
+}
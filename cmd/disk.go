@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/spinners"
+	"github.com/saltyorg/sb-go/internal/styles"
+
+	"github.com/aquasecurity/table"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+// diskUsageEntry holds the total size of a directory below the scanned mount
+type diskUsageEntry struct {
+	path string
+	size int64
+}
+
+// diskUsageResult holds the outcome of a directory size scan
+type diskUsageResult struct {
+	total      int64
+	entries    []diskUsageEntry
+	unreadable int
+	partial    bool
+}
+
+// diskCmd represents the disk command
+var diskCmd = &cobra.Command{
+	Use:   "disk",
+	Short: "Inspect disk usage",
+}
+
+// diskUsageCmd represents the disk usage command
+var diskUsageCmd = &cobra.Command{
+	Use:   "usage <mount>",
+	Short: "Show the largest directories on a mount",
+	Long: `Shows the largest directories below a mount point, to find out what is
+filling a disk flagged by the MOTD.
+
+Directory sizes include everything below them, like du. The scan stays on
+the filesystem of the given path and lists directories up to --depth levels
+deep. Scanning a large filesystem can take a while, so it stops after
+--timeout and shows the sizes counted so far.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeDirectories,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		top, _ := cmd.Flags().GetInt("top")
+		depth, _ := cmd.Flags().GetInt("depth")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		if top < 1 {
+			return fmt.Errorf("invalid --top value: %d (must be at least 1)", top)
+		}
+		if depth < 1 {
+			return fmt.Errorf("invalid --depth value: %d (must be at least 1)", depth)
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("invalid --timeout value: %s (must be greater than 0)", timeout)
+		}
+
+		root, err := filepath.Abs(args[0])
+		if err != nil {
+			return err
+		}
+		info, err := os.Stat(root)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("%s is not a directory", root)
+		}
+
+		var result diskUsageResult
+		runner := spinners.NewRunner(runnerOptions(false))
+		if err := runner.Run(cmd.Context(), spinners.TaskSpec{
+			Running: fmt.Sprintf("Scanning %s", root),
+			Success: fmt.Sprintf("Scanned %s", root),
+			Failure: fmt.Sprintf("Scanning %s", root),
+		}, func(ctx context.Context, _ *spinners.Task) error {
+			scanCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			result, err = scanDiskUsage(scanCtx, root, depth)
+			return err
+		}); err != nil {
+			return err
+		}
+
+		if result.partial {
+			fmt.Println(styles.WarningStyle.Render(fmt.Sprintf("Scan stopped after %s, sizes are incomplete", timeout)))
+		}
+		if result.unreadable > 0 {
+			fmt.Println(styles.WarningStyle.Render(fmt.Sprintf("Skipped %d unreadable directories, their sizes are not included", result.unreadable)))
+		}
+		if len(result.entries) == 0 {
+			fmt.Printf("No directories found below %s.\n", root)
+			return nil
+		}
+
+		t := table.New(cmd.OutOrStdout())
+		t.SetHeaders("Directory", "Size", "Share")
+		t.SetHeaderStyle(table.StyleBold)
+		t.SetAlignment(table.AlignLeft, table.AlignRight, table.AlignRight)
+		t.SetBorders(true)
+		t.SetRowLines(false)
+		t.SetDividers(table.UnicodeRoundedDividers)
+		t.SetLineStyle(table.StyleBlue)
+		t.SetPadding(1)
+
+		for _, entry := range topDiskUsage(result.entries, top) {
+			t.AddRow(entry.path, units.HumanSize(float64(entry.size)), formatUsageShare(entry.size, result.total))
+		}
+		t.Render()
+		fmt.Printf("Total: %s\n", units.HumanSize(float64(result.total)))
+		return nil
+	},
+}
+
+// scanDiskUsage walks root without crossing into other filesystems and totals the
+// allocated size of every directory up to depth levels below it. Hard linked files are
+// counted once. When ctx expires the sizes counted so far are returned as a partial result.
+func scanDiskUsage(ctx context.Context, root string, depth int) (diskUsageResult, error) {
+	var result diskUsageResult
+
+	rootInfo, err := os.Lstat(root)
+	if err != nil {
+		return result, err
+	}
+	rootDevice, _ := fileDevice(rootInfo)
+
+	type inode struct{ dev, ino uint64 }
+	seen := make(map[inode]bool)
+	sizes := make(map[string]int64)
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			// Unreadable directories are skipped so the rest of the mount is still counted
+			if d != nil && d.IsDir() {
+				result.unreadable++
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		stat, ok := info.Sys().(*syscall.Stat_t)
+		if d.IsDir() && path != root && ok && uint64(stat.Dev) != rootDevice {
+			return filepath.SkipDir
+		}
+
+		size := info.Size()
+		if ok {
+			if !d.IsDir() && stat.Nlink > 1 {
+				key := inode{uint64(stat.Dev), stat.Ino}
+				if seen[key] {
+					return nil
+				}
+				seen[key] = true
+			}
+			size = stat.Blocks * 512
+		}
+
+		result.total += size
+		rel, err := filepath.Rel(root, path)
+		if err != nil || rel == "." {
+			return nil
+		}
+
+		// Credit the size to every directory up to depth levels below root that holds the path
+		parts := strings.Split(rel, string(filepath.Separator))
+		if !d.IsDir() {
+			parts = parts[:len(parts)-1]
+		}
+		for i := 1; i <= len(parts) && i <= depth; i++ {
+			sizes[filepath.Join(root, filepath.Join(parts[:i]...))] += size
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, context.DeadlineExceeded) {
+			return result, err
+		}
+		result.partial = true
+	}
+
+	for path, size := range sizes {
+		result.entries = append(result.entries, diskUsageEntry{path: path, size: size})
+	}
+	return result, nil
+}
+
+// fileDevice returns the device number of the filesystem holding the file
+func fileDevice(info fs.FileInfo) (uint64, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+	return uint64(stat.Dev), true
+}
+
+// topDiskUsage returns the n largest entries, largest first
+func topDiskUsage(entries []diskUsageEntry, n int) []diskUsageEntry {
+	sorted := append([]diskUsageEntry(nil), entries...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].size != sorted[j].size {
+			return sorted[i].size > sorted[j].size
+		}
+		return sorted[i].path < sorted[j].path
+	})
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// formatUsageShare formats size as a percentage of total
+func formatUsageShare(size, total int64) string {
+	if total <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", float64(size)/float64(total)*100)
+}
+
+// completeDirectories completes the mount argument with directory names
+func completeDirectories(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return nil, cobra.ShellCompDirectiveFilterDirs
+}
+
+func init() {
+	rootCmd.AddCommand(diskCmd)
+	diskCmd.AddCommand(diskUsageCmd)
+
+	diskUsageCmd.Flags().IntP("top", "n", 10, "Number of directories to show")
+	diskUsageCmd.Flags().IntP("depth", "d", 1, "How many levels below the mount to list directories for")
+	diskUsageCmd.Flags().Duration("timeout", 2*time.Minute, "Stop scanning after this long and show partial results")
+}
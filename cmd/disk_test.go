@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func writeSizedFile(t *testing.T, path string, size int) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanDiskUsage(t *testing.T) {
+	root := t.TempDir()
+	writeSizedFile(t, filepath.Join(root, "media", "movies", "a.mkv"), 256*1024)
+	writeSizedFile(t, filepath.Join(root, "media", "tv", "b.mkv"), 64*1024)
+	writeSizedFile(t, filepath.Join(root, "downloads", "c.part"), 128*1024)
+	writeSizedFile(t, filepath.Join(root, "top.txt"), 4096)
+
+	// A hard link must not be counted twice
+	if err := os.Link(filepath.Join(root, "downloads", "c.part"), filepath.Join(root, "downloads", "c.link")); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := scanDiskUsage(context.Background(), root, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.partial {
+		t.Fatal("expected a complete scan")
+	}
+
+	var paths []string
+	sizes := make(map[string]int64)
+	for _, entry := range result.entries {
+		paths = append(paths, entry.path)
+		sizes[entry.path] = entry.size
+	}
+	slices.Sort(paths)
+	want := []string{filepath.Join(root, "downloads"), filepath.Join(root, "media")}
+	if !slices.Equal(paths, want) {
+		t.Fatalf("entries = %v, want %v", paths, want)
+	}
+
+	media, downloads := sizes[want[1]], sizes[want[0]]
+	if media < 320*1024 || downloads < 128*1024 || downloads >= 256*1024 {
+		t.Errorf("unexpected sizes: media=%d downloads=%d", media, downloads)
+	}
+	if result.total < media+downloads {
+		t.Errorf("total %d is smaller than its directories", result.total)
+	}
+
+	deeper, err := scanDiskUsage(context.Background(), root, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deeper.entries) != 4 {
+		t.Errorf("expected 4 entries at depth 2, got %d", len(deeper.entries))
+	}
+}
+
+func TestScanDiskUsageTimeout(t *testing.T) {
+	root := t.TempDir()
+	writeSizedFile(t, filepath.Join(root, "a", "file"), 1024)
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	result, err := scanDiskUsage(ctx, root, 1)
+	if err != nil {
+		t.Fatalf("expected a partial result, got error: %v", err)
+	}
+	if !result.partial {
+		t.Error("expected the scan to be marked partial")
+	}
+}
+
+func TestTopDiskUsage(t *testing.T) {
+	entries := []diskUsageEntry{
+		{path: "/mnt/b", size: 10},
+		{path: "/mnt/a", size: 30},
+		{path: "/mnt/c", size: 10},
+		{path: "/mnt/d", size: 5},
+	}
+
+	got := topDiskUsage(entries, 3)
+	want := []diskUsageEntry{{"/mnt/a", 30}, {"/mnt/b", 10}, {"/mnt/c", 10}}
+	if !slices.Equal(got, want) {
+		t.Errorf("topDiskUsage = %v, want %v", got, want)
+	}
+	if entries[0].path != "/mnt/b" {
+		t.Error("topDiskUsage must not reorder its input")
+	}
+}
+
+func TestFormatUsageShare(t *testing.T) {
+	if got := formatUsageShare(25, 200); got != "12.5%" {
+		t.Errorf("formatUsageShare = %q, want 12.5%%", got)
+	}
+	if got := formatUsageShare(1, 0); got != "-" {
+		t.Errorf("formatUsageShare with no total = %q, want -", got)
+	}
+}
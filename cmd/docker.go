@@ -240,4 +240,5 @@ func init() {
 	dockerCmd.AddCommand(stopCmd)
 	dockerCmd.AddCommand(restartCmd)
 	dockerCmd.AddCommand(psCmd)
+	dockerCmd.AddCommand(dockerPruneCmd)
 }
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// dockerLogPrefsPath stores the docker logs viewer column preferences between sessions
+const dockerLogPrefsPath = "/var/cache/sb/docker-logs.json"
+
+// logColumnMode selects which of the timestamp and stream columns are shown before a log message
+type logColumnMode int
+
+const (
+	logColumnsBoth logColumnMode = iota
+	logColumnsTimestamp
+	logColumnsStream
+	logColumnsNone
+)
+
+// logColumnModeNames are the --columns values, in the order the toggle cycles through them
+var logColumnModeNames = []string{"both", "timestamp", "stream", "none"}
+
+// parseLogColumnMode parses a --columns value
+func parseLogColumnMode(value string) (logColumnMode, error) {
+	index := slices.Index(logColumnModeNames, strings.ToLower(strings.TrimSpace(value)))
+	if index < 0 {
+		return logColumnsBoth, fmt.Errorf("invalid --columns value %q (must be one of: %s)", value, strings.Join(logColumnModeNames, ", "))
+	}
+	return logColumnMode(index), nil
+}
+
+func (m logColumnMode) String() string {
+	if m < 0 || int(m) >= len(logColumnModeNames) {
+		return logColumnModeNames[logColumnsBoth]
+	}
+	return logColumnModeNames[m]
+}
+
+// next returns the mode following m in the toggle cycle
+func (m logColumnMode) next() logColumnMode {
+	return (m + 1) % logColumnMode(len(logColumnModeNames))
+}
+
+// dockerLogColumns controls the columns shown before each container log message
+type dockerLogColumns struct {
+	mode          logColumnMode
+	compactStream bool // Show the stream as O/E instead of stdout/stderr
+}
+
+func (c dockerLogColumns) showTimestamp() bool {
+	return c.mode == logColumnsBoth || c.mode == logColumnsTimestamp
+}
+
+func (c dockerLogColumns) showStream() bool {
+	return c.mode == logColumnsBoth || c.mode == logColumnsStream
+}
+
+// streamLabel formats the stream column, padded so the separator lines up
+func (c dockerLogColumns) streamLabel(stream string) string {
+	if !c.compactStream {
+		return fmt.Sprintf("%6s", stream)
+	}
+	switch stream {
+	case "stdout":
+		return "O"
+	case "stderr":
+		return "E"
+	default:
+		return " "
+	}
+}
+
+// describe summarizes the columns for the status line, e.g. "both, compact stream"
+func (c dockerLogColumns) describe() string {
+	if c.compactStream && c.showStream() {
+		return c.mode.String() + ", compact stream"
+	}
+	return c.mode.String()
+}
+
+// dockerLogPrefs is the persisted form of dockerLogColumns
+type dockerLogPrefs struct {
+	Columns       string `json:"columns"`
+	CompactStream bool   `json:"compact_stream"`
+}
+
+// loadDockerLogColumns reads the saved column preferences, returning the defaults if they're missing or invalid
+func loadDockerLogColumns(path string) dockerLogColumns {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return dockerLogColumns{}
+	}
+	var prefs dockerLogPrefs
+	if err := json.Unmarshal(data, &prefs); err != nil {
+		return dockerLogColumns{}
+	}
+	mode, err := parseLogColumnMode(prefs.Columns)
+	if err != nil {
+		return dockerLogColumns{}
+	}
+	return dockerLogColumns{mode: mode, compactStream: prefs.CompactStream}
+}
+
+// saveDockerLogColumns writes the column preferences, creating the directory if needed
+func saveDockerLogColumns(path string, columns dockerLogColumns) error {
+	data, err := json.Marshal(dockerLogPrefs{Columns: columns.mode.String(), CompactStream: columns.compactStream})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseLogColumnMode(t *testing.T) {
+	for i, name := range logColumnModeNames {
+		mode, err := parseLogColumnMode(name)
+		if err != nil || mode != logColumnMode(i) {
+			t.Errorf("parseLogColumnMode(%q) = %v, %v", name, mode, err)
+		}
+	}
+	if mode, err := parseLogColumnMode(" Stream "); err != nil || mode != logColumnsStream {
+		t.Errorf("parseLogColumnMode is not case and space insensitive: %v, %v", mode, err)
+	}
+	if _, err := parseLogColumnMode("stdout"); err == nil {
+		t.Error("parseLogColumnMode accepted an invalid value")
+	}
+
+	if got := logColumnsNone.next(); got != logColumnsBoth {
+		t.Errorf("next after none = %v, want both", got)
+	}
+}
+
+func TestFormatDockerLogEntryColumns(t *testing.T) {
+	entry := dockerLogEntry{timestamp: "2025-06-01T12:00:00.000000000Z", stream: "stderr", message: "failed"}
+
+	tests := []struct {
+		columns dockerLogColumns
+		want    string
+	}{
+		{dockerLogColumns{mode: logColumnsBoth}, "2025-06-01T12:00:00.000000000Z stderr │ failed"},
+		{dockerLogColumns{mode: logColumnsBoth, compactStream: true}, "2025-06-01T12:00:00.000000000Z E │ failed"},
+		{dockerLogColumns{mode: logColumnsTimestamp, compactStream: true}, "2025-06-01T12:00:00.000000000Z │ failed"},
+		{dockerLogColumns{mode: logColumnsStream}, "stderr │ failed"},
+		{dockerLogColumns{mode: logColumnsNone}, "failed"},
+	}
+	for _, tt := range tests {
+		if got := formatDockerLogEntry(entry, tt.columns, logJSONFormat{}, true); got != tt.want {
+			t.Errorf("formatDockerLogEntry(%s) = %q, want %q", tt.columns.describe(), got, tt.want)
+		}
+	}
+
+	// Compact streams keep a single character width
+	entry.stream = "stdout"
+	if got, want := formatDockerLogEntry(entry, dockerLogColumns{mode: logColumnsStream, compactStream: true}, logJSONFormat{}, true), "O │ failed"; got != want {
+		t.Errorf("compact stdout = %q, want %q", got, want)
+	}
+}
+
+func TestDockerLogColumnsSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "docker-logs.json")
+	if got := loadDockerLogColumns(path); got != (dockerLogColumns{}) {
+		t.Errorf("missing file loaded as %+v, want the defaults", got)
+	}
+
+	want := dockerLogColumns{mode: logColumnsTimestamp, compactStream: true}
+	if err := saveDockerLogColumns(path, want); err != nil {
+		t.Fatalf("saveDockerLogColumns unexpected error: %v", err)
+	}
+	if got := loadDockerLogColumns(path); got != want {
+		t.Errorf("loaded columns = %+v, want %+v", got, want)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"columns":"sideways"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := loadDockerLogColumns(path); got != (dockerLogColumns{}) {
+		t.Errorf("invalid file loaded as %+v, want the defaults", got)
+	}
+}
@@ -5,10 +5,14 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/saltyorg/sb-go/internal/config"
 	"github.com/saltyorg/sb-go/internal/signals"
 	"github.com/saltyorg/sb-go/internal/styles"
 
@@ -19,54 +23,230 @@ import (
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/moby/moby/api/pkg/stdcopy"
 	"github.com/moby/moby/client"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
 // dockerLogsCmd represents the docker logs command
 var dockerLogsCmd = &cobra.Command{
-	Use:   "logs",
+	Use:   "logs [container]",
 	Short: "Display logs of Docker containers",
-	Long:  `Displays a list of Docker containers and allows viewing their logs.`,
-	Args:  cobra.NoArgs,
+	Long: `Displays a list of Docker containers and allows viewing their logs.
+
+Containers Docker restarted show their restart count and when they last
+started. Those restarted 5 times or more that last started within the past
+5 minutes are flagged in red as crash-looping, even though they show as
+running between restarts.
+
+Naming a container opens its logs right away. Part of a name is enough when it
+matches a single running container, otherwise the list opens filtered by it:
+
+  sb docker logs plex
+
+In follow mode, new logs are polled every --follow-interval. Lower values
+feel more responsive for chatty containers at the cost of more CPU use.
+
+The columns shown before each message are cycled with "t" and the stream can
+be shortened to O/E with "o". Both choices are remembered between sessions,
+and --columns overrides the remembered columns for a single session.
+
+If Docker restarts or the container is recreated while following, the viewer
+reconnects and resumes from the last log line it showed.
+
+With --alert, follow mode rings the terminal bell when a new log line matches
+the given regex, e.g. to be told when a restart finishes. Add --notify to also
+send a desktop notification (OSC 9) with the matching line:
+
+  sb docker logs --alert "Server started" --notify
+
+JSON log messages are shown as logged. "J" cycles to the level and message of
+each JSON line, or the fields named by --json-field, and then to the whole
+object pretty-printed. Lines that aren't JSON are always shown as logged:
+
+  sb docker logs --json-field time,level,msg
+
+With --no-tui, the most recent logs of the given container are printed to
+stdout instead, formatted like the viewer with the --columns and --json-field
+given. Add --follow to keep printing new lines like docker logs -f:
+
+  sb docker logs plex --no-tui -n 500
+  sb docker logs plex --no-tui --follow --columns none
+
+Add --output json to print each line as a JSON object with its timestamp,
+stream and message instead, e.g. to feed it to jq or a log shipper:
+
+  sb docker logs plex --no-tui --output json | jq -r 'select(.stream == "stderr") | .message'`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeContainerNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return handleDockerLogs(cmd.Context())
+		noTUI, _ := cmd.Flags().GetBool("no-tui")
+		lines, _ := cmd.Flags().GetInt("lines")
+		follow, _ := cmd.Flags().GetBool("follow")
+		followInterval, _ := cmd.Flags().GetDuration("follow-interval")
+		if !cmd.Flags().Changed("follow-interval") {
+			followInterval = config.SB().FollowInterval
+		}
+		if err := validateFollowInterval(followInterval); err != nil {
+			return err
+		}
+
+		alertPattern, _ := cmd.Flags().GetString("alert")
+		notify, _ := cmd.Flags().GetBool("notify")
+		if noTUI {
+			if len(args) == 0 {
+				return fmt.Errorf("--no-tui requires a container name")
+			}
+			if alertPattern != "" || notify {
+				return fmt.Errorf("--alert and --notify can't be used with --no-tui")
+			}
+		} else if cmd.Flags().Changed("lines") || follow || cmd.Flags().Changed("output") {
+			return fmt.Errorf("--lines, --follow and --output can only be used with --no-tui")
+		}
+		outputValue, _ := cmd.Flags().GetString("output")
+		output, err := parseLogOutputFormat(outputValue)
+		if err != nil {
+			return err
+		}
+		alert, err := newLogAlert(alertPattern, notify)
+		if err != nil {
+			return err
+		}
+
+		columns := loadDockerLogColumns(dockerLogPrefsPath)
+		if cmd.Flags().Changed("columns") {
+			value, _ := cmd.Flags().GetString("columns")
+			mode, err := parseLogColumnMode(value)
+			if err != nil {
+				return err
+			}
+			columns.mode = mode
+		}
+		limits, err := logBufferLimitsFromFlags(cmd, dockerLimits)
+		if err != nil {
+			return err
+		}
+
+		jsonField, _ := cmd.Flags().GetString("json-field")
+		if noTUI {
+			return handleDockerLogsDump(cmd.Context(), args[0], lines, follow, followInterval, columns, newLogJSONFormat(jsonField), limits.pageSize, output)
+		}
+		container := ""
+		if len(args) > 0 {
+			container = args[0]
+		}
+		return handleDockerLogs(cmd.Context(), container, followInterval, columns, newLogJSONFormat(jsonField), alert, limits)
 	},
 }
 
 func init() {
 	dockerCmd.AddCommand(dockerLogsCmd)
+	dockerLogsCmd.Flags().Duration("follow-interval", defaultFollowInterval, "How often to poll for new logs in follow mode (minimum "+minFollowInterval.String()+", default from follow_interval in the sb config)")
+	dockerLogsCmd.Flags().String("alert", "", "Ring the terminal bell when a new line matching this regex arrives in follow mode")
+	dockerLogsCmd.Flags().Bool("notify", false, "Also send a desktop notification for --alert matches")
+	dockerLogsCmd.Flags().String("columns", "", "Columns to show before each message: "+strings.Join(logColumnModeNames, ", ")+" (default: last used)")
+	addLogBufferFlags(dockerLogsCmd, dockerLimits)
+	dockerLogsCmd.Flags().String("json-field", "", "Comma separated fields to show of JSON log messages, e.g. time,level,msg (default: level and message)")
+	dockerLogsCmd.Flags().Bool("no-tui", false, "Print the logs of a container to stdout instead of opening the interactive UI")
+	dockerLogsCmd.Flags().IntP("lines", "n", 100, "Number of log lines to print, 0 for all (with --no-tui)")
+	dockerLogsCmd.Flags().Bool("follow", false, "Keep printing new log lines as they arrive (with --no-tui)")
+	dockerLogsCmd.Flags().String("output", string(logOutputText), "Output format: "+strings.Join(logOutputFormatNames, ", ")+" (with --no-tui)")
 }
 
 const (
-	dockerLogPageSize        = 500   // Number of log entries per page
+	dockerLogPageSize        = 500   // Default number of log entries per page
 	dockerPrefetchPagesAhead = 10    // Number of pages to stay ahead when prefetching
-	dockerMaxBufferEntries   = 20000 // Maximum entries to keep in memory
+	dockerMaxBufferEntries   = 20000 // Default maximum entries to keep in memory
 	dockerViewportsAhead     = 5     // Prefetch when within 5 viewports of edge
 	dockerViewportsToKeep    = 10    // Keep 10 viewports on each side when trimming
 )
 
 type containerItem struct {
-	name      string
-	id        string
-	status    string // running, exited, etc.
-	state     string // running (healthy), running, exited, etc. (with health if available)
-	maxLength int    // Maximum name length for alignment
+	name           string
+	id             string
+	image          string    // shortened image reference, e.g. linuxserver/plex:latest
+	status         string    // running, exited, etc.
+	state          string    // running (healthy), running, exited, etc. (with health if available)
+	restartCount   int       // Times Docker restarted the container, from inspecting it
+	startedAt      time.Time // When the container last started, zero if it couldn't be inspected
+	maxLength      int       // Maximum name length for alignment
+	imageMaxLength int       // Maximum image length for alignment
+}
+
+// crashLoopRestarts is the restart count from which a running container is flagged as crash-looping
+const crashLoopRestarts = 5
+
+// crashLoopWindow is how recently a container must have started to be flagged as crash-looping,
+// as the restart count covers its whole lifetime
+const crashLoopWindow = 5 * time.Minute
+
+// crashLooping reports whether Docker keeps restarting the container. It looks "running" between
+// restarts, so only a high restart count with a recent start gives it away.
+func (i containerItem) crashLooping(now time.Time) bool {
+	return i.restartCount >= crashLoopRestarts && !i.startedAt.IsZero() && now.Sub(i.startedAt) < crashLoopWindow
 }
 
 func (i containerItem) Title() string {
 	statusIndicator := formatContainerStatus(i.status, i.state)
+	if i.restartCount > 0 {
+		statusIndicator = formatContainerRestarts(i, time.Now())
+	}
 	if statusIndicator != "" {
-		// Pad the name to align status indicators
+		// Pad the name and image to align status indicators
 		padding := max(i.maxLength-len(i.name), 0)
-		return fmt.Sprintf("%s%s  %s", i.name, strings.Repeat(" ", padding), statusIndicator)
+		title := i.name + strings.Repeat(" ", padding)
+		if i.imageMaxLength > 0 {
+			imagePadding := max(i.imageMaxLength-len(i.image), 0)
+			title += "  " + styles.DimStyle.Render(i.image) + strings.Repeat(" ", imagePadding)
+		}
+		return fmt.Sprintf("%s  %s", title, statusIndicator)
 	}
 	return i.name
 }
 func (i containerItem) Description() string { return "" }
 func (i containerItem) FilterValue() string { return i.name }
 
+// containerDelegate renders container items with images and status indicators aligned
+// against the longest name and image on the current page rather than the whole list,
+// which keeps padding reasonable on hosts with many containers.
+type containerDelegate struct {
+	list.DefaultDelegate
+}
+
+func (d containerDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	if ci, ok := item.(containerItem); ok {
+		visible := m.VisibleItems()
+		start, end := m.Paginator.GetSliceBounds(len(visible))
+		ci.maxLength = 0
+		ci.imageMaxLength = 0
+		for _, v := range visible[start:end] {
+			if c, ok := v.(containerItem); ok {
+				ci.maxLength = max(ci.maxLength, len(c.name))
+				ci.imageMaxLength = max(ci.imageMaxLength, len(c.image))
+			}
+		}
+		item = ci
+	}
+	d.DefaultDelegate.Render(w, m, index, item)
+}
+
+// shortImageRef shortens an image reference for display by dropping the digest and
+// the registry host. Images referenced by ID are shown as a short ID.
+func shortImageRef(ref string) string {
+	if id, ok := strings.CutPrefix(ref, "sha256:"); ok {
+		return shortContainerID(id)
+	}
+	ref, _, _ = strings.Cut(ref, "@")
+
+	// Like Docker, only treat the first component as a registry host if it looks like one
+	if host, rest, ok := strings.Cut(ref, "/"); ok && (strings.ContainsAny(host, ".:") || host == "localhost") {
+		ref = rest
+	}
+	return strings.TrimPrefix(ref, "library/")
+}
+
 // formatContainerStatus creates a colored status indicator for container status
 func formatContainerStatus(status, state string) string {
 	var symbol string
@@ -95,6 +275,28 @@ func formatContainerStatus(status, state string) string {
 	return style.Render(fmt.Sprintf("[%s %s]", symbol, statusText))
 }
 
+// formatContainerRestarts creates the status indicator of a container that was restarted, with
+// the restart count and when it last started. Crash-looping containers are shown as failing.
+func formatContainerRestarts(i containerItem, now time.Time) string {
+	restarts := "1 restart"
+	if i.restartCount != 1 {
+		restarts = fmt.Sprintf("%d restarts", i.restartCount)
+	}
+	if !i.startedAt.IsZero() {
+		started := i.startedAt.Local()
+		if y, m, d := now.Local().Date(); started.Year() == y && started.Month() == m && started.Day() == d {
+			restarts += ", last " + started.Format("15:04")
+		} else {
+			restarts += ", last " + started.Format("Jan 2 15:04")
+		}
+	}
+
+	if i.crashLooping(now) {
+		return styles.ErrorStyle.Render(fmt.Sprintf("[⚠ %s · %s]", i.state, restarts))
+	}
+	return formatContainerStatus(i.status, i.state+" · "+restarts)
+}
+
 // Key bindings for help
 type dockerKeyMap struct {
 	Up       key.Binding
@@ -108,6 +310,13 @@ type dockerKeyMap struct {
 	PageDown key.Binding
 	Toggle   key.Binding
 	Follow   key.Binding
+	Copy     key.Binding
+	Save     key.Binding
+	Colors   key.Binding
+	Compact  key.Binding
+	Filter   key.Binding
+	Sort     key.Binding
+	JSON     key.Binding
 }
 
 func (k dockerKeyMap) ShortHelp() []key.Binding {
@@ -123,17 +332,17 @@ func (k dockerKeyMap) FullHelp() [][]key.Binding {
 
 // ShortHelpForList returns help bindings for list view (no "back" option)
 func (k dockerKeyMap) ShortHelpForList() []key.Binding {
-	return []key.Binding{k.Enter, k.Quit}
+	return []key.Binding{k.Enter, k.Filter, k.Sort, k.Quit}
 }
 
 // ShortHelpForLogs returns help bindings for logs view
 func (k dockerKeyMap) ShortHelpForLogs() []key.Binding {
-	return []key.Binding{k.Left, k.Right, k.Toggle, k.Follow, k.Back, k.Quit}
+	return []key.Binding{k.Left, k.Right, k.Toggle, k.Compact, k.Colors, k.JSON, k.Follow, k.Copy, k.Save, k.Back, k.Quit}
 }
 
 // ShortHelpForFollow returns help bindings for follow mode
 func (k dockerKeyMap) ShortHelpForFollow() []key.Binding {
-	return []key.Binding{k.Left, k.Right, k.Toggle, k.Follow, k.Back, k.Quit}
+	return []key.Binding{k.Left, k.Right, k.Toggle, k.Compact, k.Colors, k.JSON, k.Follow, k.Copy, k.Save, k.Back, k.Quit}
 }
 
 var dockerKeys = dockerKeyMap{
@@ -175,12 +384,106 @@ var dockerKeys = dockerKeyMap{
 	),
 	Toggle: key.NewBinding(
 		key.WithKeys("t"),
-		key.WithHelp("t", "toggle timestamp/stream"),
+		key.WithHelp("t", "cycle columns"),
 	),
 	Follow: key.NewBinding(
 		key.WithKeys("f"),
 		key.WithHelp("f", "toggle follow"),
 	),
+	Copy: key.NewBinding(
+		key.WithKeys("y", "Y"),
+		key.WithHelp("y/Y", "copy view/all"),
+	),
+	Save: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "save to file"),
+	),
+	Colors: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "toggle colors"),
+	),
+	Compact: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "compact stream"),
+	),
+	JSON: key.NewBinding(
+		key.WithKeys("J"),
+		key.WithHelp("J", "cycle JSON"),
+	),
+	Filter: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "filter"),
+	),
+	Sort: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "toggle sort name/status"),
+	),
+}
+
+const dockerListTitle = "Docker Containers"
+
+// containerStatusPriority ranks containers so that those needing attention sort first
+func containerStatusPriority(i containerItem, now time.Time) int {
+	switch i.status {
+	case "exited", "dead":
+		return 0
+	case "restarting", "removing":
+		return 1
+	case "created", "paused":
+		return 2
+	case "running":
+		if i.crashLooping(now) || strings.Contains(strings.ToLower(i.state), "unhealthy") {
+			return 1
+		}
+		return 3
+	default:
+		return 2
+	}
+}
+
+// sortContainerItems sorts container list items by name, or by status priority then name
+func sortContainerItems(items []list.Item, byStatus bool) {
+	now := time.Now()
+	sort.SliceStable(items, func(a, b int) bool {
+		ia := items[a].(containerItem)
+		ib := items[b].(containerItem)
+		if byStatus {
+			if pa, pb := containerStatusPriority(ia, now), containerStatusPriority(ib, now); pa != pb {
+				return pa < pb
+			}
+		}
+		return ia.name < ib.name
+	})
+}
+
+// matchContainerItems returns the containers a name given on the command line refers to: the
+// container with exactly that name, or else every container the picker's filter matches it with
+func matchContainerItems(items []list.Item, name string) []containerItem {
+	var containers []containerItem
+	var names []string
+	for _, item := range items {
+		if c, ok := item.(containerItem); ok {
+			if strings.EqualFold(c.name, name) {
+				return []containerItem{c}
+			}
+			containers = append(containers, c)
+			names = append(names, c.name)
+		}
+	}
+
+	var matches []containerItem
+	for _, rank := range list.UnsortedFilter(name, names) {
+		matches = append(matches, containers[rank.Index])
+	}
+	return matches
+}
+
+// containerListTitle returns the list title reflecting the active sort order
+func containerListTitle(byStatus bool) string {
+	if byStatus {
+		return dockerListTitle + " (sorted by status)"
+	}
+	return dockerListTitle + " (sorted by name)"
 }
 
 type dockerLogsModel struct {
@@ -199,48 +502,71 @@ type dockerLogsModel struct {
 	viewportInitialized bool
 	loading             bool
 	err                 error
-	viewportYPosition   int  // Store viewport scroll position
-	showTimestampStream bool // Toggle for showing timestamp and stream columns
-	followMode          bool // Follow mode enabled
+	viewportYPosition   int              // Store viewport scroll position
+	columns             dockerLogColumns // Columns shown before each message
+	stripColors         bool             // Strip the colors emitted by containers
+	json                logJSONFormat    // How JSON log messages are shown, cycled with "J"
+	limits              logBufferLimits  // Paging and memory limits, from --page-size and --buffer-size
+	followMode          bool             // Follow mode enabled
+	followInterval      time.Duration    // Poll interval for follow mode
+	alert               *logAlert        // Alerts on matching lines in follow mode, if set
+	reconnectAttempts   int              // Reconnection attempts made since follow mode lost Docker, 0 when connected
+	clockSkew           bool             // The container's timestamps went backwards, so pages are fetched with an overlap
+	status              string           // Transient copy/save confirmation shown next to the help
+	statusID            int              // Identifies the current status so only its own timer clears it
+	sortByStatus        bool             // Sort container list by status instead of name
 	dockerClient        *client.Client
 }
 
 func (m dockerLogsModel) Init() tea.Cmd {
+	// A container named on the command line is fetched right away
+	if m.loading && m.logBuf != nil {
+		return tea.Batch(m.spinner.Tick, m.logBuf.source.Fetch("", false, false))
+	}
 	return m.spinner.Tick
 }
 
+// selectContainer switches the logs view to a container and returns the command fetching its logs
+func (m *dockerLogsModel) selectContainer(item containerItem) tea.Cmd {
+	m.activeView = "logs"
+
+	// Initialize the viewport if necessary
+	if !m.viewportInitialized {
+		// Use full terminal width and height for fullscreen viewport
+		m.viewport = viewport.New(viewport.WithWidth(m.width), viewport.WithHeight(m.logsHeight()))
+		m.viewport.Style = lipgloss.NewStyle().Padding(1, 2)
+		m.viewportInitialized = true
+	}
+
+	// Clean up old buffer before switching
+	if m.logBuf != nil {
+		m.logBuf.Cleanup()
+	}
+
+	m.selectedContainer = item.name
+	m.selectedContainerID = item.id
+	m.loading = true
+	m.err = nil
+	m.viewportYPosition = 0
+	m.followMode = false
+	m.clockSkew = false
+	// Create new log buffer
+	m.logBuf = newLogViewBuffer(m.logSource(), m.limits, m.limits.targetSize(dockerPrefetchPagesAhead))
+	m.logBuf.alert = m.alert
+	return m.logBuf.source.Fetch("", false, false)
+}
+
 func (m dockerLogsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
+		// Resizes can arrive while logs are still loading, so every view is sized here
+		// rather than only the active one
 		m.width = msg.Width
 		m.height = msg.Height
-
-		helpHeight := lipgloss.Height(m.help.View(m.keys))
-
-		if m.activeView == "list" {
-			// Full-screen list view in alt screen mode
-			m.list.SetWidth(msg.Width)
-			m.list.SetHeight(msg.Height - helpHeight)
-		} else {
-			// Logs view - viewport uses full screen in alt screen mode
-			if m.viewportInitialized {
-				// Store current position before resize
-				m.viewportYPosition = m.viewport.YOffset()
-
-				m.viewport.SetWidth(msg.Width)
-				m.viewport.SetHeight(msg.Height - helpHeight)
-
-				// Restore scroll position after resize
-				m.viewport.SetYOffset(min(m.viewportYPosition, max(0, m.viewport.TotalLineCount()-m.viewport.Height())))
-			}
-		}
-
-		m.help.SetWidth(msg.Width)
-
-		return m, nil
+		return m.applyWindowSize(), nil
 
 	case tea.KeyPressMsg:
 		// Don't process navigation keys if loading (except quit)
@@ -248,6 +574,11 @@ func (m dockerLogsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// While the filter is being typed, let the list consume keys (except ctrl+c)
+		if m.activeView == "list" && m.list.SettingFilter() && msg.String() != "ctrl+c" {
+			break
+		}
+
 		switch msg.String() {
 		case "ctrl+c":
 			signals.GetGlobalManager().Shutdown(130)
@@ -266,36 +597,12 @@ func (m dockerLogsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "enter":
 			if m.activeView == "list" {
 				if i, ok := m.list.SelectedItem().(containerItem); ok {
-					newContainer := i.name
-					newContainerID := i.id
-					m.activeView = "logs"
-
-					// Initialize the viewport if necessary
-					if !m.viewportInitialized {
-						helpHeight := lipgloss.Height(m.help.View(m.keys))
-						// Use full terminal width and height for fullscreen viewport
-						m.viewport = viewport.New(viewport.WithWidth(m.width), viewport.WithHeight(m.height-helpHeight))
-						m.viewport.Style = lipgloss.NewStyle().Padding(1, 2)
-						m.viewportInitialized = true
-					}
-
 					// Only fetch new logs if a different container was selected
-					if newContainer != m.selectedContainer {
-						// Clean up old buffer before switching
-						if m.logBuf != nil {
-							m.logBuf.Cleanup()
-						}
-
-						m.selectedContainer = newContainer
-						m.selectedContainerID = newContainerID
-						m.loading = true
-						m.err = nil
-						m.viewportYPosition = 0
-						m.followMode = false
-						// Create new log buffer
-						m.logBuf = newDockerLogBuffer(m.selectedContainerID, dockerPrefetchPagesAhead*dockerLogPageSize, m.dockerClient)
-						return m, fetchDockerLogs(m.dockerClient, m.selectedContainerID, "", false, false)
+					if i.name != m.selectedContainer {
+						cmd = m.selectContainer(i)
+						return m, cmd
 					} else {
+						m.activeView = "logs"
 						// Make sure we re-apply the current log content with boundaries
 						if m.logBuf != nil {
 							m.viewport.SetContent(m.logBuf.GetContent(m.followMode))
@@ -305,6 +612,15 @@ func (m dockerLogsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
+		case "s":
+			// Toggle container list sort order between name and status
+			if m.activeView == "list" {
+				m.sortByStatus = !m.sortByStatus
+				sortContainerItems(m.containerItems, m.sortByStatus)
+				m.list.Title = containerListTitle(m.sortByStatus)
+				return m, m.list.SetItems(m.containerItems)
+			}
+
 		case "esc":
 			if m.activeView == "logs" && !m.loading {
 				// Stop follow mode if active
@@ -323,15 +639,15 @@ func (m dockerLogsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.followMode = !m.followMode
 				if m.followMode {
 					// Enable follow mode - scroll to bottom and start background fetcher
-					m.viewport.SetContent(m.logBuf.GetContentFormatted(m.showTimestampStream, m.followMode))
+					m.viewport.SetContent(m.logBuf.GetContent(m.followMode))
 					m.viewport.GotoBottom()
 					m.viewportYPosition = m.viewport.YOffset()
-					cmds = append(cmds, m.logBuf.StartFollow())
+					cmds = append(cmds, m.logBuf.StartFollow(m.followInterval))
 				} else {
 					// Disable follow mode - stop background fetcher
 					m.logBuf.StopFollow()
 					// Update content to show "end of logs" instead of "watching"
-					m.viewport.SetContent(m.logBuf.GetContentFormatted(m.showTimestampStream, m.followMode))
+					m.viewport.SetContent(m.logBuf.GetContent(m.followMode))
 				}
 			}
 
@@ -343,10 +659,10 @@ func (m dockerLogsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Fetch more older logs if we're at the top of viewport and have more to load
 			if m.activeView == "logs" && !m.loading && m.logBuf != nil {
 				atTop := m.viewport.YOffset() <= 0
-				if atTop && m.logBuf.beforeTimestamp != "" && m.logBuf.hasMoreBefore {
+				if atTop && m.logBuf.before != "" && m.logBuf.hasMoreBefore {
 					m.loading = true
 					m.err = nil
-					return m, fetchDockerLogs(m.dockerClient, m.selectedContainerID, m.logBuf.beforeTimestamp, true, false)
+					return m, m.logBuf.source.Fetch(m.logBuf.before, true, false)
 				}
 				// Otherwise, let the viewport handle scrolling
 			}
@@ -359,10 +675,10 @@ func (m dockerLogsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Only fetch more logs if we're at the bottom of viewport and have more to load
 			if m.activeView == "logs" && !m.loading && m.logBuf != nil {
 				atBottom := m.viewport.YOffset() >= m.viewport.TotalLineCount()-m.viewport.Height()
-				if atBottom && m.logBuf.afterTimestamp != "" && m.logBuf.hasMoreAfter {
+				if atBottom && m.logBuf.after != "" && m.logBuf.hasMoreAfter {
 					m.loading = true
 					m.err = nil
-					return m, fetchDockerLogs(m.dockerClient, m.selectedContainerID, m.logBuf.afterTimestamp, false, false)
+					return m, m.logBuf.source.Fetch(m.logBuf.after, false, false)
 				}
 				// Otherwise, let the viewport handle scrolling
 			}
@@ -379,21 +695,85 @@ func (m dockerLogsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.viewport.ScrollRight(10)
 			}
 
-		case "t":
-			// Toggle timestamp and stream visibility (allowed in follow mode)
+		case "y", "Y":
+			// Copy the visible lines, or all loaded lines with Y (allowed in follow mode)
+			if m.activeView == "logs" && m.viewportInitialized && m.logBuf != nil {
+				text := visibleViewportText(m.viewport)
+				if msg.String() == "Y" {
+					text = m.logBuf.PlainText(true)
+				}
+				m.statusID++
+				m.status = copiedLinesStatus(text)
+				return m, tea.Batch(copyLogText(text), clearLogStatusAfter(m.statusID))
+			}
+
+		case "w":
+			// Save all loaded lines to a file, for terminals without clipboard support
+			if m.activeView == "logs" && m.logBuf != nil {
+				return m, saveLogText(m.selectedContainer, m.logBuf.PlainText(true))
+			}
+
+		case "c":
+			// Toggle the colors emitted by the container (allowed in follow mode)
+			if m.activeView == "logs" && m.logBuf != nil {
+				m.stripColors = !m.stripColors
+				m.logBuf.source = m.logSource()
+				m.viewport.SetContent(m.logBuf.GetContent(m.followMode))
+			}
+
+		case "J":
+			// Cycle how JSON log messages are shown (allowed in follow mode)
+			if m.activeView == "logs" && m.logBuf != nil {
+				m.json.mode = m.json.mode.next()
+				m.logBuf.source = m.logSource()
+				m.viewport.SetContent(m.logBuf.GetContent(m.followMode))
+				if m.followMode {
+					m.viewport.GotoBottom()
+				} else {
+					m.viewport.SetYOffset(min(m.viewport.YOffset(), max(0, m.viewport.TotalLineCount()-m.viewport.Height())))
+				}
+				m.viewportYPosition = m.viewport.YOffset()
+
+				m.statusID++
+				m.status = "JSON: " + m.json.describe()
+				return m, clearLogStatusAfter(m.statusID)
+			}
+
+		case "t", "o":
+			// Cycle the timestamp and stream columns, or toggle the compact stream (allowed in follow mode)
 			if m.activeView == "logs" && m.logBuf != nil {
-				m.showTimestampStream = !m.showTimestampStream
+				if msg.String() == "t" {
+					m.columns.mode = m.columns.mode.next()
+				} else {
+					m.columns.compactStream = !m.columns.compactStream
+				}
+				m.logBuf.source = m.logSource()
 				// Update viewport content with new formatting
-				m.viewport.SetContent(m.logBuf.GetContentFormatted(m.showTimestampStream, m.followMode))
+				m.viewport.SetContent(m.logBuf.GetContent(m.followMode))
 				// If in follow mode, scroll back to bottom after refresh
 				if m.followMode {
 					m.viewport.GotoBottom()
 					m.viewportYPosition = m.viewport.YOffset()
 				}
+
+				// Remember the choice for the next session
+				m.statusID++
+				m.status = "Columns: " + m.columns.describe()
+				if err := saveDockerLogColumns(dockerLogPrefsPath, m.columns); err != nil {
+					m.status += " (not saved: " + err.Error() + ")"
+				}
+				return m, clearLogStatusAfter(m.statusID)
 			}
 		}
 
 	case dockerLogsMsg:
+		if msg.err != nil && m.followMode && msg.isPrefetch && !msg.reverse {
+			// The daemon restarted or the container was recreated while following
+			if m.logBuf != nil {
+				m.logBuf.prefetchingAfter = false
+			}
+			return m.startDockerReconnect(msg.err)
+		}
 		if msg.err != nil {
 			m.err = msg.err
 			if m.logBuf != nil {
@@ -421,6 +801,21 @@ func (m dockerLogsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			if m.activeView == "logs" && m.logBuf != nil {
+				if msg.skewed && !m.clockSkew {
+					// Timestamp bounds can skip or repeat entries from now on, so page with an overlap
+					m.clockSkew = true
+					m.logBuf.source = m.logSource()
+				}
+				if msg.overlapped {
+					msg.entries = dedupeDockerLogEntries(dockerLogsEdge(m.logBuf.entries, len(msg.entries), msg.reverse), msg.entries)
+				}
+
+				if len(msg.entries) == 0 && msg.overlapped && msg.hasMore {
+					// A full page of entries that are already loaded, so more may lie past it
+					if cmd := m.fetchPastPage(msg); cmd != nil {
+						return m, cmd
+					}
+				}
 				if len(msg.entries) == 0 {
 					// No entries returned - we hit a boundary
 					if msg.reverse {
@@ -442,26 +837,18 @@ func (m dockerLogsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					savedYOffset := m.viewport.YOffset()
 
 					// Use firstTimestamp (oldest entry) to continue fetching even older logs
+					hadMoreBefore := m.logBuf.hasMoreBefore
 					prefetchCmd := m.logBuf.PrependOlder(msg.entries, msg.firstTimestamp, msg.hasMore)
 
-					// Calculate how many lines were added
-					entriesAdded := len(m.logBuf.entries) - oldLen
-					linesAdded := 0
-					for i := range entriesAdded {
-						linesAdded += len(strings.Split(formatDockerLogEntry(m.logBuf.entries[i], m.showTimestampStream), "\n"))
-					}
-					// Add boundary markers if present (only if this update caused hasMoreBefore to become false)
-					if !m.logBuf.hasMoreBefore && msg.hasMore {
-						// Boundary marker was just added
-						linesAdded += 2 // "--- start of logs ---" + blank line
-					}
+					// Calculate how many lines were added, including a start of logs marker
+					linesAdded := m.logBuf.PrependedLines(len(m.logBuf.entries)-oldLen, hadMoreBefore, true)
 
 					// Update viewport content
 					m.viewport.SetContent(m.logBuf.GetContent(m.followMode))
 
 					// ALWAYS adjust viewport position when prepending to prevent scroll jumping
 					// This keeps the user's view stable regardless of prefetch or user action
-					m.viewport.SetYOffset(min(savedYOffset+linesAdded, m.viewport.TotalLineCount()-m.viewport.Height()))
+					m.viewport.SetYOffset(max(0, min(savedYOffset+linesAdded, m.viewport.TotalLineCount()-m.viewport.Height())))
 					m.viewportYPosition = m.viewport.YOffset()
 
 					// Check if logBuffer wants to prefetch more
@@ -470,7 +857,8 @@ func (m dockerLogsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				} else {
 					if len(m.logBuf.entries) == 0 {
-						// Initial load
+						// Initial load, sized to the terminal as it is now in case it was resized while loading
+						m = m.applyWindowSize()
 						prefetchCmd := m.logBuf.AppendInitial(msg.entries, msg.firstTimestamp, msg.lastTimestamp)
 
 						// Update viewport and position at bottom
@@ -506,18 +894,47 @@ func (m dockerLogsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, tea.Batch(cmds...)
 
+	case dockerReconnectedMsg:
+		return m.finishDockerReconnect(msg)
+
+	case prefetchRetryMsg:
+		if m.logBuf != nil {
+			if m.activeView == "logs" && !m.loading {
+				cmds = append(cmds, m.logBuf.RetryPrefetch(
+					m.viewport.YOffset(),
+					m.viewport.Height(),
+					m.viewport.TotalLineCount(),
+				)...)
+			} else {
+				// The retry fired without a check, so the next scroll can schedule another
+				m.logBuf.retryScheduled = false
+			}
+		}
+		return m, tea.Batch(cmds...)
+
 	case followTickMsg:
 		// Follow mode tick - fetch new logs
-		if m.followMode && m.logBuf != nil && !m.loading {
+		if m.followMode && m.logBuf != nil && !m.loading && m.reconnectAttempts == 0 {
 			// Fetch new logs since last timestamp
-			cmds = append(cmds, fetchDockerLogs(m.dockerClient, m.selectedContainerID, m.logBuf.afterTimestamp, false, true))
+			cmds = append(cmds, m.logBuf.source.Fetch(m.logBuf.after, false, true))
 		}
 		// Schedule next tick
 		if m.followMode {
-			cmds = append(cmds, tickFollow())
+			cmds = append(cmds, tickFollow(m.followInterval))
 		}
 		return m, tea.Batch(cmds...)
 
+	case logSavedMsg:
+		m.statusID++
+		m.status = savedLogsStatus(msg)
+		return m, clearLogStatusAfter(m.statusID)
+
+	case clearLogStatusMsg:
+		if msg.id == m.statusID {
+			m.status = ""
+		}
+		return m, nil
+
 	case spinner.TickMsg:
 		if m.loading {
 			m.spinner, cmd = m.spinner.Update(msg)
@@ -565,6 +982,41 @@ func (m dockerLogsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// applyWindowSize sizes the list, help and, once created, the logs viewport to the last
+// known terminal size, keeping the viewport's scroll position where possible
+func (m dockerLogsModel) applyWindowSize() dockerLogsModel {
+	m.help.SetWidth(m.width)
+	// Full-screen list view in alt screen mode
+	m.list.SetWidth(m.width)
+	m.list.SetHeight(m.logsHeight())
+
+	if m.viewportInitialized {
+		// Store current position before resize
+		m.viewportYPosition = m.viewport.YOffset()
+
+		m.viewport.SetWidth(m.width)
+		m.viewport.SetHeight(m.logsHeight())
+
+		// Restore scroll position after resize
+		m.viewport.SetYOffset(min(m.viewportYPosition, max(0, m.viewport.TotalLineCount()-m.viewport.Height())))
+	}
+	return m
+}
+
+// logsHeight returns the height left for the list or logs above the help line
+func (m dockerLogsModel) logsHeight() int {
+	return max(0, m.height-lipgloss.Height(m.help.View(m.keys)))
+}
+
+// logsPlaceholder renders a message in place of the logs, filling the screen above the help
+func (m dockerLogsModel) logsPlaceholder(message, helpView string) string {
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(max(0, m.height-lipgloss.Height(helpView))).
+		Padding(2).
+		Render(message)
+}
+
 func (m dockerLogsModel) View() tea.View {
 	// Get context-aware help based on active view
 	var helpView string
@@ -575,6 +1027,12 @@ func (m dockerLogsModel) View() tea.View {
 	} else {
 		helpView = m.help.ShortHelpView(m.keys.ShortHelpForLogs())
 	}
+	if m.activeView == "logs" && m.clockSkew {
+		helpView += "  " + styles.DimStyle.Render(dockerClockSkewWarning)
+	}
+	if m.activeView == "logs" && m.status != "" {
+		helpView += "  " + styles.InfoStyle.Render(m.status)
+	}
 
 	if m.activeView == "list" {
 		// Inline list view - render list with help at bottom
@@ -589,32 +1047,20 @@ func (m dockerLogsModel) View() tea.View {
 	if m.err != nil {
 		// Show error with styling
 		errorMsg := styles.ErrorStyle.Render("Error: " + m.err.Error())
-		logsContent = lipgloss.NewStyle().
-			Width(m.width).
-			Height(m.height - lipgloss.Height(helpView)).
-			Padding(2).
-			Render(errorMsg)
+		logsContent = m.logsPlaceholder(errorMsg, helpView)
 	} else if m.loading && len(m.logBuf.entries) == 0 {
 		// Show loading spinner (only if no logs loaded yet)
 		loadingMsg := fmt.Sprintf("%s Loading logs for %s...",
 			m.spinner.View(),
 			styles.InfoStyle.Render(m.selectedContainer))
-		logsContent = lipgloss.NewStyle().
-			Width(m.width).
-			Height(m.height - lipgloss.Height(helpView)).
-			Padding(2).
-			Render(loadingMsg)
+		logsContent = m.logsPlaceholder(loadingMsg, helpView)
 	} else if m.viewportInitialized && m.selectedContainer != "" {
 		// Show viewport with logs
 		logsContent = m.viewport.View()
 	} else {
 		// Show prompt to select a container
 		promptMsg := styles.DimStyle.Render("Select a container to view logs")
-		logsContent = lipgloss.NewStyle().
-			Width(m.width).
-			Height(m.height - lipgloss.Height(helpView)).
-			Padding(2).
-			Render(promptMsg)
+		logsContent = m.logsPlaceholder(promptMsg, helpView)
 	}
 
 	v := tea.NewView(lipgloss.JoinVertical(lipgloss.Left, logsContent, helpView))
@@ -622,49 +1068,81 @@ func (m dockerLogsModel) View() tea.View {
 	return v
 }
 
-// formatDockerLogEntriesWithBoundaries formats log entries with boundary indicators inline
-func formatDockerLogEntriesWithBoundaries(entries []dockerLogEntry, hasMoreBefore, hasMoreAfter bool, showTimestampStream bool, followMode bool) string {
-	if len(entries) == 0 {
-		return "No log entries"
+// formatDockerLogEntry formats a single log entry for display with the selected columns.
+// Colors emitted by the container are kept unless stripColors is set, and JSON messages
+// are shown as selected by jsonFormat.
+func formatDockerLogEntry(entry dockerLogEntry, columns dockerLogColumns, jsonFormat logJSONFormat, stripColors bool) string {
+	// Pretty-printed JSON spans several lines, so each line is sanitized on its own
+	lines := strings.Split(jsonFormat.apply(entry.message), "\n")
+	for i, line := range lines {
+		lines[i] = sanitizeLogMessage(line, stripColors)
 	}
+	message := strings.Join(lines, "\n")
 
-	var lines []string
-
-	// Add start indicator at the beginning if we've hit the start boundary
-	if !hasMoreBefore {
-		lines = append(lines, styles.DimStyle.Render("--- start of logs ---"))
-		lines = append(lines, "")
+	// Format: timestamp stream │ message, leaving out hidden columns
+	var prefix []string
+	if columns.showTimestamp() {
+		prefix = append(prefix, entry.timestamp)
+	}
+	if columns.showStream() {
+		prefix = append(prefix, columns.streamLabel(entry.stream))
+	}
+	if len(prefix) == 0 {
+		// Simplified format: just the message (no timestamp, stream, or divider)
+		return message
 	}
+	return indentLogLines(strings.Join(prefix, " ")+" │ ", message)
+}
 
-	// Add all log entries
-	for _, entry := range entries {
-		lines = append(lines, formatDockerLogEntry(entry, showTimestampStream))
+// logEscapeSequence matches CSI sequences, OSC sequences and other two-byte escape sequences
+var logEscapeSequence = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)?|\x1b[@-Z\\-_]`)
+
+// sanitizeLogMessage makes a container log message safe to render in the viewport.
+// Only color and style (SGR) sequences are kept, as cursor movement, line clearing and
+// other control sequences garble the viewport and break its width calculations.
+// A reset is appended when colors are kept, so colors left open by the container
+// don't bleed into the padding or the following lines.
+func sanitizeLogMessage(message string, stripColors bool) string {
+	if !strings.ContainsFunc(message, isLogControlChar) {
+		return message
 	}
 
-	// Add end indicator at the end if we've hit the end boundary
-	if !hasMoreAfter {
-		lines = append(lines, "")
-		if followMode {
-			lines = append(lines, styles.InfoStyle.Render("--- watching for new logs (press 'f' to disable) ---"))
-		} else {
-			lines = append(lines, styles.DimStyle.Render("--- end of logs ---"))
+	var b strings.Builder
+	styled := false
+	last := 0
+	for _, loc := range logEscapeSequence.FindAllStringIndex(message, -1) {
+		writeLogText(&b, message[last:loc[0]])
+		if seq := message[loc[0]:loc[1]]; !stripColors && strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m") {
+			b.WriteString(seq)
+			styled = true
 		}
+		last = loc[1]
 	}
+	writeLogText(&b, message[last:])
 
-	return strings.Join(lines, "\n")
+	if styled {
+		b.WriteString(ansi.ResetStyle)
+	}
+	return b.String()
 }
 
-// formatDockerLogEntry formats a single log entry for display
-func formatDockerLogEntry(entry dockerLogEntry, showTimestampStream bool) string {
-	if showTimestampStream {
-		// Format: timestamp stream │ message
-		return fmt.Sprintf("%s %6s │ %s", entry.timestamp, entry.stream, entry.message)
-	} else {
-		// Simplified format: just the message (no timestamp, stream, or divider)
-		return entry.message
+// writeLogText writes text without control characters, expanding tabs to spaces
+func writeLogText(b *strings.Builder, text string) {
+	for _, r := range text {
+		switch {
+		case r == '\t':
+			b.WriteString("    ")
+		case !isLogControlChar(r):
+			b.WriteRune(r)
+		}
 	}
 }
 
+// isLogControlChar reports whether r is a control character, including the escape character
+func isLogControlChar(r rune) bool {
+	return r < 0x20 || r == 0x7f
+}
+
 type dockerLogsMsg struct {
 	entries        []dockerLogEntry // Parsed log entries
 	firstTimestamp string           // First timestamp in the result (for bidirectional nav)
@@ -672,6 +1150,8 @@ type dockerLogsMsg struct {
 	reverse        bool
 	hasMore        bool // Whether there are more entries in this direction
 	isPrefetch     bool // Whether this is a background prefetch request
+	skewed         bool // Whether the timestamps of the page go backwards
+	overlapped     bool // Whether the page was fetched with an overlap and may repeat loaded entries
 	err            error
 }
 
@@ -681,208 +1161,101 @@ type dockerLogEntry struct {
 	message   string
 }
 
+const (
+	defaultFollowInterval = 500 * time.Millisecond // Default poll interval for follow mode
+	minFollowInterval     = 100 * time.Millisecond // Shortest poll interval accepted by --follow-interval
+)
+
+// validateFollowInterval checks a --follow-interval value
+func validateFollowInterval(interval time.Duration) error {
+	if interval < minFollowInterval {
+		return fmt.Errorf("invalid --follow-interval %s (must be at least %s)", interval, minFollowInterval)
+	}
+	return nil
+}
+
 // followTickMsg is sent periodically when follow mode is active
 type followTickMsg struct{}
 
-func tickFollow() tea.Cmd {
-	return tea.Tick(500*time.Millisecond, func(time.Time) tea.Msg {
+func tickFollow(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
 		return followTickMsg{}
 	})
 }
 
-// dockerLogBuffer manages log entries and handles prefetching
-type dockerLogBuffer struct {
-	entries          []dockerLogEntry
-	beforeTimestamp  string // Timestamp for fetching older logs
-	afterTimestamp   string // Timestamp for fetching newer logs
-	hasMoreBefore    bool
-	hasMoreAfter     bool
-	containerID      string
-	prefetching      bool
-	prefetchingAfter bool
-	targetSize       int // Target number of entries to keep loaded
-	dockerClient     *client.Client
-	followActive     bool
-}
+// dockerLogBuffer manages the log entries of a container
+type dockerLogBuffer = logViewBuffer[dockerLogEntry]
 
-func newDockerLogBuffer(containerID string, targetSize int, dockerClient *client.Client) *dockerLogBuffer {
-	return &dockerLogBuffer{
-		entries:       []dockerLogEntry{},
-		containerID:   containerID,
-		targetSize:    targetSize,
-		hasMoreBefore: true,
-		hasMoreAfter:  false,
-		dockerClient:  dockerClient,
-		followActive:  false,
-	}
+// dockerLimits are the default paging and memory limits of the Docker log viewer
+var dockerLimits = logBufferLimits{
+	pageSize:        dockerLogPageSize,
+	maxEntries:      dockerMaxBufferEntries,
+	viewportsAhead:  dockerViewportsAhead,
+	viewportsToKeep: dockerViewportsToKeep,
 }
 
-// GetContent returns formatted content for display with boundary markers (timestamp/stream shown)
-func (lb *dockerLogBuffer) GetContent(followMode bool) string {
-	return formatDockerLogEntriesWithBoundaries(lb.entries, lb.hasMoreBefore, lb.hasMoreAfter, true, followMode)
+// containerLogSource fetches the logs of a container from the Docker API, positioned by timestamps
+type containerLogSource struct {
+	client      *client.Client
+	containerID string
+	pageSize    int              // Number of entries fetched at a time
+	columns     dockerLogColumns // Columns shown before each message
+	json        logJSONFormat    // How JSON log messages are shown
+	stripColors bool             // Strip the colors emitted by the container
+	overlap     bool             // Fetch pages with an overlap, as the container's timestamps are skewed
 }
 
-// GetContentFormatted returns formatted content with optional timestamp/stream visibility
-func (lb *dockerLogBuffer) GetContentFormatted(showTimestampStream bool, followMode bool) string {
-	return formatDockerLogEntriesWithBoundaries(lb.entries, lb.hasMoreBefore, lb.hasMoreAfter, showTimestampStream, followMode)
+// Fetch returns a command fetching a page of the container's logs
+func (s containerLogSource) Fetch(timestamp string, reverse bool, isPrefetch bool) tea.Cmd {
+	return fetchDockerLogs(s, timestamp, reverse, isPrefetch)
 }
 
-// ShouldPrefetch returns true if we need to fetch more older logs
-func (lb *dockerLogBuffer) ShouldPrefetch() bool {
-	return len(lb.entries) < lb.targetSize && lb.hasMoreBefore && lb.beforeTimestamp != "" && !lb.prefetching
+// Position returns the timestamp of an entry
+func (s containerLogSource) Position(entry dockerLogEntry) string {
+	return entry.timestamp
 }
 
-// StartPrefetch marks prefetch as in progress and returns the fetch command
-func (lb *dockerLogBuffer) StartPrefetch() tea.Cmd {
-	if !lb.ShouldPrefetch() {
-		return nil
+// Format formats an entry with the source's columns, or only the message without details
+func (s containerLogSource) Format(entry dockerLogEntry, showDetails bool) string {
+	columns := s.columns
+	if !showDetails {
+		columns.mode = logColumnsNone
 	}
-	lb.prefetching = true
-	return fetchDockerLogs(lb.dockerClient, lb.containerID, lb.beforeTimestamp, true, true)
+	return formatDockerLogEntry(entry, columns, s.json, s.stripColors)
 }
 
-// AppendInitial sets initial logs (most recent)
-func (lb *dockerLogBuffer) AppendInitial(entries []dockerLogEntry, firstTimestamp, lastTimestamp string) tea.Cmd {
-	lb.entries = entries
-	lb.beforeTimestamp = firstTimestamp
-	lb.afterTimestamp = lastTimestamp
-	lb.hasMoreBefore = true
-	lb.hasMoreAfter = false
-	return lb.StartPrefetch()
+// logSource returns the log source of the selected container with the current display settings
+func (m dockerLogsModel) logSource() containerLogSource {
+	return containerLogSource{client: m.dockerClient, containerID: m.selectedContainerID, pageSize: m.limits.pageSize, columns: m.columns, json: m.json, stripColors: m.stripColors, overlap: m.clockSkew}
 }
 
-// PrependOlder adds older logs to the beginning
-// oldestTimestamp should be the timestamp of the oldest entry in the batch (to fetch even older logs)
-func (lb *dockerLogBuffer) PrependOlder(entries []dockerLogEntry, oldestTimestamp string, hasMore bool) tea.Cmd {
-	lb.entries = append(entries, lb.entries...)
-	lb.beforeTimestamp = oldestTimestamp
-	lb.hasMoreBefore = hasMore
-	lb.prefetching = false
-	return lb.StartPrefetch()
-}
-
-// AppendNewer adds newer logs to the end
-func (lb *dockerLogBuffer) AppendNewer(entries []dockerLogEntry, lastTimestamp string, hasMore bool) tea.Cmd {
-	lb.entries = append(lb.entries, entries...)
-	lb.afterTimestamp = lastTimestamp
-	lb.hasMoreAfter = hasMore
-	lb.prefetchingAfter = false
-	return nil
-}
-
-// StartFollow enables follow mode and returns the tick command
-func (lb *dockerLogBuffer) StartFollow() tea.Cmd {
-	lb.followActive = true
-	return tickFollow()
-}
-
-// StopFollow disables follow mode
-func (lb *dockerLogBuffer) StopFollow() {
-	lb.followActive = false
-}
-
-// Cleanup clears all log entries and resets state for memory cleanup
-func (lb *dockerLogBuffer) Cleanup() {
-	lb.entries = nil
-	lb.beforeTimestamp = ""
-	lb.afterTimestamp = ""
-	lb.hasMoreBefore = false
-	lb.hasMoreAfter = false
-	lb.prefetching = false
-	lb.prefetchingAfter = false
-	lb.followActive = false
-}
-
-// TrimBuffer removes entries far from viewport to limit memory usage
-// Returns the number of lines trimmed from the top (for viewport offset adjustment)
-func (lb *dockerLogBuffer) TrimBuffer(viewportY, viewportHeight int) int {
-	if len(lb.entries) <= dockerMaxBufferEntries {
-		return 0 // No need to trim
-	}
-
-	// Calculate viewport position in terms of log entries
-	// We need to estimate which entries are visible
-	totalLines := 0
-	visibleStartEntry := 0
-	visibleEndEntry := len(lb.entries) - 1
-
-	// Find which entries correspond to the viewport position
-	for i, entry := range lb.entries {
-		// Use true for timestamp/stream since this is just for line counting
-		entryLines := len(strings.Split(formatDockerLogEntry(entry, true), "\n"))
-		if totalLines+entryLines > viewportY {
-			visibleStartEntry = i
-			break
+// fetchPastPage moves the buffer's bound past an overlapped page that only held loaded entries
+// and fetches from there, or returns nil when the bound can't move any further
+func (m dockerLogsModel) fetchPastPage(msg dockerLogsMsg) tea.Cmd {
+	if msg.reverse {
+		bound, ok := dockerBoundPastPage(msg, m.logBuf.before)
+		if !ok {
+			return nil
 		}
-		totalLines += entryLines
-	}
-
-	// Calculate how many entries to keep on each side
-	entriesToKeep := max(
-		// Rough estimate: ~3 lines per entry
-		dockerViewportsToKeep*viewportHeight/3,
-		// Keep at least one page
-		dockerLogPageSize)
-
-	// Calculate trim boundaries
-	trimStart := max(0, visibleStartEntry-entriesToKeep)
-	trimEnd := min(len(lb.entries), visibleEndEntry+entriesToKeep)
-
-	// Don't trim if we're not actually removing much
-	if trimStart < 100 && trimEnd > len(lb.entries)-100 {
-		return 0 // Not worth trimming
+		m.logBuf.before = bound
+		m.logBuf.prefetching = true
+		return m.logBuf.source.Fetch(bound, true, true)
 	}
-
-	// Calculate lines being removed from the top
-	linesTrimmed := 0
-	for i := range trimStart {
-		// Use true for timestamp/stream since this is just for line counting
-		linesTrimmed += len(strings.Split(formatDockerLogEntry(lb.entries[i], true), "\n"))
-	}
-
-	// Trim the entries
-	oldEntries := lb.entries
-	lb.entries = make([]dockerLogEntry, trimEnd-trimStart)
-	copy(lb.entries, oldEntries[trimStart:trimEnd])
-
-	// Update timestamps if we trimmed from edges
-	if trimStart > 0 && len(lb.entries) > 0 {
-		lb.beforeTimestamp = lb.entries[0].timestamp
-		lb.hasMoreBefore = true // We know there are more entries we trimmed
-	}
-	if trimEnd < len(oldEntries) && len(lb.entries) > 0 {
-		lb.afterTimestamp = lb.entries[len(lb.entries)-1].timestamp
-		lb.hasMoreAfter = true // We know there are more entries we trimmed
-	}
-
-	return linesTrimmed
-}
-
-// CheckPrefetchNeeds checks if prefetching should be triggered based on viewport position
-// Returns commands for prefetching in both directions if needed
-func (lb *dockerLogBuffer) CheckPrefetchNeeds(viewportY, viewportHeight, totalHeight int) []tea.Cmd {
-	var cmds []tea.Cmd
-
-	// Calculate threshold for prefetching (viewportsAhead * viewport height)
-	prefetchThreshold := dockerViewportsAhead * viewportHeight
-
-	// Check if we should prefetch older logs (scrolling near top)
-	if viewportY < prefetchThreshold && lb.hasMoreBefore && lb.beforeTimestamp != "" && !lb.prefetching {
-		lb.prefetching = true
-		cmds = append(cmds, fetchDockerLogs(lb.dockerClient, lb.containerID, lb.beforeTimestamp, true, true))
-	}
-
-	// Check if we should prefetch newer logs (scrolling near bottom)
-	distanceFromBottom := totalHeight - (viewportY + viewportHeight)
-	if distanceFromBottom < prefetchThreshold && lb.hasMoreAfter && lb.afterTimestamp != "" && !lb.prefetchingAfter {
-		lb.prefetchingAfter = true
-		cmds = append(cmds, fetchDockerLogs(lb.dockerClient, lb.containerID, lb.afterTimestamp, false, true))
+	bound, ok := dockerBoundPastPage(msg, m.logBuf.after)
+	if !ok {
+		return nil
 	}
-
-	return cmds
+	m.logBuf.after = bound
+	m.logBuf.prefetchingAfter = true
+	return m.logBuf.source.Fetch(bound, false, true)
 }
 
-func fetchDockerLogs(cli *client.Client, containerID string, timestamp string, reverse bool, isPrefetch bool) tea.Cmd {
+// fetchDockerLogs returns a command fetching a page of the source's container logs before or after
+// timestamp, or the most recent page without one. Once the source pages with an overlap, the bound
+// is widened by dockerSkewOverlap and the entries already loaded are dropped when the page arrives.
+func fetchDockerLogs(source containerLogSource, timestamp string, reverse bool, isPrefetch bool) tea.Cmd {
+	cli, containerID, pageSize := source.client, source.containerID, source.pageSize
+	overlapped := timestamp != "" && source.overlap
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(signals.GetGlobalManager().Context(), 10*time.Second)
 		defer cancel()
@@ -894,18 +1267,27 @@ func fetchDockerLogs(cli *client.Client, containerID string, timestamp string, r
 		}
 
 		if timestamp != "" {
+			bound := timestamp
+			if overlapped {
+				// Reach past the bound so entries logged around a clock jump aren't skipped
+				if reverse {
+					bound = shiftDockerTimestamp(timestamp, dockerSkewOverlap)
+				} else {
+					bound = shiftDockerTimestamp(timestamp, -dockerSkewOverlap)
+				}
+			}
 			if reverse {
 				// Fetch logs before this timestamp (older logs)
-				options.Until = timestamp
-				options.Tail = fmt.Sprintf("%d", dockerLogPageSize)
+				options.Until = bound
+				options.Tail = strconv.Itoa(pageSize)
 			} else {
 				// Fetch logs after this timestamp (newer logs)
-				options.Since = timestamp
+				options.Since = bound
 				// Don't use Tail for forward fetching to get all new logs
 			}
 		} else {
 			// No timestamp - show most recent entries
-			options.Tail = fmt.Sprintf("%d", dockerLogPageSize)
+			options.Tail = strconv.Itoa(pageSize)
 		}
 
 		logsReader, err := cli.ContainerLogs(ctx, containerID, options)
@@ -920,8 +1302,9 @@ func fetchDockerLogs(cli *client.Client, containerID string, timestamp string, r
 			return dockerLogsMsg{isPrefetch: isPrefetch, err: fmt.Errorf("failed to parse logs: %w", err)}
 		}
 
-		// Filter out the timestamp entry if fetching since/until a specific time
-		if timestamp != "" && len(entries) > 0 {
+		// Filter out the timestamp entry if fetching since/until a specific time. Overlapping
+		// pages are deduplicated against the loaded entries instead.
+		if timestamp != "" && !overlapped && len(entries) > 0 {
 			// Remove entries with exact matching timestamp to avoid duplicates
 			filtered := entries[:0]
 			for _, entry := range entries {
@@ -941,10 +1324,13 @@ func fetchDockerLogs(cli *client.Client, containerID string, timestamp string, r
 				reverse:        reverse,
 				hasMore:        false,
 				isPrefetch:     isPrefetch,
+				overlapped:     overlapped,
 				err:            nil,
 			}
 		}
 
+		skewed := !dockerTimestampsMonotonic(entries)
+
 		// Docker returns logs in chronological order (oldest to newest)
 		// For reverse mode, we need newest to oldest, so reverse the slice
 		if reverse {
@@ -955,7 +1341,7 @@ func fetchDockerLogs(cli *client.Client, containerID string, timestamp string, r
 
 		// Determine if there are more entries available
 		// If we got fewer entries than requested, we've hit a boundary
-		hasMore := len(entries) >= dockerLogPageSize
+		hasMore := len(entries) >= pageSize
 
 		// Extract timestamps
 		var firstTimestamp, lastTimestamp string
@@ -978,6 +1364,12 @@ func fetchDockerLogs(cli *client.Client, containerID string, timestamp string, r
 			}
 		}
 
+		// The first and last entries don't hold the earliest and latest timestamps of a skewed
+		// page, which would page from the wrong bound
+		if skewed || overlapped {
+			firstTimestamp, lastTimestamp = dockerTimestampRange(entries)
+		}
+
 		return dockerLogsMsg{
 			entries:        entries,
 			firstTimestamp: firstTimestamp,
@@ -985,6 +1377,8 @@ func fetchDockerLogs(cli *client.Client, containerID string, timestamp string, r
 			reverse:        reverse,
 			hasMore:        hasMore,
 			isPrefetch:     isPrefetch,
+			skewed:         skewed,
+			overlapped:     overlapped,
 			err:            nil,
 		}
 	}
@@ -993,8 +1387,6 @@ func fetchDockerLogs(cli *client.Client, containerID string, timestamp string, r
 // parseDockerLogs parses Docker log format from the reader
 // Docker uses stdcopy multiplexing for stdout/stderr streams
 func parseDockerLogs(reader io.Reader) ([]dockerLogEntry, error) {
-	var entries []dockerLogEntry
-
 	// Create buffers for demultiplexed streams
 	var stdout, stderr strings.Builder
 
@@ -1003,93 +1395,131 @@ func parseDockerLogs(reader io.Reader) ([]dockerLogEntry, error) {
 		return nil, fmt.Errorf("failed to demultiplex streams: %w", err)
 	}
 
-	// Process stdout entries
-	if stdout.Len() > 0 {
-		scanner := bufio.NewScanner(strings.NewReader(stdout.String()))
-		for scanner.Scan() {
-			line := scanner.Text()
-			if line == "" {
-				continue
-			}
+	// Each stream is already in chronological order, so parse both concurrently
+	// and merge them instead of sorting all entries afterwards
+	var stdoutEntries, stderrEntries []dockerLogEntry
+	var stdoutErr, stderrErr error
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		stdoutEntries, stdoutErr = parseDockerLogStream(stdout.String(), "stdout")
+	})
+	wg.Go(func() {
+		stderrEntries, stderrErr = parseDockerLogStream(stderr.String(), "stderr")
+	})
+	wg.Wait()
 
-			// Parse timestamp from line if present
-			// Docker format with timestamps: "2025-11-12T14:23:45.123456789Z message"
-			parts := strings.SplitN(line, " ", 2)
-			var timestamp, msg string
-			if len(parts) == 2 {
-				// Try to parse as timestamp
-				if _, err := time.Parse(time.RFC3339Nano, parts[0]); err == nil {
-					timestamp = parts[0]
-					msg = parts[1]
-				} else {
-					// Not a timestamp, treat whole line as message
-					timestamp = time.Now().Format(time.RFC3339Nano)
-					msg = line
-				}
-			} else {
-				timestamp = time.Now().Format(time.RFC3339Nano)
-				msg = line
-			}
+	if stdoutErr != nil {
+		return nil, stdoutErr
+	}
+	if stderrErr != nil {
+		return nil, stderrErr
+	}
 
-			entries = append(entries, dockerLogEntry{
-				timestamp: timestamp,
-				stream:    "stdout",
-				message:   msg,
-			})
-		}
-		if err := scanner.Err(); err != nil {
-			return nil, err
-		}
+	return mergeDockerLogEntries(stdoutEntries, stderrEntries), nil
+}
+
+// parseDockerLogStream parses the lines of a single demultiplexed stream into entries
+func parseDockerLogStream(data, stream string) ([]dockerLogEntry, error) {
+	if data == "" {
+		return nil, nil
 	}
 
-	// Process stderr entries
-	if stderr.Len() > 0 {
-		scanner := bufio.NewScanner(strings.NewReader(stderr.String()))
-		for scanner.Scan() {
-			line := scanner.Text()
-			if line == "" {
-				continue
-			}
+	var entries []dockerLogEntry
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
 
-			// Parse timestamp from line if present
-			parts := strings.SplitN(line, " ", 2)
-			var timestamp, msg string
-			if len(parts) == 2 {
-				// Try to parse as timestamp
-				if _, err := time.Parse(time.RFC3339Nano, parts[0]); err == nil {
-					timestamp = parts[0]
-					msg = parts[1]
-				} else {
-					// Not a timestamp, treat whole line as message
-					timestamp = time.Now().Format(time.RFC3339Nano)
-					msg = line
-				}
+		// Parse timestamp from line if present
+		// Docker format with timestamps: "2025-11-12T14:23:45.123456789Z message"
+		parts := strings.SplitN(line, " ", 2)
+		var timestamp, msg string
+		if len(parts) == 2 {
+			// Try to parse as timestamp
+			if _, err := time.Parse(time.RFC3339Nano, parts[0]); err == nil {
+				timestamp = parts[0]
+				msg = parts[1]
 			} else {
+				// Not a timestamp, treat whole line as message
 				timestamp = time.Now().Format(time.RFC3339Nano)
 				msg = line
 			}
-
-			entries = append(entries, dockerLogEntry{
-				timestamp: timestamp,
-				stream:    "stderr",
-				message:   msg,
-			})
+		} else {
+			timestamp = time.Now().Format(time.RFC3339Nano)
+			msg = line
 		}
-		if err := scanner.Err(); err != nil {
-			return nil, err
+
+		entries = append(entries, dockerLogEntry{
+			timestamp: timestamp,
+			stream:    stream,
+			message:   msg,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// mergeDockerLogEntries merges two chronologically ordered entry slices in a single pass.
+// On equal timestamps stdout entries come first, matching the previous stable ordering.
+func mergeDockerLogEntries(stdout, stderr []dockerLogEntry) []dockerLogEntry {
+	if len(stderr) == 0 {
+		return stdout
+	}
+	if len(stdout) == 0 {
+		return stderr
+	}
+
+	merged := make([]dockerLogEntry, 0, len(stdout)+len(stderr))
+	i, j := 0, 0
+	for i < len(stdout) && j < len(stderr) {
+		if stderr[j].timestamp < stdout[i].timestamp {
+			merged = append(merged, stderr[j])
+			j++
+		} else {
+			merged = append(merged, stdout[i])
+			i++
 		}
 	}
+	merged = append(merged, stdout[i:]...)
+	merged = append(merged, stderr[j:]...)
 
-	// Sort entries by timestamp to maintain chronological order
-	// (since stdout and stderr are processed separately)
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].timestamp < entries[j].timestamp
-	})
+	return merged
+}
 
-	return entries, nil
+// maxContainerInspections is the number of containers inspected at once when listing them
+const maxContainerInspections = 8
+
+// inspectContainerItems fills in the restart count and start time of each container, inspecting
+// up to maxContainerInspections at once. A container that fails to inspect is listed without them.
+func inspectContainerItems(ctx context.Context, cli *client.Client, items []list.Item) {
+	var g errgroup.Group
+	g.SetLimit(maxContainerInspections)
+	for i := range items {
+		g.Go(func() error {
+			c := items[i].(containerItem)
+			inspect, err := cli.ContainerInspect(ctx, c.id, client.ContainerInspectOptions{})
+			if err != nil {
+				return nil
+			}
+			c.restartCount = inspect.Container.RestartCount
+			if inspect.Container.State != nil {
+				c.startedAt, _ = time.Parse(time.RFC3339Nano, inspect.Container.State.StartedAt)
+			}
+			items[i] = c
+			return nil
+		})
+	}
+	_ = g.Wait() // Failed inspections are skipped, not returned
 }
 
-func handleDockerLogs(ctx context.Context) error {
+// handleDockerLogs runs the docker logs UI. A non-empty container opens the logs of the running
+// container it matches right away, or the picker filtered by it when several match.
+func handleDockerLogs(ctx context.Context, container string, followInterval time.Duration, columns dockerLogColumns, jsonFormat logJSONFormat, alert *logAlert, limits logBufferLimits) error {
 	cli, err := client.New(client.FromEnv)
 	if err != nil {
 		return fmt.Errorf("failed to connect to Docker: %w", err)
@@ -1106,21 +1536,11 @@ func handleDockerLogs(ctx context.Context) error {
 		return nil
 	}
 
-	// Calculate maximum container name length for alignment
-	maxNameLength := 0
-	for _, c := range containersSummary.Items {
-		name := containerDisplayName(c.ID, c.Names)
-		if len(name) > maxNameLength {
-			maxNameLength = len(name)
-		}
-	}
-
 	// Convert containers to list items
 	items := make([]list.Item, len(containersSummary.Items))
 	for i, c := range containersSummary.Items {
 		name := containerDisplayName(c.ID, c.Names)
-		// Use the simple string status for now (e.g., "Up 2 hours (healthy)")
-		// The State field is a complex struct and we'd need to inspect for details
+		// Use the simple string status (e.g., "Up 2 hours (healthy)"), restarts come from inspecting below
 		statusDisplay := c.Status
 
 		// Extract simple state from status string for coloring
@@ -1133,30 +1553,31 @@ func handleDockerLogs(ctx context.Context) error {
 			simpleState = "paused"
 		}
 
+		// Alignment width is computed per page by containerDelegate
 		items[i] = containerItem{
-			name:      name,
-			id:        c.ID,
-			status:    simpleState,
-			state:     statusDisplay,
-			maxLength: maxNameLength,
+			name:   name,
+			id:     c.ID,
+			image:  shortImageRef(c.Image),
+			status: simpleState,
+			state:  statusDisplay,
 		}
 	}
+	inspectContainerItems(ctx, cli, items)
 
 	// Sort by name
-	sort.Slice(items, func(i, j int) bool {
-		return items[i].(containerItem).name < items[j].(containerItem).name
-	})
+	sortContainerItems(items, false)
 
 	// Create a list with styling for inline display
-	listDelegate := list.NewDefaultDelegate()
+	listDelegate := containerDelegate{DefaultDelegate: list.NewDefaultDelegate()}
 	listDelegate.ShowDescription = false
 
 	// Initialize with 0, 0 like the example - will be sized in WindowSizeMsg
 	listModel := list.New(items, listDelegate, 0, 0)
-	listModel.Title = "Docker Containers"
+	listModel.Title = containerListTitle(false)
 	listModel.Styles.Title = lipgloss.NewStyle().Foreground(lipgloss.Color("10")).UnsetBackground()
-	listModel.SetFilteringEnabled(false)
-	listModel.SetShowHelp(false) // We'll use our own help
+	listModel.SetFilteringEnabled(true)
+	listModel.Filter = list.UnsortedFilter // Keep name ordering while filtering
+	listModel.SetShowHelp(false)           // We'll use our own help
 
 	// Enable pagination to show dots
 	listModel.SetShowPagination(true)
@@ -1182,14 +1603,35 @@ func handleDockerLogs(ctx context.Context) error {
 		viewportInitialized: false,
 		loading:             false,
 		err:                 nil,
-		showTimestampStream: true, // Show timestamp/stream by default
+		columns:             columns,
+		json:                jsonFormat,
+		limits:              limits,
 		followMode:          false,
+		followInterval:      followInterval,
+		alert:               alert,
 		dockerClient:        cli,
 	}
 
+	if container != "" {
+		matches := matchContainerItems(items, container)
+		switch len(matches) {
+		case 0:
+			return fmt.Errorf("no running container matches %q", container)
+		case 1:
+			initialModel.selectContainer(matches[0])
+		default:
+			initialModel.list.SetFilterText(container)
+		}
+	}
+
 	// Run the program with alt screen controlled declaratively in View().
 	p := tea.NewProgram(initialModel, tea.WithContext(ctx))
-	if _, err := p.Run(); err != nil {
+	finalModel, err := p.Run()
+	// A reconnect in follow mode replaces the client
+	if final, ok := finalModel.(dockerLogsModel); ok && final.dockerClient != nil && final.dockerClient != cli {
+		_ = final.dockerClient.Close()
+	}
+	if err != nil {
 		return fmt.Errorf("error running docker logs UI: %w", err)
 	}
 
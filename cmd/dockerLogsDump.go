@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/signals"
+	"github.com/saltyorg/sb-go/internal/tty"
+
+	"github.com/moby/moby/client"
+)
+
+// handleDockerLogsDump prints the most recent count log lines of a container to stdout (all lines
+// when count is 0), then keeps printing new lines every followInterval when follow is set.
+// This bypasses the interactive UI so the output can be piped or used in scripts. Lines are
+// formatted like the viewer formats them, with the container's colors kept only on a terminal,
+// or as JSON objects with output set to logOutputJSON.
+func handleDockerLogsDump(ctx context.Context, containerName string, count int, follow bool, followInterval time.Duration, columns dockerLogColumns, jsonFormat logJSONFormat, pageSize int, output logOutputFormat) error {
+	if count < 0 {
+		return fmt.Errorf("invalid number of lines: %d (must be 0 or more)", count)
+	}
+
+	cli, err := client.New(client.FromEnv)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+	defer func() { _ = cli.Close() }()
+
+	inspect, err := cli.ContainerInspect(ctx, containerName, client.ContainerInspectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to find container %s: %w", containerName, err)
+	}
+
+	source := containerLogSource{
+		client:      cli,
+		containerID: inspect.Container.ID,
+		pageSize:    pageSize,
+		columns:     columns,
+		json:        jsonFormat,
+		stripColors: !tty.IsInteractive(),
+	}
+	if count > 0 && count < source.pageSize {
+		// A single page of exactly count lines is enough
+		source.pageSize = count
+	}
+
+	err = dumpDockerLogs(ctx, os.Stdout, source, count, follow, followInterval, output)
+	// Exit quietly with the signal manager's exit code when interrupted
+	if err != nil && signals.GetGlobalManager().IsShutdown() {
+		return nil
+	}
+	return err
+}
+
+// dumpDockerLogs writes the most recent count entries of source to w, then polls for and writes
+// new entries every followInterval until ctx is done when follow is set
+func dumpDockerLogs(ctx context.Context, w io.Writer, source logSource[dockerLogEntry], count int, follow bool, followInterval time.Duration, output logOutputFormat) error {
+	entries, err := collectDockerLogEntries(func(timestamp string) dockerLogsMsg {
+		return source.Fetch(timestamp, true, false)().(dockerLogsMsg)
+	}, count)
+	if err != nil {
+		return err
+	}
+	if err := writeDockerLogEntries(w, source, entries, output); err != nil {
+		return err
+	}
+	if !follow {
+		return nil
+	}
+
+	// Continue from the newest line printed, or from now if the container hasn't logged anything
+	after := time.Now().UTC().Format(time.RFC3339Nano)
+	if len(entries) > 0 {
+		after = source.Position(entries[len(entries)-1])
+	}
+
+	ticker := time.NewTicker(followInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		// A tick can win the race against cancellation
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		msg := source.Fetch(after, false, true)().(dockerLogsMsg)
+		if msg.err != nil {
+			return msg.err
+		}
+		if err := writeDockerLogEntries(w, source, msg.entries, output); err != nil {
+			return err
+		}
+		if len(msg.entries) > 0 {
+			after = msg.lastTimestamp
+		}
+	}
+}
+
+// dockerLogEntryJSON is a container log entry as written by --output json
+type dockerLogEntryJSON struct {
+	Timestamp string `json:"timestamp"`
+	Stream    string `json:"stream"`
+	Message   string `json:"message"`
+}
+
+// writeDockerLogEntries writes entries to w one per line, formatted with all of source's columns,
+// or as JSON objects holding the messages as logged
+func writeDockerLogEntries(w io.Writer, source logSource[dockerLogEntry], entries []dockerLogEntry, output logOutputFormat) error {
+	if output == logOutputJSON {
+		encoder := newLogJSONEncoder(w)
+		for _, entry := range entries {
+			err := encoder.Encode(dockerLogEntryJSON{Timestamp: entry.timestamp, Stream: entry.stream, Message: entry.message})
+			if err != nil {
+				return fmt.Errorf("failed to write log entry: %w", err)
+			}
+		}
+		return nil
+	}
+
+	for _, entry := range entries {
+		if _, err := fmt.Fprintln(w, source.Format(entry, true)); err != nil {
+			return fmt.Errorf("failed to write log entry: %w", err)
+		}
+	}
+	return nil
+}
+
+// collectDockerLogEntries pages backwards through a container's logs using fetch until count
+// entries are found (all entries when count is 0) or the start of the logs is reached.
+// Entries are returned oldest first.
+func collectDockerLogEntries(fetch func(timestamp string) dockerLogsMsg, count int) ([]dockerLogEntry, error) {
+	// Pages arrive newest first, each ordered oldest to newest
+	var pages [][]dockerLogEntry
+	var total int
+	timestamp := ""
+
+	for {
+		msg := fetch(timestamp)
+		if msg.err != nil {
+			return nil, msg.err
+		}
+		pages = append(pages, msg.entries)
+		total += len(msg.entries)
+
+		if count > 0 && total >= count {
+			break
+		}
+		if !msg.hasMore || len(msg.entries) == 0 {
+			break
+		}
+		if msg.firstTimestamp == timestamp {
+			return nil, errors.New("failed to page through logs: timestamp did not advance")
+		}
+		timestamp = msg.firstTimestamp
+	}
+
+	entries := make([]dockerLogEntry, 0, total)
+	for _, page := range slices.Backward(pages) {
+		entries = append(entries, page...)
+	}
+	if count > 0 && len(entries) > count {
+		entries = entries[len(entries)-count:]
+	}
+	return entries, nil
+}
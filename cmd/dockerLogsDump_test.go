@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// fakeDockerLogs serves pages of entries newest page first, like fetchDockerLogs in reverse mode
+func fakeDockerLogs(t *testing.T, pages [][]dockerLogEntry) func(timestamp string) dockerLogsMsg {
+	return func(timestamp string) dockerLogsMsg {
+		index := 0
+		if timestamp != "" {
+			if _, err := fmt.Sscanf(timestamp, "page-%d", &index); err != nil {
+				t.Fatalf("unexpected timestamp %q", timestamp)
+			}
+		}
+		return dockerLogsMsg{
+			entries:        pages[index],
+			firstTimestamp: fmt.Sprintf("page-%d", index+1),
+			hasMore:        index+1 < len(pages),
+		}
+	}
+}
+
+func TestCollectDockerLogEntries(t *testing.T) {
+	pages := [][]dockerLogEntry{
+		{{message: "3"}, {message: "4"}, {message: "5"}},
+		{{message: "1"}, {message: "2"}},
+	}
+
+	tests := []struct {
+		name  string
+		count int
+		want  []string
+	}{
+		{"within the newest page", 2, []string{"4", "5"}},
+		{"across pages", 4, []string{"2", "3", "4", "5"}},
+		{"all lines", 0, []string{"1", "2", "3", "4", "5"}},
+		{"more than logged", 10, []string{"1", "2", "3", "4", "5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := collectDockerLogEntries(fakeDockerLogs(t, pages), tt.count)
+			if err != nil {
+				t.Fatalf("collectDockerLogEntries() error = %v", err)
+			}
+			var got []string
+			for _, entry := range entries {
+				got = append(got, entry.message)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("collectDockerLogEntries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectDockerLogEntriesStuckTimestamp(t *testing.T) {
+	fetch := func(timestamp string) dockerLogsMsg {
+		return dockerLogsMsg{entries: []dockerLogEntry{{message: "line"}}, firstTimestamp: "same", hasMore: true}
+	}
+	if _, err := collectDockerLogEntries(fetch, 0); err == nil {
+		t.Fatal("expected an error when the timestamp does not advance")
+	}
+}
+
+// scriptedContainerLogSource answers fetches with fetch, formatting entries like a container source
+type scriptedContainerLogSource struct {
+	containerLogSource
+	fetch func(position string, reverse bool) dockerLogsMsg
+}
+
+func (s scriptedContainerLogSource) Fetch(position string, reverse bool, isPrefetch bool) tea.Cmd {
+	return func() tea.Msg { return s.fetch(position, reverse) }
+}
+
+func TestDumpDockerLogsFollow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var afters []string
+	source := scriptedContainerLogSource{
+		containerLogSource: containerLogSource{columns: dockerLogColumns{mode: logColumnsStream}, stripColors: true},
+		fetch: func(position string, reverse bool) dockerLogsMsg {
+			if reverse {
+				return dockerLogsMsg{entries: []dockerLogEntry{{timestamp: "t1", stream: "stdout", message: "starting"}}}
+			}
+			afters = append(afters, position)
+			if len(afters) == 2 {
+				cancel()
+			}
+			return dockerLogsMsg{entries: []dockerLogEntry{{timestamp: "t2", stream: "stderr", message: "\x1b[31mfailed\x1b[0m"}}, lastTimestamp: "t2"}
+		},
+	}
+
+	var out bytes.Buffer
+	if err := dumpDockerLogs(ctx, &out, source, 10, true, time.Millisecond, logOutputText); err != nil {
+		t.Fatalf("dumpDockerLogs() error = %v", err)
+	}
+
+	want := "stdout │ starting\nstderr │ failed\nstderr │ failed\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+	if strings.Join(afters, ",") != "t1,t2" {
+		t.Errorf("followed from %v, want [t1 t2]", afters)
+	}
+}
+
+func TestDumpDockerLogsJSON(t *testing.T) {
+	source := scriptedContainerLogSource{
+		containerLogSource: containerLogSource{columns: dockerLogColumns{mode: logColumnsStream}, stripColors: true},
+		fetch: func(position string, reverse bool) dockerLogsMsg {
+			return dockerLogsMsg{entries: []dockerLogEntry{
+				{timestamp: "2025-11-12T14:00:00.000000001Z", stream: "stdout", message: "starting"},
+				{timestamp: "2025-11-12T14:00:00.000000002Z", stream: "stderr", message: "\x1b[31mfailed\x1b[0m"},
+			}}
+		},
+	}
+
+	var out bytes.Buffer
+	if err := dumpDockerLogs(context.Background(), &out, source, 10, false, time.Millisecond, logOutputJSON); err != nil {
+		t.Fatalf("dumpDockerLogs() error = %v", err)
+	}
+
+	// Messages are kept as logged rather than formatted with the source's columns
+	want := `{"timestamp":"2025-11-12T14:00:00.000000001Z","stream":"stdout","message":"starting"}` + "\n" +
+		`{"timestamp":"2025-11-12T14:00:00.000000002Z","stream":"stderr","message":"\u001b[31mfailed\u001b[0m"}` + "\n"
+	if out.String() != want {
+		t.Errorf("output = %s, want %s", out.String(), want)
+	}
+}
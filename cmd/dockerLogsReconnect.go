@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/signals"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/moby/moby/client"
+)
+
+const (
+	dockerReconnectAttempts = 10              // Reconnection attempts before follow mode gives up
+	dockerReconnectDelay    = 2 * time.Second // Wait before each reconnection attempt
+)
+
+// dockerReconnectedMsg is the result of a reconnection attempt
+type dockerReconnectedMsg struct {
+	container   string // Name of the container the attempt was for
+	client      *client.Client
+	containerID string
+	err         error
+}
+
+// reconnectDocker returns a command that waits, then connects to the Docker daemon again and
+// resolves the container by name, as a restarted daemon or a recreated container changes its ID
+func reconnectDocker(containerName string, delay time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		parentCtx := signals.GetGlobalManager().Context()
+		select {
+		case <-time.After(delay):
+		case <-parentCtx.Done():
+			return dockerReconnectedMsg{container: containerName, err: parentCtx.Err()}
+		}
+
+		ctx, cancel := context.WithTimeout(parentCtx, 10*time.Second)
+		defer cancel()
+
+		cli, err := client.New(client.FromEnv)
+		if err != nil {
+			return dockerReconnectedMsg{container: containerName, err: fmt.Errorf("failed to connect to Docker: %w", err)}
+		}
+		inspect, err := cli.ContainerInspect(ctx, containerName, client.ContainerInspectOptions{})
+		if err != nil {
+			_ = cli.Close()
+			return dockerReconnectedMsg{container: containerName, err: fmt.Errorf("failed to find container %s: %w", containerName, err)}
+		}
+		return dockerReconnectedMsg{container: containerName, client: cli, containerID: inspect.Container.ID}
+	}
+}
+
+// startDockerReconnect handles a failed follow fetch by reconnecting to Docker, showing the attempt as the status.
+// Follow mode is stopped with an error once all attempts have failed.
+func (m dockerLogsModel) startDockerReconnect(err error) (dockerLogsModel, tea.Cmd) {
+	if m.reconnectAttempts >= dockerReconnectAttempts {
+		m.reconnectAttempts = 0
+		m.followMode = false
+		if m.logBuf != nil {
+			m.logBuf.StopFollow()
+			m.viewport.SetContent(m.logBuf.GetContent(m.followMode))
+		}
+		m.status = ""
+		m.err = fmt.Errorf("lost connection to Docker, stopped following after %d reconnection attempts: %w", dockerReconnectAttempts, err)
+		return m, nil
+	}
+
+	m.reconnectAttempts++
+	m.statusID++
+	m.status = fmt.Sprintf("Reconnecting to Docker (attempt %d/%d)...", m.reconnectAttempts, dockerReconnectAttempts)
+	return m, reconnectDocker(m.selectedContainer, dockerReconnectDelay)
+}
+
+// finishDockerReconnect switches to the new client and container, resuming follow from the last timestamp
+func (m dockerLogsModel) finishDockerReconnect(msg dockerReconnectedMsg) (dockerLogsModel, tea.Cmd) {
+	// Reconnection only continues while follow mode is still active for the container
+	if !m.followMode || m.reconnectAttempts == 0 || msg.container != m.selectedContainer {
+		if msg.client != nil {
+			_ = msg.client.Close()
+		}
+		m.reconnectAttempts = 0
+		return m, nil
+	}
+	if msg.err != nil {
+		return m.startDockerReconnect(msg.err)
+	}
+
+	if m.dockerClient != nil {
+		_ = m.dockerClient.Close()
+	}
+	m.dockerClient = msg.client
+	m.selectedContainerID = msg.containerID
+	if m.logBuf != nil {
+		m.logBuf.source = m.logSource()
+	}
+	m.reconnectAttempts = 0
+	m.err = nil
+	m.statusID++
+	m.status = "Reconnected to Docker"
+	return m, clearLogStatusAfter(m.statusID)
+}
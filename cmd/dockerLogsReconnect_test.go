@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/moby/moby/client"
+)
+
+// newFollowingDockerLogsModel returns a model following the logs of the plex container
+func newFollowingDockerLogsModel() dockerLogsModel {
+	lb, _ := newTestDockerLogBuffer(100)
+	lb.after = "2025-06-01T12:00:00.000000000Z"
+	lb.StartFollow(defaultFollowInterval)
+	return dockerLogsModel{
+		activeView:          "logs",
+		selectedContainer:   "plex",
+		selectedContainerID: "old-id",
+		followMode:          true,
+		logBuf:              lb,
+	}
+}
+
+func TestDockerLogsFollowErrorStartsReconnect(t *testing.T) {
+	m := newFollowingDockerLogsModel()
+
+	updated, cmd := m.Update(dockerLogsMsg{isPrefetch: true, err: errors.New("connection refused")})
+	m = updated.(dockerLogsModel)
+	if cmd == nil || m.reconnectAttempts != 1 {
+		t.Fatalf("follow error did not start reconnecting: attempts = %d", m.reconnectAttempts)
+	}
+	if m.err != nil || !strings.Contains(m.status, "Reconnecting to Docker (attempt 1/") {
+		t.Errorf("status = %q, err = %v, want a reconnecting status", m.status, m.err)
+	}
+
+	// Follow ticks don't fetch while reconnecting
+	if _, cmd := m.Update(followTickMsg{}); cmd == nil {
+		t.Error("follow tick stopped ticking while reconnecting")
+	}
+}
+
+func TestDockerLogsReconnectGivesUp(t *testing.T) {
+	m := newFollowingDockerLogsModel()
+	m, _ = m.startDockerReconnect(errors.New("connection refused"))
+
+	for range dockerReconnectAttempts {
+		m, _ = m.finishDockerReconnect(dockerReconnectedMsg{container: "plex", err: errors.New("connection refused")})
+	}
+	if m.followMode || m.logBuf.followActive {
+		t.Error("follow mode still active after all reconnection attempts failed")
+	}
+	if m.err == nil || !strings.Contains(m.err.Error(), "stopped following") {
+		t.Errorf("err = %v, want a clear give up message", m.err)
+	}
+}
+
+func TestDockerLogsReconnectResumesFollow(t *testing.T) {
+	cli, err := client.New(client.FromEnv)
+	if err != nil {
+		t.Fatalf("create Docker client: %v", err)
+	}
+
+	m := newFollowingDockerLogsModel()
+	m, _ = m.startDockerReconnect(errors.New("connection refused"))
+
+	// Attempts for a container that is no longer shown are discarded
+	otherCli, err := client.New(client.FromEnv)
+	if err != nil {
+		t.Fatalf("create Docker client: %v", err)
+	}
+	if other, _ := m.finishDockerReconnect(dockerReconnectedMsg{container: "sonarr", client: otherCli, containerID: "sonarr-id"}); other.selectedContainerID != "old-id" || other.reconnectAttempts != 0 {
+		t.Errorf("reconnect for another container applied: %+v", other.selectedContainerID)
+	}
+
+	m, cmd := m.finishDockerReconnect(dockerReconnectedMsg{container: "plex", client: cli, containerID: "new-id"})
+	if cmd == nil || m.reconnectAttempts != 0 || m.status != "Reconnected to Docker" {
+		t.Errorf("reconnect not finished: attempts = %d, status = %q", m.reconnectAttempts, m.status)
+	}
+	if m.selectedContainerID != "new-id" || m.dockerClient != cli {
+		t.Errorf("container ID = %q, want the resolved ID and client", m.selectedContainerID)
+	}
+	if !m.followMode || m.logBuf.after != "2025-06-01T12:00:00.000000000Z" {
+		t.Errorf("follow does not resume from the last timestamp: follow = %t, after = %q", m.followMode, m.logBuf.after)
+	}
+}
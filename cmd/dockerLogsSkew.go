@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"time"
+)
+
+// dockerSkewOverlap widens the timestamp bound of a page once a container's timestamps were seen
+// going backwards, so entries logged around a clock jump are fetched again rather than skipped
+const dockerSkewOverlap = 2 * time.Second
+
+// dockerClockSkewWarning is shown next to the help while pages are fetched with an overlap
+const dockerClockSkewWarning = "Clock skew detected, paging with overlap"
+
+// dockerTimestampsMonotonic reports whether the timestamps of entries never go backwards.
+// Docker returns each stream in the order it was logged, so a step back means the clock jumped.
+// Entries with a timestamp that doesn't parse are ignored.
+func dockerTimestampsMonotonic(entries []dockerLogEntry) bool {
+	var previous time.Time
+	for _, entry := range entries {
+		ts, err := time.Parse(time.RFC3339Nano, entry.timestamp)
+		if err != nil {
+			continue
+		}
+		if ts.Before(previous) {
+			return false
+		}
+		previous = ts
+	}
+	return true
+}
+
+// dockerTimestampRange returns the earliest and latest timestamps of entries, which differ from
+// the first and last entry when the timestamps are skewed
+func dockerTimestampRange(entries []dockerLogEntry) (oldest, newest string) {
+	var oldestTime, newestTime time.Time
+	for _, entry := range entries {
+		ts, err := time.Parse(time.RFC3339Nano, entry.timestamp)
+		if err != nil {
+			continue
+		}
+		if oldest == "" || ts.Before(oldestTime) {
+			oldest, oldestTime = entry.timestamp, ts
+		}
+		if newest == "" || ts.After(newestTime) {
+			newest, newestTime = entry.timestamp, ts
+		}
+	}
+	return oldest, newest
+}
+
+// shiftDockerTimestamp moves an RFC3339Nano timestamp by d, returning it unchanged if it doesn't parse
+func shiftDockerTimestamp(timestamp string, d time.Duration) string {
+	ts, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return timestamp
+	}
+	return ts.Add(d).Format(time.RFC3339Nano)
+}
+
+// dedupeDockerLogEntries drops the entries of an overlapping page that are already loaded.
+// Each loaded entry cancels out one fetched entry, so identical lines logged more than once are kept.
+func dedupeDockerLogEntries(loaded, fetched []dockerLogEntry) []dockerLogEntry {
+	if len(loaded) == 0 {
+		return fetched
+	}
+	seen := make(map[dockerLogEntry]int, len(loaded))
+	for _, entry := range loaded {
+		seen[entry]++
+	}
+	deduped := make([]dockerLogEntry, 0, len(fetched))
+	for _, entry := range fetched {
+		if seen[entry] > 0 {
+			seen[entry]--
+			continue
+		}
+		deduped = append(deduped, entry)
+	}
+	return deduped
+}
+
+// dockerLogsEdge returns up to n of the loaded entries at the end a page is being added to,
+// the oldest for older pages and the newest otherwise
+func dockerLogsEdge(entries []dockerLogEntry, n int, older bool) []dockerLogEntry {
+	n = min(n, len(entries))
+	if older {
+		return entries[:n]
+	}
+	return entries[len(entries)-n:]
+}
+
+// dockerBoundPastPage returns the bound to fetch from after a full overlapped page held only
+// loaded entries. Fetches widen the bound by the overlap again, so it's moved past the page by
+// that much. ok is false when the bound wouldn't move beyond current, as paging can't go further.
+func dockerBoundPastPage(msg dockerLogsMsg, current string) (bound string, ok bool) {
+	edge, shift := msg.lastTimestamp, dockerSkewOverlap
+	if msg.reverse {
+		edge, shift = msg.firstTimestamp, -dockerSkewOverlap
+	}
+	currentTime, err := time.Parse(time.RFC3339Nano, current)
+	if err != nil {
+		return "", false
+	}
+	edgeTime, err := time.Parse(time.RFC3339Nano, edge)
+	if err != nil {
+		return "", false
+	}
+	boundTime := edgeTime.Add(shift)
+	if msg.reverse && !boundTime.Before(currentTime) || !msg.reverse && !boundTime.After(currentTime) {
+		return "", false
+	}
+	return boundTime.Format(time.RFC3339Nano), true
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"charm.land/bubbles/v2/help"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestDockerTimestampsMonotonic(t *testing.T) {
+	entries := func(timestamps ...string) []dockerLogEntry {
+		var result []dockerLogEntry
+		for _, ts := range timestamps {
+			result = append(result, dockerLogEntry{timestamp: ts})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name    string
+		entries []dockerLogEntry
+		want    bool
+	}{
+		{"empty", nil, true},
+		{"ascending", entries("2025-06-01T12:00:00Z", "2025-06-01T12:00:00.5Z", "2025-06-01T12:00:01Z"), true},
+		{"equal", entries("2025-06-01T12:00:00Z", "2025-06-01T12:00:00Z"), true},
+		{"backwards", entries("2025-06-01T12:00:01Z", "2025-06-01T11:59:00Z", "2025-06-01T12:00:02Z"), false},
+		// RFC3339Nano trims trailing zeros, so timestamps can't be compared as strings
+		{"trimmed zeros", entries("2025-06-01T12:00:00.9Z", "2025-06-01T12:00:00.123456789Z"), false},
+		{"unparsable ignored", entries("2025-06-01T12:00:00Z", "garbage", "2025-06-01T12:00:01Z"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dockerTimestampsMonotonic(tt.entries); got != tt.want {
+				t.Errorf("dockerTimestampsMonotonic() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDockerTimestampRange(t *testing.T) {
+	entries := []dockerLogEntry{
+		{timestamp: "2025-06-01T12:00:05Z"},
+		{timestamp: "2025-06-01T11:58:00Z"},
+		{timestamp: "2025-06-01T12:00:06Z"},
+		{timestamp: "2025-06-01T11:58:01Z"},
+	}
+	oldest, newest := dockerTimestampRange(entries)
+	if oldest != "2025-06-01T11:58:00Z" || newest != "2025-06-01T12:00:06Z" {
+		t.Errorf("dockerTimestampRange() = %q, %q", oldest, newest)
+	}
+}
+
+func TestShiftDockerTimestamp(t *testing.T) {
+	if got := shiftDockerTimestamp("2025-06-01T12:00:00.5Z", -dockerSkewOverlap); got != "2025-06-01T11:59:58.5Z" {
+		t.Errorf("shiftDockerTimestamp() = %q", got)
+	}
+	if got := shiftDockerTimestamp("garbage", dockerSkewOverlap); got != "garbage" {
+		t.Errorf("shiftDockerTimestamp() = %q, want the timestamp unchanged", got)
+	}
+}
+
+func TestDedupeDockerLogEntries(t *testing.T) {
+	loaded := []dockerLogEntry{
+		{timestamp: "t1", stream: "stdout", message: "a"},
+		{timestamp: "t2", stream: "stdout", message: "b"},
+	}
+	fetched := []dockerLogEntry{
+		{timestamp: "t1", stream: "stdout", message: "a"},
+		{timestamp: "t1", stream: "stderr", message: "a"},
+		{timestamp: "t2", stream: "stdout", message: "b"},
+		{timestamp: "t2", stream: "stdout", message: "b"}, // Logged twice, only one copy is loaded
+		{timestamp: "t3", stream: "stdout", message: "c"},
+	}
+
+	got := dedupeDockerLogEntries(loaded, fetched)
+	want := []dockerLogEntry{fetched[1], fetched[3], fetched[4]}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("dedupeDockerLogEntries() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDockerLogsClockSkewPagesWithOverlap(t *testing.T) {
+	lb, _ := newTestDockerLogBuffer(100)
+	loaded := []dockerLogEntry{
+		{timestamp: "2025-06-01T12:00:00Z", stream: "stdout", message: "a"},
+		{timestamp: "2025-06-01T11:59:00Z", stream: "stdout", message: "b"},
+	}
+	lb.entries = append(lb.entries, loaded...)
+	lb.after = "2025-06-01T12:00:00Z"
+	lb.hasMoreAfter = true
+	m := dockerLogsModel{activeView: "logs", logBuf: lb, limits: dockerLimits, help: help.New(), keys: dockerKeys, width: 200, height: 30}
+
+	skewed := []dockerLogEntry{
+		{timestamp: "2025-06-01T12:00:02Z", stream: "stdout", message: "c"},
+		{timestamp: "2025-06-01T12:00:01Z", stream: "stdout", message: "d"},
+	}
+	updated, _ := m.Update(dockerLogsMsg{entries: skewed, lastTimestamp: "2025-06-01T12:00:02Z", skewed: true, isPrefetch: true})
+	m = updated.(dockerLogsModel)
+	if !m.clockSkew {
+		t.Fatal("expected skewed timestamps to be detected")
+	}
+	if source, ok := m.logBuf.source.(containerLogSource); !ok || !source.overlap {
+		t.Fatalf("source = %+v, want a container source paging with overlap", m.logBuf.source)
+	}
+	if view := m.View().Content; !strings.Contains(ansi.Strip(view), dockerClockSkewWarning) {
+		t.Errorf("view lacks the clock skew warning")
+	}
+
+	// An overlapping page repeating loaded entries only adds the new ones
+	before := len(m.logBuf.entries)
+	page := []dockerLogEntry{skewed[1], {timestamp: "2025-06-01T12:00:03Z", stream: "stdout", message: "e"}}
+	updated, _ = m.Update(dockerLogsMsg{entries: page, lastTimestamp: "2025-06-01T12:00:03Z", overlapped: true, isPrefetch: true})
+	m = updated.(dockerLogsModel)
+	if got := len(m.logBuf.entries) - before; got != 1 {
+		t.Errorf("added %d entries, want 1", got)
+	}
+}
+
+func TestDockerLogsPagesPastLoadedEntries(t *testing.T) {
+	lb, fetcher := newTestDockerLogBuffer(100)
+	loaded := []dockerLogEntry{
+		{timestamp: "2025-06-01T12:00:01Z", stream: "stdout", message: "a"},
+		{timestamp: "2025-06-01T12:00:02Z", stream: "stdout", message: "b"},
+	}
+	lb.entries = append(lb.entries, loaded...)
+	lb.before = "2025-06-01T12:00:01Z"
+	lb.hasMoreBefore = true
+	m := dockerLogsModel{activeView: "logs", logBuf: lb, limits: dockerLimits, help: help.New(), keys: dockerKeys, width: 200, height: 30, clockSkew: true}
+
+	// A full overlapped page holding only loaded entries keeps paging past it
+	page := dockerLogsMsg{entries: loaded, firstTimestamp: "2025-06-01T12:00:01Z", lastTimestamp: "2025-06-01T12:00:02Z", reverse: true, hasMore: true, isPrefetch: true, overlapped: true}
+	updated, cmd := m.Update(page)
+	m = updated.(dockerLogsModel)
+	if cmd == nil || !m.logBuf.hasMoreBefore || !m.logBuf.prefetching {
+		t.Fatal("expected a fetch past the page")
+	}
+	if want := (fetchCall{position: "2025-06-01T11:59:59Z", reverse: true, isPrefetch: true}); len(fetcher.calls) != 1 || fetcher.calls[0] != want {
+		t.Errorf("fetches = %+v, want %+v", fetcher.calls, want)
+	}
+	if len(m.logBuf.entries) != len(loaded) {
+		t.Errorf("entries = %d, want %d", len(m.logBuf.entries), len(loaded))
+	}
+
+	// A bound that can't move any further is the start of the logs
+	page.firstTimestamp = "2025-06-01T12:00:02Z"
+	updated, _ = m.Update(page)
+	m = updated.(dockerLogsModel)
+	if m.logBuf.hasMoreBefore || m.logBuf.prefetching || len(fetcher.calls) != 1 {
+		t.Errorf("hasMoreBefore = %v, prefetching = %v, fetches = %d, want the start of the logs", m.logBuf.hasMoreBefore, m.logBuf.prefetching, len(fetcher.calls))
+	}
+
+	// Without more entries an empty page is the boundary
+	lb.hasMoreAfter = true
+	lb.after = "2025-06-01T12:00:02Z"
+	updated, _ = m.Update(dockerLogsMsg{entries: loaded[1:], lastTimestamp: "2025-06-01T12:00:02Z", isPrefetch: true, overlapped: true})
+	m = updated.(dockerLogsModel)
+	if m.logBuf.hasMoreAfter || len(fetcher.calls) != 1 {
+		t.Errorf("hasMoreAfter = %v, fetches = %d, want the end of the logs", m.logBuf.hasMoreAfter, len(fetcher.calls))
+	}
+}
@@ -0,0 +1,409 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"charm.land/bubbles/v2/help"
+	"charm.land/bubbles/v2/list"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestSortContainerItemsByStatus(t *testing.T) {
+	items := []list.Item{
+		containerItem{name: "sonarr", status: "running", state: "Up 2 hours (healthy)"},
+		containerItem{name: "radarr", status: "exited", state: "Exited (1) 5 minutes ago"},
+		containerItem{name: "plex", status: "running", state: "Up 2 hours (unhealthy)"},
+		containerItem{name: "authelia", status: "running", state: "Up 3 days"},
+		containerItem{name: "bazarr", status: "created", state: "Created"},
+	}
+
+	sortContainerItems(items, true)
+
+	want := []string{"radarr", "plex", "bazarr", "authelia", "sonarr"}
+	for i, name := range want {
+		if got := items[i].(containerItem).name; got != name {
+			t.Fatalf("items[%d] = %q, want %q", i, got, name)
+		}
+	}
+
+	sortContainerItems(items, false)
+
+	want = []string{"authelia", "bazarr", "plex", "radarr", "sonarr"}
+	for i, name := range want {
+		if got := items[i].(containerItem).name; got != name {
+			t.Fatalf("items[%d] = %q, want %q", i, got, name)
+		}
+	}
+}
+
+// buildDockerLogStream returns a multiplexed log stream with lines alternating between stdout and stderr
+func buildDockerLogStream(tb testing.TB, lines int) []byte {
+	tb.Helper()
+
+	var buf bytes.Buffer
+	start := time.Date(2025, 11, 12, 14, 0, 0, 0, time.UTC)
+
+	for i := range lines {
+		// stdcopy frame header: stream type (1 = stdout, 2 = stderr), 3 padding bytes, big-endian payload size
+		header := make([]byte, 8)
+		header[0] = 1
+		if i%3 == 0 {
+			header[0] = 2
+		}
+		timestamp := start.Add(time.Duration(i) * time.Millisecond).Format("2006-01-02T15:04:05.000000000Z07:00")
+		payload := fmt.Sprintf("%s line %d\n", timestamp, i)
+		binary.BigEndian.PutUint32(header[4:], uint32(len(payload)))
+		buf.Write(header)
+		buf.WriteString(payload)
+	}
+
+	return buf.Bytes()
+}
+
+func TestParseDockerLogsMergesStreamsInOrder(t *testing.T) {
+	entries, err := parseDockerLogs(bytes.NewReader(buildDockerLogStream(t, 100)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 100 {
+		t.Fatalf("expected 100 entries, got %d", len(entries))
+	}
+	for i, entry := range entries {
+		if want := fmt.Sprintf("line %d", i); entry.message != want {
+			t.Fatalf("entries[%d].message = %q, want %q", i, entry.message, want)
+		}
+		wantStream := "stdout"
+		if i%3 == 0 {
+			wantStream = "stderr"
+		}
+		if entry.stream != wantStream {
+			t.Fatalf("entries[%d].stream = %q, want %q", i, entry.stream, wantStream)
+		}
+	}
+}
+
+func BenchmarkParseDockerLogs(b *testing.B) {
+	data := buildDockerLogStream(b, 50000)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := parseDockerLogs(bytes.NewReader(data)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// fakeContainerLogSource records fetch requests instead of calling the Docker API
+type fakeContainerLogSource struct {
+	containerLogSource
+	calls []fetchCall
+}
+
+func (f *fakeContainerLogSource) Fetch(position string, reverse bool, isPrefetch bool) tea.Cmd {
+	f.calls = append(f.calls, fetchCall{position: position, reverse: reverse, isPrefetch: isPrefetch})
+	return func() tea.Msg { return dockerLogsMsg{} }
+}
+
+// newTestDockerLogBuffer returns a container log buffer backed by a fake source
+func newTestDockerLogBuffer(targetSize int) (*dockerLogBuffer, *fakeContainerLogSource) {
+	source := &fakeContainerLogSource{}
+	return newLogViewBuffer(source, dockerLimits, targetSize), source
+}
+
+func TestDockerLogBufferPrependOlder(t *testing.T) {
+	lb, fetcher := newTestDockerLogBuffer(100)
+	lb.entries = []dockerLogEntry{{timestamp: "t3"}, {timestamp: "t4"}}
+	lb.prefetching = true
+
+	cmd := lb.PrependOlder([]dockerLogEntry{{timestamp: "t1"}, {timestamp: "t2"}}, "t1", true)
+
+	if len(lb.entries) != 4 || lb.entries[0].timestamp != "t1" || lb.entries[3].timestamp != "t4" {
+		t.Fatalf("unexpected entry order: %+v", lb.entries)
+	}
+	if cmd == nil || !lb.prefetching {
+		t.Fatal("expected a prefetch of older logs")
+	}
+	if want := (fetchCall{position: "t1", reverse: true, isPrefetch: true}); len(fetcher.calls) != 1 || fetcher.calls[0] != want {
+		t.Errorf("fetches = %+v, want %+v", fetcher.calls, want)
+	}
+
+	// Reaching the start of the logs stops prefetching
+	if cmd := lb.PrependOlder([]dockerLogEntry{{timestamp: "t0"}}, "t0", false); cmd != nil {
+		t.Error("expected no prefetch at the start of the logs")
+	}
+}
+
+func TestDockerLogBufferTrimBuffer(t *testing.T) {
+	lb, _ := newTestDockerLogBuffer(100)
+	for i := range dockerMaxBufferEntries + 50 {
+		lb.entries = append(lb.entries, dockerLogEntry{timestamp: fmt.Sprintf("t%d", i), message: "line"})
+	}
+
+	trimmed := lb.TrimBuffer(10000, 30)
+
+	want := 10000 - dockerLogPageSize
+	if trimmed != want {
+		t.Fatalf("TrimBuffer() = %d, want %d", trimmed, want)
+	}
+	if wantFirst := fmt.Sprintf("t%d", want); lb.entries[0].timestamp != wantFirst || lb.before != wantFirst || !lb.hasMoreBefore {
+		t.Errorf("first entry = %q, before = %q, hasMoreBefore = %v", lb.entries[0].timestamp, lb.before, lb.hasMoreBefore)
+	}
+}
+
+func TestDockerLogBufferCheckPrefetchNeeds(t *testing.T) {
+	lb, fetcher := newTestDockerLogBuffer(100)
+	lb.before = "before"
+	lb.after = "after"
+	lb.hasMoreAfter = true
+
+	// A viewport showing all content is near both edges
+	cmds := lb.CheckPrefetchNeeds(0, 20, 40)
+	if len(cmds) != 2 {
+		t.Fatalf("CheckPrefetchNeeds() returned %d commands, want 2", len(cmds))
+	}
+	want := []fetchCall{
+		{position: "before", reverse: true, isPrefetch: true},
+		{position: "after", reverse: false, isPrefetch: true},
+	}
+	if fmt.Sprint(fetcher.calls) != fmt.Sprint(want) {
+		t.Errorf("fetches = %+v, want %+v", fetcher.calls, want)
+	}
+
+	if cmds := lb.CheckPrefetchNeeds(0, 20, 40); len(cmds) != 0 {
+		t.Errorf("duplicate prefetch while in progress: %d commands", len(cmds))
+	}
+}
+
+func TestShortImageRef(t *testing.T) {
+	const testImageDigest = "4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945"
+	tests := map[string]string{
+		"linuxserver/plex:latest":              "linuxserver/plex:latest",
+		"ghcr.io/hotio/sonarr:release":         "hotio/sonarr:release",
+		"docker.io/library/nginx:latest":       "nginx:latest",
+		"localhost:5000/custom/app":            "custom/app",
+		"traefik:v3@sha256:" + testImageDigest: "traefik:v3",
+		"sha256:" + testImageDigest:            testImageDigest[:12],
+	}
+	for ref, want := range tests {
+		if got := shortImageRef(ref); got != want {
+			t.Errorf("shortImageRef(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
+
+func TestContainerItemTitleAlignsImages(t *testing.T) {
+	short := containerItem{name: "plex", image: "plex:latest", status: "running", state: "Up", maxLength: 6, imageMaxLength: 15}
+	long := containerItem{name: "sonarr", image: "hotio/sonarr:v4", status: "running", state: "Up", maxLength: 6, imageMaxLength: 15}
+
+	shortTitle, longTitle := short.Title(), long.Title()
+	if lipgloss.Width(shortTitle) != lipgloss.Width(longTitle) {
+		t.Errorf("titles not aligned:\n%q\n%q", shortTitle, longTitle)
+	}
+	if !strings.Contains(shortTitle, "plex:latest") {
+		t.Errorf("title %q is missing the image", shortTitle)
+	}
+}
+
+func TestFormatContainerRestarts(t *testing.T) {
+	now := time.Date(2025, 11, 12, 18, 0, 0, 0, time.Local)
+
+	flapping := containerItem{name: "plex", status: "running", state: "Up 20 seconds", restartCount: 12, startedAt: now.Add(-20 * time.Second)}
+	got := ansi.Strip(formatContainerRestarts(flapping, now))
+	if want := "[⚠ Up 20 seconds · 12 restarts, last 17:59]"; got != want {
+		t.Errorf("crash-looping indicator = %q, want %q", got, want)
+	}
+	if containerStatusPriority(flapping, now) != 1 {
+		t.Errorf("crash-looping container priority = %d, want 1", containerStatusPriority(flapping, now))
+	}
+
+	restarted := containerItem{name: "sonarr", status: "running", state: "Up 2 days", restartCount: 1, startedAt: now.AddDate(0, 0, -2)}
+	got = ansi.Strip(formatContainerRestarts(restarted, now))
+	if want := "[✓ Up 2 days · 1 restart, last Nov 10 18:00]"; got != want {
+		t.Errorf("restarted indicator = %q, want %q", got, want)
+	}
+	if containerStatusPriority(restarted, now) != 3 {
+		t.Errorf("restarted container priority = %d, want 3", containerStatusPriority(restarted, now))
+	}
+
+	// A long-running container that crash-looped once keeps its lifetime restart count
+	recovered := containerItem{name: "lidarr", status: "running", state: "Up 3 weeks", restartCount: 40, startedAt: now.AddDate(0, 0, -21)}
+	got = ansi.Strip(formatContainerRestarts(recovered, now))
+	if want := "[✓ Up 3 weeks · 40 restarts, last Oct 22 18:00]"; got != want {
+		t.Errorf("recovered indicator = %q, want %q", got, want)
+	}
+	if containerStatusPriority(recovered, now) != 3 {
+		t.Errorf("recovered container priority = %d, want 3", containerStatusPriority(recovered, now))
+	}
+
+	uninspected := containerItem{name: "radarr", status: "running", state: "Up 1 hour", restartCount: 2}
+	if got, want := ansi.Strip(formatContainerRestarts(uninspected, now)), "[✓ Up 1 hour · 2 restarts]"; got != want {
+		t.Errorf("indicator without start time = %q, want %q", got, want)
+	}
+}
+
+func TestValidateFollowInterval(t *testing.T) {
+	for _, interval := range []time.Duration{minFollowInterval, defaultFollowInterval, 2 * time.Second} {
+		if err := validateFollowInterval(interval); err != nil {
+			t.Errorf("validateFollowInterval(%s) unexpected error: %v", interval, err)
+		}
+	}
+	for _, interval := range []time.Duration{0, -time.Second, 50 * time.Millisecond} {
+		if err := validateFollowInterval(interval); err == nil {
+			t.Errorf("validateFollowInterval(%s) expected error", interval)
+		}
+	}
+}
+
+func TestSanitizeLogMessage(t *testing.T) {
+	tests := []struct {
+		name        string
+		message     string
+		stripColors bool
+		want        string
+	}{
+		{"plain", "plain message", false, "plain message"},
+		{"colors kept and reset", "\x1b[32mINFO\x1b[0m started", false, "\x1b[32mINFO\x1b[0m started\x1b[m"},
+		{"unterminated color reset", "\x1b[1;31mERROR failed", false, "\x1b[1;31mERROR failed\x1b[m"},
+		{"colors stripped", "\x1b[38;5;208mWARN\x1b[0m slow", true, "WARN slow"},
+		{"cursor movement dropped", "\x1b[2K\rprogress 50%\x1b[1A", false, "progress 50%"},
+		{"osc dropped", "\x1b]0;title\x07done", false, "done"},
+		{"tabs expanded", "key\tvalue", false, "key    value"},
+		{"stray escape dropped", "bad \x1b escape", false, "bad  escape"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sanitizeLogMessage(tt.message, tt.stripColors)
+			if got != tt.want {
+				t.Errorf("sanitizeLogMessage(%q) = %q, want %q", tt.message, got, tt.want)
+			}
+			if want := lipgloss.Width(ansi.Strip(tt.want)); lipgloss.Width(got) != want {
+				t.Errorf("display width = %d, want %d", lipgloss.Width(got), want)
+			}
+		})
+	}
+}
+
+func TestDockerLogsResizeWhileLoading(t *testing.T) {
+	items := []list.Item{containerItem{name: "plex", id: "abc"}}
+	m := dockerLogsModel{
+		list:       list.New(items, list.NewDefaultDelegate(), 0, 0),
+		help:       help.New(),
+		keys:       dockerKeys,
+		activeView: "list",
+		limits:     dockerLimits,
+	}
+
+	update := func(msg tea.Msg) {
+		t.Helper()
+		model, _ := m.Update(msg)
+		m = model.(dockerLogsModel)
+	}
+
+	update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if !m.loading || !m.viewportInitialized {
+		t.Fatalf("expected logs to be loading in an initialized viewport")
+	}
+
+	// Resize before the logs arrive
+	update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	if got := lipgloss.Width(m.logsPlaceholder("Loading", "help")); got != 120 {
+		t.Errorf("loading view width = %d, want 120", got)
+	}
+
+	update(dockerLogsMsg{entries: []dockerLogEntry{{timestamp: "2024-01-01T00:00:00Z", stream: "stdout", message: "hello"}}})
+	if m.loading {
+		t.Fatal("expected loading to finish")
+	}
+	if m.viewport.Width() != 120 || m.viewport.Height() != m.logsHeight() {
+		t.Errorf("viewport = %dx%d, want 120x%d", m.viewport.Width(), m.viewport.Height(), m.logsHeight())
+	}
+
+	// Resizing in the list view also resizes the viewport for the next visit
+	m.activeView = "list"
+	update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	if m.viewport.Width() != 100 || m.viewport.Height() != m.logsHeight() {
+		t.Errorf("viewport after list resize = %dx%d, want 100x%d", m.viewport.Width(), m.viewport.Height(), m.logsHeight())
+	}
+}
+
+func TestMatchContainerItems(t *testing.T) {
+	items := []list.Item{
+		containerItem{name: "plex", id: "1"},
+		containerItem{name: "plex-autoscan", id: "2"},
+		containerItem{name: "sonarr", id: "3"},
+		containerItem{name: "radarr", id: "4"},
+	}
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"plex", []string{"plex"}}, // An exact name wins over longer names containing it
+		{"PLEX", []string{"plex"}},
+		{"son", []string{"sonarr"}},
+		{"autosc", []string{"plex-autoscan"}},
+		{"arr", []string{"sonarr", "radarr"}},
+		{"lidarr", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range matchContainerItems(items, tt.name) {
+				got = append(got, c.name)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("matchContainerItems(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDockerLogsSelectContainerUpFront(t *testing.T) {
+	items := []list.Item{containerItem{name: "plex", id: "abc"}}
+	m := dockerLogsModel{
+		list:       list.New(items, list.NewDefaultDelegate(), 0, 0),
+		help:       help.New(),
+		keys:       dockerKeys,
+		activeView: "list",
+		limits:     dockerLimits,
+	}
+	m.selectContainer(items[0].(containerItem))
+
+	if m.activeView != "logs" || !m.loading || m.selectedContainerID != "abc" {
+		t.Fatalf("activeView = %q, loading = %v, container ID = %q", m.activeView, m.loading, m.selectedContainerID)
+	}
+	if m.Init() == nil {
+		t.Fatal("Init() did not fetch the selected container's logs")
+	}
+
+	// The first window size sizes the viewport created before the terminal size was known
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = model.(dockerLogsModel)
+	if m.viewport.Width() != 100 || m.viewport.Height() != m.logsHeight() {
+		t.Errorf("viewport = %dx%d, want 100x%d", m.viewport.Width(), m.viewport.Height(), m.logsHeight())
+	}
+}
+
+func TestDockerLogsPrefetchRetryWhileLoading(t *testing.T) {
+	lb, fetcher := newTestDockerLogBuffer(100)
+	lb.retryScheduled = true
+	m := dockerLogsModel{activeView: "logs", logBuf: lb, loading: true, limits: dockerLimits, help: help.New(), keys: dockerKeys}
+
+	updated, _ := m.Update(prefetchRetryMsg{})
+	m = updated.(dockerLogsModel)
+	if m.logBuf.retryScheduled {
+		t.Error("expected the fired retry to be cleared while loading")
+	}
+	if len(fetcher.calls) != 0 {
+		t.Errorf("fetches = %+v, want none while loading", fetcher.calls)
+	}
+}
@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/saltyorg/sb-go/internal/spinners"
+	"github.com/saltyorg/sb-go/internal/styles"
+	"github.com/saltyorg/sb-go/internal/tty"
+
+	"github.com/docker/go-units"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/image"
+	"github.com/moby/moby/api/types/volume"
+	"github.com/moby/moby/client"
+	"github.com/spf13/cobra"
+)
+
+// anonymousVolumeLabel marks volumes Docker created without a name, the only volumes VolumePrune removes by default
+const anonymousVolumeLabel = "com.docker.volume.anonymous"
+
+// predefinedNetworks are created by Docker itself and never pruned
+var predefinedNetworks = []string{"bridge", "host", "none"}
+
+// dockerPruneCategories selects the resource types to prune
+type dockerPruneCategories struct {
+	containers bool
+	images     bool
+	networks   bool
+	volumes    bool
+	buildCache bool
+}
+
+// dockerPruneSummary holds what a prune of each category would remove
+type dockerPruneSummary struct {
+	containers     int
+	containersSize int64
+	images         int
+	imagesSize     int64
+	volumes        int
+	volumesSize    int64
+	networks       int
+	buildCacheSize int64
+}
+
+// dockerPruneCmd represents the docker prune command
+var dockerPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove unused Docker resources",
+	Long: `Remove unused Docker resources.
+
+Shows how much space each selected category would reclaim and asks for
+confirmation before pruning. Without category flags, dangling images and
+unused build cache are pruned.
+
+Stopped containers and unused networks are only pruned when requested, as
+Saltbox containers can be stopped on purpose and pruning them removes the
+container. Only anonymous volumes are pruned with --volumes; named volumes
+are never removed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		assumeYes, _ := cmd.Flags().GetBool("yes")
+		containers, _ := cmd.Flags().GetBool("containers")
+		images, _ := cmd.Flags().GetBool("images")
+		networks, _ := cmd.Flags().GetBool("networks")
+		volumes, _ := cmd.Flags().GetBool("volumes")
+		buildCache, _ := cmd.Flags().GetBool("build-cache")
+
+		categories := selectPruneCategories(dockerPruneCategories{
+			containers: containers,
+			images:     images,
+			networks:   networks,
+			volumes:    volumes,
+			buildCache: buildCache,
+		})
+		return handleDockerPrune(cmd.Context(), categories, verbose, assumeYes)
+	},
+}
+
+// selectPruneCategories returns the requested categories, or the safe default set if none were requested
+func selectPruneCategories(requested dockerPruneCategories) dockerPruneCategories {
+	if requested == (dockerPruneCategories{}) {
+		return dockerPruneCategories{images: true, buildCache: true}
+	}
+	return requested
+}
+
+func handleDockerPrune(ctx context.Context, categories dockerPruneCategories, verbose, assumeYes bool) error {
+	cli, err := client.New(client.FromEnv)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cli.Close() }()
+
+	usage, err := cli.DiskUsage(ctx, client.DiskUsageOptions{
+		Containers: categories.containers,
+		Images:     categories.images,
+		BuildCache: categories.buildCache,
+		Volumes:    categories.volumes,
+		Verbose:    true,
+	})
+	if err != nil {
+		return fmt.Errorf("error reading Docker disk usage: %w", err)
+	}
+	summary := summarizeDiskUsage(usage)
+
+	if categories.networks {
+		summary.networks, err = countUnusedNetworks(ctx, cli)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(formatDockerPruneSummary(summary, categories))
+	if summary.empty(categories) {
+		fmt.Println(styles.SuccessStyle.Render("Nothing to prune"))
+		return nil
+	}
+
+	confirmed, err := tty.Confirm("Prune the Docker resources listed above?", assumeYes)
+	if err != nil {
+		return fmt.Errorf("read prune confirmation: %w", err)
+	}
+	if !confirmed {
+		fmt.Println("Prune aborted by user.")
+		return nil
+	}
+
+	var reclaimed uint64
+	runner := spinners.NewRunner(runnerOptions(verbose))
+	if err := runner.Run(ctx, spinners.TaskSpec{
+		Running:      "Pruning Docker resources",
+		Success:      "Docker resources pruned",
+		Failure:      "Docker prune",
+		ChildDisplay: spinners.RetainChildTasks,
+	}, func(ctx context.Context, task *spinners.Task) error {
+		if categories.containers {
+			if err := task.Run(ctx, spinners.TaskSpec{Running: "Removing stopped containers"}, func(ctx context.Context, _ *spinners.Task) error {
+				result, err := cli.ContainerPrune(ctx, client.ContainerPruneOptions{})
+				reclaimed += result.Report.SpaceReclaimed
+				return err
+			}); err != nil {
+				return fmt.Errorf("error pruning containers: %w", err)
+			}
+		}
+
+		if categories.images {
+			if err := task.Run(ctx, spinners.TaskSpec{Running: "Removing dangling images"}, func(ctx context.Context, _ *spinners.Task) error {
+				result, err := cli.ImagePrune(ctx, client.ImagePruneOptions{})
+				reclaimed += result.Report.SpaceReclaimed
+				return err
+			}); err != nil {
+				return fmt.Errorf("error pruning images: %w", err)
+			}
+		}
+
+		if categories.networks {
+			if err := task.Run(ctx, spinners.TaskSpec{Running: "Removing unused networks"}, func(ctx context.Context, _ *spinners.Task) error {
+				_, err := cli.NetworkPrune(ctx, client.NetworkPruneOptions{})
+				return err
+			}); err != nil {
+				return fmt.Errorf("error pruning networks: %w", err)
+			}
+		}
+
+		if categories.volumes {
+			if err := task.Run(ctx, spinners.TaskSpec{Running: "Removing unused anonymous volumes"}, func(ctx context.Context, _ *spinners.Task) error {
+				result, err := cli.VolumePrune(ctx, client.VolumePruneOptions{})
+				reclaimed += result.Report.SpaceReclaimed
+				return err
+			}); err != nil {
+				return fmt.Errorf("error pruning volumes: %w", err)
+			}
+		}
+
+		if categories.buildCache {
+			if err := task.Run(ctx, spinners.TaskSpec{Running: "Removing unused build cache"}, func(ctx context.Context, _ *spinners.Task) error {
+				result, err := cli.BuildCachePrune(ctx, client.BuildCachePruneOptions{})
+				reclaimed += result.Report.SpaceReclaimed
+				return err
+			}); err != nil {
+				return fmt.Errorf("error pruning build cache: %w", err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	fmt.Println(styles.SuccessStyle.Render("Reclaimed " + units.HumanSize(float64(reclaimed))))
+	return nil
+}
+
+// summarizeDiskUsage counts the resources the default prune of each category removes,
+// using the per-item details of a verbose disk usage report
+func summarizeDiskUsage(usage client.DiskUsageResult) dockerPruneSummary {
+	var summary dockerPruneSummary
+	for _, c := range usage.Containers.Items {
+		if isStoppedContainer(c) {
+			summary.containers++
+			summary.containersSize += c.SizeRw
+		}
+	}
+	for _, img := range usage.Images.Items {
+		if isDanglingImage(img) {
+			summary.images++
+			summary.imagesSize += img.Size
+		}
+	}
+	for _, v := range usage.Volumes.Items {
+		if isPrunableVolume(v) {
+			summary.volumes++
+			summary.volumesSize += v.UsageData.Size
+		}
+	}
+	summary.buildCacheSize = usage.BuildCache.Reclaimable
+	return summary
+}
+
+// isStoppedContainer reports whether ContainerPrune removes the container
+func isStoppedContainer(c container.Summary) bool {
+	switch c.State {
+	case container.StateCreated, container.StateExited, container.StateDead:
+		return true
+	}
+	return false
+}
+
+// isDanglingImage reports whether the image is untagged and unused, which is what ImagePrune removes by default
+func isDanglingImage(img image.Summary) bool {
+	if img.Containers > 0 {
+		return false
+	}
+	for _, tag := range img.RepoTags {
+		if tag != "<none>:<none>" {
+			return false
+		}
+	}
+	return true
+}
+
+// isPrunableVolume reports whether the volume is anonymous and unused, which is what VolumePrune removes by default
+func isPrunableVolume(v volume.Volume) bool {
+	if v.UsageData == nil || v.UsageData.RefCount != 0 {
+		return false
+	}
+	_, anonymous := v.Labels[anonymousVolumeLabel]
+	return anonymous
+}
+
+// countUnusedNetworks counts the user-defined networks without connected containers
+func countUnusedNetworks(ctx context.Context, cli *client.Client) (int, error) {
+	networks, err := cli.NetworkList(ctx, client.NetworkListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("error listing Docker networks: %w", err)
+	}
+
+	unused := 0
+	for _, n := range networks.Items {
+		if slices.Contains(predefinedNetworks, n.Name) {
+			continue
+		}
+		inspect, err := cli.NetworkInspect(ctx, n.ID, client.NetworkInspectOptions{})
+		if err != nil {
+			return 0, fmt.Errorf("error inspecting Docker network %s: %w", n.Name, err)
+		}
+		if len(inspect.Network.Containers) == 0 {
+			unused++
+		}
+	}
+	return unused, nil
+}
+
+// empty reports whether pruning the selected categories would remove anything
+func (s dockerPruneSummary) empty(categories dockerPruneCategories) bool {
+	return (!categories.containers || s.containers == 0) &&
+		(!categories.images || s.images == 0) &&
+		(!categories.networks || s.networks == 0) &&
+		(!categories.volumes || s.volumes == 0) &&
+		(!categories.buildCache || s.buildCacheSize == 0)
+}
+
+// formatDockerPruneSummary lists what each selected category would remove
+func formatDockerPruneSummary(s dockerPruneSummary, categories dockerPruneCategories) string {
+	var b strings.Builder
+	b.WriteString(styles.HeaderStyle.Render("Reclaimable space") + "\n")
+
+	line := func(label, detail string) {
+		fmt.Fprintf(&b, "  %-20s %s\n", label, detail)
+	}
+	if categories.containers {
+		line("Stopped containers", fmt.Sprintf("%d (%s)", s.containers, units.HumanSize(float64(s.containersSize))))
+	}
+	if categories.images {
+		line("Dangling images", fmt.Sprintf("%d (%s)", s.images, units.HumanSize(float64(s.imagesSize))))
+	}
+	if categories.networks {
+		line("Unused networks", fmt.Sprintf("%d", s.networks))
+	}
+	if categories.volumes {
+		line("Anonymous volumes", fmt.Sprintf("%d (%s)", s.volumes, units.HumanSize(float64(s.volumesSize))))
+	}
+	if categories.buildCache {
+		line("Build cache", units.HumanSize(float64(s.buildCacheSize)))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func init() {
+	dockerPruneCmd.Flags().Bool("containers", false, "Prune stopped containers")
+	dockerPruneCmd.Flags().Bool("images", false, "Prune dangling images")
+	dockerPruneCmd.Flags().Bool("networks", false, "Prune networks without connected containers")
+	dockerPruneCmd.Flags().Bool("volumes", false, "Prune anonymous volumes not used by any container")
+	dockerPruneCmd.Flags().Bool("build-cache", false, "Prune unused build cache")
+	dockerPruneCmd.Flags().BoolP("yes", "y", false, "Prune without asking for confirmation")
+	dockerPruneCmd.Flags().BoolP("verbose", "v", false, "Enable verbose output")
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/image"
+	"github.com/moby/moby/api/types/volume"
+	"github.com/moby/moby/client"
+)
+
+func TestSelectPruneCategories(t *testing.T) {
+	defaults := selectPruneCategories(dockerPruneCategories{})
+	if defaults != (dockerPruneCategories{images: true, buildCache: true}) {
+		t.Errorf("default categories = %+v, want images and build cache only", defaults)
+	}
+
+	requested := dockerPruneCategories{volumes: true}
+	if got := selectPruneCategories(requested); got != requested {
+		t.Errorf("selectPruneCategories(%+v) = %+v, want the requested categories", requested, got)
+	}
+}
+
+func TestSummarizeDiskUsage(t *testing.T) {
+	usage := client.DiskUsageResult{
+		Containers: client.ContainersDiskUsage{Items: []container.Summary{
+			{State: container.StateRunning, SizeRw: 100},
+			{State: container.StateExited, SizeRw: 10},
+			{State: container.StateCreated, SizeRw: 5},
+		}},
+		Images: client.ImagesDiskUsage{Items: []image.Summary{
+			{RepoTags: []string{"saltbox/plex:latest"}, Size: 1000},
+			{RepoTags: []string{"<none>:<none>"}, Size: 200},
+			{Size: 300},
+			{Containers: 1, Size: 400},
+		}},
+		Volumes: client.VolumesDiskUsage{Items: []volume.Volume{
+			{Name: "named", UsageData: &volume.UsageData{Size: 50}},
+			{Labels: map[string]string{anonymousVolumeLabel: ""}, UsageData: &volume.UsageData{Size: 20}},
+			{Labels: map[string]string{anonymousVolumeLabel: ""}, UsageData: &volume.UsageData{RefCount: 1, Size: 30}},
+		}},
+		BuildCache: client.BuildCacheDiskUsage{Reclaimable: 70},
+	}
+
+	got := summarizeDiskUsage(usage)
+	want := dockerPruneSummary{
+		containers:     2,
+		containersSize: 15,
+		images:         2,
+		imagesSize:     500,
+		volumes:        1,
+		volumesSize:    20,
+		buildCacheSize: 70,
+	}
+	if got != want {
+		t.Errorf("summarizeDiskUsage = %+v, want %+v", got, want)
+	}
+
+	if !got.empty(dockerPruneCategories{networks: true}) {
+		t.Error("summary without unused networks should be empty when only networks are selected")
+	}
+	if got.empty(dockerPruneCategories{images: true}) {
+		t.Error("summary with dangling images should not be empty when images are selected")
+	}
+}
@@ -24,7 +24,7 @@ var restartCmd = &cobra.Command{
 		ctx := cmd.Context()
 		verbose, _ := cmd.Flags().GetBool("verbose")
 		ignoreContainers, _ := cmd.Flags().GetStringSlice("ignore")
-		runner := spinners.NewRunner(spinners.RunnerOptions{Verbose: verbose})
+		runner := spinners.NewRunner(runnerOptions(verbose))
 		return runDockerRestart(ctx, runner, verbose, ignoreContainers, spinners.CollapseChildTasks)
 	},
 }
@@ -187,4 +187,5 @@ func init() {
 
 	// Add ignore flag
 	restartCmd.Flags().StringSlice("ignore", []string{}, "Containers to ignore during restart operation (can be specified multiple times)")
+	_ = restartCmd.RegisterFlagCompletionFunc("ignore", completeContainerNames)
 }
@@ -22,7 +22,7 @@ var startCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 		verbose, _ := cmd.Flags().GetBool("verbose")
-		runner := spinners.NewRunner(spinners.RunnerOptions{Verbose: verbose})
+		runner := spinners.NewRunner(runnerOptions(verbose))
 		return runDockerStart(ctx, runner, verbose, spinners.CollapseChildTasks)
 	},
 }
@@ -24,7 +24,7 @@ var stopCmd = &cobra.Command{
 		ctx := cmd.Context()
 		verbose, _ := cmd.Flags().GetBool("verbose")
 		ignoreContainers, _ := cmd.Flags().GetStringSlice("ignore")
-		runner := spinners.NewRunner(spinners.RunnerOptions{Verbose: verbose})
+		runner := spinners.NewRunner(runnerOptions(verbose))
 		return runDockerStop(ctx, runner, verbose, ignoreContainers, spinners.CollapseChildTasks)
 	},
 }
@@ -115,4 +115,5 @@ func init() {
 
 	// Add ignore flag
 	stopCmd.Flags().StringSlice("ignore", []string{}, "Containers to ignore during stop operation (can be specified multiple times)")
+	_ = stopCmd.RegisterFlagCompletionFunc("ignore", completeContainerNames)
 }
@@ -103,9 +103,24 @@ func editorCommand(path string) (*exec.Cmd, error) {
 		return nil, fmt.Errorf("inspect configuration file %s: %w", path, err)
 	}
 
+	editor, err := resolveEditor()
+	if err != nil {
+		return nil, err
+	}
+	args := append(editor[1:], path)
+
+	return exec.Command(editor[0], args...), nil
+}
+
+// resolveEditor returns the user's preferred editor from $EDITOR or $VISUAL, falling back to nano.
+// The first element is the absolute path of the executable, followed by any arguments.
+func resolveEditor() ([]string, error) {
 	editor := os.Getenv("EDITOR")
 	if editor == "" {
-		editor = "nano" // Default to nano if EDITOR is not set
+		editor = os.Getenv("VISUAL")
+	}
+	if editor == "" {
+		editor = "nano" // Default to nano if neither is set
 	}
 
 	// Validate and sanitize editor command
@@ -124,15 +139,7 @@ func editorCommand(path string) (*exec.Cmd, error) {
 		}
 	}
 
-	// Construct command with validated editor and additional args if any
-	var args []string
-	if len(editorParts) > 1 {
-		args = append(editorParts[1:], path)
-	} else {
-		args = []string{path}
-	}
-
-	return exec.Command(editorPath, args...), nil
+	return append([]string{editorPath}, editorParts[1:]...), nil
 }
 
 func openEditor(ctx context.Context, path string) error {
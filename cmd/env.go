@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/config"
+	"github.com/saltyorg/sb-go/internal/constants"
+	"github.com/saltyorg/sb-go/internal/ubuntu"
+	"github.com/saltyorg/sb-go/internal/utils"
+
+	"github.com/aquasecurity/table"
+	"github.com/spf13/cobra"
+)
+
+// envReport holds the paths and settings sb resolved for this system.
+// Detection failures are reported in the matching error field instead of failing the command.
+type envReport struct {
+	SaltboxRepoPath   string      `json:"saltbox_repo_path"`
+	AnsibleVenvPath   string      `json:"ansible_venv_path"`
+	AnsibleVenvPython string      `json:"ansible_venv_python"`
+	UbuntuCodename    string      `json:"ubuntu_codename,omitempty"`
+	UbuntuError       string      `json:"ubuntu_error,omitempty"`
+	SaltboxUser       string      `json:"saltbox_user,omitempty"`
+	SaltboxUserError  string      `json:"saltbox_user_error,omitempty"`
+	Timezone          string      `json:"timezone,omitempty"`
+	TimezoneError     string      `json:"timezone_error,omitempty"`
+	Binaries          []envBinary `json:"binaries"`
+}
+
+// envBinary is a tool sb depends on and where it was found in PATH
+type envBinary struct {
+	Name  string `json:"name"`
+	Path  string `json:"path,omitempty"`
+	Found bool   `json:"found"`
+}
+
+// envSources are the system lookups behind an envReport, replaced in tests
+type envSources struct {
+	currentRelease func() (ubuntu.Release, error)
+	saltboxUser    func() (string, error)
+	timezone       func() (string, error)
+	lookPath       func(string) (string, error)
+}
+
+// systemEnvSources reads the environment of the running system
+var systemEnvSources = envSources{
+	currentRelease: ubuntu.CurrentRelease,
+	saltboxUser:    utils.GetSaltboxUser,
+	timezone:       configuredTimezone,
+	lookPath:       exec.LookPath,
+}
+
+// envCmd represents the env command
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Print the paths and settings sb detected",
+	Long: `Prints the runtime environment sb operates on: the Saltbox repository and
+Ansible virtual environment paths, the detected Ubuntu codename, the Saltbox
+user from accounts.yml, the timezone from adv_settings.yml with "auto"
+resolved to the system timezone and where the tools sb depends on were found in PATH.
+
+Use --json for machine-readable output, e.g. when attaching it to an issue.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		report := collectEnv(systemEnvSources)
+		if asJSON {
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format environment: %w", err)
+			}
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return nil
+		}
+
+		renderEnv(cmd.OutOrStdout(), report)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(envCmd)
+	envCmd.Flags().Bool("json", false, "Print the environment as JSON")
+}
+
+// configuredTimezone resolves the timezone set in adv_settings.yml
+func configuredTimezone() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return config.ConfiguredTimezone(ctx, constants.SaltboxAdvancedSettingsConfigPath)
+}
+
+// collectEnv resolves the environment from the given sources
+func collectEnv(sources envSources) envReport {
+	report := envReport{
+		SaltboxRepoPath:   constants.SaltboxRepoPath,
+		AnsibleVenvPath:   constants.AnsibleVenvPath,
+		AnsibleVenvPython: constants.AnsibleVenvPythonPath(),
+		Binaries:          []envBinary{},
+	}
+
+	if release, err := sources.currentRelease(); err != nil {
+		report.UbuntuError = err.Error()
+	} else {
+		report.UbuntuCodename = release.Codename
+	}
+
+	if user, err := sources.saltboxUser(); err != nil {
+		report.SaltboxUserError = err.Error()
+	} else {
+		report.SaltboxUser = user
+	}
+
+	if timezone, err := sources.timezone(); err != nil {
+		report.TimezoneError = err.Error()
+	} else {
+		report.Timezone = timezone
+	}
+
+	for _, tool := range selfTestTools {
+		binary := envBinary{Name: tool}
+		if path, err := sources.lookPath(tool); err == nil {
+			binary.Path = path
+			binary.Found = true
+		}
+		report.Binaries = append(report.Binaries, binary)
+	}
+
+	return report
+}
+
+// renderEnv prints the report as a table
+func renderEnv(w io.Writer, report envReport) {
+	t := table.New(w)
+	t.SetHeaders("Setting", "Value")
+	t.SetHeaderStyle(table.StyleBold)
+	t.SetAlignment(table.AlignLeft, table.AlignLeft)
+	t.SetBorders(true)
+	t.SetRowLines(false)
+	t.SetDividers(table.UnicodeRoundedDividers)
+	t.SetLineStyle(table.StyleBlue)
+	t.SetPadding(1)
+
+	t.AddRow("Saltbox repository", report.SaltboxRepoPath)
+	t.AddRow("Ansible venv", report.AnsibleVenvPath)
+	t.AddRow("Ansible venv Python", report.AnsibleVenvPython)
+	t.AddRow("Ubuntu codename", envValue(report.UbuntuCodename, report.UbuntuError))
+	t.AddRow("Saltbox user", envValue(report.SaltboxUser, report.SaltboxUserError))
+	t.AddRow("Timezone", envValue(report.Timezone, report.TimezoneError))
+	for _, binary := range report.Binaries {
+		path := "not found in PATH"
+		if binary.Found {
+			path = binary.Path
+		}
+		t.AddRow(binary.Name, path)
+	}
+	t.Render()
+}
+
+// envValue returns value, or the reason it could not be detected
+func envValue(value, errMsg string) string {
+	if errMsg != "" {
+		return "unknown (" + errMsg + ")"
+	}
+	return value
+}
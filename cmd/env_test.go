@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/saltyorg/sb-go/internal/constants"
+	"github.com/saltyorg/sb-go/internal/ubuntu"
+)
+
+func fakeEnvSources() envSources {
+	return envSources{
+		currentRelease: func() (ubuntu.Release, error) {
+			return ubuntu.Release{Name: "Ubuntu 24.04 LTS", Version: "24.04", Codename: "noble"}, nil
+		},
+		saltboxUser: func() (string, error) {
+			return "", errors.New("failed to read accounts.yml")
+		},
+		timezone: func() (string, error) {
+			return "Europe/Oslo", nil
+		},
+		lookPath: func(name string) (string, error) {
+			if name == "git" {
+				return "/usr/bin/git", nil
+			}
+			return "", errors.New("not found")
+		},
+	}
+}
+
+func TestCollectEnv(t *testing.T) {
+	report := collectEnv(fakeEnvSources())
+
+	if report.SaltboxRepoPath != constants.SaltboxRepoPath || report.AnsibleVenvPython != constants.AnsibleVenvPythonPath() {
+		t.Errorf("unexpected paths: %+v", report)
+	}
+	if report.UbuntuCodename != "noble" || report.UbuntuError != "" {
+		t.Errorf("unexpected Ubuntu detection: %q (%q)", report.UbuntuCodename, report.UbuntuError)
+	}
+	if report.SaltboxUser != "" || report.SaltboxUserError == "" {
+		t.Errorf("expected the Saltbox user lookup error to be reported, got %+v", report)
+	}
+	if report.Timezone != "Europe/Oslo" || report.TimezoneError != "" {
+		t.Errorf("unexpected timezone: %q (%q)", report.Timezone, report.TimezoneError)
+	}
+	if len(report.Binaries) != len(selfTestTools) {
+		t.Fatalf("expected %d binaries, got %d", len(selfTestTools), len(report.Binaries))
+	}
+	if git := report.Binaries[0]; !git.Found || git.Path != "/usr/bin/git" {
+		t.Errorf("expected git to be found, got %+v", git)
+	}
+	if docker := report.Binaries[1]; docker.Found || docker.Path != "" {
+		t.Errorf("expected docker to be missing, got %+v", docker)
+	}
+}
+
+func TestEnvReportJSON(t *testing.T) {
+	data, err := json.Marshal(collectEnv(fakeEnvSources()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"saltbox_repo_path", "ansible_venv_path", "ansible_venv_python", "ubuntu_codename", "saltbox_user_error", "timezone", "binaries"} {
+		if _, ok := decoded[key]; !ok {
+			t.Errorf("expected key %q in %s", key, data)
+		}
+	}
+	if _, ok := decoded["saltbox_user"]; ok {
+		t.Errorf("expected saltbox_user to be omitted when it could not be detected: %s", data)
+	}
+}
+
+func TestRenderEnv(t *testing.T) {
+	var buf bytes.Buffer
+	renderEnv(&buf, collectEnv(fakeEnvSources()))
+
+	out := buf.String()
+	for _, want := range []string{constants.SaltboxRepoPath, "noble", "unknown (failed to read accounts.yml)", "Europe/Oslo", "/usr/bin/git", "not found in PATH"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q:\n%s", want, out)
+		}
+	}
+}
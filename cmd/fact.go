@@ -40,7 +40,9 @@ Example usage:
   sb fact role instance --method=save --key key1=value --key key2=value
   sb fact role instance --method=delete --delete-type=key --key key1
   sb fact role instance --method=delete --delete-type=instance
-  sb fact role --method=delete --delete-type=role`,
+  sb fact role --method=delete --delete-type=role
+
+Use "sb fact show" to print the output of saltbox.fact.`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get flag values and create config
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/saltyorg/sb-go/internal/fact"
+
+	"github.com/spf13/cobra"
+)
+
+// factShowCmd represents the fact show command
+var factShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Run saltbox.fact and print its output",
+	Long: `Runs the installed saltbox.fact and pretty-prints the JSON it reports to
+Ansible, such as the paths, user and settings Saltbox detected. This shows
+what the playbooks will see without running one.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, err := fact.Load(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format saltbox.fact output: %w", err)
+		}
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	},
+}
+
+func init() {
+	factCmd.AddCommand(factShowCmd)
+}
@@ -3,14 +3,17 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/saltyorg/sb-go/internal/ansible"
 	"github.com/saltyorg/sb-go/internal/cache"
 	"github.com/saltyorg/sb-go/internal/constants"
+	sbErrors "github.com/saltyorg/sb-go/internal/errors"
 	"github.com/saltyorg/sb-go/internal/git"
 	"github.com/saltyorg/sb-go/internal/logging"
 	"github.com/saltyorg/sb-go/internal/styles"
@@ -19,6 +22,7 @@ import (
 	"charm.land/lipgloss/v2"
 	"github.com/agnivade/levenshtein"
 	"github.com/charmbracelet/x/exp/charmtone"
+	"github.com/moby/moby/client"
 	"github.com/spf13/cobra"
 )
 
@@ -39,6 +43,7 @@ type suggestion struct {
 	currentRepo string
 	targetRepo  string
 	sType       suggestionType
+	skipTag     bool // The tag was passed to --skip-tags
 }
 
 var forceDiskFull bool
@@ -47,8 +52,22 @@ var forceDiskFull bool
 var installCmd = &cobra.Command{
 	Use:   "install [tags]",
 	Short: "Runs Ansible playbooks with specified tags",
-	Long:  `Runs Ansible playbooks with specified tags`,
-	Args:  cobra.MinimumNArgs(1),
+	Long: `Runs Ansible playbooks with specified tags.
+
+The playbook output is shown as it runs, followed by a summary of the tags,
+duration and ok/changed/failed task counts. Use --dry-run to run Ansible in
+check mode and --diff to show file changes, e.g. to preview an install with:
+
+  sb install sonarr --dry-run --diff
+
+The exit code tells failures apart:
+
+  0  success
+  1  generic or unexpected failure
+  2  a task failed
+  3  a host was unreachable
+  4  the playbook or its options were rejected`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 		if err := utils.CheckLXC(ctx); err != nil {
@@ -75,55 +94,44 @@ var installCmd = &cobra.Command{
 		skipTags, _ := cmd.Flags().GetStringSlice("skip-tags")
 		extraVars, _ := cmd.Flags().GetStringArray("extra-vars")
 		noCache, _ := cmd.Flags().GetBool("no-cache")
+		noValidate, _ := cmd.Flags().GetBool("no-validate")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		diff, _ := cmd.Flags().GetBool("diff")
+
+		extraVars, err := resolveExtraVarFiles(extraVars)
+		if err != nil {
+			return err
+		}
 
 		var extraArgs []string
 		if verbosity > 0 {
 			vFlag := "-" + strings.Repeat("v", verbosity)
 			extraArgs = append(extraArgs, vFlag)
 		}
-		// Silence help usage output once initial flags have been validated
-		cmd.SilenceUsage = true
-
-		return handleInstall(cmd, tags, extraVars, skipTags, extraArgs, verbosity, noCache)
-	},
-	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		// Initialize cache
-		cacheInstance, err := cache.NewCache()
-		if err != nil {
-			return nil, cobra.ShellCompDirectiveError
+		if dryRun {
+			extraArgs = append(extraArgs, "--check")
 		}
-
-		// Check if cache is populated
-		if !isCachePopulated(cacheInstance) {
-			// Try to auto-generate cache - at least one must succeed for completion to work
-			ctx := cmd.Context()
-
-			// Try Saltbox first
-			_, saltboxErr := ansible.RunAndCacheAnsibleTags(ctx, constants.SaltboxRepoPath, constants.SaltboxPlaybookPath(), "", cacheInstance, 0)
-			saltboxSuccess := saltboxErr == nil
-
-			// Try Sandbox
-			_, sandboxErr := ansible.RunAndCacheAnsibleTags(ctx, constants.SandboxRepoPath, constants.SandboxPlaybookPath(), "", cacheInstance, 0)
-			sandboxSuccess := sandboxErr == nil
-
-			// If both failed, abort completion
-			if !saltboxSuccess && !sandboxSuccess {
-				return nil, cobra.ShellCompDirectiveError
-			}
+		if diff {
+			extraArgs = append(extraArgs, "--diff")
 		}
+		// Silence help usage output once initial flags have been validated
+		cmd.SilenceUsage = true
 
-		// Retrieve and return all tags
-		allTags := getCompletionTags(cacheInstance)
-		return allTags, cobra.ShellCompDirectiveNoFileComp
+		return handleInstall(cmd, tags, extraVars, skipTags, extraArgs, verbosity, noCache || noValidate)
 	},
+	ValidArgsFunction: completeInstallTags,
 }
 
 func init() {
 	rootCmd.AddCommand(installCmd)
-	installCmd.Flags().StringArrayP("extra-vars", "e", []string{}, "Extra variables to pass to Ansible")
+	installCmd.Flags().StringArrayP("extra-vars", "e", []string{}, "Extra variables to pass to Ansible as key=value, JSON or @file (repeatable, each value is passed as-is)")
 	installCmd.Flags().StringSliceP("skip-tags", "s", []string{}, "Tags to skip during Ansible playbook execution")
+	_ = installCmd.RegisterFlagCompletionFunc("skip-tags", completeSkipTags)
 	installCmd.Flags().CountP("verbose", "v", "Increase verbosity level (can be used multiple times, e.g. -vvv)")
 	installCmd.Flags().Bool("no-cache", false, "Skip cache validation and always perform tag checks")
+	installCmd.Flags().Bool("dry-run", false, "Run Ansible in check mode to preview the changes without applying them")
+	installCmd.Flags().Bool("diff", false, "Show the changes made to files and templates, combine with --dry-run to preview them")
+	installCmd.Flags().Bool("no-validate", false, "Pass tags and skip tags to Ansible without checking that they exist, e.g. for dynamic tags")
 	installCmd.Flags().BoolVar(&forceDiskFull, "force-disk-full", false, "Force disk space failure (debug)")
 	_ = installCmd.Flags().MarkHidden("force-disk-full")
 }
@@ -197,6 +205,14 @@ func handleInstall(cmd *cobra.Command, tags []string, extraVars []string, skipTa
 			allSuggestions = append(allSuggestions, suggestions...)
 		}
 
+		if len(skipTags) > 0 {
+			suggestions, err := validateSkipTags(ctx, skipTags, cacheInstance, verbosity)
+			if err != nil {
+				return err
+			}
+			allSuggestions = append(allSuggestions, suggestions...)
+		}
+
 		if len(allSuggestions) > 0 {
 			return fmt.Errorf("%s", formatSuggestions(allSuggestions))
 		}
@@ -206,47 +222,178 @@ func handleInstall(cmd *cobra.Command, tags []string, extraVars []string, skipTa
 
 	ansibleBinaryPath := constants.AnsiblePlaybookBinaryPath
 
-	if len(saltboxTags) > 0 {
-		if err := runPlaybook(ctx, constants.SaltboxRepoPath, constants.SaltboxPlaybookPath(), saltboxTags, ansibleBinaryPath, extraVars, skipTags, extraArgs); err != nil {
-			return err
-		}
+	runs := []struct {
+		repoPath     string
+		playbookPath string
+		tags         []string
+	}{
+		{constants.SaltboxRepoPath, constants.SaltboxPlaybookPath(), saltboxTags},
+		{constants.SaltboxModRepoPath, constants.SaltboxModPlaybookPath(), saltboxModTags},
+		{constants.SandboxRepoPath, constants.SandboxPlaybookPath(), sandboxTags},
 	}
 
-	if len(saltboxModTags) > 0 {
-		if err := runPlaybook(ctx, constants.SaltboxModRepoPath, constants.SaltboxModPlaybookPath(), saltboxModTags, ansibleBinaryPath, extraVars, skipTags, extraArgs); err != nil {
-			return err
-		}
+	// Keep the output so the run can be reviewed with sb install-log once it scrolled by
+	installLog, err := createInstallLog()
+	if err != nil {
+		logging.Debug(verbosity, "Not saving the install log: %v", err)
+	} else {
+		defer func() { _ = installLog.Close() }()
+		ctx = ansible.WithOutputLog(ctx, installLog)
 	}
+	logSaved := err == nil
 
-	if len(sandboxTags) > 0 {
-		if err := runPlaybook(ctx, constants.SandboxRepoPath, constants.SandboxPlaybookPath(), sandboxTags, ansibleBinaryPath, extraVars, skipTags, extraArgs); err != nil {
-			return err
+	checkRun := slices.Contains(extraArgs, "--check")
+	start := time.Now()
+	var recap ansible.PlaybookRecap
+	for _, run := range runs {
+		if len(run.tags) == 0 {
+			continue
+		}
+		runRecap, err := runPlaybook(ctx, run.repoPath, run.playbookPath, run.tags, ansibleBinaryPath, extraVars, skipTags, extraArgs)
+		recap.Add(runRecap)
+		if err != nil {
+			// Interrupted runs are already reported by the signal handling
+			if ctx.Err() == nil {
+				fmt.Println(formatInstallSummary(tags, time.Since(start), recap, checkRun, err))
+				fmt.Println(formatInstallFailureHint(run.tags, logSaved, containerExists(ctx, run.tags[0])))
+			}
+			return sbErrors.WithExitCode(err, installExitCode(sbErrors.ExitCode(err)))
 		}
 	}
 
+	fmt.Println(formatInstallSummary(tags, time.Since(start), recap, checkRun, nil))
 	return nil
 }
 
-func runPlaybook(ctx context.Context, repoPath, playbookPath string, tags []string, ansibleBinaryPath string, extraVars []string, skipTags []string, extraArgs []string) error {
-	tagsArg := strings.Join(tags, ",")
-	allArgs := []string{"--tags", tagsArg}
+func runPlaybook(ctx context.Context, repoPath, playbookPath string, tags []string, ansibleBinaryPath string, extraVars []string, skipTags []string, extraArgs []string) (ansible.PlaybookRecap, error) {
+	allArgs := buildPlaybookArgs(tags, extraVars, skipTags, extraArgs)
 
-	for _, extraVar := range extraVars {
-		allArgs = append(allArgs, "--extra-vars", extraVar)
+	recap, err := ansible.RunAnsiblePlaybookWithRecap(ctx, repoPath, playbookPath, ansibleBinaryPath, allArgs)
+	if err != nil {
+		handleInterruptError(err)
+		return recap, err
 	}
+	return recap, nil
+}
 
-	if len(skipTags) > 0 {
-		allArgs = append(allArgs, "--skip-tags", strings.Join(skipTags, ","))
+// Exit codes of sb install. They follow the ansible-playbook exit codes that scripts are
+// most likely to act on, and fold the rest into a generic failure.
+const (
+	installExitFailed      = 1 // Generic or unexpected failure
+	installExitTaskFailed  = 2 // A task failed
+	installExitUnreachable = 3 // A host was unreachable
+	installExitInvalid     = 4 // The playbook or its options were rejected
+)
+
+// installExitCode maps an ansible-playbook exit code to the exit code of sb install
+func installExitCode(ansibleCode int) int {
+	switch ansibleCode {
+	case 2:
+		return installExitTaskFailed
+	case 3:
+		return installExitUnreachable
+	case 4, 5: // Parser error, bad or incomplete options
+		return installExitInvalid
+	default: // 1 (error), 99 (user interrupted), 250 (unexpected error)
+		return installExitFailed
 	}
+}
 
-	allArgs = append(allArgs, extraArgs...)
+// formatInstallSummary renders the post-run summary of an install: the tags, the duration and,
+// if ansible printed a PLAY RECAP, the task counts summed over every playbook that ran.
+// Check runs are labeled as such, as their changed count is what would have changed.
+func formatInstallSummary(tags []string, duration time.Duration, recap ansible.PlaybookRecap, checkRun bool, err error) string {
+	run := "Install"
+	if checkRun {
+		run = "Check run (no changes applied)"
+	}
+	status := styles.SuccessStyle.Render(run + " succeeded")
+	if err != nil {
+		status = styles.ErrorStyle.Render(run + " failed")
+	}
+
+	parts := []string{status, "tags: " + strings.Join(tags, ","), "duration: " + duration.Round(time.Second).String()}
+	if recap.Found {
+		failed := fmt.Sprintf("failed=%d", recap.Failed+recap.Unreachable)
+		if recap.Failed+recap.Unreachable > 0 {
+			failed = styles.ErrorStyle.Render(failed)
+		}
+		changed := fmt.Sprintf("changed=%d", recap.Changed)
+		if recap.Changed > 0 {
+			changed = styles.WarningStyle.Render(changed)
+		}
+		parts = append(parts, fmt.Sprintf("ok=%d", recap.Ok), changed, failed)
+	}
+	return "\n" + strings.Join(parts, " | ")
+}
 
-	err := ansible.RunAnsiblePlaybook(ctx, repoPath, playbookPath, ansibleBinaryPath, allArgs, true) // Always use true for verbose
+// formatInstallFailureHint points at the failed task of a failed playbook run, and at the logs of
+// the container deployed by its first tag when one exists. Most tags are Docker roles rather than
+// systemd services, so sb logs is not suggested.
+func formatInstallFailureHint(tags []string, logSaved, isContainer bool) string {
+	hint := "Scroll up to the failed task for details"
+	if logSaved {
+		hint += ", or review the install with: sb install-log"
+	}
+	if isContainer {
+		hint += fmt.Sprintf("\nCheck the logs of the %s container with: sb docker logs %s --no-tui", tags[0], tags[0])
+	}
+	return hint
+}
+
+// containerExists reports whether a Docker container with the given name exists, replaced in tests
+var containerExists = func(ctx context.Context, name string) bool {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	cli, err := client.New(client.FromEnv)
 	if err != nil {
-		handleInterruptError(err)
-		return err
+		return false
 	}
-	return nil
+	defer func() { _ = cli.Close() }()
+	_, err = cli.ContainerInspect(ctx, name, client.ContainerInspectOptions{})
+	return err == nil
+}
+
+// resolveExtraVarFiles makes relative @file extra vars absolute and checks that the files exist.
+// Playbooks run from the repository directory, so relative paths would otherwise be resolved
+// against the repository instead of the directory sb was run from. All other values, such as
+// key=value pairs and JSON objects, are returned unchanged.
+func resolveExtraVarFiles(extraVars []string) ([]string, error) {
+	resolved := make([]string, 0, len(extraVars))
+	for _, extraVar := range extraVars {
+		path, isFile := strings.CutPrefix(extraVar, "@")
+		if !isFile {
+			resolved = append(resolved, extraVar)
+			continue
+		}
+
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve extra vars file %s: %w", path, err)
+		}
+		if _, err := os.Stat(absPath); err != nil {
+			return nil, fmt.Errorf("extra vars file %s not found: %w", path, err)
+		}
+		resolved = append(resolved, "@"+absPath)
+	}
+	return resolved, nil
+}
+
+// buildPlaybookArgs builds the ansible-playbook arguments for an install.
+// Each extra var is passed verbatim as a single --extra-vars argument, so values containing
+// spaces, commas, lists or JSON reach Ansible intact without any shell quoting.
+func buildPlaybookArgs(tags []string, extraVars []string, skipTags []string, extraArgs []string) []string {
+	args := []string{"--tags", strings.Join(tags, ",")}
+
+	for _, extraVar := range extraVars {
+		args = append(args, "--extra-vars", extraVar)
+	}
+
+	if len(skipTags) > 0 {
+		args = append(args, "--skip-tags", strings.Join(skipTags, ","))
+	}
+
+	return append(args, extraArgs...)
 }
 
 // formatSuggestions builds a formatted string with all suggestions
@@ -263,11 +410,16 @@ func formatSuggestions(suggestions []suggestion) string {
 	result.WriteString("\n\n")
 
 	for _, s := range suggestions {
+		tagLabel := labelStyle.Render("Tag:")
+		if s.skipTag {
+			tagLabel = labelStyle.Render("Skip tag:")
+		}
+
 		switch s.sType {
 		case suggestionExactMatch:
 			// Exact match in other repo - this is the most helpful suggestion
 			result.WriteString(fmt.Sprintf("%s %s %s\n",
-				labelStyle.Render("Tag:"),
+				tagLabel,
 				inputStyle.Render(s.inputTag),
 				normalStyle.Render("not present in "+s.currentRepo)))
 			result.WriteString(fmt.Sprintf("%s %s %s\n",
@@ -278,7 +430,7 @@ func formatSuggestions(suggestions []suggestion) string {
 		case suggestionTypo:
 			// Likely typo in same repo
 			result.WriteString(fmt.Sprintf("%s %s %s\n",
-				labelStyle.Render("Tag:"),
+				tagLabel,
 				inputStyle.Render(s.inputTag),
 				normalStyle.Render("not present in "+s.currentRepo)))
 			result.WriteString(fmt.Sprintf("%s %s\n",
@@ -288,7 +440,7 @@ func formatSuggestions(suggestions []suggestion) string {
 		case suggestionTypoOther:
 			// Likely typo in other repo
 			result.WriteString(fmt.Sprintf("%s %s %s\n",
-				labelStyle.Render("Tag:"),
+				tagLabel,
 				inputStyle.Render(s.inputTag),
 				normalStyle.Render("not present in "+s.currentRepo)))
 			result.WriteString(fmt.Sprintf("%s %s %s\n",
@@ -300,12 +452,12 @@ func formatSuggestions(suggestions []suggestion) string {
 			// Not found anywhere
 			infoStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(styles.ColorLightBlue))
 			result.WriteString(fmt.Sprintf("%s %s %s\n",
-				labelStyle.Render("Tag:"),
+				tagLabel,
 				inputStyle.Render(s.inputTag),
 				normalStyle.Render("not present in Saltbox or Sandbox")))
 			result.WriteString(fmt.Sprintf("%s %s %s\n",
 				labelStyle.Render("Add:"),
-				infoStyle.Render("--no-cache"),
+				infoStyle.Render("--no-validate"),
 				normalStyle.Render("if developing your own role")))
 		}
 
@@ -448,6 +600,65 @@ func validateAndSuggest(ctx context.Context, repoPath string, providedTags []str
 	return suggestions, nil
 }
 
+// ansibleSpecialTags are the tags Ansible understands without any task declaring them
+var ansibleSpecialTags = []string{"all", "always", "never", "tagged", "untagged"}
+
+// validateSkipTags checks that every --skip-tags value exists in the Saltbox or Sandbox playbook,
+// as Ansible silently ignores unknown skip tags and would then run the tasks meant to be skipped.
+// Skip tags are passed to every playbook that runs, so they aren't prefixed with sandbox- or mod-.
+func validateSkipTags(ctx context.Context, skipTags []string, cacheInstance *cache.Cache, verbosity int) ([]suggestion, error) {
+	validTags, err := getValidTags(ctx, constants.SaltboxRepoPath, cacheInstance, verbosity)
+	if err != nil {
+		return nil, err
+	}
+	if sandboxTags, err := getValidTags(ctx, constants.SandboxRepoPath, cacheInstance, verbosity); err != nil {
+		logging.Debug(verbosity, "Error getting tags for %s: %v", constants.SandboxRepoPath, err)
+	} else {
+		validTags = append(validTags, sandboxTags...)
+	}
+
+	var suggestions []suggestion
+	for _, skipTag := range skipTags {
+		if slices.Contains(validTags, skipTag) || slices.Contains(ansibleSpecialTags, skipTag) {
+			continue
+		}
+
+		bestMatch := ""
+		bestDistance := 3 // Only suggest tags within a distance of 2
+		for _, validTag := range validTags {
+			if distance := levenshtein.ComputeDistance(skipTag, validTag); distance < bestDistance {
+				bestDistance = distance
+				bestMatch = validTag
+			}
+		}
+
+		if bestMatch != "" {
+			logging.Debug(verbosity, "Suggesting skip tag '%s' for '%s'", bestMatch, skipTag)
+			suggestions = append(suggestions, suggestion{
+				inputTag:    skipTag,
+				suggestTag:  bestMatch,
+				currentRepo: "Saltbox or Sandbox",
+				targetRepo:  "Saltbox or Sandbox",
+				sType:       suggestionTypo,
+				skipTag:     true,
+			})
+			continue
+		}
+
+		logging.Debug(verbosity, "No match found for skip tag '%s'", skipTag)
+		suggestions = append(suggestions, suggestion{
+			inputTag: skipTag,
+			sType:    suggestionNotFound,
+			skipTag:  true,
+		})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		return suggestions[i].inputTag < suggestions[j].inputTag
+	})
+	return suggestions, nil
+}
+
 // getValidTags retrieves valid tags from the cache, handling potential errors, and updates the cache if needed
 func getValidTags(ctx context.Context, repoPath string, cacheInstance *cache.Cache, verbosity int) ([]string, error) {
 	playbookPath := ""
@@ -602,6 +813,25 @@ func isCachePopulated(cacheInstance *cache.Cache) bool {
 	return false
 }
 
+// loadCompletionTags returns the tags for shell completion, populating the cache first if needed
+func loadCompletionTags(ctx context.Context) ([]string, error) {
+	cacheInstance, err := cache.NewCache()
+	if err != nil {
+		return nil, err
+	}
+
+	if !isCachePopulated(cacheInstance) {
+		// At least one of the repositories must succeed for completion to work
+		_, saltboxErr := ansible.RunAndCacheAnsibleTags(ctx, constants.SaltboxRepoPath, constants.SaltboxPlaybookPath(), "", cacheInstance, 0)
+		_, sandboxErr := ansible.RunAndCacheAnsibleTags(ctx, constants.SandboxRepoPath, constants.SandboxPlaybookPath(), "", cacheInstance, 0)
+		if saltboxErr != nil && sandboxErr != nil {
+			return nil, saltboxErr
+		}
+	}
+
+	return getCompletionTags(cacheInstance), nil
+}
+
 // getCompletionTags retrieves and formats all tags from cache for shell completion
 func getCompletionTags(cacheInstance *cache.Cache) []string {
 	var allTags []string
@@ -0,0 +1,525 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/ansible"
+	"github.com/saltyorg/sb-go/internal/constants"
+	"github.com/saltyorg/sb-go/internal/signals"
+	"github.com/saltyorg/sb-go/internal/styles"
+
+	"charm.land/bubbles/v2/help"
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/spf13/cobra"
+)
+
+// installLogPath is where sb install saves the output of its playbook runs, replaced in tests
+var installLogPath = constants.SaltboxInstallLogFile
+
+// installLogCmd represents the install-log command
+var installLogCmd = &cobra.Command{
+	Use:   "install-log",
+	Short: "Review the output of the last install",
+	Long: `Shows the Ansible output of the last sb install, with failed tasks highlighted.
+
+Tasks that were ok or skipped are folded into a single line, press 'e' to expand them.
+Press 'n' to jump to the next failure.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := os.Stat(installLogPath); errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("no install log found at %s, one is saved by every sb install", installLogPath)
+		}
+		return handleInstallLog(cmd)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(installLogCmd)
+}
+
+// createInstallLog replaces the saved install log with an empty one for the next run.
+// The log is only readable by root as playbook output can contain secrets.
+func createInstallLog() (io.WriteCloser, error) {
+	if err := os.MkdirAll(filepath.Dir(installLogPath), 0755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(installLogPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	// Logs saved by older versions keep their mode when truncated
+	if err := file.Chmod(0600); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return file, nil
+}
+
+// installLogEntry is a section of the install log, or a run of ok and skipped tasks folded together
+type installLogEntry struct {
+	sections []ansible.RunLogSection
+	quiet    bool // Only ok and skipped tasks, folded unless expanded
+}
+
+// failed reports whether any section of the entry shows a failure
+func (e installLogEntry) failed() bool {
+	for _, section := range e.sections {
+		if section.Failed() {
+			return true
+		}
+	}
+	return false
+}
+
+// isQuietTask reports whether a section is a task whose output is rarely of interest
+func isQuietTask(section ansible.RunLogSection) bool {
+	if section.Kind != ansible.SectionTask && section.Kind != ansible.SectionHandler {
+		return false
+	}
+	return section.Status == ansible.TaskOk || section.Status == ansible.TaskSkipped
+}
+
+// groupInstallLog turns sections into entries, folding consecutive ok and skipped tasks together
+func groupInstallLog(sections []ansible.RunLogSection) []installLogEntry {
+	var entries []installLogEntry
+	for _, section := range sections {
+		quiet := isQuietTask(section)
+		if quiet && len(entries) > 0 && entries[len(entries)-1].quiet {
+			last := &entries[len(entries)-1]
+			last.sections = append(last.sections, section)
+			continue
+		}
+		entries = append(entries, installLogEntry{sections: []ansible.RunLogSection{section}, quiet: quiet})
+	}
+	return entries
+}
+
+// installLogMsg carries the parsed install log
+type installLogMsg struct {
+	entries  []installLogEntry
+	modified time.Time
+	err      error
+}
+
+// installLogSource reads the saved install log. The log is small enough to be loaded at once,
+// so there is only ever a single page.
+type installLogSource struct {
+	path string
+}
+
+func (s installLogSource) Fetch(position string, reverse bool, isPrefetch bool) tea.Cmd {
+	return func() tea.Msg {
+		info, err := os.Stat(s.path)
+		if err != nil {
+			return installLogMsg{err: fmt.Errorf("failed to read install log: %w", err)}
+		}
+		data, err := os.ReadFile(s.path)
+		if err != nil {
+			return installLogMsg{err: fmt.Errorf("failed to read install log: %w", err)}
+		}
+		sections := ansible.ParseRunLog(strings.Split(string(data), "\n"))
+		return installLogMsg{entries: groupInstallLog(sections), modified: info.ModTime()}
+	}
+}
+
+func (s installLogSource) Position(entry installLogEntry) string {
+	return ""
+}
+
+// Format formats an entry, showing folded tasks in full when expanded is set
+func (s installLogSource) Format(entry installLogEntry, expanded bool) string {
+	if entry.quiet && !expanded {
+		return styles.DimStyle.Render("▸ " + quietTasksSummary(entry.sections))
+	}
+	lines := make([]string, 0, len(entry.sections))
+	for _, section := range entry.sections {
+		lines = append(lines, formatRunLogSection(section))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// quietTasksSummary counts folded tasks, e.g. "12 tasks ok, 3 skipped"
+func quietTasksSummary(sections []ansible.RunLogSection) string {
+	var ok, skipped int
+	for _, section := range sections {
+		if section.Status == ansible.TaskSkipped {
+			skipped++
+		} else {
+			ok++
+		}
+	}
+	noun := "tasks"
+	if len(sections) == 1 {
+		noun = "task"
+	}
+	switch {
+	case skipped == 0:
+		return fmt.Sprintf("%d %s ok", ok, noun)
+	case ok == 0:
+		return fmt.Sprintf("%d %s skipped", skipped, noun)
+	default:
+		return fmt.Sprintf("%d %s ok, %d skipped", ok, noun, skipped)
+	}
+}
+
+// formatRunLogSection colors a section of the install log like ansible colors its output,
+// with failed tasks and errors standing out
+func formatRunLogSection(section ansible.RunLogSection) string {
+	lines := make([]string, 0, len(section.Lines))
+	for i, line := range section.Lines {
+		if i == 0 && section.Kind != ansible.SectionOther {
+			lines = append(lines, runLogHeaderStyle(section).Render(line))
+			continue
+		}
+		lines = append(lines, runLogLineStyle(section, line).Render(line))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// runLogHeaderStyle returns the style of the header of a section
+func runLogHeaderStyle(section ansible.RunLogSection) lipgloss.Style {
+	switch section.Kind {
+	case ansible.SectionPlay:
+		return styles.HeaderStyle
+	case ansible.SectionRecap:
+		if section.Failed() {
+			return styles.ErrorStyle
+		}
+		return styles.HeaderStyle
+	}
+	switch section.Status {
+	case ansible.TaskFailed:
+		return styles.ErrorStyle
+	case ansible.TaskChanged, ansible.TaskIgnored:
+		return styles.WarningStyle
+	case ansible.TaskPending:
+		// No result was printed, usually because the run stopped during this task
+		return styles.HighlightStyle
+	default:
+		return styles.DimStyle
+	}
+}
+
+// runLogLineStyle returns the style of a line of output within a section
+func runLogLineStyle(section ansible.RunLogSection, line string) lipgloss.Style {
+	switch section.Kind {
+	case ansible.SectionRecap:
+		if section.Failed() {
+			return styles.ErrorStyle
+		}
+		return styles.SuccessStyle
+	case ansible.SectionOther:
+		switch {
+		case strings.HasPrefix(line, "ERROR!"):
+			return styles.ErrorStyle
+		case strings.HasPrefix(line, "[WARNING]"), strings.HasPrefix(line, "[DEPRECATION WARNING]"):
+			return styles.WarningStyle
+		}
+		return styles.DefaultStyle
+	}
+	switch ansible.ResultLineStatus(line) {
+	case ansible.TaskFailed:
+		if section.Status == ansible.TaskIgnored {
+			return styles.WarningStyle
+		}
+		return styles.ErrorStyle
+	case ansible.TaskChanged:
+		return styles.WarningStyle
+	case ansible.TaskOk:
+		return styles.SuccessStyle
+	case ansible.TaskSkipped:
+		return styles.DimStyle
+	}
+	// Multi-line output like the message of a failed task belongs to the result above it
+	if section.Status == ansible.TaskFailed {
+		return styles.ErrorStyle
+	}
+	return styles.DefaultStyle
+}
+
+// installLogKeyMap holds the key bindings of the install log viewer
+type installLogKeyMap struct {
+	Expand key.Binding
+	Next   key.Binding
+	Copy   key.Binding
+	Save   key.Binding
+	Quit   key.Binding
+}
+
+func (k installLogKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{keys.Up, keys.Down, keys.Left, keys.Right, k.Expand, k.Next, k.Copy, k.Save, k.Quit}
+}
+
+func (k installLogKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+var installLogKeys = installLogKeyMap{
+	Expand: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "expand/fold ok tasks"),
+	),
+	Next: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next failure"),
+	),
+	Copy: keys.Copy,
+	Save: keys.Save,
+	Quit: keys.Quit,
+}
+
+// installLogModel is the install log viewer
+type installLogModel struct {
+	viewport            viewport.Model
+	help                help.Model
+	logBuf              *logViewBuffer[installLogEntry]
+	width               int
+	height              int
+	viewportInitialized bool
+	loading             bool
+	err                 error
+	expanded            bool      // Whether folded ok and skipped tasks are shown in full
+	modified            time.Time // When the install log was last written
+	status              string    // Transient status shown next to the help
+	statusID            int       // Identifies the current status so only its own timer clears it
+}
+
+func (m installLogModel) Init() tea.Cmd {
+	return m.logBuf.source.Fetch("", true, false)
+}
+
+// refresh shows the loaded log in the viewport, keeping the scroll position within bounds
+func (m *installLogModel) refresh() {
+	if !m.viewportInitialized {
+		return
+	}
+	m.viewport.SetContent(m.logBuf.GetContentFormatted(m.expanded, false))
+	m.viewport.SetYOffset(min(m.viewport.YOffset(), max(0, m.viewport.TotalLineCount()-m.viewport.Height())))
+}
+
+// entryLines returns the line of the viewport content each entry starts on
+func (m installLogModel) entryLines() []int {
+	lines := make([]int, len(m.logBuf.entries))
+	line := 2 // "--- start of logs ---" + blank line
+	for i, entry := range m.logBuf.entries {
+		lines[i] = line
+		line += m.logBuf.LineCount([]installLogEntry{entry}, m.expanded)
+	}
+	return lines
+}
+
+// failureLines returns the line of the viewport content each failed entry starts on
+func (m installLogModel) failureLines() []int {
+	var failures []int
+	for i, line := range m.entryLines() {
+		if m.logBuf.entries[i].failed() {
+			failures = append(failures, line)
+		}
+	}
+	return failures
+}
+
+// entryAt returns the index of the entry shown on a line of the viewport content
+func (m installLogModel) entryAt(line int) int {
+	index := 0
+	for i, start := range m.entryLines() {
+		if start > line {
+			break
+		}
+		index = i
+	}
+	return index
+}
+
+// nextFailure returns the first failure line below the top of the view, wrapping around to the first
+func nextFailure(failures []int, top int) (int, bool) {
+	if len(failures) == 0 {
+		return 0, false
+	}
+	for _, line := range failures {
+		if line > top {
+			return line, true
+		}
+	}
+	return failures[0], true
+}
+
+// showFirstFailure scrolls to the first failure, or to the end of the log when the run succeeded
+func (m *installLogModel) showFirstFailure() {
+	if line, ok := nextFailure(m.failureLines(), -1); ok {
+		m.viewport.SetYOffset(line)
+	} else {
+		m.viewport.GotoBottom()
+	}
+}
+
+func (m installLogModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.help.SetWidth(msg.Width)
+		helpHeight := lipgloss.Height(m.help.View(installLogKeys))
+
+		if !m.viewportInitialized {
+			m.viewport = viewport.New(viewport.WithWidth(m.width), viewport.WithHeight(m.height-helpHeight))
+			m.viewport.Style = lipgloss.NewStyle().Padding(1, 2)
+			m.viewportInitialized = true
+			if !m.loading {
+				m.refresh()
+				m.showFirstFailure()
+			}
+		} else {
+			m.viewport.SetWidth(msg.Width)
+			m.viewport.SetHeight(msg.Height - helpHeight)
+			m.refresh()
+		}
+		return m, nil
+
+	case installLogMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.modified = msg.modified
+		m.logBuf.AppendInitial(msg.entries, "", "")
+		m.logBuf.hasMoreBefore = false
+		m.refresh()
+		if m.viewportInitialized {
+			m.showFirstFailure()
+		}
+		return m, nil
+
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			signals.GetGlobalManager().Shutdown(130)
+			return m, tea.Quit
+		case "q", "esc":
+			return m, tea.Quit
+		}
+		if m.loading || m.err != nil || !m.viewportInitialized {
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "e":
+			// Keep the entry at the top of the view in place while entries change size
+			index := m.entryAt(m.viewport.YOffset())
+			m.expanded = !m.expanded
+			m.refresh()
+			if lines := m.entryLines(); index < len(lines) {
+				m.viewport.SetYOffset(lines[index])
+			}
+			return m, nil
+
+		case "n":
+			line, ok := nextFailure(m.failureLines(), m.viewport.YOffset())
+			if !ok {
+				m.statusID++
+				m.status = "No failures in this install"
+				return m, clearLogStatusAfter(m.statusID)
+			}
+			m.viewport.SetYOffset(line)
+			return m, nil
+
+		case "left":
+			m.viewport.ScrollLeft(10)
+			return m, nil
+
+		case "right":
+			m.viewport.ScrollRight(10)
+			return m, nil
+
+		case "y", "Y":
+			text := visibleViewportText(m.viewport)
+			if msg.String() == "Y" {
+				text = m.logBuf.PlainText(true)
+			}
+			m.statusID++
+			m.status = copiedLinesStatus(text)
+			return m, tea.Batch(copyLogText(text), clearLogStatusAfter(m.statusID))
+
+		case "w":
+			return m, saveLogText("install", m.logBuf.PlainText(true))
+		}
+
+	case logSavedMsg:
+		m.statusID++
+		m.status = savedLogsStatus(msg)
+		return m, clearLogStatusAfter(m.statusID)
+
+	case clearLogStatusMsg:
+		if msg.id == m.statusID {
+			m.status = ""
+		}
+		return m, nil
+	}
+
+	if m.viewportInitialized && !m.loading {
+		m.viewport, cmd = m.viewport.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m installLogModel) View() tea.View {
+	helpView := m.help.ShortHelpView(installLogKeys.ShortHelp())
+	if !m.modified.IsZero() {
+		helpView += "  " + styles.DimStyle.Render("saved "+m.modified.Format("2006-01-02 15:04"))
+	}
+	if m.status != "" {
+		helpView += "  " + styles.InfoStyle.Render(m.status)
+	}
+
+	var content string
+	switch {
+	case m.err != nil:
+		content = lipgloss.NewStyle().
+			Width(m.width).
+			Height(m.height - lipgloss.Height(helpView)).
+			Padding(2).
+			Render(styles.ErrorStyle.Render("Error: " + m.err.Error()))
+	case m.loading || !m.viewportInitialized:
+		content = lipgloss.NewStyle().
+			Width(m.width).
+			Height(m.height - lipgloss.Height(helpView)).
+			Padding(2).
+			Render(styles.DimStyle.Render("Loading install log..."))
+	default:
+		content = m.viewport.View()
+	}
+
+	v := tea.NewView(lipgloss.JoinVertical(lipgloss.Left, content, helpView))
+	v.AltScreen = true
+	return v
+}
+
+// handleInstallLog runs the install log viewer
+func handleInstallLog(cmd *cobra.Command) error {
+	h := help.New()
+	h.ShowAll = false
+
+	m := installLogModel{
+		help:    h,
+		logBuf:  newLogViewBuffer[installLogEntry](installLogSource{path: installLogPath}, logBufferLimits{}, 0),
+		loading: true,
+	}
+
+	p := tea.NewProgram(m, tea.WithContext(cmd.Context()))
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("error running install log UI: %w", err)
+	}
+	return nil
+}
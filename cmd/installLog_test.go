@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/saltyorg/sb-go/internal/ansible"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestGroupInstallLog(t *testing.T) {
+	task := func(status ansible.TaskStatus) ansible.RunLogSection {
+		return ansible.RunLogSection{Kind: ansible.SectionTask, Lines: []string{"TASK [x] ***"}, Status: status}
+	}
+	sections := []ansible.RunLogSection{
+		{Kind: ansible.SectionPlay, Lines: []string{"PLAY [Saltbox] ***"}},
+		task(ansible.TaskOk),
+		task(ansible.TaskSkipped),
+		task(ansible.TaskOk),
+		task(ansible.TaskFailed),
+		task(ansible.TaskSkipped),
+	}
+
+	entries := groupInstallLog(sections)
+	want := []struct {
+		sections int
+		quiet    bool
+	}{{1, false}, {3, true}, {1, false}, {1, true}}
+	if len(entries) != len(want) {
+		t.Fatalf("groupInstallLog() returned %d entries, want %d", len(entries), len(want))
+	}
+	for i, w := range want {
+		if len(entries[i].sections) != w.sections || entries[i].quiet != w.quiet {
+			t.Errorf("entry %d has %d sections (quiet %v), want %d (quiet %v)", i, len(entries[i].sections), entries[i].quiet, w.sections, w.quiet)
+		}
+	}
+
+	source := installLogSource{}
+	if got := ansi.Strip(source.Format(entries[1], false)); got != "▸ 2 tasks ok, 1 skipped" {
+		t.Errorf("folded entry = %q", got)
+	}
+	if got := ansi.Strip(source.Format(entries[1], true)); got != "TASK [x] ***\nTASK [x] ***\nTASK [x] ***" {
+		t.Errorf("expanded entry = %q", got)
+	}
+	if got := ansi.Strip(source.Format(entries[3], false)); got != "▸ 1 task skipped" {
+		t.Errorf("folded single task = %q", got)
+	}
+}
+
+func TestNextFailure(t *testing.T) {
+	failures := []int{10, 40}
+	tests := []struct {
+		top  int
+		want int
+	}{
+		{-1, 10},
+		{10, 40},
+		{25, 40},
+		{40, 10}, // Wraps around to the first failure
+	}
+	for _, tt := range tests {
+		if got, ok := nextFailure(failures, tt.top); !ok || got != tt.want {
+			t.Errorf("nextFailure(%d) = %d, %v, want %d", tt.top, got, ok, tt.want)
+		}
+	}
+	if _, ok := nextFailure(nil, 0); ok {
+		t.Error("nextFailure() found a failure in a successful run")
+	}
+}
+
+func TestCreateInstallLog_Permissions(t *testing.T) {
+	original := installLogPath
+	installLogPath = filepath.Join(t.TempDir(), "logs", "install.log")
+	t.Cleanup(func() { installLogPath = original })
+
+	// An install log saved by an older version that was readable by everyone
+	if err := os.MkdirAll(filepath.Dir(installLogPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(installLogPath, []byte("old run"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	log, err := createInstallLog()
+	if err != nil {
+		t.Fatalf("createInstallLog() error: %v", err)
+	}
+	if err := log.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(installLogPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("install log mode = %o, want 600", mode)
+	}
+	if info.Size() != 0 {
+		t.Errorf("install log not truncated, size %d", info.Size())
+	}
+}
@@ -39,8 +39,9 @@ func (m *MockGitExecutor) ExecuteCommand(ctx context.Context, dir string, name s
 
 // MockAnsibleExecutor for ansible operations
 type MockAnsibleExecutor struct {
-	ExecuteContextFunc func(ctx context.Context, dir string, name string, args ...string) ([]byte, error)
-	ExecuteWithIOFunc  func(ctx context.Context, dir string, name string, args []string, stdout, stderr, stdin any) error
+	ExecuteContextFunc     func(ctx context.Context, dir string, name string, args ...string) ([]byte, error)
+	ExecuteWithIOFunc      func(ctx context.Context, dir string, name string, args []string, stdout, stderr, stdin any) error
+	ExecuteInteractiveFunc func(ctx context.Context, dir string, name string, args ...string) error
 }
 
 func (m *MockAnsibleExecutor) ExecuteContext(ctx context.Context, dir string, name string, args ...string) ([]byte, error) {
@@ -64,6 +65,13 @@ func (m *MockAnsibleExecutor) ExecuteWithIO(ctx context.Context, dir string, nam
 	return nil
 }
 
+func (m *MockAnsibleExecutor) ExecuteInteractive(ctx context.Context, dir string, name string, args ...string) error {
+	if m.ExecuteInteractiveFunc != nil {
+		return m.ExecuteInteractiveFunc(ctx, dir, name, args...)
+	}
+	return nil
+}
+
 // TestCacheExistsAndIsValid_Integration tests the actual function
 func TestCacheExistsAndIsValid_Integration(t *testing.T) {
 	t.Skip("Skipping: tests use real system paths that can't be mocked without refactoring production code")
@@ -413,6 +421,47 @@ func TestValidateAndSuggest_Integration(t *testing.T) {
 	}
 }
 
+// TestValidateSkipTags_Integration tests that unknown skip tags are reported with suggestions
+func TestValidateSkipTags_Integration(t *testing.T) {
+	originalGitExecutor := git.GetExecutor()
+	defer git.SetExecutor(originalGitExecutor)
+	git.SetExecutor(&MockGitExecutor{
+		ExecuteFunc: func(ctx context.Context, dir string, name string, args ...string) ([]byte, error) {
+			return []byte("abc123def456\n"), nil
+		},
+	})
+
+	c, err := cache.NewCacheWithFile(filepath.Join(t.TempDir(), "cache.json"))
+	if err != nil {
+		t.Fatalf("Failed to create test cache: %v", err)
+	}
+	_ = c.SetRepoCache(constants.SaltboxRepoPath, map[string]any{
+		"commit": "abc123def456",
+		"tags":   convertToAnySlice([]string{"settings", "sonarr"}),
+	})
+	_ = c.SetRepoCache(constants.SandboxRepoPath, map[string]any{
+		"commit": "abc123def456",
+		"tags":   convertToAnySlice([]string{"jellyseerr"}),
+	})
+
+	suggestions, err := validateSkipTags(context.Background(), []string{"settings", "jellyseerr", "always", "sonar", "made-up"}, c, 0)
+	if err != nil {
+		t.Fatalf("validateSkipTags() returned error: %v", err)
+	}
+	if len(suggestions) != 2 {
+		t.Fatalf("validateSkipTags() returned %d suggestions, want 2: %+v", len(suggestions), suggestions)
+	}
+	if s := suggestions[0]; s.inputTag != "made-up" || s.sType != suggestionNotFound || !s.skipTag {
+		t.Errorf("unknown skip tag suggestion = %+v", s)
+	}
+	if s := suggestions[1]; s.inputTag != "sonar" || s.suggestTag != "sonarr" || s.sType != suggestionTypo || !s.skipTag {
+		t.Errorf("skip tag typo suggestion = %+v", s)
+	}
+	if output := formatSuggestions(suggestions); !strings.Contains(output, "Skip tag:") || !strings.Contains(output, "--no-validate") {
+		t.Errorf("formatted skip tag suggestions = %q", output)
+	}
+}
+
 // TestHandleInstall_Integration tests parts of handleInstall with mocks
 func TestHandleInstall_Integration(t *testing.T) {
 	t.Skip("Skipping TestHandleInstall_Integration: this test modifies the real cache file")
@@ -3,16 +3,22 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/saltyorg/sb-go/internal/ansible"
 	"github.com/saltyorg/sb-go/internal/cache"
 	"github.com/saltyorg/sb-go/internal/constants"
+	sbErrors "github.com/saltyorg/sb-go/internal/errors"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // TestTagParsing tests the tag parsing and categorization logic
@@ -469,6 +475,228 @@ func TestRunPlaybook(t *testing.T) {
 	}
 }
 
+// TestBuildPlaybookArgsExtraVars tests that extra vars are passed to Ansible as single arguments
+func TestBuildPlaybookArgsExtraVars(t *testing.T) {
+	tests := []struct {
+		name      string
+		extraVars []string
+	}{
+		{"list value", []string{"testvar=['foo','bar']"}},
+		{"quoted value with spaces", []string{"greeting='hello world'"}},
+		{"JSON extra vars", []string{`{"plex_name": "plex2", "list": [1, 2, 3]}`}},
+		{"multiple extra vars", []string{"a=1,2,3", "b=x y"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := buildPlaybookArgs([]string{"plex"}, tt.extraVars, []string{"always"}, []string{"-v"})
+
+			want := []string{"--tags", "plex"}
+			for _, ev := range tt.extraVars {
+				want = append(want, "--extra-vars", ev)
+			}
+			want = append(want, "--skip-tags", "always", "-v")
+
+			if !slices.Equal(args, want) {
+				t.Errorf("buildPlaybookArgs() = %q, want %q", args, want)
+			}
+		})
+	}
+}
+
+// TestExtraVarsFlagParsing tests that -e values are not split on commas or spaces
+func TestExtraVarsFlagParsing(t *testing.T) {
+	flag := installCmd.Flags().Lookup("extra-vars")
+	defer func() {
+		_ = flag.Value.(pflag.SliceValue).Replace(nil)
+		flag.Changed = false
+	}()
+
+	values := []string{"testvar=['foo','bar']", "msg=a, b c", `{"key": ["x", "y"]}`}
+	var args []string
+	for _, v := range values {
+		args = append(args, "-e", v)
+	}
+	if err := installCmd.Flags().Parse(args); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	got, _ := installCmd.Flags().GetStringArray("extra-vars")
+	if !slices.Equal(got, values) {
+		t.Errorf("extra-vars = %q, want %q", got, values)
+	}
+}
+
+// TestResolveExtraVarFiles tests that @file extra vars are made absolute and other forms are untouched
+func TestResolveExtraVarFiles(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.WriteFile("vars.yml", []byte("plex_name: plex2\n"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	extraVars := []string{
+		"@vars.yml",
+		"@" + filepath.Join(dir, "vars.yml"),
+		`{"k":"v"}`,
+		"testvar=['foo','bar']",
+	}
+	got, err := resolveExtraVarFiles(extraVars)
+	if err != nil {
+		t.Fatalf("resolveExtraVarFiles() error = %v", err)
+	}
+
+	want := []string{
+		"@" + filepath.Join(dir, "vars.yml"),
+		"@" + filepath.Join(dir, "vars.yml"),
+		`{"k":"v"}`,
+		"testvar=['foo','bar']",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("resolveExtraVarFiles() = %q, want %q", got, want)
+	}
+
+	if _, err := resolveExtraVarFiles([]string{"@missing.yml"}); err == nil {
+		t.Error("resolveExtraVarFiles() should fail for a missing file")
+	}
+}
+
+// TestBuildPlaybookArgsRepeatedExtraVars tests that every -e maps to exactly one --extra-vars
+func TestBuildPlaybookArgsRepeatedExtraVars(t *testing.T) {
+	extraVars := []string{"@/tmp/vars.yml", `{"k":"v"}`, "a=1"}
+	args := buildPlaybookArgs([]string{"plex"}, extraVars, nil, nil)
+
+	var got []string
+	for i, arg := range args {
+		if arg == "--extra-vars" {
+			got = append(got, args[i+1])
+		}
+	}
+	if !slices.Equal(got, extraVars) {
+		t.Errorf("--extra-vars values = %q, want %q", got, extraVars)
+	}
+}
+
+// TestRunPlaybookPassesExtraVarsVerbatim tests the arguments handed to ansible-playbook
+func TestRunPlaybookPassesExtraVarsVerbatim(t *testing.T) {
+	original := ansible.GetExecutor()
+	defer ansible.SetExecutor(original)
+
+	var gotArgs []string
+	ansible.SetExecutor(&MockAnsibleExecutor{
+		ExecuteInteractiveFunc: func(ctx context.Context, dir string, name string, args ...string) error {
+			gotArgs = args
+			return nil
+		},
+	})
+
+	_, err := runPlaybook(context.Background(), constants.SaltboxRepoPath, constants.SaltboxPlaybookPath(), []string{"sonarr"},
+		constants.AnsiblePlaybookBinaryPath, []string{"testvar=['foo','bar']"}, nil, nil)
+	if err != nil {
+		t.Fatalf("runPlaybook() error = %v", err)
+	}
+
+	want := []string{constants.SaltboxPlaybookPath(), "--become", "--tags", "sonarr", "--extra-vars", "testvar=['foo','bar']"}
+	if !slices.Equal(gotArgs, want) {
+		t.Errorf("ansible-playbook args = %q, want %q", gotArgs, want)
+	}
+}
+
+// TestInstallDryRunFlags tests that --dry-run and --diff reach ansible-playbook as --check and --diff
+func TestInstallDryRunFlags(t *testing.T) {
+	original := ansible.GetExecutor()
+	defer ansible.SetExecutor(original)
+
+	var gotArgs []string
+	ansible.SetExecutor(&MockAnsibleExecutor{
+		ExecuteInteractiveFunc: func(ctx context.Context, dir string, name string, args ...string) error {
+			gotArgs = args
+			return nil
+		},
+	})
+
+	extraArgs := []string{"--check", "--diff"}
+	if _, err := runPlaybook(context.Background(), constants.SaltboxRepoPath, constants.SaltboxPlaybookPath(), []string{"sonarr"},
+		constants.AnsiblePlaybookBinaryPath, nil, nil, extraArgs); err != nil {
+		t.Fatalf("runPlaybook() error = %v", err)
+	}
+	if !slices.Contains(gotArgs, "--check") || !slices.Contains(gotArgs, "--diff") {
+		t.Errorf("ansible-playbook args = %q, want --check and --diff", gotArgs)
+	}
+
+	for _, flag := range []string{"dry-run", "diff"} {
+		if installCmd.Flags().Lookup(flag) == nil {
+			t.Errorf("install command has no --%s flag", flag)
+		}
+	}
+}
+
+// TestInstallExitCode tests how ansible-playbook exit codes map to sb install exit codes
+func TestInstallExitCode(t *testing.T) {
+	for ansibleCode, want := range map[int]int{1: installExitFailed, 2: installExitTaskFailed, 3: installExitUnreachable, 4: installExitInvalid, 5: installExitInvalid, 99: installExitFailed, 250: installExitFailed} {
+		if got := installExitCode(ansibleCode); got != want {
+			t.Errorf("installExitCode(%d) = %d, want %d", ansibleCode, got, want)
+		}
+	}
+}
+
+// TestRunPlaybookFailureExitCode tests that a failed task surfaces as the install exit code
+func TestRunPlaybookFailureExitCode(t *testing.T) {
+	original := ansible.GetExecutor()
+	defer ansible.SetExecutor(original)
+
+	ansible.SetExecutor(&MockAnsibleExecutor{
+		ExecuteInteractiveFunc: func(ctx context.Context, dir string, name string, args ...string) error {
+			return sbErrors.WithExitCode(errors.New("exit status 2"), 2)
+		},
+	})
+
+	_, err := runPlaybook(context.Background(), constants.SaltboxRepoPath, constants.SaltboxPlaybookPath(), []string{"sonarr"},
+		constants.AnsiblePlaybookBinaryPath, nil, nil, nil)
+	if err == nil {
+		t.Fatal("runPlaybook() succeeded for a failing playbook")
+	}
+	if got := installExitCode(sbErrors.ExitCode(err)); got != installExitTaskFailed {
+		t.Errorf("install exit code = %d, want %d", got, installExitTaskFailed)
+	}
+}
+
+// TestFormatInstallSummary tests the post-run summary with and without a recap
+func TestFormatInstallSummary(t *testing.T) {
+	recap := ansible.PlaybookRecap{Found: true, Ok: 34, Changed: 3, Failed: 1}
+	summary := formatInstallSummary([]string{"sonarr", "sandbox-jellyfin"}, 95*time.Second, recap, false, errors.New("failed"))
+	for _, want := range []string{"Install failed", "tags: sonarr,sandbox-jellyfin", "duration: 1m35s", "ok=34", "changed=3", "failed=1"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary %q does not contain %q", summary, want)
+		}
+	}
+
+	summary = formatInstallSummary([]string{"plex"}, time.Second, ansible.PlaybookRecap{}, false, nil)
+	if !strings.Contains(summary, "Install succeeded") || strings.Contains(summary, "ok=") {
+		t.Errorf("summary without recap = %q", summary)
+	}
+
+	summary = formatInstallSummary([]string{"plex"}, time.Second, ansible.PlaybookRecap{Found: true, Changed: 2}, true, nil)
+	if !strings.Contains(summary, "Check run (no changes applied) succeeded") {
+		t.Errorf("check run summary = %q", summary)
+	}
+}
+
+// TestFormatInstallFailureHint tests that only container tags are pointed at their container logs
+func TestFormatInstallFailureHint(t *testing.T) {
+	hint := formatInstallFailureHint([]string{"sonarr"}, true, true)
+	for _, want := range []string{"sb install-log", "sb docker logs sonarr --no-tui"} {
+		if !strings.Contains(hint, want) {
+			t.Errorf("hint %q does not contain %q", hint, want)
+		}
+	}
+
+	hint = formatInstallFailureHint([]string{"core"}, false, false)
+	if strings.Contains(hint, "sb docker logs") || strings.Contains(hint, "sb install-log") || strings.Contains(hint, "sb logs") {
+		t.Errorf("hint for a tag without container or saved log = %q", hint)
+	}
+}
+
 // TestLevenshteinDistance tests the Levenshtein distance calculation used for suggestions
 func TestLevenshteinDistance(t *testing.T) {
 	tests := []struct {
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// logAlert alerts the user when a log entry matching its pattern arrives in follow mode,
+// by ringing the terminal bell and optionally sending an OSC 9 desktop notification
+type logAlert struct {
+	pattern *regexp.Regexp
+	notify  bool // Also send a desktop notification with the matching line
+}
+
+// newLogAlert compiles an --alert pattern, returning nil if no pattern was given
+func newLogAlert(pattern string, notify bool) (*logAlert, error) {
+	if pattern == "" {
+		if notify {
+			return nil, fmt.Errorf("--notify requires an --alert pattern")
+		}
+		return nil, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --alert pattern: %w", err)
+	}
+	return &logAlert{pattern: re, notify: notify}, nil
+}
+
+// check returns a command alerting about the first line matching the pattern, or nil if none match.
+// Lines are matched without styling, as they are shown without colors when copied.
+func (a *logAlert) check(lines []string) tea.Cmd {
+	for _, line := range lines {
+		line = ansi.Strip(line)
+		if !a.pattern.MatchString(line) {
+			continue
+		}
+
+		alert := "\a"
+		if a.notify {
+			alert += ansi.Notify("sb: " + line)
+		}
+		return tea.Raw(alert)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestNewLogAlert(t *testing.T) {
+	if alert, err := newLogAlert("", false); alert != nil || err != nil {
+		t.Errorf("newLogAlert without pattern = %v, %v, want nil, nil", alert, err)
+	}
+	if _, err := newLogAlert("", true); err == nil {
+		t.Error("newLogAlert accepted --notify without --alert")
+	}
+	if _, err := newLogAlert("(unclosed", false); err == nil || !strings.Contains(err.Error(), "--alert") {
+		t.Errorf("newLogAlert invalid pattern error = %v", err)
+	}
+}
+
+func TestLogAlertCheck(t *testing.T) {
+	alert, err := newLogAlert("restart (finished|failed)", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cmd := alert.check([]string{"starting restart", "still waiting"}); cmd != nil {
+		t.Error("check alerted without a matching line")
+	}
+
+	cmd := alert.check([]string{"still waiting", "\x1b[32mrestart finished\x1b[0m"})
+	if cmd == nil {
+		t.Fatal("check did not alert on a matching line")
+	}
+	raw, ok := cmd().(tea.RawMsg)
+	if !ok {
+		t.Fatalf("alert message = %T, want tea.RawMsg", cmd())
+	}
+	if seq := raw.Msg.(string); !strings.HasPrefix(seq, "\a") || !strings.Contains(seq, "\x1b]9;sb: restart finished") {
+		t.Errorf("alert sequence = %q, want a bell and a notification", seq)
+	}
+}
+
+func TestLogBufferAlertsOnlyInFollowMode(t *testing.T) {
+	lb, _ := newTestLogBuffer(100)
+	lb.alert, _ = newLogAlert("done", false)
+	lb.entries = makeLogEntries("old", 2)
+
+	if cmd := lb.AppendNewer(makeLogEntries("done", 1), "done-0", false); cmd != nil {
+		t.Error("AppendNewer alerted outside of follow mode")
+	}
+
+	lb.StartFollow(time.Second)
+	if cmd := lb.AppendNewer(makeLogEntries("done", 1), "done-0", false); cmd == nil {
+		t.Error("AppendNewer did not alert on a matching entry in follow mode")
+	}
+	if cmd := lb.AppendNewer(makeLogEntries("busy", 1), "busy-0", false); cmd != nil {
+		t.Error("AppendNewer alerted on an entry that doesn't match")
+	}
+	if content := lb.GetContent(true); !strings.Contains(content, "alerting on /done/") {
+		t.Errorf("follow marker does not mention the alert: %q", content)
+	}
+}
@@ -0,0 +1,410 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/styles"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/spf13/cobra"
+)
+
+// logSource fetches and formats the entries shown by a log viewer.
+// Positions are opaque to the buffer: journal cursors for systemd services and timestamps for containers.
+type logSource[E any] interface {
+	// Fetch returns a command fetching a page of entries; reverse fetches entries older than position
+	Fetch(position string, reverse bool, isPrefetch bool) tea.Cmd
+	// Position returns the position of an entry to continue fetching from
+	Position(entry E) string
+	// Format formats an entry for display, with or without details like the timestamp
+	Format(entry E, showDetails bool) string
+}
+
+// minPrefetchInterval is the shortest time between two scroll-triggered prefetches in one
+// direction, so fast scrolling near an edge doesn't start a storm of fetches
+const minPrefetchInterval = 250 * time.Millisecond
+
+// prefetchRetryMsg asks a log viewer to check its prefetch needs again once a prefetch held
+// back by minPrefetchInterval is due
+type prefetchRetryMsg struct{}
+
+// logBufferLimits controls paging and memory usage of a log buffer
+type logBufferLimits struct {
+	pageSize        int // Number of log entries per page
+	maxEntries      int // Maximum entries to keep in memory
+	viewportsAhead  int // Prefetch when within this many viewports of an edge
+	viewportsToKeep int // Viewports to keep on each side when trimming
+}
+
+// Smallest values accepted by --page-size and --buffer-size
+const (
+	minLogPageSize   = 50
+	minLogBufferSize = 500
+)
+
+// addLogBufferFlags adds the --page-size and --buffer-size flags of a log viewer, defaulting to defaults
+func addLogBufferFlags(cmd *cobra.Command, defaults logBufferLimits) {
+	cmd.Flags().Int("page-size", defaults.pageSize, fmt.Sprintf("Number of log entries fetched at a time (minimum %d)", minLogPageSize))
+	cmd.Flags().Int("buffer-size", defaults.maxEntries, fmt.Sprintf("Maximum number of log entries kept in memory, at least --page-size (minimum %d)", minLogBufferSize))
+}
+
+// logBufferLimitsFromFlags returns defaults with the page and buffer sizes from the --page-size
+// and --buffer-size flags
+func logBufferLimitsFromFlags(cmd *cobra.Command, defaults logBufferLimits) (logBufferLimits, error) {
+	pageSize, _ := cmd.Flags().GetInt("page-size")
+	bufferSize, _ := cmd.Flags().GetInt("buffer-size")
+	return defaults.withSizes(pageSize, bufferSize)
+}
+
+// withSizes returns the limits with the given page size and buffer size, after checking them
+func (l logBufferLimits) withSizes(pageSize, bufferSize int) (logBufferLimits, error) {
+	if pageSize < minLogPageSize {
+		return l, fmt.Errorf("invalid --page-size %d (must be at least %d)", pageSize, minLogPageSize)
+	}
+	if bufferSize < minLogBufferSize {
+		return l, fmt.Errorf("invalid --buffer-size %d (must be at least %d)", bufferSize, minLogBufferSize)
+	}
+	if bufferSize < pageSize {
+		return l, fmt.Errorf("invalid --buffer-size %d (must be at least the --page-size of %d)", bufferSize, pageSize)
+	}
+	l.pageSize = pageSize
+	l.maxEntries = bufferSize
+	return l, nil
+}
+
+// targetSize returns the number of entries to prefetch up to: pagesAhead pages, but no more
+// than the buffer holds
+func (l logBufferLimits) targetSize(pagesAhead int) int {
+	return min(pagesAhead*l.pageSize, l.maxEntries)
+}
+
+// logViewBuffer manages the log entries of a log viewer and handles prefetching in both directions
+type logViewBuffer[E any] struct {
+	entries          []E
+	before           string // Position for fetching older logs
+	after            string // Position for fetching newer logs
+	hasMoreBefore    bool
+	hasMoreAfter     bool
+	source           logSource[E]
+	limits           logBufferLimits
+	prefetching      bool
+	prefetchingAfter bool
+	targetSize       int          // Target number of entries to keep loaded
+	followActive     bool         // Whether follow mode background fetching is active
+	alert            *logAlert    // Alerts on matching entries that arrive in follow mode, if set
+	visible          func(E) bool // Hides the entries it returns false for, shows all entries if nil
+	lastBefore       time.Time    // When the last prefetch of older logs started
+	lastAfter        time.Time    // When the last prefetch of newer logs started
+	retryScheduled   bool         // Whether a prefetchRetryMsg is on its way
+	now              func() time.Time
+}
+
+func newLogViewBuffer[E any](source logSource[E], limits logBufferLimits, targetSize int) *logViewBuffer[E] {
+	return &logViewBuffer[E]{
+		entries:       []E{},
+		source:        source,
+		limits:        limits,
+		targetSize:    targetSize,
+		hasMoreBefore: true,
+		hasMoreAfter:  false,
+		now:           time.Now,
+	}
+}
+
+// GetContent returns formatted content for display with boundary markers (details shown)
+func (lb *logViewBuffer[E]) GetContent(followMode bool) string {
+	return lb.GetContentFormatted(true, followMode)
+}
+
+// GetContentFormatted returns formatted content with boundary markers and optional details
+func (lb *logViewBuffer[E]) GetContentFormatted(showDetails bool, followMode bool) string {
+	if len(lb.entries) == 0 {
+		return "No log entries"
+	}
+
+	var lines []string
+
+	// Add start indicator at the beginning if we've hit the start boundary
+	if !lb.hasMoreBefore {
+		lines = append(lines, styles.DimStyle.Render("--- start of logs ---"))
+		lines = append(lines, "")
+	}
+
+	// Add all log entries
+	for _, entry := range lb.entries {
+		if lb.isVisible(entry) {
+			lines = append(lines, lb.source.Format(entry, showDetails))
+		}
+	}
+
+	// Add end indicator at the end if we've hit the end boundary
+	if !lb.hasMoreAfter {
+		lines = append(lines, "")
+		if followMode && lb.alert != nil {
+			lines = append(lines, styles.InfoStyle.Render(fmt.Sprintf("--- watching for new logs, alerting on /%s/ (press 'f' to disable) ---", lb.alert.pattern)))
+		} else if followMode {
+			lines = append(lines, styles.InfoStyle.Render("--- watching for new logs (press 'f' to disable) ---"))
+		} else {
+			lines = append(lines, styles.DimStyle.Render("--- end of logs ---"))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// PlainText returns the loaded entries formatted without styling or boundary markers, for copying or saving
+func (lb *logViewBuffer[E]) PlainText(showDetails bool) string {
+	lines := make([]string, 0, len(lb.entries))
+	for _, entry := range lb.entries {
+		if lb.isVisible(entry) {
+			lines = append(lines, ansi.Strip(lb.source.Format(entry, showDetails)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// EntryAtLine returns the visible entry shown on the given line of the content, or the first
+// entry when the line is part of the start of logs marker
+func (lb *logViewBuffer[E]) EntryAtLine(line int, showDetails bool) (E, bool) {
+	if !lb.hasMoreBefore {
+		line -= 2 // "--- start of logs ---" + blank line
+	}
+	for _, entry := range lb.entries {
+		if !lb.isVisible(entry) {
+			continue
+		}
+		line -= lb.LineCount([]E{entry}, showDetails)
+		if line < 0 {
+			return entry, true
+		}
+	}
+	var zero E
+	return zero, false
+}
+
+// LineCount returns the number of display lines of the given entries, skipping hidden entries
+func (lb *logViewBuffer[E]) LineCount(entries []E, showDetails bool) int {
+	lines := 0
+	for _, entry := range entries {
+		if lb.isVisible(entry) {
+			lines += strings.Count(lb.source.Format(entry, showDetails), "\n") + 1
+		}
+	}
+	return lines
+}
+
+// isVisible reports whether an entry passes the visible filter
+func (lb *logViewBuffer[E]) isVisible(entry E) bool {
+	return lb.visible == nil || lb.visible(entry)
+}
+
+// PrependedLines returns the number of display lines added to the top by prepending count entries,
+// so the viewport offset can be adjusted to keep the view stable. hadMoreBefore is the state before
+// prepending, since reaching the start of the logs also adds the start of logs marker.
+func (lb *logViewBuffer[E]) PrependedLines(count int, hadMoreBefore bool, showDetails bool) int {
+	lines := lb.LineCount(lb.entries[:min(count, len(lb.entries))], showDetails)
+	if hadMoreBefore && !lb.hasMoreBefore {
+		lines += 2 // "--- start of logs ---" + blank line
+	}
+	return lines
+}
+
+// ShouldPrefetch returns true if we need to fetch more older logs
+func (lb *logViewBuffer[E]) ShouldPrefetch() bool {
+	return len(lb.entries) < lb.targetSize && lb.hasMoreBefore && lb.before != "" && !lb.prefetching
+}
+
+// StartPrefetch marks prefetch as in progress and returns the fetch command
+func (lb *logViewBuffer[E]) StartPrefetch() tea.Cmd {
+	if !lb.ShouldPrefetch() {
+		return nil
+	}
+	lb.prefetching = true
+	lb.lastBefore = lb.now()
+	return lb.source.Fetch(lb.before, true, true)
+}
+
+// AppendInitial sets initial logs (most recent)
+func (lb *logViewBuffer[E]) AppendInitial(entries []E, first, last string) tea.Cmd {
+	lb.entries = entries
+	lb.before = first
+	lb.after = last
+	lb.hasMoreBefore = true
+	lb.hasMoreAfter = false
+	return lb.StartPrefetch()
+}
+
+// PrependOlder adds older logs to the beginning
+// oldest should be the position of the oldest entry in the batch (to fetch even older logs)
+func (lb *logViewBuffer[E]) PrependOlder(entries []E, oldest string, hasMore bool) tea.Cmd {
+	lb.entries = append(entries, lb.entries...)
+	lb.before = oldest
+	lb.hasMoreBefore = hasMore
+	lb.prefetching = false
+	return lb.StartPrefetch()
+}
+
+// AppendNewer adds newer logs to the end.
+// In follow mode, it returns a command alerting about the new entries if they match the alert pattern.
+func (lb *logViewBuffer[E]) AppendNewer(entries []E, last string, hasMore bool) tea.Cmd {
+	lb.entries = append(lb.entries, entries...)
+	lb.after = last
+	lb.hasMoreAfter = hasMore
+	lb.prefetchingAfter = false
+
+	if lb.followActive && lb.alert != nil {
+		lines := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			if lb.isVisible(entry) {
+				lines = append(lines, lb.source.Format(entry, true))
+			}
+		}
+		return lb.alert.check(lines)
+	}
+
+	// For forward prefetching, we could continue prefetching newer logs
+	// but typically we want to stay near "now", so we don't auto-prefetch forward
+	return nil
+}
+
+// StartFollow starts follow mode background fetching, polling for new entries every interval
+func (lb *logViewBuffer[E]) StartFollow(interval time.Duration) tea.Cmd {
+	lb.followActive = true
+	return tickFollow(interval)
+}
+
+// StopFollow stops follow mode background fetching
+func (lb *logViewBuffer[E]) StopFollow() {
+	lb.followActive = false
+}
+
+// Cleanup clears all log entries and resets state for memory cleanup
+func (lb *logViewBuffer[E]) Cleanup() {
+	lb.entries = nil
+	lb.before = ""
+	lb.after = ""
+	lb.hasMoreBefore = false
+	lb.hasMoreAfter = false
+	lb.prefetching = false
+	lb.prefetchingAfter = false
+	lb.followActive = false
+	lb.lastBefore = time.Time{}
+	lb.lastAfter = time.Time{}
+	lb.retryScheduled = false
+}
+
+// TrimBuffer removes entries far from viewport to limit memory usage
+// Returns the number of lines trimmed from the top (for viewport offset adjustment)
+func (lb *logViewBuffer[E]) TrimBuffer(viewportY, viewportHeight int) int {
+	if len(lb.entries) <= lb.limits.maxEntries {
+		return 0 // No need to trim
+	}
+
+	// Calculate viewport position in terms of log entries
+	// We need to estimate which entries are visible
+	totalLines := 0
+	visibleStartEntry := 0
+	visibleEndEntry := len(lb.entries) - 1
+
+	// Find which entries correspond to the viewport position
+	for i, entry := range lb.entries {
+		// Use details since this is just for line counting
+		entryLines := lb.LineCount([]E{entry}, true)
+		if totalLines+entryLines > viewportY {
+			visibleStartEntry = i
+			break
+		}
+		totalLines += entryLines
+	}
+
+	// Calculate how many entries to keep on each side
+	entriesToKeep := max(
+		// Rough estimate: ~3 lines per entry
+		lb.limits.viewportsToKeep*viewportHeight/3,
+		// Keep at least one page
+		lb.limits.pageSize)
+
+	// Calculate trim boundaries
+	trimStart := max(0, visibleStartEntry-entriesToKeep)
+	trimEnd := min(len(lb.entries), visibleEndEntry+entriesToKeep)
+
+	// Don't trim if we're not actually removing much
+	if trimStart < 100 && trimEnd > len(lb.entries)-100 {
+		return 0 // Not worth trimming
+	}
+
+	// Calculate lines being removed from the top
+	linesTrimmed := lb.LineCount(lb.entries[:trimStart], true)
+
+	// Trim the entries
+	oldEntries := lb.entries
+	lb.entries = make([]E, trimEnd-trimStart)
+	copy(lb.entries, oldEntries[trimStart:trimEnd])
+
+	// Update positions if we trimmed from edges
+	if trimStart > 0 && len(lb.entries) > 0 {
+		lb.before = lb.source.Position(lb.entries[0])
+		lb.hasMoreBefore = true // We know there are more entries we trimmed
+	}
+	if trimEnd < len(oldEntries) && len(lb.entries) > 0 {
+		lb.after = lb.source.Position(lb.entries[len(lb.entries)-1])
+		lb.hasMoreAfter = true // We know there are more entries we trimmed
+	}
+
+	return linesTrimmed
+}
+
+// CheckPrefetchNeeds checks if prefetching should be triggered based on viewport position
+// Returns commands for prefetching in both directions if needed. Only one prefetch per
+// direction is in flight at a time, and a new one starts at most every minPrefetchInterval.
+// A prefetch held back by the interval is retried through a prefetchRetryMsg.
+func (lb *logViewBuffer[E]) CheckPrefetchNeeds(viewportY, viewportHeight, totalHeight int) []tea.Cmd {
+	var cmds []tea.Cmd
+	var wait time.Duration
+	now := lb.now()
+
+	// Calculate threshold for prefetching (viewportsAhead * viewport height)
+	prefetchThreshold := lb.limits.viewportsAhead * viewportHeight
+
+	// Check if we should prefetch older logs (scrolling near top)
+	if viewportY < prefetchThreshold && lb.hasMoreBefore && lb.before != "" && !lb.prefetching {
+		if due := lb.lastBefore.Add(minPrefetchInterval).Sub(now); due > 0 {
+			wait = due
+		} else {
+			lb.prefetching = true
+			lb.lastBefore = now
+			cmds = append(cmds, lb.source.Fetch(lb.before, true, true))
+		}
+	}
+
+	// Check if we should prefetch newer logs (scrolling near bottom)
+	distanceFromBottom := totalHeight - (viewportY + viewportHeight)
+	if distanceFromBottom < prefetchThreshold && lb.hasMoreAfter && lb.after != "" && !lb.prefetchingAfter {
+		if due := lb.lastAfter.Add(minPrefetchInterval).Sub(now); due > 0 {
+			wait = max(wait, due)
+		} else {
+			lb.prefetchingAfter = true
+			lb.lastAfter = now
+			cmds = append(cmds, lb.source.Fetch(lb.after, false, true))
+		}
+	}
+
+	// Check again once the held back prefetch is due, as the viewport may not move again
+	if wait > 0 && !lb.retryScheduled {
+		lb.retryScheduled = true
+		cmds = append(cmds, tea.Tick(wait, func(time.Time) tea.Msg {
+			return prefetchRetryMsg{}
+		}))
+	}
+
+	return cmds
+}
+
+// RetryPrefetch handles a prefetchRetryMsg, checking the prefetch needs of the current viewport
+func (lb *logViewBuffer[E]) RetryPrefetch(viewportY, viewportHeight, totalHeight int) []tea.Cmd {
+	lb.retryScheduled = false
+	return lb.CheckPrefetchNeeds(viewportY, viewportHeight, totalHeight)
+}
@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// fetchCall records a single logSource.Fetch call
+type fetchCall struct {
+	position   string
+	reverse    bool
+	isPrefetch bool
+}
+
+// fakeJournalSource records fetch requests instead of running journalctl
+type fakeJournalSource struct {
+	journalSource
+	calls []fetchCall
+}
+
+func (f *fakeJournalSource) Fetch(position string, reverse bool, isPrefetch bool) tea.Cmd {
+	f.calls = append(f.calls, fetchCall{position: position, reverse: reverse, isPrefetch: isPrefetch})
+	return func() tea.Msg { return logsMsg{} }
+}
+
+// newTestLogBuffer returns a journal log buffer backed by a fake source
+func newTestLogBuffer(targetSize int) (*logBuffer, *fakeJournalSource) {
+	source := &fakeJournalSource{}
+	return newLogViewBuffer(source, journalLimits, targetSize), source
+}
+
+// makeLogEntries returns count single-line entries with cursors prefix-0 to prefix-(count-1)
+func makeLogEntries(prefix string, count int) []logEntry {
+	entries := make([]logEntry, count)
+	for i := range entries {
+		entries[i] = logEntry{message: fmt.Sprintf("%s %d", prefix, i), cursor: fmt.Sprintf("%s-%d", prefix, i)}
+	}
+	return entries
+}
+
+func TestLogBufferPrependOlder(t *testing.T) {
+	tests := []struct {
+		name       string
+		hasMore    bool
+		wantFetch  bool
+		targetSize int
+	}{
+		{"more older logs below target prefetches", true, true, 100},
+		{"start of journal stops prefetching", false, false, 100},
+		{"target reached stops prefetching", true, false, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lb, fetcher := newTestLogBuffer(tt.targetSize)
+			lb.entries = makeLogEntries("new", 2)
+			lb.prefetching = true
+
+			cmd := lb.PrependOlder(makeLogEntries("old", 2), "old-0", tt.hasMore)
+
+			if len(lb.entries) != 4 || lb.entries[0].cursor != "old-0" || lb.entries[2].cursor != "new-0" {
+				t.Fatalf("unexpected entry order: %+v", lb.entries)
+			}
+			if lb.before != "old-0" || lb.hasMoreBefore != tt.hasMore {
+				t.Errorf("before = %q, hasMoreBefore = %v", lb.before, lb.hasMoreBefore)
+			}
+			if (cmd != nil) != tt.wantFetch || lb.prefetching != tt.wantFetch {
+				t.Fatalf("cmd = %v, prefetching = %v, want fetch %v", cmd != nil, lb.prefetching, tt.wantFetch)
+			}
+			if tt.wantFetch && (fetcher.calls[0] != fetchCall{position: "old-0", reverse: true, isPrefetch: true}) {
+				t.Errorf("unexpected fetch: %+v", fetcher.calls[0])
+			}
+		})
+	}
+}
+
+func TestLogBufferAppendNewer(t *testing.T) {
+	lb, fetcher := newTestLogBuffer(100)
+	lb.entries = makeLogEntries("old", 2)
+	lb.prefetchingAfter = true
+
+	if cmd := lb.AppendNewer(makeLogEntries("new", 2), "new-1", true); cmd != nil {
+		t.Error("AppendNewer should not prefetch further")
+	}
+	if len(lb.entries) != 4 || lb.entries[3].cursor != "new-1" {
+		t.Fatalf("unexpected entry order: %+v", lb.entries)
+	}
+	if lb.after != "new-1" || !lb.hasMoreAfter || lb.prefetchingAfter {
+		t.Errorf("after = %q, hasMoreAfter = %v, prefetchingAfter = %v", lb.after, lb.hasMoreAfter, lb.prefetchingAfter)
+	}
+	if len(fetcher.calls) != 0 {
+		t.Errorf("unexpected fetches: %+v", fetcher.calls)
+	}
+}
+
+func TestLogBufferTrimBuffer(t *testing.T) {
+	tests := []struct {
+		name          string
+		entries       int
+		viewportY     int
+		wantTrimmed   int
+		wantFirst     string
+		wantMoreAfter bool
+	}{
+		{"below limit", maxBufferEntries, 10000, 0, "log-0", false},
+		{"viewport near top", maxBufferEntries + 50, 0, 0, "log-0", false},
+		{"viewport in the middle", maxBufferEntries + 50, 10000, 10000 - logPageSize, fmt.Sprintf("log-%d", 10000-logPageSize), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lb, _ := newTestLogBuffer(100)
+			lb.entries = makeLogEntries("log", tt.entries)
+			lb.before = "log-0"
+			lb.after = fmt.Sprintf("log-%d", tt.entries-1)
+
+			trimmed := lb.TrimBuffer(tt.viewportY, 30)
+
+			if trimmed != tt.wantTrimmed {
+				t.Errorf("TrimBuffer() = %d, want %d", trimmed, tt.wantTrimmed)
+			}
+			if lb.entries[0].cursor != tt.wantFirst || lb.before != tt.wantFirst {
+				t.Errorf("first entry = %q, before = %q, want %q", lb.entries[0].cursor, lb.before, tt.wantFirst)
+			}
+			if trimmed > 0 && (!lb.hasMoreBefore || len(lb.entries) != tt.entries-trimmed) {
+				t.Errorf("hasMoreBefore = %v, entries = %d", lb.hasMoreBefore, len(lb.entries))
+			}
+			if lb.hasMoreAfter != tt.wantMoreAfter {
+				t.Errorf("hasMoreAfter = %v, want %v", lb.hasMoreAfter, tt.wantMoreAfter)
+			}
+		})
+	}
+}
+
+func TestLogBufferCheckPrefetchNeeds(t *testing.T) {
+	tests := []struct {
+		name        string
+		viewportY   int
+		hasMore     bool
+		prefetching bool
+		want        []fetchCall
+	}{
+		{"near top fetches older", 10, true, false, []fetchCall{{position: "before", reverse: true, isPrefetch: true}}},
+		{"near bottom fetches newer", 9900, true, false, []fetchCall{{position: "after", reverse: false, isPrefetch: true}}},
+		{"middle fetches nothing", 5000, true, false, nil},
+		{"no more logs fetches nothing", 10, false, false, nil},
+		{"prefetch in progress fetches nothing", 10, true, true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lb, fetcher := newTestLogBuffer(100)
+			lb.before = "before"
+			lb.after = "after"
+			lb.hasMoreBefore = tt.hasMore
+			lb.hasMoreAfter = tt.hasMore
+			lb.prefetching = tt.prefetching
+			lb.prefetchingAfter = tt.prefetching
+
+			cmds := lb.CheckPrefetchNeeds(tt.viewportY, 20, 10000)
+
+			if len(cmds) != len(tt.want) || fmt.Sprint(fetcher.calls) != fmt.Sprint(tt.want) {
+				t.Fatalf("fetches = %+v (%d cmds), want %+v", fetcher.calls, len(cmds), tt.want)
+			}
+
+			// A second check while the fetch is in flight must not fetch again
+			if len(tt.want) > 0 {
+				if cmds := lb.CheckPrefetchNeeds(tt.viewportY, 20, 10000); len(cmds) != 0 {
+					t.Errorf("duplicate prefetch while in progress: %+v", fetcher.calls)
+				}
+			}
+		})
+	}
+}
+
+func TestLogBufferCheckPrefetchNeedsThrottle(t *testing.T) {
+	// A target size of 0 leaves prefetching to the viewport position
+	lb, fetcher := newTestLogBuffer(0)
+	now := time.Unix(1000, 0)
+	lb.now = func() time.Time { return now }
+	lb.before = "before"
+
+	if cmds := lb.CheckPrefetchNeeds(10, 20, 10000); len(cmds) != 1 {
+		t.Fatalf("expected the first prefetch to start, got %d commands", len(cmds))
+	}
+
+	// The prefetch completes and the viewport is still near the top right away
+	lb.PrependOlder(nil, "older", true)
+	fetcher.calls = nil
+	now = now.Add(minPrefetchInterval / 2)
+	cmds := lb.CheckPrefetchNeeds(10, 20, 10000)
+	if len(fetcher.calls) != 0 || lb.prefetching {
+		t.Fatalf("expected the prefetch to be held back, got %+v", fetcher.calls)
+	}
+	if len(cmds) != 1 || !lb.retryScheduled {
+		t.Fatalf("expected a single retry to be scheduled, got %d commands", len(cmds))
+	}
+
+	// Further scrolling doesn't schedule more retries
+	if cmds := lb.CheckPrefetchNeeds(5, 20, 10000); len(cmds) != 0 {
+		t.Errorf("expected no commands while a retry is scheduled, got %d", len(cmds))
+	}
+
+	// The retry fetches once the interval has passed
+	now = now.Add(minPrefetchInterval / 2)
+	cmds = lb.RetryPrefetch(10, 20, 10000)
+	want := []fetchCall{{position: "older", reverse: true, isPrefetch: true}}
+	if len(cmds) != 1 || fmt.Sprint(fetcher.calls) != fmt.Sprint(want) {
+		t.Errorf("fetches = %+v (%d cmds), want %+v", fetcher.calls, len(cmds), want)
+	}
+	if lb.retryScheduled {
+		t.Error("expected the retry to be cleared")
+	}
+
+	// Cleaning up drops a pending retry along with the entries
+	lb.retryScheduled = true
+	lb.Cleanup()
+	if lb.retryScheduled {
+		t.Error("expected Cleanup to clear the retry")
+	}
+}
+
+func TestLogBufferPrependedLines(t *testing.T) {
+	tests := []struct {
+		name          string
+		hadMoreBefore bool
+		hasMore       bool
+		want          int
+	}{
+		{"more logs before", true, true, 3},
+		{"start of logs reached adds the marker", true, false, 5},
+		{"marker already shown", false, false, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lb, _ := newTestLogBuffer(100)
+			lb.entries = makeLogEntries("new", 2)
+			lb.hasMoreBefore = tt.hadMoreBefore
+			lb.prefetching = true
+
+			older := makeLogEntries("old", 2)
+			older[0].message = "multi\nline"
+			lb.PrependOlder(older, "old-0", tt.hasMore)
+
+			if got := lb.PrependedLines(len(older), tt.hadMoreBefore, false); got != tt.want {
+				t.Errorf("PrependedLines() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogBufferLimitsWithSizes(t *testing.T) {
+	tests := []struct {
+		name       string
+		pageSize   int
+		bufferSize int
+		wantErr    string
+	}{
+		{"defaults", logPageSize, maxBufferEntries, ""},
+		{"small box", minLogPageSize, minLogBufferSize, ""},
+		{"page too small", minLogPageSize - 1, maxBufferEntries, "invalid --page-size"},
+		{"buffer too small", logPageSize, minLogBufferSize - 1, "invalid --buffer-size"},
+		{"buffer smaller than a page", 2000, 1000, "must be at least the --page-size of 2000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits, err := journalLimits.withSizes(tt.pageSize, tt.bufferSize)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if limits.pageSize != tt.pageSize || limits.maxEntries != tt.bufferSize || limits.viewportsAhead != journalLimits.viewportsAhead {
+				t.Errorf("unexpected limits: %+v", limits)
+			}
+		})
+	}
+}
+
+func TestLogBufferLimitsTargetSize(t *testing.T) {
+	if got := journalLimits.targetSize(prefetchPagesAhead); got != prefetchPagesAhead*logPageSize {
+		t.Errorf("targetSize() = %d, want %d", got, prefetchPagesAhead*logPageSize)
+	}
+
+	// A small buffer caps the prefetch target so it isn't trimmed right away
+	limits, err := journalLimits.withSizes(500, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := limits.targetSize(prefetchPagesAhead); got != 1000 {
+		t.Errorf("targetSize() = %d, want 1000", got)
+	}
+}
+
+func TestLogBufferEntryAtLine(t *testing.T) {
+	lb, _ := newTestLogBuffer(0)
+	lb.entries = makeLogEntries("log", 4)
+	lb.entries[1].priority = 7
+	lb.visible = func(entry logEntry) bool { return entry.priority != 7 }
+
+	tests := []struct {
+		name          string
+		hasMoreBefore bool
+		line          int
+		want          string
+	}{
+		{"first line", true, 0, "log-0"},
+		{"hidden entries are skipped", true, 1, "log-2"},
+		{"start of logs marker", false, 0, "log-0"},
+		{"after the start marker", false, 3, "log-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lb.hasMoreBefore = tt.hasMoreBefore
+			entry, ok := lb.EntryAtLine(tt.line, true)
+			if !ok || entry.cursor != tt.want {
+				t.Errorf("EntryAtLine(%d) = %q (ok=%v), want %q", tt.line, entry.cursor, ok, tt.want)
+			}
+		})
+	}
+
+	lb.hasMoreBefore = true
+	if _, ok := lb.EntryAtLine(10, true); ok {
+		t.Error("expected no entry past the end of the logs")
+	}
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/executor"
+	"github.com/saltyorg/sb-go/internal/utils"
+
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// logStatusDuration is how long copy and save confirmations stay next to the help line
+const logStatusDuration = 3 * time.Second
+
+// logSavedMsg reports the outcome of saving the loaded logs to a file
+type logSavedMsg struct {
+	path      string
+	err       error
+	ownership error // Why the file couldn't be given to the user who invoked sb, if it couldn't
+}
+
+// chownToInvokingUser gives files sb creates to the user who invoked it, replaced in tests
+var chownToInvokingUser = utils.ChownToInvokingUser
+
+// clearLogStatusMsg clears the status with the given id, unless a newer status replaced it
+type clearLogStatusMsg struct {
+	id int
+}
+
+// clearLogStatusAfter returns a command clearing status id after logStatusDuration
+func clearLogStatusAfter(id int) tea.Cmd {
+	return tea.Tick(logStatusDuration, func(time.Time) tea.Msg {
+		return clearLogStatusMsg{id: id}
+	})
+}
+
+// visibleViewportText returns the lines currently shown in the viewport without styling
+func visibleViewportText(vp viewport.Model) string {
+	lines := strings.Split(vp.GetContent(), "\n")
+	start := min(max(vp.YOffset(), 0), len(lines))
+	end := min(start+vp.VisibleLineCount(), len(lines))
+	return ansi.Strip(strings.Join(lines[start:end], "\n"))
+}
+
+// copyLogText copies text to the clipboard. OSC 52 is always used so copying works over SSH;
+// local graphical sessions also use wl-copy or xclip when available, since not every terminal supports OSC 52.
+func copyLogText(text string) tea.Cmd {
+	cmds := []tea.Cmd{tea.SetClipboard(text)}
+	if command := localClipboardCommand(os.Getenv, exec.LookPath); command != nil {
+		cmds = append(cmds, func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			// Best effort, OSC 52 has already been sent. Output is discarded rather than captured
+			// because xclip stays in the background to serve the selection.
+			_, _ = executor.Run(ctx, command[0],
+				executor.WithArgs(command[1:]...),
+				executor.WithStdin(strings.NewReader(text)),
+				executor.WithOutputMode(executor.OutputModeDiscard))
+			return nil
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// localClipboardCommand returns the command copying stdin to the clipboard of the local graphical session,
+// or nil over SSH or when no clipboard tool is installed
+func localClipboardCommand(getenv func(string) string, lookPath func(string) (string, error)) []string {
+	if getenv("SSH_CONNECTION") != "" || getenv("SSH_TTY") != "" {
+		return nil
+	}
+	if getenv("WAYLAND_DISPLAY") != "" {
+		if path, err := lookPath("wl-copy"); err == nil {
+			return []string{path}
+		}
+	}
+	if getenv("DISPLAY") != "" {
+		if path, err := lookPath("xclip"); err == nil {
+			return []string{path, "-selection", "clipboard"}
+		}
+	}
+	return nil
+}
+
+// unsafeFileNameChars matches characters replaced when using a service or container name in a file name
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// saveLogText returns a command writing text to a new file in the temporary directory,
+// which works on terminals without clipboard support
+func saveLogText(name, text string) tea.Cmd {
+	return func() tea.Msg {
+		pattern := fmt.Sprintf("sb-logs-%s-*.log", unsafeFileNameChars.ReplaceAllString(name, "_"))
+		file, err := os.CreateTemp("", pattern)
+		if err != nil {
+			return logSavedMsg{err: err}
+		}
+		if _, err := file.WriteString(text + "\n"); err != nil {
+			_ = file.Close()
+			return logSavedMsg{err: err}
+		}
+		if err := file.Close(); err != nil {
+			return logSavedMsg{err: err}
+		}
+		return logSavedMsg{path: file.Name(), ownership: chownToInvokingUser(file.Name())}
+	}
+}
+
+// copiedLinesStatus describes a clipboard copy of text. Terminals don't confirm OSC 52 writes,
+// so it only claims the text was sent and points at saving for terminals that ignore it.
+func copiedLinesStatus(text string) string {
+	lines := 0
+	if text != "" {
+		lines = strings.Count(text, "\n") + 1
+	}
+	if lines == 1 {
+		return "Sent 1 line to the terminal clipboard (press w to save instead)"
+	}
+	return fmt.Sprintf("Sent %d lines to the terminal clipboard (press w to save instead)", lines)
+}
+
+// savedLogsStatus describes the outcome of saving logs to a file
+func savedLogsStatus(msg logSavedMsg) string {
+	if msg.err != nil {
+		return "Failed to save logs: " + msg.err.Error()
+	}
+	if msg.ownership != nil {
+		return fmt.Sprintf("Saved logs to %s (%v)", msg.path, msg.ownership)
+	}
+	return "Saved logs to " + msg.path
+}
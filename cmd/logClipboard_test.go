@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/saltyorg/sb-go/internal/utils"
+
+	"charm.land/bubbles/v2/viewport"
+)
+
+func TestLocalClipboardCommand(t *testing.T) {
+	installed := func(tools ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			if slices.Contains(tools, name) {
+				return "/usr/bin/" + name, nil
+			}
+			return "", errors.New("not found")
+		}
+	}
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+
+	tests := []struct {
+		name  string
+		env   map[string]string
+		tools []string
+		want  []string
+	}{
+		{"wayland", map[string]string{"WAYLAND_DISPLAY": "wayland-0", "DISPLAY": ":0"}, []string{"wl-copy", "xclip"}, []string{"/usr/bin/wl-copy"}},
+		{"x11", map[string]string{"DISPLAY": ":0"}, []string{"wl-copy", "xclip"}, []string{"/usr/bin/xclip", "-selection", "clipboard"}},
+		{"wayland without wl-copy", map[string]string{"WAYLAND_DISPLAY": "wayland-0", "DISPLAY": ":0"}, []string{"xclip"}, []string{"/usr/bin/xclip", "-selection", "clipboard"}},
+		{"ssh", map[string]string{"DISPLAY": ":0", "SSH_CONNECTION": "10.0.0.2 50000 10.0.0.1 22"}, []string{"xclip"}, nil},
+		{"headless", map[string]string{}, []string{"xclip"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := localClipboardCommand(env(tt.env), installed(tt.tools...)); !slices.Equal(got, tt.want) {
+				t.Errorf("localClipboardCommand = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVisibleViewportText(t *testing.T) {
+	vp := viewport.New(viewport.WithWidth(80), viewport.WithHeight(2))
+	vp.SetContent("\x1b[2mfirst\x1b[0m\nsecond\nthird\nfourth")
+	vp.SetYOffset(1)
+
+	if got, want := visibleViewportText(vp), "second\nthird"; got != want {
+		t.Errorf("visibleViewportText = %q, want %q", got, want)
+	}
+}
+
+func TestCopiedLinesStatus(t *testing.T) {
+	tests := map[string]string{
+		"":       "Sent 0 lines to the terminal clipboard (press w to save instead)",
+		"a":      "Sent 1 line to the terminal clipboard (press w to save instead)",
+		"a\nb":   "Sent 2 lines to the terminal clipboard (press w to save instead)",
+		"a\n\nc": "Sent 3 lines to the terminal clipboard (press w to save instead)",
+	}
+	for text, want := range tests {
+		if got := copiedLinesStatus(text); got != want {
+			t.Errorf("copiedLinesStatus(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestSaveLogText(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	var chowned []string
+	chownToInvokingUser = func(path string) error {
+		chowned = append(chowned, path)
+		return nil
+	}
+	t.Cleanup(func() { chownToInvokingUser = utils.ChownToInvokingUser })
+
+	msg := saveLogText("saltbox/managed docker", "line 1\nline 2")().(logSavedMsg)
+	if msg.err != nil {
+		t.Fatalf("saveLogText unexpected error: %v", msg.err)
+	}
+	if !strings.Contains(msg.path, "sb-logs-saltbox_managed_docker-") {
+		t.Errorf("path %q doesn't contain the sanitized name", msg.path)
+	}
+
+	data, err := os.ReadFile(msg.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), "line 1\nline 2\n"; got != want {
+		t.Errorf("saved content = %q, want %q", got, want)
+	}
+	if len(chowned) != 1 || chowned[0] != msg.path {
+		t.Errorf("chowned %q, want the saved file", chowned)
+	}
+}
+
+func TestSavedLogsStatusOwnership(t *testing.T) {
+	msg := logSavedMsg{path: "/tmp/sb-logs.log", ownership: utils.ErrNoInvokingUser}
+	want := "Saved logs to /tmp/sb-logs.log (" + utils.ErrNoInvokingUser.Error() + ")"
+	if got := savedLogsStatus(msg); got != want {
+		t.Errorf("savedLogsStatus() = %q, want %q", got, want)
+	}
+}
+
+func TestLogBufferPlainText(t *testing.T) {
+	lb, _ := newTestLogBuffer(0)
+	lb.AppendInitial(makeLogEntries("entry", 2), "entry-0", "entry-1")
+
+	if got, want := lb.PlainText(false), "entry 0\nentry 1"; got != want {
+		t.Errorf("PlainText = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// logJSONMode selects how log messages holding a JSON object are shown
+type logJSONMode int
+
+const (
+	logJSONRaw    logJSONMode = iota // The message as logged
+	logJSONFields                    // Only the chosen fields of the object
+	logJSONPretty                    // The object indented over several lines
+)
+
+// logJSONModeNames are the names of the JSON modes, in the order the toggle cycles through them
+var logJSONModeNames = []string{"raw", "fields", "pretty"}
+
+func (m logJSONMode) String() string {
+	if m < 0 || int(m) >= len(logJSONModeNames) {
+		return logJSONModeNames[logJSONRaw]
+	}
+	return logJSONModeNames[m]
+}
+
+// next returns the mode following m in the toggle cycle
+func (m logJSONMode) next() logJSONMode {
+	return (m + 1) % logJSONMode(len(logJSONModeNames))
+}
+
+// Keys holding the level and message of common JSON loggers, tried in order
+var (
+	logJSONLevelKeys   = []string{"level", "lvl", "severity", "log.level"}
+	logJSONMessageKeys = []string{"msg", "message", "MESSAGE", "error"}
+)
+
+// logJSONFormat controls how the log viewers show JSON log messages
+type logJSONFormat struct {
+	mode   logJSONMode
+	fields []string // Fields shown in fields mode, the level and message if empty
+}
+
+// newLogJSONFormat returns the format for a --json-field value, a comma separated list of
+// fields. Naming fields starts the viewer in fields mode.
+func newLogJSONFormat(fields string) logJSONFormat {
+	var format logJSONFormat
+	for field := range strings.SplitSeq(fields, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			format.fields = append(format.fields, field)
+		}
+	}
+	if len(format.fields) > 0 {
+		format.mode = logJSONFields
+	}
+	return format
+}
+
+// describe summarizes the format for the status line, e.g. "fields (time, msg)"
+func (f logJSONFormat) describe() string {
+	if f.mode == logJSONFields && len(f.fields) > 0 {
+		return fmt.Sprintf("%s (%s)", f.mode, strings.Join(f.fields, ", "))
+	}
+	return f.mode.String()
+}
+
+// apply formats message for display. Messages that aren't a JSON object, or lack the
+// fields to show, are returned unchanged.
+func (f logJSONFormat) apply(message string) string {
+	if f.mode == logJSONRaw {
+		return message
+	}
+	trimmed := strings.TrimSpace(message)
+	if !strings.HasPrefix(trimmed, "{") || !json.Valid([]byte(trimmed)) {
+		return message
+	}
+
+	if f.mode == logJSONPretty {
+		// Indent the original text so the keys keep the order they were logged in
+		var b bytes.Buffer
+		if err := json.Indent(&b, []byte(trimmed), "", "  "); err != nil {
+			return message
+		}
+		return b.String()
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(trimmed))
+	decoder.UseNumber()
+	var object map[string]any
+	if err := decoder.Decode(&object); err != nil {
+		return message
+	}
+
+	var values []string
+	if len(f.fields) > 0 {
+		for _, field := range f.fields {
+			if value, ok := object[field]; ok {
+				values = append(values, logJSONValue(value))
+			}
+		}
+	} else if text, ok := firstLogJSONValue(object, logJSONMessageKeys); ok {
+		if level, ok := firstLogJSONValue(object, logJSONLevelKeys); ok {
+			values = append(values, strings.ToUpper(level))
+		}
+		values = append(values, text)
+	}
+
+	if len(values) == 0 {
+		return message
+	}
+	return strings.Join(values, " ")
+}
+
+// firstLogJSONValue returns the value of the first of keys present in object
+func firstLogJSONValue(object map[string]any, keys []string) (string, bool) {
+	for _, key := range keys {
+		if value, ok := object[key]; ok {
+			return logJSONValue(value), true
+		}
+	}
+	return "", false
+}
+
+// logJSONValue formats a decoded JSON value, strings without their quotes
+func logJSONValue(value any) string {
+	if text, ok := value.(string); ok {
+		return text
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// indentLogLines prefixes the first line of message and indents the following lines to line up
+// with it, for messages spread over several lines such as pretty-printed JSON
+func indentLogLines(prefix, message string) string {
+	if !strings.Contains(message, "\n") {
+		return prefix + message
+	}
+	indent := strings.Repeat(" ", ansi.StringWidth(prefix))
+	return prefix + strings.ReplaceAll(message, "\n", "\n"+indent)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import "testing"
+
+func TestLogJSONFormatApply(t *testing.T) {
+	const line = `{"time":"2024-01-01T00:00:00Z","level":"info","msg":"server started","port":8080}`
+
+	tests := []struct {
+		name    string
+		format  logJSONFormat
+		message string
+		want    string
+	}{
+		{"raw", logJSONFormat{}, line, line},
+		{"level and message", logJSONFormat{mode: logJSONFields}, line, "INFO server started"},
+		{"chosen fields", newLogJSONFormat("time, msg,port"), line, "2024-01-01T00:00:00Z server started 8080"},
+		{"message without level", logJSONFormat{mode: logJSONFields}, `{"message":"done"}`, "done"},
+		{"chosen field absent", newLogJSONFormat("caller"), line, line},
+		{"no message key", logJSONFormat{mode: logJSONFields}, `{"a":1}`, `{"a":1}`},
+		{"not JSON", logJSONFormat{mode: logJSONFields}, "plain text {", "plain text {"},
+		{"JSON array", logJSONFormat{mode: logJSONPretty}, `[1,2]`, `[1,2]`},
+		{"invalid JSON", logJSONFormat{mode: logJSONPretty}, `{"msg":`, `{"msg":`},
+		{"pretty keeps key order", logJSONFormat{mode: logJSONPretty}, `{"b":1,"a":{"c":true}}`, "{\n  \"b\": 1,\n  \"a\": {\n    \"c\": true\n  }\n}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.format.apply(tt.message); got != tt.want {
+				t.Errorf("apply(%q) = %q, want %q", tt.message, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewLogJSONFormat(t *testing.T) {
+	if format := newLogJSONFormat(""); format.mode != logJSONRaw || len(format.fields) != 0 {
+		t.Errorf("expected raw mode without fields, got %+v", format)
+	}
+	format := newLogJSONFormat("level,,msg ")
+	if format.mode != logJSONFields || len(format.fields) != 2 {
+		t.Errorf("expected fields mode with 2 fields, got %+v", format)
+	}
+	if got, want := format.describe(), "fields (level, msg)"; got != want {
+		t.Errorf("describe() = %q, want %q", got, want)
+	}
+	if got := format.mode.next().next(); got != logJSONRaw {
+		t.Errorf("expected the toggle to cycle back to raw, got %s", got)
+	}
+}
+
+func TestFormatLogEntriesIndentPrettyJSON(t *testing.T) {
+	pretty := logJSONFormat{mode: logJSONPretty}
+
+	docker := formatDockerLogEntry(dockerLogEntry{timestamp: "ts", stream: "stdout", message: `{"a":1}`}, dockerLogColumns{mode: logColumnsStream, compactStream: true}, pretty, true)
+	if want := "O │ {\n      \"a\": 1\n    }"; docker != want {
+		t.Errorf("docker entry = %q, want %q", docker, want)
+	}
+
+	entry := logEntry{unit: "app", message: pretty.apply(`{"a":1}`)}
+	if got, want := formatLogEntry(entry, false), "app: {\n       \"a\": 1\n     }"; got != want {
+		t.Errorf("journal entry = %q, want %q", got, want)
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/saltyorg/sb-go/internal/config"
 	"github.com/saltyorg/sb-go/internal/executor"
+	"github.com/saltyorg/sb-go/internal/motd"
 	"github.com/saltyorg/sb-go/internal/signals"
 	"github.com/saltyorg/sb-go/internal/styles"
 	"github.com/saltyorg/sb-go/internal/systemd"
@@ -25,23 +28,178 @@ import (
 
 // logsCmd represents the logs command
 var logsCmd = &cobra.Command{
-	Use:   "logs",
+	Use:   "logs [service]",
 	Short: "Display logs of managed systemd services",
-	Long:  `Displays a list of managed systemd services.`,
-	Args:  cobra.NoArgs,
+	Long: `Displays a list of managed systemd services, and the kernel messages of the
+current boot where OOM kills, disk errors and hardware issues show up.
+--kernel opens the kernel messages directly, and works with --dump too.
+
+"all services" merges the logs of every listed service into one time-ordered
+stream with colored unit names, to follow interactions between services during
+a deploy. --all-services opens it directly, and works with --dump too.
+
+With --dump, the most recent logs of the given service are printed to stdout
+without the interactive UI, optionally filtered by a --grep regex:
+
+  sb logs saltbox_managed_docker --dump --grep "error" -n 50
+
+Add --output json to print each entry as a JSON object per line instead, with
+its timestamp, hostname, unit, message and cursor, e.g. to pipe it into jq:
+
+  sb logs saltbox_managed_docker --dump --output json | jq -r .message
+
+In the interactive UI, follow mode polls for new logs every --follow-interval.
+With --alert, follow mode rings the terminal bell when a new log line matches
+the given regex, and --notify also sends a desktop notification (OSC 9):
+
+  sb logs --alert "Finished|failed" --notify
+
+--priority only fetches entries at or above a syslog severity, like journalctl -p.
+In the interactive UI, 'p' cycles the shown entries through warning and err:
+
+  sb logs --priority warning
+
+JSON log messages are shown as logged. 'J' cycles to the level and message of
+each JSON line, or the fields named by --json-field, and then to the whole
+object pretty-printed. Lines that aren't JSON are always shown as logged:
+
+  sb logs --json-field time,level,msg
+
+'C' copies the journal cursor of the entry at the top of the view. Pass it to
+--cursor with the same service to open its logs at that entry again later:
+
+  sb logs saltbox_managed_docker --cursor "s=...;i=...;b=..."`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeServiceNames,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return handleLogs(cmd.Context())
+		dump, _ := cmd.Flags().GetBool("dump")
+		grep, _ := cmd.Flags().GetString("grep")
+		lines, _ := cmd.Flags().GetInt("lines")
+		followInterval, _ := cmd.Flags().GetDuration("follow-interval")
+		if !cmd.Flags().Changed("follow-interval") {
+			followInterval = config.SB().FollowInterval
+		}
+		kernel, _ := cmd.Flags().GetBool("kernel")
+		allServices, _ := cmd.Flags().GetBool("all-services")
+		alertPattern, _ := cmd.Flags().GetString("alert")
+		notify, _ := cmd.Flags().GetBool("notify")
+		priorityValue, _ := cmd.Flags().GetString("priority")
+		jsonField, _ := cmd.Flags().GetString("json-field")
+		jsonFormat := newLogJSONFormat(jsonField)
+		outputValue, _ := cmd.Flags().GetString("output")
+		output, err := parseLogOutputFormat(outputValue)
+		if err != nil {
+			return err
+		}
+		limits, err := logBufferLimitsFromFlags(cmd, journalLimits)
+		if err != nil {
+			return err
+		}
+		priority, err := parseJournalPriority(priorityValue)
+		if err != nil {
+			return err
+		}
+
+		// --kernel and --all-services open those logs directly instead of the service list
+		var initial string
+		switch {
+		case kernel && allServices:
+			return fmt.Errorf("--kernel and --all-services can't be used together")
+		case kernel:
+			initial = kernelLogsName
+		case allServices:
+			initial = allServicesLogsName
+		}
+		if initial != "" && len(args) > 0 {
+			return fmt.Errorf("a service can't be used with --kernel or --all-services")
+		}
+
+		cursor, _ := cmd.Flags().GetString("cursor")
+		if !dump {
+			if cmd.Flags().Changed("grep") || cmd.Flags().Changed("lines") || cmd.Flags().Changed("output") {
+				return fmt.Errorf("--grep, --lines and --output can only be used with --dump")
+			}
+			if cursor != "" {
+				if len(args) > 0 {
+					initial = args[0]
+				}
+				if initial == "" {
+					return fmt.Errorf("--cursor requires a service name, --kernel or --all-services")
+				}
+			} else if len(args) > 0 {
+				return fmt.Errorf("a service can only be used with --dump or --cursor")
+			}
+			if err := validateFollowInterval(followInterval); err != nil {
+				return err
+			}
+			alert, err := newLogAlert(alertPattern, notify)
+			if err != nil {
+				return err
+			}
+			return handleLogs(cmd.Context(), followInterval, alert, priority, initial, cursor, jsonFormat, limits)
+		}
+
+		if cursor != "" {
+			return fmt.Errorf("--cursor can't be used with --dump")
+		}
+
+		if len(args) > 0 {
+			initial = args[0]
+		}
+		if initial == "" {
+			return fmt.Errorf("--dump requires a service name, --kernel or --all-services")
+		}
+		if cmd.Flags().Changed("follow-interval") || alertPattern != "" || notify {
+			return fmt.Errorf("--follow-interval, --alert and --notify can't be used with --dump")
+		}
+		source := journalSource{service: initial, priority: priority, pageSize: limits.pageSize, json: jsonFormat}
+		if allServices {
+			services, err := listLogServices(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if len(services) == 0 {
+				return fmt.Errorf("no matching systemd services found")
+			}
+			for _, service := range services {
+				source.units = append(source.units, service.Name)
+			}
+		}
+		return handleLogsDump(source, grep, lines, output)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(logsCmd)
+	logsCmd.Flags().Bool("all-services", false, "Show the logs of all listed services merged into one time-ordered stream")
+	logsCmd.Flags().Bool("kernel", false, "Show the kernel messages of the current boot (journalctl -k), e.g. OOM kills and disk errors")
+	logsCmd.Flags().Bool("dump", false, "Print the logs of a service to stdout instead of opening the interactive UI")
+	logsCmd.Flags().String("grep", "", "Only print log lines whose message matches this regular expression (with --dump)")
+	logsCmd.Flags().IntP("lines", "n", 100, "Number of matching log lines to print, 0 for all (with --dump)")
+	logsCmd.Flags().String("output", string(logOutputText), "Output format: "+strings.Join(logOutputFormatNames, ", ")+" (with --dump)")
+	logsCmd.Flags().Duration("follow-interval", defaultFollowInterval, "How often to poll for new logs in follow mode (minimum "+minFollowInterval.String()+", default from follow_interval in the sb config)")
+	logsCmd.Flags().String("alert", "", "Ring the terminal bell when a new line matching this regex arrives in follow mode")
+	logsCmd.Flags().Bool("notify", false, "Also send a desktop notification for --alert matches")
+	logsCmd.Flags().StringP("priority", "p", "", "Only show entries at or above this priority: "+strings.Join(journalPriorityNames, ", ")+" or 0-7")
+	logsCmd.Flags().String("cursor", "", "Open the logs of the given service at this journal cursor, as copied with 'C' in the logs view")
+	addLogBufferFlags(logsCmd, journalLimits)
+	logsCmd.Flags().String("json-field", "", "Comma separated fields to show of JSON log messages, e.g. time,level,msg (default: level and message)")
+	_ = logsCmd.RegisterFlagCompletionFunc("priority", cobra.FixedCompletions(journalPriorityNames, cobra.ShellCompDirectiveNoFileComp))
 }
 
+// Entries of the service list that aren't a single service.
+// The spaces keep them from clashing with a unit name.
 const (
-	logPageSize        = 500   // Number of log entries per page
+	// kernelLogsName selects the kernel messages (journalctl -k)
+	kernelLogsName = "kernel messages"
+	// allServicesLogsName selects the merged logs of all listed services
+	allServicesLogsName = "all services"
+)
+
+const (
+	logPageSize        = 500   // Default number of log entries per page
 	prefetchPagesAhead = 10    // Number of pages to stay ahead when prefetching
-	maxBufferEntries   = 20000 // Maximum entries to keep in memory
+	maxBufferEntries   = 20000 // Default maximum entries to keep in memory
 	viewportsAhead     = 5     // Prefetch when within 5 viewports of edge
 	viewportsToKeep    = 10    // Keep 10 viewports on each side when trimming
 )
@@ -113,6 +271,11 @@ type keyMap struct {
 	PageDown key.Binding
 	Toggle   key.Binding
 	Follow   key.Binding
+	Copy     key.Binding
+	Save     key.Binding
+	Priority key.Binding
+	JSON     key.Binding
+	Cursor   key.Binding
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
@@ -133,12 +296,12 @@ func (k keyMap) ShortHelpForList() []key.Binding {
 
 // ShortHelpForLogs returns help bindings for logs view
 func (k keyMap) ShortHelpForLogs() []key.Binding {
-	return []key.Binding{k.Left, k.Right, k.Toggle, k.Follow, k.Back, k.Quit}
+	return []key.Binding{k.Left, k.Right, k.Toggle, k.Priority, k.JSON, k.Follow, k.Copy, k.Cursor, k.Save, k.Back, k.Quit}
 }
 
 // ShortHelpForFollow returns help bindings for follow mode
 func (k keyMap) ShortHelpForFollow() []key.Binding {
-	return []key.Binding{k.Left, k.Right, k.Toggle, k.Follow, k.Back, k.Quit}
+	return []key.Binding{k.Left, k.Right, k.Toggle, k.Priority, k.JSON, k.Follow, k.Copy, k.Cursor, k.Save, k.Back, k.Quit}
 }
 
 var keys = keyMap{
@@ -186,6 +349,26 @@ var keys = keyMap{
 		key.WithKeys("f"),
 		key.WithHelp("f", "toggle follow mode"),
 	),
+	Copy: key.NewBinding(
+		key.WithKeys("y", "Y"),
+		key.WithHelp("y/Y", "copy view/all"),
+	),
+	Save: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "save to file"),
+	),
+	Priority: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "cycle priority"),
+	),
+	JSON: key.NewBinding(
+		key.WithKeys("J"),
+		key.WithHelp("J", "cycle JSON"),
+	),
+	Cursor: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "copy cursor"),
+	),
 }
 
 type model struct {
@@ -203,15 +386,75 @@ type model struct {
 	viewportInitialized bool
 	loading             bool
 	err                 error
-	viewportYPosition   int  // Store viewport scroll position
-	showTimestampHost   bool // Toggle for showing timestamp and hostname columns
-	followMode          bool // Follow mode enabled
+	viewportYPosition   int             // Store viewport scroll position
+	showTimestampHost   bool            // Toggle for showing timestamp and hostname columns
+	followMode          bool            // Follow mode enabled
+	followInterval      time.Duration   // Poll interval for follow mode
+	alert               *logAlert       // Alerts on matching lines in follow mode, if set
+	allUnits            []string        // Services merged by the "all services" entry
+	priority            int             // Priority threshold passed to journalctl, from --priority
+	displayPriority     int             // Priority threshold of the shown entries, cycled with 'p'
+	json                logJSONFormat   // How JSON log messages are shown, cycled with 'J'
+	limits              logBufferLimits // Paging and memory limits, from --page-size and --buffer-size
+	startCursor         string          // Journal cursor the first logs shown start at, from --cursor
+	status              string          // Transient copy/save confirmation shown next to the help
+	statusID            int             // Identifies the current status so only its own timer clears it
 }
 
 func (m model) Init() tea.Cmd {
+	// Logs selected up front, like the kernel logs with --kernel, are fetched right away
+	if m.loading && m.logBuf != nil {
+		return tea.Batch(m.spinner.Tick, m.fetchInitialLogs())
+	}
 	return m.spinner.Tick
 }
 
+// initViewport creates the fullscreen viewport of the logs view, showing any logs already loaded
+func (m *model) initViewport() {
+	helpHeight := lipgloss.Height(m.help.View(m.keys))
+	// Use full terminal width and height for fullscreen viewport
+	m.viewport = viewport.New(viewport.WithWidth(m.width), viewport.WithHeight(m.height-helpHeight))
+	m.viewport.Style = lipgloss.NewStyle().Padding(1, 2)
+	m.viewportInitialized = true
+	if m.logBuf != nil && len(m.logBuf.entries) > 0 {
+		m.viewport.SetContent(m.logBuf.GetContent(m.followMode))
+		m.viewport.GotoBottom()
+		m.viewportYPosition = m.viewport.YOffset()
+	}
+}
+
+// selectService switches the logs view to service and returns the command fetching its most recent logs
+func (m *model) selectService(service string) tea.Cmd {
+	// Clean up old buffer before switching
+	if m.logBuf != nil {
+		m.logBuf.Cleanup()
+	}
+
+	m.selectedService = service
+	m.loading = true
+	m.err = nil
+	m.viewportYPosition = 0
+	m.followMode = false
+	// Create new log buffer with target size of 10 pages
+	source := journalSource{service: m.selectedService, priority: m.priority, pageSize: m.limits.pageSize, json: m.json}
+	if service == allServicesLogsName {
+		source.units = m.allUnits
+	}
+	m.logBuf = newLogViewBuffer(source, m.limits, m.limits.targetSize(prefetchPagesAhead))
+	m.logBuf.alert = m.alert
+	m.logBuf.visible = priorityFilter(m.displayPriority)
+	return m.fetchInitialLogs()
+}
+
+// fetchInitialLogs returns the command fetching the first page of the selected logs: the most
+// recent entries, or the entries from --cursor on
+func (m model) fetchInitialLogs() tea.Cmd {
+	if source, ok := m.logBuf.source.(journalSource); ok && m.startCursor != "" {
+		return fetchLogsAtCursor(source, m.startCursor)
+	}
+	return m.logBuf.source.Fetch("", false, false)
+}
+
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
@@ -229,7 +472,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.list.SetHeight(msg.Height - helpHeight)
 		} else {
 			// Logs view - viewport uses full screen in alt screen mode
-			if m.viewportInitialized {
+			if !m.viewportInitialized {
+				// The logs view was opened before the terminal size was known, e.g. with --kernel
+				m.list.SetWidth(msg.Width)
+				m.list.SetHeight(msg.Height - helpHeight)
+				m.initViewport()
+			} else {
 				// Store current position before resize
 				m.viewportYPosition = m.viewport.YOffset()
 
@@ -266,28 +514,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 					// Initialize the viewport if necessary
 					if !m.viewportInitialized {
-						helpHeight := lipgloss.Height(m.help.View(m.keys))
-						// Use full terminal width and height for fullscreen viewport
-						m.viewport = viewport.New(viewport.WithWidth(m.width), viewport.WithHeight(m.height-helpHeight))
-						m.viewport.Style = lipgloss.NewStyle().Padding(1, 2)
-						m.viewportInitialized = true
+						m.initViewport()
 					}
 
 					// Only fetch new logs if a different service was selected
 					if newService != m.selectedService {
-						// Clean up old buffer before switching
-						if m.logBuf != nil {
-							m.logBuf.Cleanup()
-						}
-
-						m.selectedService = newService
-						m.loading = true
-						m.err = nil
-						m.viewportYPosition = 0
-						m.followMode = false
-						// Create new log buffer with target size of 10 pages
-						m.logBuf = newLogBuffer(m.selectedService, prefetchPagesAhead*logPageSize)
-						return m, fetchLogs(m.selectedService, false, "", false)
+						return m, m.selectService(newService)
 					} else {
 						// Make sure we re-apply the current log content with boundaries
 						if m.logBuf != nil {
@@ -313,10 +545,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Fetch more older logs if we're at the top of viewport and have more to load
 			if m.activeView == "logs" && !m.loading && m.logBuf != nil {
 				atTop := m.viewport.YOffset() <= 0
-				if atTop && m.logBuf.beforeCursor != "" && m.logBuf.hasMoreBefore {
+				if atTop && m.logBuf.before != "" && m.logBuf.hasMoreBefore {
 					m.loading = true
 					m.err = nil
-					return m, fetchLogs(m.selectedService, true, m.logBuf.beforeCursor, false)
+					return m, m.logBuf.source.Fetch(m.logBuf.before, true, false)
 				}
 				// Otherwise, let the viewport handle scrolling
 			}
@@ -329,10 +561,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Only fetch more logs if we're at the bottom of viewport and have more to load
 			if m.activeView == "logs" && !m.loading && m.logBuf != nil {
 				atBottom := m.viewport.YOffset() >= m.viewport.TotalLineCount()-m.viewport.Height()
-				if atBottom && m.logBuf.afterCursor != "" && m.logBuf.hasMoreAfter {
+				if atBottom && m.logBuf.after != "" && m.logBuf.hasMoreAfter {
 					m.loading = true
 					m.err = nil
-					return m, fetchLogs(m.selectedService, false, m.logBuf.afterCursor, false)
+					return m, m.logBuf.source.Fetch(m.logBuf.after, false, false)
 				}
 				// Otherwise, let the viewport handle scrolling
 			}
@@ -349,6 +581,36 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.viewport.ScrollRight(10)
 			}
 
+		case "y", "Y":
+			// Copy the visible lines, or all loaded lines with Y (allowed in follow mode)
+			if m.activeView == "logs" && m.viewportInitialized && m.logBuf != nil {
+				text := visibleViewportText(m.viewport)
+				if msg.String() == "Y" {
+					text = m.logBuf.PlainText(m.showTimestampHost)
+				}
+				m.statusID++
+				m.status = copiedLinesStatus(text)
+				return m, tea.Batch(copyLogText(text), clearLogStatusAfter(m.statusID))
+			}
+
+		case "C":
+			// Copy the journal cursor of the entry at the top of the view, to return to it with --cursor
+			if m.activeView == "logs" && m.viewportInitialized && m.logBuf != nil {
+				entry, ok := m.logBuf.EntryAtLine(m.viewport.YOffset(), m.showTimestampHost)
+				if !ok || entry.cursor == "" {
+					return m, nil
+				}
+				m.statusID++
+				m.status = "Sent the journal cursor of the entry at " + entry.timestamp + " to the terminal clipboard, for --cursor"
+				return m, tea.Batch(copyLogText(entry.cursor), clearLogStatusAfter(m.statusID))
+			}
+
+		case "w":
+			// Save all loaded lines to a file, for terminals without clipboard support
+			if m.activeView == "logs" && m.logBuf != nil {
+				return m, saveLogText(m.selectedService, m.logBuf.PlainText(m.showTimestampHost))
+			}
+
 		case "t":
 			// Toggle timestamp and hostname visibility (allowed in follow mode)
 			if m.activeView == "logs" && m.logBuf != nil {
@@ -362,6 +624,40 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
+		case "J":
+			// Cycle how JSON log messages are shown (allowed in follow mode)
+			if m.activeView == "logs" && m.logBuf != nil {
+				m.json.mode = m.json.mode.next()
+				source := m.logBuf.source.(journalSource)
+				source.json = m.json
+				m.logBuf.source = source
+				m.viewport.SetContent(m.logBuf.GetContentFormatted(m.showTimestampHost, m.followMode))
+				if m.followMode {
+					m.viewport.GotoBottom()
+				} else {
+					m.viewport.SetYOffset(min(m.viewport.YOffset(), max(0, m.viewport.TotalLineCount()-m.viewport.Height())))
+				}
+				m.viewportYPosition = m.viewport.YOffset()
+
+				m.statusID++
+				m.status = "JSON: " + m.json.describe()
+				return m, clearLogStatusAfter(m.statusID)
+			}
+
+		case "p":
+			// Cycle through stricter priority thresholds, filtering the loaded entries (allowed in follow mode)
+			if m.activeView == "logs" && !m.loading && m.logBuf != nil {
+				m.displayPriority = nextPriority(m.displayPriority, m.priority)
+				m.logBuf.visible = priorityFilter(m.displayPriority)
+				m.viewport.SetContent(m.logBuf.GetContentFormatted(m.showTimestampHost, m.followMode))
+				if m.followMode {
+					m.viewport.GotoBottom()
+				} else {
+					m.viewport.SetYOffset(min(m.viewport.YOffset(), max(0, m.viewport.TotalLineCount()-m.viewport.Height())))
+				}
+				m.viewportYPosition = m.viewport.YOffset()
+			}
+
 		case "f":
 			// Toggle follow mode
 			if m.activeView == "logs" && !m.loading && m.logBuf != nil {
@@ -371,7 +667,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.viewport.SetContent(m.logBuf.GetContentFormatted(m.showTimestampHost, m.followMode))
 					m.viewport.GotoBottom()
 					m.viewportYPosition = m.viewport.YOffset()
-					cmds = append(cmds, m.logBuf.StartFollow())
+					cmds = append(cmds, m.logBuf.StartFollow(m.followInterval))
 				} else {
 					// Disable follow mode - stop background fetcher
 					m.logBuf.StopFollow()
@@ -430,26 +726,18 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					savedYOffset := m.viewport.YOffset()
 
 					// Use firstCursor (oldest entry) to continue fetching even older logs
+					hadMoreBefore := m.logBuf.hasMoreBefore
 					prefetchCmd := m.logBuf.PrependOlder(msg.entries, msg.firstCursor, msg.hasMore)
 
-					// Calculate how many lines were added
-					entriesAdded := len(m.logBuf.entries) - oldLen
-					linesAdded := 0
-					for i := range entriesAdded {
-						linesAdded += len(strings.Split(formatLogEntry(m.logBuf.entries[i], m.showTimestampHost), "\n"))
-					}
-					// Add boundary markers if present (only if this update caused hasMoreBefore to become false)
-					if !m.logBuf.hasMoreBefore && msg.hasMore {
-						// Boundary marker was just added
-						linesAdded += 2 // "--- start of logs ---" + blank line
-					}
+					// Calculate how many lines were added, including a start of logs marker
+					linesAdded := m.logBuf.PrependedLines(len(m.logBuf.entries)-oldLen, hadMoreBefore, m.showTimestampHost)
 
 					// Update viewport content
 					m.viewport.SetContent(m.logBuf.GetContent(m.followMode))
 
 					// ALWAYS adjust viewport position when prepending to prevent scroll jumping
 					// This keeps the user's view stable regardless of prefetch or user action
-					m.viewport.SetYOffset(min(savedYOffset+linesAdded, m.viewport.TotalLineCount()-m.viewport.Height()))
+					m.viewport.SetYOffset(max(0, min(savedYOffset+linesAdded, m.viewport.TotalLineCount()-m.viewport.Height())))
 					m.viewportYPosition = m.viewport.YOffset()
 
 					// Check if logBuffer wants to prefetch more
@@ -461,9 +749,17 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						// Initial load
 						prefetchCmd := m.logBuf.AppendInitial(msg.entries, msg.firstCursor, msg.lastCursor)
 
-						// Update viewport and position at bottom
-						m.viewport.SetContent(m.logBuf.GetContent(m.followMode))
-						m.viewport.GotoBottom()
+						if m.startCursor != "" {
+							// Opened at --cursor: newer logs follow and the cursor's entry is shown at the top
+							m.startCursor = ""
+							m.logBuf.hasMoreAfter = msg.hasMore
+							m.viewport.SetContent(m.logBuf.GetContent(m.followMode))
+							m.viewport.GotoTop()
+						} else {
+							// Update viewport and position at bottom
+							m.viewport.SetContent(m.logBuf.GetContent(m.followMode))
+							m.viewport.GotoBottom()
+						}
 						m.viewportYPosition = m.viewport.YOffset()
 
 						// Start prefetching if logBuffer wants to
@@ -494,21 +790,47 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, tea.Batch(cmds...)
 
+	case logSavedMsg:
+		m.statusID++
+		m.status = savedLogsStatus(msg)
+		return m, clearLogStatusAfter(m.statusID)
+
+	case clearLogStatusMsg:
+		if msg.id == m.statusID {
+			m.status = ""
+		}
+		return m, nil
+
 	case spinner.TickMsg:
 		if m.loading {
 			m.spinner, cmd = m.spinner.Update(msg)
 			cmds = append(cmds, cmd)
 		}
 
+	case prefetchRetryMsg:
+		if m.logBuf != nil {
+			if m.activeView == "logs" && !m.loading {
+				cmds = append(cmds, m.logBuf.RetryPrefetch(
+					m.viewport.YOffset(),
+					m.viewport.Height(),
+					m.viewport.TotalLineCount(),
+				)...)
+			} else {
+				// The retry fired without a check, so the next scroll can schedule another
+				m.logBuf.retryScheduled = false
+			}
+		}
+		return m, tea.Batch(cmds...)
+
 	case followTickMsg:
 		// Background ticker for follow mode
 		if m.followMode && m.logBuf != nil && !m.loading {
 			// Fetch new logs from the current end cursor
-			cmds = append(cmds, fetchLogs(m.selectedService, false, m.logBuf.afterCursor, true))
+			cmds = append(cmds, m.logBuf.source.Fetch(m.logBuf.after, false, true))
 		}
 		// Continue ticking if still in follow mode
 		if m.followMode {
-			cmds = append(cmds, tickFollow())
+			cmds = append(cmds, tickFollow(m.followInterval))
 		}
 		return m, tea.Batch(cmds...)
 	}
@@ -566,6 +888,12 @@ func (m model) View() tea.View {
 	} else {
 		helpView = m.help.ShortHelpView(m.keys.ShortHelpForLogs())
 	}
+	if m.activeView == "logs" && m.displayPriority != allPriorities {
+		helpView += "  " + styles.WarningStyle.Render("priority: "+journalPriorityName(m.displayPriority)+" and above")
+	}
+	if m.activeView == "logs" && m.status != "" {
+		helpView += "  " + styles.InfoStyle.Render(m.status)
+	}
 
 	if m.activeView == "list" {
 		// Inline list view - render list with help at bottom
@@ -613,58 +941,25 @@ func (m model) View() tea.View {
 	return v
 }
 
-// formatLogEntriesWithBoundaries formats log entries with boundary indicators inline
-func formatLogEntriesWithBoundaries(entries []logEntry, hasMoreBefore, hasMoreAfter bool, showTimestampHost bool, followMode bool) string {
-	if len(entries) == 0 {
-		return "No log entries"
-	}
-
-	var lines []string
-
-	// Add start indicator at the beginning if we've hit the start boundary
-	if !hasMoreBefore {
-		lines = append(lines, styles.DimStyle.Render("--- start of logs ---"))
-		lines = append(lines, "")
-	}
-
-	// Add all log entries
-	for _, entry := range entries {
-		lines = append(lines, formatLogEntry(entry, showTimestampHost))
-	}
-
-	// Add end indicator at the end if we've hit the end boundary
-	if !hasMoreAfter {
-		lines = append(lines, "")
-		if followMode {
-			lines = append(lines, styles.InfoStyle.Render("--- watching for new logs (press 'f' to disable) ---"))
-		} else {
-			lines = append(lines, styles.DimStyle.Render("--- end of logs ---"))
-		}
-	}
-
-	return strings.Join(lines, "\n")
-}
-
-// formatLogEntry formats a single log entry for display
+// formatLogEntry formats a single log entry for display.
+// Messages spread over several lines are indented to line up after the prefix.
 func formatLogEntry(entry logEntry, showTimestampHost bool) string {
+	var prefix string
 	if showTimestampHost {
 		// Format: timestamp hostname unit: message
 		// Similar to journalctl short-iso format
 		if entry.hostname != "" && entry.unit != "" {
-			return fmt.Sprintf("%s %s %s: %s", entry.timestamp, entry.hostname, entry.unit, entry.message)
+			prefix = fmt.Sprintf("%s %s %s: ", entry.timestamp, entry.hostname, entry.unit)
 		} else if entry.unit != "" {
-			return fmt.Sprintf("%s %s: %s", entry.timestamp, entry.unit, entry.message)
+			prefix = fmt.Sprintf("%s %s: ", entry.timestamp, entry.unit)
 		} else {
-			return fmt.Sprintf("%s %s", entry.timestamp, entry.message)
+			prefix = entry.timestamp + " "
 		}
-	} else {
+	} else if entry.unit != "" {
 		// Simplified format: unit: message (no timestamp or hostname)
-		if entry.unit != "" {
-			return fmt.Sprintf("%s: %s", entry.unit, entry.message)
-		} else {
-			return entry.message
-		}
+		prefix = entry.unit + ": "
 	}
+	return indentLogLines(prefix, entry.message)
 }
 
 type logsMsg struct {
@@ -683,212 +978,86 @@ type logEntry struct {
 	unit      string
 	message   string
 	cursor    string
+	priority  int // Syslog severity from 0 (emerg) to 7 (debug), allPriorities if unknown
 }
 
-// logBuffer manages log entries and handles prefetching
-type logBuffer struct {
-	entries          []logEntry
-	beforeCursor     string
-	afterCursor      string
-	hasMoreBefore    bool
-	hasMoreAfter     bool
-	serviceName      string
-	prefetching      bool
-	prefetchingAfter bool
-	targetSize       int  // Target number of entries to keep loaded
-	followActive     bool // Whether follow mode background fetching is active
-}
+// logBuffer manages the log entries of a systemd service
+type logBuffer = logViewBuffer[logEntry]
 
-func newLogBuffer(serviceName string, targetSize int) *logBuffer {
-	return &logBuffer{
-		entries:       []logEntry{},
-		serviceName:   serviceName,
-		targetSize:    targetSize,
-		hasMoreBefore: true,
-		hasMoreAfter:  false,
-	}
+// journalLimits are the default paging and memory limits of the systemd log viewer
+var journalLimits = logBufferLimits{
+	pageSize:        logPageSize,
+	maxEntries:      maxBufferEntries,
+	viewportsAhead:  viewportsAhead,
+	viewportsToKeep: viewportsToKeep,
 }
 
-// GetContent returns formatted content for display with boundary markers (timestamp/host shown)
-func (lb *logBuffer) GetContent(followMode bool) string {
-	return formatLogEntriesWithBoundaries(lb.entries, lb.hasMoreBefore, lb.hasMoreAfter, true, followMode)
+// journalSource fetches the logs of a systemd service from journalctl, positioned by journal cursors
+type journalSource struct {
+	service  string
+	units    []string      // Services whose logs are merged instead of service's, if set
+	priority int           // Only fetch entries at or above this priority, allPriorities for all entries
+	pageSize int           // Number of entries fetched at a time
+	json     logJSONFormat // How JSON log messages are shown
 }
 
-// GetContentFormatted returns formatted content with optional timestamp/hostname visibility
-func (lb *logBuffer) GetContentFormatted(showTimestampHost bool, followMode bool) string {
-	return formatLogEntriesWithBoundaries(lb.entries, lb.hasMoreBefore, lb.hasMoreAfter, showTimestampHost, followMode)
+// Fetch returns a command fetching a page of the service's journal
+func (s journalSource) Fetch(cursor string, reverse bool, isPrefetch bool) tea.Cmd {
+	return fetchLogs(s, reverse, cursor, isPrefetch)
 }
 
-// ShouldPrefetch returns true if we need to fetch more older logs
-func (lb *logBuffer) ShouldPrefetch() bool {
-	return len(lb.entries) < lb.targetSize && lb.hasMoreBefore && lb.beforeCursor != "" && !lb.prefetching
+// Position returns the journal cursor of an entry
+func (s journalSource) Position(entry logEntry) string {
+	return entry.cursor
 }
 
-// StartPrefetch marks prefetch as in progress and returns the fetch command
-func (lb *logBuffer) StartPrefetch() tea.Cmd {
-	if !lb.ShouldPrefetch() {
-		return nil
+// Format formats an entry with or without the timestamp and hostname.
+// Merged logs color the unit names to tell the services apart.
+func (s journalSource) Format(entry logEntry, showTimestampHost bool) string {
+	if len(s.units) > 0 && entry.unit != "" {
+		entry.unit = unitStyle(entry.unit).Render(entry.unit)
 	}
-	lb.prefetching = true
-	return fetchLogs(lb.serviceName, true, lb.beforeCursor, true)
-}
-
-// AppendInitial sets initial logs (most recent)
-func (lb *logBuffer) AppendInitial(entries []logEntry, firstCursor, lastCursor string) tea.Cmd {
-	lb.entries = entries
-	lb.beforeCursor = firstCursor
-	lb.afterCursor = lastCursor
-	lb.hasMoreBefore = true
-	lb.hasMoreAfter = false
-	return lb.StartPrefetch()
-}
-
-// PrependOlder adds older logs to the beginning
-// oldestCursor should be the cursor of the oldest entry in the batch (to fetch even older logs)
-func (lb *logBuffer) PrependOlder(entries []logEntry, oldestCursor string, hasMore bool) tea.Cmd {
-	lb.entries = append(entries, lb.entries...)
-	lb.beforeCursor = oldestCursor
-	lb.hasMoreBefore = hasMore
-	lb.prefetching = false
-	return lb.StartPrefetch()
-}
-
-// AppendNewer adds newer logs to the end
-func (lb *logBuffer) AppendNewer(entries []logEntry, lastCursor string, hasMore bool) tea.Cmd {
-	lb.entries = append(lb.entries, entries...)
-	lb.afterCursor = lastCursor
-	lb.hasMoreAfter = hasMore
-	lb.prefetchingAfter = false
-	// For forward prefetching, we could continue prefetching newer logs
-	// but typically we want to stay near "now", so we don't auto-prefetch forward
-	return nil
+	entry.message = s.json.apply(entry.message)
+	return formatLogEntry(entry, showTimestampHost)
 }
 
-// Cleanup clears all log entries and resets state for memory cleanup
-func (lb *logBuffer) Cleanup() {
-	lb.entries = nil
-	lb.beforeCursor = ""
-	lb.afterCursor = ""
-	lb.hasMoreBefore = false
-	lb.hasMoreAfter = false
-	lb.prefetching = false
-	lb.prefetchingAfter = false
+// unitColors are the colors of the unit names in merged logs
+var unitColors = []string{
+	styles.ColorCyan, styles.ColorMagenta, styles.ColorYellow, styles.ColorBrightBlue,
+	styles.ColorMediumGreen, styles.ColorOrange, styles.ColorPurple, styles.ColorBrightRed,
 }
 
-// TrimBuffer removes entries far from viewport to limit memory usage
-// Returns the number of lines trimmed from the top (for viewport offset adjustment)
-func (lb *logBuffer) TrimBuffer(viewportY, viewportHeight int) int {
-	if len(lb.entries) <= maxBufferEntries {
-		return 0 // No need to trim
-	}
-
-	// Calculate viewport position in terms of log entries
-	// We need to estimate which entries are visible
-	totalLines := 0
-	visibleStartEntry := 0
-	visibleEndEntry := len(lb.entries) - 1
-
-	// Find which entries correspond to the viewport position
-	for i, entry := range lb.entries {
-		// Use true for timestamp/host since this is just for line counting
-		entryLines := len(strings.Split(formatLogEntry(entry, true), "\n"))
-		if totalLines+entryLines > viewportY {
-			visibleStartEntry = i
-			break
-		}
-		totalLines += entryLines
-	}
-
-	// Calculate how many entries to keep on each side
-	entriesToKeep := max(
-		// Rough estimate: ~3 lines per entry
-		viewportsToKeep*viewportHeight/3,
-		// Keep at least one page
-		logPageSize)
-
-	// Calculate trim boundaries
-	trimStart := max(0, visibleStartEntry-entriesToKeep)
-	trimEnd := min(len(lb.entries), visibleEndEntry+entriesToKeep)
-
-	// Don't trim if we're not actually removing much
-	if trimStart < 100 && trimEnd > len(lb.entries)-100 {
-		return 0 // Not worth trimming
-	}
-
-	// Calculate lines being removed from the top
-	linesTrimmed := 0
-	for i := range trimStart {
-		// Use true for timestamp/host since this is just for line counting
-		linesTrimmed += len(strings.Split(formatLogEntry(lb.entries[i], true), "\n"))
-	}
-
-	// Trim the entries
-	oldEntries := lb.entries
-	lb.entries = make([]logEntry, trimEnd-trimStart)
-	copy(lb.entries, oldEntries[trimStart:trimEnd])
-
-	// Update cursors if we trimmed from edges
-	if trimStart > 0 {
-		lb.beforeCursor = lb.entries[0].cursor
-		lb.hasMoreBefore = true // We know there are more entries we trimmed
-	}
-	if trimEnd < len(oldEntries) {
-		lb.afterCursor = lb.entries[len(lb.entries)-1].cursor
-		lb.hasMoreAfter = true // We know there are more entries we trimmed
-	}
-
-	return linesTrimmed
-}
-
-// CheckPrefetchNeeds checks if prefetching should be triggered based on viewport position
-// Returns commands for prefetching in both directions if needed
-func (lb *logBuffer) CheckPrefetchNeeds(viewportY, viewportHeight, totalHeight int) []tea.Cmd {
-	var cmds []tea.Cmd
-
-	// Calculate threshold for prefetching (viewportsAhead * viewport height)
-	prefetchThreshold := viewportsAhead * viewportHeight
-
-	// Check if we should prefetch older logs (scrolling near top)
-	if viewportY < prefetchThreshold && lb.hasMoreBefore && lb.beforeCursor != "" && !lb.prefetching {
-		lb.prefetching = true
-		cmds = append(cmds, fetchLogs(lb.serviceName, true, lb.beforeCursor, true))
-	}
-
-	// Check if we should prefetch newer logs (scrolling near bottom)
-	distanceFromBottom := totalHeight - (viewportY + viewportHeight)
-	if distanceFromBottom < prefetchThreshold && lb.hasMoreAfter && lb.afterCursor != "" && !lb.prefetchingAfter {
-		lb.prefetchingAfter = true
-		cmds = append(cmds, fetchLogs(lb.serviceName, false, lb.afterCursor, true))
-	}
-
-	return cmds
+// unitStyle returns the style of a unit name, the same for a unit every time
+func unitStyle(unit string) lipgloss.Style {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(unit))
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(unitColors[hash.Sum32()%uint32(len(unitColors))]))
 }
 
-// StartFollow starts follow mode background fetching
-func (lb *logBuffer) StartFollow() tea.Cmd {
-	lb.followActive = true
-	return tickFollow()
+// fetchLogs returns a command fetching a page of the journal of source, older than cursor when reverse is set
+func fetchLogs(source journalSource, reverse bool, cursor string, isPrefetch bool) tea.Cmd {
+	return fetchLogPage(source, reverse, cursor, isPrefetch, false)
 }
 
-// StopFollow stops follow mode background fetching
-func (lb *logBuffer) StopFollow() {
-	lb.followActive = false
+// fetchLogsAtCursor returns a command fetching a page of the journal of source starting with
+// the entry at cursor
+func fetchLogsAtCursor(source journalSource, cursor string) tea.Cmd {
+	return fetchLogPage(source, false, cursor, false, true)
 }
 
-func fetchLogs(service string, reverse bool, cursor string, isPrefetch bool) tea.Cmd {
+// fetchLogPage returns a command fetching a page of the journal of source from cursor.
+// The entry at cursor is left out unless includeCursor is set, as it's already loaded when paging.
+func fetchLogPage(source journalSource, reverse bool, cursor string, isPrefetch bool, includeCursor bool) tea.Cmd {
 	return func() tea.Msg {
 		// Build journalctl command with JSON output for proper parsing
-		// Add .service suffix to ensure exact unit match
-		serviceUnit := service
-		if !strings.HasSuffix(serviceUnit, ".service") {
-			serviceUnit = serviceUnit + ".service"
-		}
-
-		args := []string{
-			"journalctl",
-			"-u", serviceUnit,
+		args := append([]string{"journalctl"}, source.matchArgs()...)
+		args = append(args,
 			"-o", "json", // Use JSON for structured parsing
 			"--all", // Prevent truncation of large fields (e.g., big MESSAGE payloads)
+		)
+		if source.priority != allPriorities {
+			// Let journalctl drop lower priority entries instead of transferring them
+			args = append(args, "-p", strconv.Itoa(source.priority))
 		}
 
 		if cursor != "" {
@@ -897,18 +1066,18 @@ func fetchLogs(service string, reverse bool, cursor string, isPrefetch bool) tea
 			if reverse {
 				// Get entries before this cursor (older logs)
 				// --reverse makes it go backward from the cursor
-				args = append(args, "--reverse", "-n", fmt.Sprintf("%d", logPageSize))
+				args = append(args, "--reverse", "-n", strconv.Itoa(source.pageSize))
 			} else {
 				// Get entries after this cursor (newer logs)
 				// Forward from the cursor (default behavior)
-				args = append(args, "-n", fmt.Sprintf("%d", logPageSize))
+				args = append(args, "-n", strconv.Itoa(source.pageSize))
 			}
 		} else {
 			// No cursor - show most recent entries
 			if reverse {
-				args = append(args, "--reverse", "-n", fmt.Sprintf("%d", logPageSize))
+				args = append(args, "--reverse", "-n", strconv.Itoa(source.pageSize))
 			} else {
-				args = append(args, "-n", fmt.Sprintf("%d", logPageSize))
+				args = append(args, "-n", strconv.Itoa(source.pageSize))
 			}
 		}
 
@@ -936,7 +1105,7 @@ func fetchLogs(service string, reverse bool, cursor string, isPrefetch bool) tea
 
 		// When using --cursor, journalctl ALWAYS includes the cursor entry as the first result
 		// We need to skip it in both forward and reverse modes to avoid duplicates
-		if cursor != "" && len(entries) > 0 {
+		if cursor != "" && !includeCursor && len(entries) > 0 {
 			if entries[0].cursor == cursor {
 				entries = entries[1:]
 			}
@@ -956,9 +1125,9 @@ func fetchLogs(service string, reverse bool, cursor string, isPrefetch bool) tea
 		}
 
 		// Determine if there are more entries available
-		// After cursor skip, we get logPageSize-1 entries if more exist
+		// After cursor skip, we get pageSize-1 entries if more exist
 		// If we got fewer entries, we've hit a boundary
-		hasMore := len(entries) >= logPageSize-1
+		hasMore := len(entries) >= source.pageSize-1
 
 		// Extract cursors BEFORE normalizing entry order
 		// For reverse mode: journalctl returns newest→oldest, so last entry is oldest
@@ -995,6 +1164,32 @@ func fetchLogs(service string, reverse bool, cursor string, isPrefetch bool) tea
 	}
 }
 
+// matchArgs returns the journalctl arguments selecting the entries of the source
+func (s journalSource) matchArgs() []string {
+	switch {
+	case s.service == kernelLogsName:
+		// Kernel messages of the current boot, like dmesg
+		return []string{"-k"}
+	case len(s.units) > 0:
+		// journalctl interleaves the entries of all units by time
+		var args []string
+		for _, unit := range s.units {
+			args = append(args, "-u", serviceUnitName(unit))
+		}
+		return args
+	default:
+		return []string{"-u", serviceUnitName(s.service)}
+	}
+}
+
+// serviceUnitName adds the .service suffix to a service name to ensure an exact unit match
+func serviceUnitName(service string) string {
+	if strings.HasSuffix(service, ".service") {
+		return service
+	}
+	return service + ".service"
+}
+
 // parseJSONLogs parses line-delimited JSON from journalctl -o json
 func parseJSONLogs(output []byte) ([]logEntry, error) {
 	var entries []logEntry
@@ -1034,6 +1229,7 @@ func parseJSONLogs(output []byte) ([]logEntry, error) {
 			entry.unit = unit
 		}
 		entry.message = decodeMessage(rawEntry["MESSAGE"])
+		entry.priority = parseEntryPriority(rawEntry["PRIORITY"])
 		if cursor, ok := rawEntry["__CURSOR"].(string); ok {
 			entry.cursor = cursor
 		}
@@ -1072,18 +1268,33 @@ func decodeMessage(raw any) string {
 	}
 }
 
-func handleLogs(parentCtx context.Context) error {
+// listLogServices returns the systemd services sb logs lists
+func listLogServices(parentCtx context.Context) ([]systemd.ServiceInfo, error) {
 	ctx, cancel := context.WithTimeout(parentCtx, 10*time.Second)
 	defer cancel()
 
-	services, err := systemd.GetFilteredServices(ctx, systemd.DefaultFilters)
+	// Include services under any extra prefixes configured in the MOTD config
+	var prefixes []string
+	if systemdCfg := motd.LoadSystemdConfig(); systemdCfg != nil {
+		prefixes = systemdCfg.LogPrefixes
+	}
+
+	services, err := systemd.GetFilteredServices(ctx, systemd.FiltersWithPrefixes(prefixes))
 	if err != nil {
-		return fmt.Errorf("error getting systemd services: %w", err)
+		return nil, fmt.Errorf("error getting systemd services: %w", err)
 	}
+	return services, nil
+}
 
-	if len(services) == 0 {
-		fmt.Println("No matching systemd services found.")
-		return nil
+// handleLogs runs the logs UI. A non-empty initial, like kernelLogsName, opens those logs
+// instead of the service list.
+func handleLogs(parentCtx context.Context, followInterval time.Duration, alert *logAlert, priority int, initial, cursor string, jsonFormat logJSONFormat, limits logBufferLimits) error {
+	services, err := listLogServices(parentCtx)
+	if err != nil {
+		return err
+	}
+	if initial == allServicesLogsName && len(services) == 0 {
+		return fmt.Errorf("no matching systemd services found")
 	}
 
 	// Calculate maximum service name length for alignment
@@ -1094,16 +1305,23 @@ func handleLogs(parentCtx context.Context) error {
 		}
 	}
 
-	// Convert systemd.ServiceInfo slice to list items
-	items := make([]list.Item, len(services))
-	for i, service := range services {
-		items[i] = serviceItem{
+	// Convert systemd.ServiceInfo slice to list items, after the merged logs of all services
+	// and the kernel messages which are always available
+	var items []list.Item
+	var allUnits []string
+	if len(services) > 0 {
+		items = append(items, serviceItem{name: allServicesLogsName})
+	}
+	items = append(items, serviceItem{name: kernelLogsName})
+	for _, service := range services {
+		allUnits = append(allUnits, service.Name)
+		items = append(items, serviceItem{
 			name:      service.Name,
 			active:    service.Active,
 			sub:       service.Sub,
 			runtime:   service.Runtime,
 			maxLength: maxNameLength,
-		}
+		})
 	}
 
 	// Create a list and size it from WindowSizeMsg
@@ -1138,6 +1356,18 @@ func handleLogs(parentCtx context.Context) error {
 		loading:             false,
 		err:                 nil,
 		showTimestampHost:   true, // Show timestamp/host by default
+		followInterval:      followInterval,
+		alert:               alert,
+		allUnits:            allUnits,
+		priority:            priority,
+		displayPriority:     priority,
+		json:                jsonFormat,
+		limits:              limits,
+		startCursor:         cursor,
+	}
+	if initial != "" {
+		initialModel.activeView = "logs"
+		initialModel.selectService(initial)
 	}
 
 	// Run the program with alt screen controlled declaratively in View().
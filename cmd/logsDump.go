@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/saltyorg/sb-go/internal/signals"
+)
+
+// logOutputFormat is how the log dumps print entries, chosen with --output
+type logOutputFormat string
+
+const (
+	logOutputText logOutputFormat = "text" // Lines formatted like the viewer shows them
+	logOutputJSON logOutputFormat = "json" // A JSON object per entry, for jq or log shippers
+)
+
+// logOutputFormatNames lists the values accepted by --output
+var logOutputFormatNames = []string{string(logOutputText), string(logOutputJSON)}
+
+// parseLogOutputFormat parses an --output value
+func parseLogOutputFormat(value string) (logOutputFormat, error) {
+	switch format := logOutputFormat(strings.ToLower(value)); format {
+	case logOutputText, logOutputJSON:
+		return format, nil
+	}
+	return "", fmt.Errorf("invalid --output %q (must be one of: %s)", value, strings.Join(logOutputFormatNames, ", "))
+}
+
+// newLogJSONEncoder returns an encoder writing a JSON object per line to w, with messages
+// kept readable for jq
+func newLogJSONEncoder(w io.Writer) *json.Encoder {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return encoder
+}
+
+// logEntryJSON is a journal entry as written by --output json
+type logEntryJSON struct {
+	Timestamp string `json:"timestamp"`
+	Hostname  string `json:"hostname"`
+	Unit      string `json:"unit"`
+	Message   string `json:"message"`
+	Cursor    string `json:"cursor"`
+}
+
+// handleLogsDump prints the most recent log lines of source matching pattern to stdout.
+// This bypasses the interactive UI so the output can be piped or used in scripts.
+func handleLogsDump(source journalSource, pattern string, count int, output logOutputFormat) error {
+	if count < 0 {
+		return fmt.Errorf("invalid number of lines: %d (must be 0 or more)", count)
+	}
+
+	var re *regexp.Regexp
+	if pattern != "" {
+		var err error
+		if re, err = regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid --grep pattern: %w", err)
+		}
+	}
+
+	entries, err := collectLogEntries(func(cursor string) logsMsg {
+		return fetchLogs(source, true, cursor, false)().(logsMsg)
+	}, re, count)
+	if err != nil {
+		// Exit quietly with the signal manager's exit code when interrupted
+		if signals.GetGlobalManager().IsShutdown() {
+			return nil
+		}
+		return err
+	}
+
+	return writeLogEntries(os.Stdout, entries, source.json, output)
+}
+
+// writeLogEntries writes entries to w in the given output format. Text lines are formatted like
+// the viewer with jsonFormat applied, while JSON objects hold the messages as logged.
+func writeLogEntries(w io.Writer, entries []logEntry, jsonFormat logJSONFormat, output logOutputFormat) error {
+	if output == logOutputJSON {
+		encoder := newLogJSONEncoder(w)
+		for _, entry := range entries {
+			err := encoder.Encode(logEntryJSON{
+				Timestamp: entry.timestamp,
+				Hostname:  entry.hostname,
+				Unit:      entry.unit,
+				Message:   entry.message,
+				Cursor:    entry.cursor,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to write log entry: %w", err)
+			}
+		}
+		return nil
+	}
+
+	for _, entry := range entries {
+		entry.message = jsonFormat.apply(entry.message)
+		if _, err := fmt.Fprintln(w, formatLogEntry(entry, true)); err != nil {
+			return fmt.Errorf("failed to write log entry: %w", err)
+		}
+	}
+	return nil
+}
+
+// collectLogEntries pages backwards through the journal using fetch until count entries
+// matching re are found (all entries when count is 0) or the start of the journal is reached.
+// A nil re matches every entry. Entries are returned oldest first.
+func collectLogEntries(fetch func(cursor string) logsMsg, re *regexp.Regexp, count int) ([]logEntry, error) {
+	var matches []logEntry
+	cursor := ""
+
+	for {
+		msg := fetch(cursor)
+		if msg.err != nil {
+			return nil, msg.err
+		}
+
+		// Pages are ordered oldest to newest, so walk them from the end to collect the newest matches first
+		for _, entry := range slices.Backward(msg.entries) {
+			if re != nil && !re.MatchString(entry.message) {
+				continue
+			}
+			matches = append(matches, entry)
+			if count > 0 && len(matches) == count {
+				slices.Reverse(matches)
+				return matches, nil
+			}
+		}
+
+		if !msg.hasMore || len(msg.entries) == 0 {
+			break
+		}
+		if msg.firstCursor == cursor {
+			return nil, errors.New("failed to page through logs: cursor did not advance")
+		}
+		cursor = msg.firstCursor
+	}
+
+	slices.Reverse(matches)
+	return matches, nil
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// fakeJournal serves pages of entries newest page first, like fetchLogs in reverse mode
+func fakeJournal(t *testing.T, pages [][]logEntry) func(cursor string) logsMsg {
+	return func(cursor string) logsMsg {
+		index := 0
+		if cursor != "" {
+			if _, err := fmt.Sscanf(cursor, "page-%d", &index); err != nil {
+				t.Fatalf("unexpected cursor %q", cursor)
+			}
+		}
+		page := pages[index]
+		return logsMsg{
+			entries:     page,
+			firstCursor: fmt.Sprintf("page-%d", index+1),
+			hasMore:     index+1 < len(pages),
+		}
+	}
+}
+
+func TestCollectLogEntries(t *testing.T) {
+	pages := [][]logEntry{
+		{{message: "error 3"}, {message: "info 4"}, {message: "error 5"}},
+		{{message: "error 1"}, {message: "info 2"}},
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		count   int
+		want    []string
+	}{
+		{"newest lines", "", 2, []string{"info 4", "error 5"}},
+		{"grep across pages", "^error", 3, []string{"error 1", "error 3", "error 5"}},
+		{"all matches", "info", 0, []string{"info 2", "info 4"}},
+		{"fewer matches than requested", "error 1", 10, []string{"error 1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var re *regexp.Regexp
+			if tt.pattern != "" {
+				re = regexp.MustCompile(tt.pattern)
+			}
+
+			entries, err := collectLogEntries(fakeJournal(t, pages), re, tt.count)
+			if err != nil {
+				t.Fatalf("collectLogEntries() error = %v", err)
+			}
+
+			var got []string
+			for _, entry := range entries {
+				got = append(got, entry.message)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("collectLogEntries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectLogEntriesError(t *testing.T) {
+	fetch := func(cursor string) logsMsg {
+		return logsMsg{err: fmt.Errorf("journalctl failed")}
+	}
+
+	if _, err := collectLogEntries(fetch, nil, 10); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestParseLogOutputFormat(t *testing.T) {
+	for value, want := range map[string]logOutputFormat{"text": logOutputText, "json": logOutputJSON, "JSON": logOutputJSON} {
+		if got, err := parseLogOutputFormat(value); err != nil || got != want {
+			t.Errorf("parseLogOutputFormat(%q) = %q, %v, want %q", value, got, err, want)
+		}
+	}
+	if _, err := parseLogOutputFormat("yaml"); err == nil {
+		t.Error("parseLogOutputFormat(\"yaml\") expected an error")
+	}
+}
+
+func TestWriteLogEntriesJSON(t *testing.T) {
+	entries, err := parseJSONLogs([]byte(`{"__REALTIME_TIMESTAMP":"1762956000000000","_HOSTNAME":"saltbox","_SYSTEMD_UNIT":"saltbox_managed_docker.service","MESSAGE":"{\"level\":\"error\",\"msg\":\"a < b\"}","__CURSOR":"s=1;i=2"}`))
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("parseJSONLogs() = %v, %v", entries, err)
+	}
+
+	var out bytes.Buffer
+	if err := writeLogEntries(&out, entries, newLogJSONFormat("msg"), logOutputJSON); err != nil {
+		t.Fatalf("writeLogEntries() error = %v", err)
+	}
+
+	timestamp := time.Unix(1762956000, 0).Format("2006-01-02T15:04:05-0700")
+	want := `{"timestamp":"` + timestamp + `","hostname":"saltbox","unit":"saltbox_managed_docker.service","message":"{\"level\":\"error\",\"msg\":\"a < b\"}","cursor":"s=1;i=2"}` + "\n"
+	if out.String() != want {
+		t.Errorf("output = %s, want %s", out.String(), want)
+	}
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// journalPriorityNames are the syslog severities journald records in PRIORITY, indexed by value
+var journalPriorityNames = []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
+const (
+	// allPriorities shows entries of every priority, including entries without one
+	allPriorities = -1
+	// Thresholds the 'p' key cycles through besides the one the logs were fetched with
+	priorityWarning = 4
+	priorityErr     = 3
+)
+
+// parseJournalPriority parses a priority given by name or number, like journalctl -p.
+// An empty value returns allPriorities.
+func parseJournalPriority(value string) (int, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return allPriorities, nil
+	}
+	if i := slices.Index(journalPriorityNames, value); i >= 0 {
+		return i, nil
+	}
+	if n, err := strconv.Atoi(value); err == nil && n >= 0 && n < len(journalPriorityNames) {
+		return n, nil
+	}
+	return 0, fmt.Errorf("invalid priority %q (valid priorities: %s or 0-7)", value, strings.Join(journalPriorityNames, ", "))
+}
+
+// journalPriorityName returns the name of a priority threshold
+func journalPriorityName(priority int) string {
+	if priority < 0 || priority >= len(journalPriorityNames) {
+		return "all"
+	}
+	return journalPriorityNames[priority]
+}
+
+// parseEntryPriority returns the PRIORITY of a journal entry, or allPriorities if it has none
+func parseEntryPriority(raw any) int {
+	value, ok := raw.(string)
+	if !ok {
+		return allPriorities
+	}
+	priority, err := strconv.Atoi(value)
+	if err != nil || priority < 0 || priority >= len(journalPriorityNames) {
+		return allPriorities
+	}
+	return priority
+}
+
+// nextPriority returns the threshold after current when cycling with the 'p' key.
+// Only thresholds at or above floor, the one the logs were fetched with, can be shown.
+func nextPriority(current, floor int) int {
+	cycle := []int{floor}
+	for _, threshold := range []int{priorityWarning, priorityErr} {
+		if floor == allPriorities || threshold < floor {
+			cycle = append(cycle, threshold)
+		}
+	}
+	i := slices.Index(cycle, current)
+	return cycle[(i+1)%len(cycle)]
+}
+
+// priorityFilter returns a filter showing the entries at or above threshold, or nil to show all entries
+func priorityFilter(threshold int) func(logEntry) bool {
+	if threshold == allPriorities {
+		return nil
+	}
+	return func(entry logEntry) bool {
+		return entry.priority != allPriorities && entry.priority <= threshold
+	}
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseJournalPriority(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{"", allPriorities, false},
+		{"warning", 4, false},
+		{"ERR", 3, false},
+		{"7", 7, false},
+		{"8", 0, true},
+		{"error", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseJournalPriority(tt.value)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && got != tt.want) {
+			t.Errorf("parseJournalPriority(%q) = %d, %v, want %d, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestNextPriority(t *testing.T) {
+	tests := []struct {
+		name    string
+		floor   int
+		current int
+		want    int
+	}{
+		{"all to warning", allPriorities, allPriorities, priorityWarning},
+		{"warning to err", allPriorities, priorityWarning, priorityErr},
+		{"err back to all", allPriorities, priorityErr, allPriorities},
+		{"info floor cycles stricter thresholds", 6, 6, priorityWarning},
+		{"warning floor only adds err", priorityWarning, priorityErr, priorityWarning},
+		{"err floor stays", priorityErr, priorityErr, priorityErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextPriority(tt.current, tt.floor); got != tt.want {
+				t.Errorf("nextPriority(%d, %d) = %d, want %d", tt.current, tt.floor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseJSONLogsPriority(t *testing.T) {
+	output := `{"__CURSOR":"c1","MESSAGE":"started","PRIORITY":"6"}
+{"__CURSOR":"c2","MESSAGE":"no priority"}`
+	entries, err := parseJSONLogs([]byte(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].priority != 6 || entries[1].priority != allPriorities {
+		t.Errorf("unexpected priorities: %+v", entries)
+	}
+}
+
+func TestLogBufferPriorityFilter(t *testing.T) {
+	lb, _ := newTestLogBuffer(100)
+	lb.entries = []logEntry{
+		{message: "debug noise", cursor: "c1", priority: 7},
+		{message: "disk almost full", cursor: "c2", priority: priorityWarning},
+		{message: "crashed", cursor: "c3", priority: priorityErr},
+		{message: "unknown", cursor: "c4", priority: allPriorities},
+	}
+
+	lb.visible = priorityFilter(priorityWarning)
+	if got := lb.LineCount(lb.entries, false); got != 2 {
+		t.Errorf("LineCount with warning filter = %d, want 2", got)
+	}
+	text := lb.PlainText(false)
+	if strings.Contains(text, "debug noise") || strings.Contains(text, "unknown") || !strings.Contains(text, "disk almost full") {
+		t.Errorf("warning filter shows wrong entries: %q", text)
+	}
+
+	lb.visible = priorityFilter(allPriorities)
+	if got := lb.LineCount(lb.entries, false); got != 4 {
+		t.Errorf("LineCount without filter = %d, want 4", got)
+	}
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"charm.land/bubbles/v2/help"
+	"charm.land/bubbles/v2/list"
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestKernelLogsBeforeWindowSize(t *testing.T) {
+	m := model{
+		list:            list.New([]list.Item{serviceItem{name: kernelLogsName}}, list.NewDefaultDelegate(), 0, 0),
+		help:            help.New(),
+		keys:            keys,
+		activeView:      "logs",
+		priority:        allPriorities,
+		displayPriority: allPriorities,
+	}
+	if cmd := m.selectService(kernelLogsName); cmd == nil {
+		t.Fatal("selectService returned no fetch command")
+	}
+	if m.logBuf.source.(journalSource).service != kernelLogsName {
+		t.Fatalf("buffer source = %+v, want the kernel messages", m.logBuf.source)
+	}
+
+	// The logs can arrive before the terminal size is known
+	updated, _ := m.Update(logsMsg{
+		entries:     []logEntry{{unit: "kernel", message: "Out of memory: Killed process 4242 (plex)", cursor: "c1", priority: 3}},
+		firstCursor: "c1",
+		lastCursor:  "c1",
+	})
+	updated, _ = updated.(model).Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	m = updated.(model)
+	if !m.viewportInitialized {
+		t.Fatal("viewport not initialized after the window size arrived")
+	}
+	if content := m.viewport.View(); !strings.Contains(content, "Out of memory") {
+		t.Errorf("kernel logs not shown after initializing the viewport: %q", content)
+	}
+}
+
+func TestJournalSourceMatchArgs(t *testing.T) {
+	tests := []struct {
+		name   string
+		source journalSource
+		want   []string
+	}{
+		{"service", journalSource{service: "saltbox_managed_docker"}, []string{"-u", "saltbox_managed_docker.service"}},
+		{"kernel", journalSource{service: kernelLogsName}, []string{"-k"}},
+		{"all services", journalSource{service: allServicesLogsName, units: []string{"a", "b.service"}}, []string{"-u", "a.service", "-u", "b.service"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.source.matchArgs(); !slices.Equal(got, tt.want) {
+				t.Errorf("matchArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergedLogsColorUnits(t *testing.T) {
+	entry := logEntry{unit: "saltbox_managed_docker.service", message: "started"}
+	merged := journalSource{service: allServicesLogsName, units: []string{"saltbox_managed_docker"}}
+
+	if got := (journalSource{service: "saltbox_managed_docker"}).Format(entry, false); got != "saltbox_managed_docker.service: started" {
+		t.Errorf("single service entry = %q, want it uncolored", got)
+	}
+	if got := merged.Format(entry, false); ansi.Strip(got) != "saltbox_managed_docker.service: started" || got == ansi.Strip(got) {
+		t.Errorf("merged entry = %q, want a colored unit name", got)
+	}
+	if unitStyle("a.service").Render("x") != unitStyle("a.service").Render("x") {
+		t.Error("unit colors aren't stable")
+	}
+}
+
+func TestSelectAllServicesMergesUnits(t *testing.T) {
+	m := model{allUnits: []string{"a", "b"}, priority: allPriorities, displayPriority: allPriorities}
+	m.selectService(allServicesLogsName)
+	if source := m.logBuf.source.(journalSource); !slices.Equal(source.units, []string{"a", "b"}) {
+		t.Errorf("merged source units = %q, want a and b", source.units)
+	}
+	m.selectService("a")
+	if source := m.logBuf.source.(journalSource); source.units != nil {
+		t.Errorf("single service source has units %q", source.units)
+	}
+}
+
+func TestLogsOpenAtCursor(t *testing.T) {
+	m := model{
+		list:            list.New([]list.Item{serviceItem{name: "app"}}, list.NewDefaultDelegate(), 0, 0),
+		help:            help.New(),
+		keys:            keys,
+		activeView:      "logs",
+		priority:        allPriorities,
+		displayPriority: allPriorities,
+		limits:          journalLimits,
+		startCursor:     "c5",
+	}
+	m.selectService("app")
+
+	update := func(msg tea.Msg) tea.Cmd {
+		t.Helper()
+		updated, cmd := m.Update(msg)
+		m = updated.(model)
+		return cmd
+	}
+
+	update(tea.WindowSizeMsg{Width: 120, Height: 10})
+	entries := make([]logEntry, 0, 20)
+	for i := 5; i < 25; i++ {
+		entries = append(entries, logEntry{timestamp: fmt.Sprintf("t%d", i), unit: "app", message: fmt.Sprintf("line %d", i), cursor: fmt.Sprintf("c%d", i), priority: allPriorities})
+	}
+	update(logsMsg{entries: entries, firstCursor: "c5", lastCursor: "c24", hasMore: true})
+
+	if m.startCursor != "" {
+		t.Error("expected the start cursor to be used once")
+	}
+	if m.viewport.YOffset() != 0 || !m.logBuf.hasMoreAfter {
+		t.Errorf("expected the cursor's entry at the top with newer logs to follow, got offset %d, hasMoreAfter %v", m.viewport.YOffset(), m.logBuf.hasMoreAfter)
+	}
+
+	// The cursor of the entry at the top of the view is copied
+	m.viewport.SetYOffset(3)
+	if cmd := update(tea.KeyPressMsg{Code: 'C', Text: "C"}); cmd == nil {
+		t.Fatal("expected a copy command")
+	}
+	if want := "Sent the journal cursor of the entry at t8 to the terminal clipboard, for --cursor"; m.status != want {
+		t.Errorf("status = %q, want %q", m.status, want)
+	}
+}
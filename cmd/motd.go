@@ -8,7 +8,9 @@ import (
 	"strings"
 
 	"github.com/saltyorg/sb-go/internal/motd"
+	"github.com/saltyorg/sb-go/internal/signals"
 
+	"github.com/charmbracelet/x/ansi"
 	"github.com/spf13/cobra"
 )
 
@@ -39,8 +41,25 @@ type motdConfig struct {
 	showSystemd          bool
 	showTraefik          bool
 	showUptime           bool
+	showTemperatures     bool
+	showDiskHealth       bool
+	showPools            bool
+	showMounts           bool
+	showNetwork          bool
+	showHistory          bool
+	showPressure         bool
+	onlyProblems         bool
 	shareMode            bool
 	generateConfig       bool
+	watch                bool
+	install              bool
+	uninstall            bool
+	removeDefaults       bool
+	installArgs          []string
+	watchInterval        int
+	width                int
+	showHeader           bool
+	headerText           string
 	bannerFile           string
 	bannerFileToiletArgs string
 	bannerFont           string
@@ -86,6 +105,14 @@ last login, user sessions, process information, and system update status based o
 		config.showSystemd, _ = cmd.Flags().GetBool("systemd")
 		config.showTraefik, _ = cmd.Flags().GetBool("traefik")
 		config.showUptime, _ = cmd.Flags().GetBool("uptime")
+		config.showTemperatures, _ = cmd.Flags().GetBool("temps")
+		config.showDiskHealth, _ = cmd.Flags().GetBool("smart")
+		config.showPools, _ = cmd.Flags().GetBool("pools")
+		config.showMounts, _ = cmd.Flags().GetBool("mounts")
+		config.showNetwork, _ = cmd.Flags().GetBool("network")
+		config.showHistory, _ = cmd.Flags().GetBool("history")
+		config.showPressure, _ = cmd.Flags().GetBool("pressure")
+		config.onlyProblems, _ = cmd.Flags().GetBool("only-problems")
 		config.bannerFile, _ = cmd.Flags().GetString("banner-file")
 		config.bannerFileToiletArgs, _ = cmd.Flags().GetString("banner-file-toilet")
 		config.bannerFont, _ = cmd.Flags().GetString("font")
@@ -95,6 +122,15 @@ last login, user sessions, process information, and system update status based o
 		config.verbosity, _ = cmd.Flags().GetCount("verbose")
 		config.shareMode, _ = cmd.Flags().GetBool("share")
 		config.generateConfig, _ = cmd.Flags().GetBool("generate-config")
+		config.watch, _ = cmd.Flags().GetBool("watch")
+		config.watchInterval, _ = cmd.Flags().GetInt("interval")
+		config.width, _ = cmd.Flags().GetInt("width")
+		config.install, _ = cmd.Flags().GetBool("install")
+		config.uninstall, _ = cmd.Flags().GetBool("uninstall")
+		config.removeDefaults, _ = cmd.Flags().GetBool("remove-defaults")
+		config.installArgs = motdDropInArgs(cmd.Flags())
+		config.showHeader, _ = cmd.Flags().GetBool("header")
+		config.headerText, _ = cmd.Flags().GetString("header-text")
 
 		return runMotdCommand(cmd.Context(), config)
 	},
@@ -112,9 +148,21 @@ func runMotdCommand(ctx context.Context, mcfg *motdConfig) error {
 		return nil
 	}
 
+	// Handle --install and --uninstall of the update-motd.d drop-in
+	if mcfg.install {
+		return installMotdDropIn(mcfg.installArgs, mcfg.removeDefaults)
+	}
+	if mcfg.uninstall {
+		return uninstallMotdDropIn()
+	}
+
 	// Initialize custom colors from config if available
 	motd.InitializeColors()
 
+	if motd.OnlyProblemsEnabled() {
+		mcfg.onlyProblems = true
+	}
+
 	// If --all flag is used, enable everything
 	if mcfg.showAll {
 		mcfg.showAptStatus = true
@@ -141,14 +189,23 @@ func runMotdCommand(ctx context.Context, mcfg *motdConfig) error {
 		mcfg.showSystemd = true
 		mcfg.showTraefik = true
 		mcfg.showUptime = true
+		mcfg.showTemperatures = true
+		mcfg.showDiskHealth = true
+		mcfg.showPools = true
+		mcfg.showMounts = true
+		mcfg.showNetwork = true
+		mcfg.showHistory = true
+		mcfg.showPressure = true
 	}
 
-	// Check if at least one flag is enabled
-	if !mcfg.showAptStatus && !mcfg.showCPU && !mcfg.showCpuAverages && !mcfg.showDisk && !mcfg.showDistribution &&
+	// Check if at least one flag is enabled. Only showing problems checks every section by default.
+	if !mcfg.onlyProblems && !mcfg.showAptStatus && !mcfg.showCPU && !mcfg.showCpuAverages && !mcfg.showDisk && !mcfg.showDistribution &&
 		!mcfg.showDocker && !mcfg.showEmby && !mcfg.showGPU && !mcfg.showJellyfin && !mcfg.showKernel && !mcfg.showLastLogin &&
 		!mcfg.showMemory && !mcfg.showNzbget && !mcfg.showPlex && !mcfg.showProcesses && !mcfg.showQbittorrent &&
 		!mcfg.showQueues && !mcfg.showRebootRequired && !mcfg.showRtorrent && !mcfg.showSabnzbd && !mcfg.showSessions &&
-		!mcfg.showSystemd && !mcfg.showTraefik && !mcfg.showUptime {
+		!mcfg.showSystemd && !mcfg.showTraefik && !mcfg.showUptime &&
+		!mcfg.showTemperatures && !mcfg.showDiskHealth && !mcfg.showPools && !mcfg.showMounts && !mcfg.showNetwork && !mcfg.showHistory &&
+		!mcfg.showPressure {
 		return fmt.Errorf("no information selected to display (use --all or specific flags)")
 	}
 
@@ -195,102 +252,174 @@ func runMotdCommand(ctx context.Context, mcfg *motdConfig) error {
 		return fmt.Errorf("invalid font specified: %s%s", mcfg.bannerFont, availableFonts.String())
 	}
 
+	if mcfg.watch && mcfg.watchInterval < 1 {
+		return fmt.Errorf("invalid refresh interval: %d (must be at least 1 second)", mcfg.watchInterval)
+	}
+
+	if mcfg.width < 0 {
+		return fmt.Errorf("invalid width: %d (must be 0 or more)", mcfg.width)
+	}
+
 	return displayMotd(ctx, mcfg, mcfg.verbosity > 0)
 }
 
 func displayMotd(ctx context.Context, config *motdConfig, verbose bool) error {
+	sources := motdSources(config)
+
+	if config.watch {
+		return runMotdWatch(ctx, config, sources)
+	}
+
+	output, err := renderMotd(ctx, config, sources, verbose)
+	if err != nil {
+		// Exit quietly with the signal manager's exit code when interrupted
+		if ctx.Err() != nil && signals.GetGlobalManager().IsShutdown() {
+			return nil
+		}
+		return err
+	}
+
+	// Honor NO_COLOR (https://no-color.org) by stripping all styling
+	if os.Getenv("NO_COLOR") != "" {
+		output = ansi.Strip(output)
+	}
+	fmt.Print(output)
+
+	return nil
+}
+
+// motdSources resolves the enabled sections into info sources in display order
+func motdSources(config *motdConfig) []motd.InfoSource {
+	flags := motdSectionFlags(config)
+	quietHours := motd.QuietHoursActive()
+
+	// Only check the sections with a health check, limited to the enabled ones if any are
+	if config.onlyProblems {
+		var enabled []string
+		for name, on := range flags {
+			if on {
+				enabled = append(enabled, name)
+			}
+		}
+		return motd.ProblemSources(enabled, quietHours)
+	}
+
+	// Resolve section order and visibility from the MOTD config (defaults to built-in order)
+	sections, unknown := motd.ResolveSections(motd.LoadSectionsConfig())
+	for _, name := range unknown {
+		fmt.Fprintf(os.Stderr, "Warning: unknown MOTD section %q in config, skipping\n", name)
+	}
+
+	// Build the active sources from enabled sections, keeping the resolved order
+	var activeSources []motd.InfoSource
+	for _, section := range sections {
+		if flags[section.Name] {
+			activeSources = append(activeSources, motd.InfoSource{
+				Key:      section.Key,
+				Provider: motd.SectionProvider(section, quietHours),
+				Order:    len(activeSources) + 1,
+			})
+		}
+	}
+
+	return activeSources
+}
+
+// motdSectionFlags maps section names to whether their flags are enabled
+func motdSectionFlags(config *motdConfig) map[string]bool {
+	return map[string]bool{
+		"distro":      config.showDistribution,
+		"kernel":      config.showKernel,
+		"uptime":      config.showUptime,
+		"cpu":         config.showCpuAverages,
+		"processes":   config.showProcesses,
+		"cpu-info":    config.showCPU,
+		"gpu":         config.showGPU,
+		"memory":      config.showMemory,
+		"apt":         config.showAptStatus,
+		"reboot":      config.showRebootRequired,
+		"sessions":    config.showSessions,
+		"login":       config.showLastLogin,
+		"disk":        config.showDisk,
+		"systemd":     config.showSystemd,
+		"docker":      config.showDocker,
+		"traefik":     config.showTraefik,
+		"queues":      config.showQueues,
+		"sabnzbd":     config.showSabnzbd,
+		"nzbget":      config.showNzbget,
+		"qbittorrent": config.showQbittorrent,
+		"rtorrent":    config.showRtorrent,
+		"plex":        config.showPlex,
+		"emby":        config.showEmby,
+		"jellyfin":    config.showJellyfin,
+		"network":     config.showNetwork,
+		"history":     config.showHistory,
+		"mounts":      config.showMounts,
+		"pools":       config.showPools,
+		"smart":       config.showDiskHealth,
+		"temps":       config.showTemperatures,
+		"pressure":    config.showPressure,
+	}
+}
+
+// renderMotd renders the banner, header and system information to a string
+func renderMotd(ctx context.Context, config *motdConfig, sources []motd.InfoSource, verbose bool) (string, error) {
+	var output strings.Builder
+
 	// Set share mode if enabled
 	motd.SetShareMode(config.shareMode)
 
+	// Render for a fixed width if requested, e.g., in drop-in scripts without a TTY
+	motd.SetOutputWidth(config.width)
+	// Size values to the key column every section is rendered with
+	keyColumn := motdKeyColumn(sources)
+	motd.SetValueIndent(keyColumn.Indent())
+
 	// Display a banner from a file if provided. This takes precedence.
 	if config.bannerFile != "" {
 		content, err := os.ReadFile(config.bannerFile)
 		if err != nil {
-			return fmt.Errorf("could not read banner file '%s': %w", config.bannerFile, err)
+			return "", fmt.Errorf("could not read banner file '%s': %w", config.bannerFile, err)
 		}
 
 		var banner string
 		// If toilet args are provided, process the file content through toilet.
 		if config.bannerFileToiletArgs != "" {
-			banner = motd.GenerateBannerFromFile(string(content), config.bannerFileToiletArgs)
+			banner = motd.GenerateBannerFromFile(ctx, string(content), config.bannerFileToiletArgs)
 		} else {
 			// Otherwise, just use the raw file content.
 			banner = string(content)
 		}
-		fmt.Println(banner)
+		output.WriteString(banner + "\n")
 
 	} else if config.bannerTitle != "" {
 		// Otherwise, generate banner if title is provided
-		banner := motd.GenerateBanner(config.bannerTitle, config.bannerFont, config.bannerType)
-		fmt.Println(banner)
-	}
-
-	// Set up info sources with display order
-	sources := []motd.InfoSource{
-		{Key: "Distribution:", Provider: motd.GetDistributionWithContext, Order: 1},
-		{Key: "Kernel:", Provider: motd.GetKernelWithContext, Order: 2},
-		{Key: "Uptime:", Provider: motd.GetUptimeWithContext, Order: 3},
-		{Key: "Load Averages:", Provider: motd.GetCpuAveragesWithContext, Order: 4},
-		{Key: "Processes:", Provider: motd.GetProcessCountWithContext, Order: 5},
-		{Key: "CPU:", Provider: motd.GetCpuInfoWithContext, Order: 6},
-		{Key: "GPU:", Provider: motd.GetGpuInfoWithContext, Order: 7},
-		{Key: "Memory Usage:", Provider: motd.GetMemoryInfoWithContext, Order: 8},
-		{Key: "Package Status:", Provider: motd.GetAptStatusWithContext, Order: 9},
-		{Key: "Reboot Status:", Provider: motd.GetRebootRequiredWithContext, Order: 10},
-		{Key: "User Sessions:", Provider: motd.GetUserSessionsWithContext, Order: 11},
-		{Key: "Last login:", Provider: motd.GetLastLoginWithContext, Order: 12},
-		{Key: "Disk Usage:", Provider: motd.GetDiskInfoWithContext, Order: 13},
-		{Key: "Services:", Provider: motd.GetSystemdServicesInfoWithContext, Order: 14},
-		{Key: "Docker:", Provider: motd.GetDockerInfoWithContext, Order: 15},
-		{Key: "Traefik:", Provider: motd.GetTraefikInfoWithContext, Order: 16},
-		{Key: "Download Queues:", Provider: motd.GetQueueInfoWithContext, Order: 17},
-		{Key: "SABnzbd:", Provider: motd.GetSabnzbdInfoWithContext, Order: 18},
-		{Key: "NZBGet:", Provider: motd.GetNzbgetInfoWithContext, Order: 19},
-		{Key: "qBittorrent:", Provider: motd.GetQbittorrentInfoWithContext, Order: 20},
-		{Key: "rTorrent:", Provider: motd.GetRtorrentInfoWithContext, Order: 21},
-		{Key: "Plex:", Provider: motd.GetPlexInfoWithContext, Order: 22},
-		{Key: "Emby:", Provider: motd.GetEmbyInfoWithContext, Order: 23},
-		{Key: "Jellyfin:", Provider: motd.GetJellyfinInfoWithContext, Order: 24},
+		banner := motd.GenerateBanner(ctx, config.bannerTitle, config.bannerFont, config.bannerType)
+		output.WriteString(banner + "\n")
 	}
 
-	// Filter sources based on enabled flags
-	var activeSources []motd.InfoSource
-	flags := map[string]bool{
-		"Distribution:":    config.showDistribution,
-		"Kernel:":          config.showKernel,
-		"Uptime:":          config.showUptime,
-		"Load Averages:":   config.showCpuAverages,
-		"Processes:":       config.showProcesses,
-		"CPU:":             config.showCPU,
-		"GPU:":             config.showGPU,
-		"Memory Usage:":    config.showMemory,
-		"Package Status:":  config.showAptStatus,
-		"Reboot Status:":   config.showRebootRequired,
-		"User Sessions:":   config.showSessions,
-		"Last login:":      config.showLastLogin,
-		"Disk Usage:":      config.showDisk,
-		"Services:":        config.showSystemd,
-		"Docker:":          config.showDocker,
-		"Download Queues:": config.showQueues,
-		"SABnzbd:":         config.showSabnzbd,
-		"NZBGet:":          config.showNzbget,
-		"qBittorrent:":     config.showQbittorrent,
-		"rTorrent:":        config.showRtorrent,
-		"Plex:":            config.showPlex,
-		"Emby:":            config.showEmby,
-		"Jellyfin:":        config.showJellyfin,
-		"Traefik:":         config.showTraefik,
+	// Display the hostname header if enabled by flag or config
+	showHeader := config.showHeader
+	headerText := config.headerText
+	if headerCfg := motd.LoadHeaderConfig(); headerCfg != nil && headerCfg.IsEnabled() {
+		showHeader = true
+		if headerText == "" {
+			headerText = headerCfg.Text
+		}
 	}
-
-	// Simply use all enabled sources
-	for _, source := range sources {
-		if enabled, exists := flags[source.Key]; exists && enabled {
-			activeSources = append(activeSources, source)
+	if showHeader {
+		if header := motd.GenerateHeader(headerText); header != "" {
+			output.WriteString(header + "\n\n")
 		}
 	}
 
 	// Get system information in parallel
-	results := motd.GetSystemInfo(ctx, activeSources, verbose)
+	results := motd.GetSystemInfo(ctx, sources, verbose)
+
+	// Don't render partial results when interrupted (e.g., ctrl+c)
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
 
 	// Filter out any results with empty values
 	var filteredResults []motd.Result
@@ -300,42 +429,37 @@ func displayMotd(ctx context.Context, config *motdConfig, verbose bool) error {
 		}
 	}
 
-	// Calculate spacing for display
-	maxKeyLen := 0
-	for _, result := range filteredResults {
-		if len(result.Key) > maxKeyLen {
-			maxKeyLen = len(result.Key)
-		}
-	}
-
-	// Add additional spacing (2 spaces)
-	spacing := maxKeyLen + 2
-
 	// Display results with consistently styled keys
+	continuation := strings.Repeat(" ", keyColumn.Indent())
 	for _, result := range filteredResults {
-		// Apply key style and add proper spacing
-		styledKey := motd.KeyStyle.Render(result.Key)
-		paddingLength := spacing - len(result.Key)
-		padding := strings.Repeat(" ", paddingLength)
-
 		// Split the value by line breaks to support multi-line values
 		lines := strings.Split(result.Value, "\n")
 
-		// Print the first line with the key
-		fmt.Printf("%s%s%s\n", styledKey, padding, lines[0])
-
-		// Print any remaining lines with consistent padding
-		if len(lines) > 1 {
-			for i := 1; i < len(lines); i++ {
-				padding := strings.Repeat(" ", spacing)
-				fmt.Printf("%s%s\n", padding, lines[i])
-			}
+		// Print the first line with the key, and any remaining lines aligned with it
+		output.WriteString(fmt.Sprintf("%s%s\n", keyColumn.Render(result.Key), lines[0]))
+		for _, line := range lines[1:] {
+			output.WriteString(fmt.Sprintf("%s%s\n", continuation, line))
 		}
 	}
 
-	fmt.Println()
+	// A single line keeps the MOTD quiet on healthy boxes
+	if config.onlyProblems && len(filteredResults) == 0 {
+		output.WriteString(motd.ValueStyle.Render("All systems nominal") + "\n")
+	}
+
+	output.WriteString("\n")
 
-	return nil
+	return output.String(), nil
+}
+
+// motdKeyColumn returns the key column for the given sources. Sizing it from the sources
+// rather than the results keeps values at the same column when a section ends up empty.
+func motdKeyColumn(sources []motd.InfoSource) motd.KeyColumn {
+	keys := make([]string, len(sources))
+	for i, source := range sources {
+		keys[i] = source.Key
+	}
+	return motd.NewKeyColumn(keys)
 }
 
 func init() {
@@ -367,6 +491,14 @@ func init() {
 	motdCmd.Flags().Bool("systemd", false, "Show systemd services status")
 	motdCmd.Flags().Bool("traefik", false, "Show Traefik router status information")
 	motdCmd.Flags().Bool("uptime", false, "Show uptime information")
+	motdCmd.Flags().Bool("network", false, "Show current network throughput of the primary interface")
+	motdCmd.Flags().Bool("history", false, "Show sparklines of recent load and memory usage")
+	motdCmd.Flags().Bool("mounts", false, "Show rclone mount health")
+	motdCmd.Flags().Bool("pools", false, "Show ZFS and Btrfs pool status")
+	motdCmd.Flags().Bool("smart", false, "Show disk SMART health (requires disk_health in motd.yml)")
+	motdCmd.Flags().Bool("temps", false, "Show CPU and drive temperatures")
+	motdCmd.Flags().Bool("pressure", false, "Show CPU, memory and IO pressure stall information")
+	motdCmd.Flags().Bool("only-problems", false, "Only show failed services, unhealthy containers, full disks, Traefik router errors and pending reboots, or a single line when all is well")
 
 	// Add verbosity flag
 	motdCmd.Flags().CountP("verbose", "v", "Increase verbosity level (can be used multiple times, e.g. -vvv)")
@@ -377,10 +509,27 @@ func init() {
 	// Add config generation flag
 	motdCmd.Flags().Bool("generate-config", false, "Print an example MOTD configuration file to stdout")
 
+	// Add watch mode options
+	motdCmd.Flags().Bool("watch", false, "Continuously refresh the MOTD in a full-screen view")
+	motdCmd.Flags().Int("interval", 10, "Refresh interval in seconds when using --watch")
+
+	// Add layout options
+	motdCmd.Flags().Int("width", 0, "Render for a fixed width instead of the terminal width (0 detects the terminal width)")
+
+	// Add update-motd.d drop-in options
+	motdCmd.Flags().Bool("install", false, "Install an update-motd.d script that shows the MOTD at login with the given flags")
+	motdCmd.Flags().Bool("uninstall", false, "Remove the update-motd.d script installed by --install")
+	motdCmd.Flags().Bool("remove-defaults", false, "Disable conflicting default update-motd.d scripts when used with --install")
+	motdCmd.MarkFlagsMutuallyExclusive("install", "uninstall")
+
 	// Add banner options
 	motdCmd.Flags().String("title", "Saltbox", "Text to display in the banner")
 	motdCmd.Flags().String("type", "peek", "Banner type for boxes (use 'none' to omit box)")
 	motdCmd.Flags().String("font", "ivrit", "Font for toilet cli")
 	motdCmd.Flags().String("banner-file", "", "Path to a file containing a custom banner to display")
 	motdCmd.Flags().String("banner-file-toilet", "", "A string of arguments for toilet when using --banner-file")
+
+	// Add hostname header options
+	motdCmd.Flags().Bool("header", false, "Show a header with the hostname above the system information")
+	motdCmd.Flags().String("header-text", "", "Custom text for the header (defaults to the hostname)")
 }
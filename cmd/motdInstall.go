@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/saltyorg/sb-go/internal/motd"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	// motdDropInDir is where pam_motd looks for scripts to build the login message
+	motdDropInDir = "/etc/update-motd.d"
+	// motdDropInName is the file name of the Saltbox MOTD drop-in script
+	motdDropInName = "10-saltbox"
+	// motdDisabledMarker prefixes the comment listing default scripts disabled on install
+	motdDisabledMarker = "# sb-disabled-scripts:"
+)
+
+// conflictingMotdScripts are default Ubuntu update-motd.d scripts that duplicate the Saltbox MOTD
+var conflictingMotdScripts = []string{
+	"00-header",
+	"10-help-text",
+	"50-landscape-sysinfo",
+	"50-motd-news",
+	"90-updates-available",
+}
+
+// motdInstallSkipFlags are flags that control the install itself and aren't passed to the drop-in
+var motdInstallSkipFlags = []string{
+	"install",
+	"uninstall",
+	"remove-defaults",
+	"watch",
+	"interval",
+	"verbose",
+	"generate-config",
+}
+
+// motdDropInArgs returns the motd flags set on the command line to be passed through to the drop-in.
+// Defaults to --all when no sections were selected.
+func motdDropInArgs(flags *pflag.FlagSet) []string {
+	var args []string
+	hasSection := false
+
+	flags.Visit(func(f *pflag.Flag) {
+		if slices.Contains(motdInstallSkipFlags, f.Name) {
+			return
+		}
+		if f.Name == "all" || slices.ContainsFunc(motd.DefaultSections, func(s motd.Section) bool { return s.Name == f.Name }) {
+			hasSection = true
+		}
+		if f.Value.Type() == "bool" {
+			if f.Value.String() == "true" {
+				args = append(args, "--"+f.Name)
+			}
+			return
+		}
+		args = append(args, fmt.Sprintf("--%s=%s", f.Name, shellQuote(f.Value.String())))
+	})
+
+	if !hasSection {
+		args = append([]string{"--all"}, args...)
+	}
+	return args
+}
+
+// installMotdDropIn writes the update-motd.d script and optionally disables conflicting default scripts
+func installMotdDropIn(args []string, removeDefaults bool) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to determine sb executable path: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+
+	if _, err := os.Stat(motdDropInDir); err != nil {
+		return fmt.Errorf("%s not found, is update-motd installed?: %w", motdDropInDir, err)
+	}
+
+	// Keep scripts disabled by a previous install so uninstall can restore them all
+	disabled := readDisabledMotdScripts(filepath.Join(motdDropInDir, motdDropInName))
+	if removeDefaults {
+		for _, name := range conflictingMotdScripts {
+			path := filepath.Join(motdDropInDir, name)
+			info, err := os.Stat(path)
+			if err != nil || info.Mode()&0111 == 0 {
+				continue
+			}
+			// Removing the executable bit disables the script without deleting it
+			if err := os.Chmod(path, info.Mode()&^0111); err != nil {
+				return fmt.Errorf("failed to disable %s: %w", path, err)
+			}
+			if !slices.Contains(disabled, name) {
+				disabled = append(disabled, name)
+			}
+			fmt.Printf("Disabled %s\n", path)
+		}
+	}
+
+	path := filepath.Join(motdDropInDir, motdDropInName)
+	if err := os.WriteFile(path, []byte(buildMotdDropInScript(exe, args, disabled)), 0755); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	// WriteFile doesn't change the mode of an existing file
+	if err := os.Chmod(path, 0755); err != nil {
+		return fmt.Errorf("failed to make %s executable: %w", path, err)
+	}
+
+	fmt.Printf("Installed MOTD drop-in at %s\n", path)
+	return nil
+}
+
+// uninstallMotdDropIn removes the update-motd.d script and re-enables any default scripts it disabled
+func uninstallMotdDropIn() error {
+	path := filepath.Join(motdDropInDir, motdDropInName)
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("MOTD drop-in is not installed (%s not found)", path)
+	}
+
+	for _, name := range readDisabledMotdScripts(path) {
+		scriptPath := filepath.Join(motdDropInDir, name)
+		info, err := os.Stat(scriptPath)
+		if err != nil {
+			continue
+		}
+		if err := os.Chmod(scriptPath, info.Mode()|0111); err != nil {
+			return fmt.Errorf("failed to re-enable %s: %w", scriptPath, err)
+		}
+		fmt.Printf("Re-enabled %s\n", scriptPath)
+	}
+
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+
+	fmt.Printf("Removed MOTD drop-in %s\n", path)
+	return nil
+}
+
+// buildMotdDropInScript returns the contents of the update-motd.d script
+func buildMotdDropInScript(exe string, args []string, disabled []string) string {
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n")
+	b.WriteString("# Saltbox MOTD, generated by \"sb motd --install\".\n")
+	b.WriteString("# Remove with \"sb motd --uninstall\" instead of deleting this file.\n")
+	if len(disabled) > 0 {
+		b.WriteString(fmt.Sprintf("%s %s\n", motdDisabledMarker, strings.Join(disabled, " ")))
+	}
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("[ -x %s ] || exit 0\n\n", shellQuote(exe)))
+	b.WriteString("# Disable color when the output isn't a terminal\n")
+	b.WriteString("[ -t 1 ] || export NO_COLOR=1\n\n")
+	b.WriteString(fmt.Sprintf("exec %s motd", shellQuote(exe)))
+	for _, arg := range args {
+		b.WriteString(" " + arg)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// readDisabledMotdScripts returns the default scripts recorded as disabled in an installed drop-in
+func readDisabledMotdScripts(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	for line := range strings.Lines(string(data)) {
+		if names, ok := strings.CutPrefix(strings.TrimSpace(line), motdDisabledMarker); ok {
+			return strings.Fields(names)
+		}
+	}
+	return nil
+}
+
+// shellQuote quotes s for safe use in a POSIX shell script
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
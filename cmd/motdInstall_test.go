@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestMotdDropInArgs(t *testing.T) {
+	flags := pflag.NewFlagSet("motd", pflag.ContinueOnError)
+	flags.Bool("install", false, "")
+	flags.Bool("docker", false, "")
+	flags.Bool("disk", false, "")
+	flags.String("title", "Saltbox", "")
+
+	if err := flags.Parse([]string{"--install", "--docker", "--title", "My Box"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := motdDropInArgs(flags), []string{"--docker", "--title='My Box'"}; !slices.Equal(got, want) {
+		t.Fatalf("motdDropInArgs() = %v, want %v", got, want)
+	}
+
+	flags = pflag.NewFlagSet("motd", pflag.ContinueOnError)
+	flags.Bool("install", false, "")
+	if err := flags.Parse([]string{"--install"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := motdDropInArgs(flags), []string{"--all"}; !slices.Equal(got, want) {
+		t.Fatalf("motdDropInArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestBuildMotdDropInScript(t *testing.T) {
+	script := buildMotdDropInScript("/usr/local/bin/sb", []string{"--all"}, []string{"00-header", "50-motd-news"})
+
+	if !strings.HasPrefix(script, "#!/bin/sh\n") {
+		t.Fatalf("expected shebang, got %q", script)
+	}
+	if !strings.Contains(script, "exec '/usr/local/bin/sb' motd --all\n") {
+		t.Fatalf("expected sb motd invocation, got %q", script)
+	}
+
+	path := filepath.Join(t.TempDir(), motdDropInName)
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got := readDisabledMotdScripts(path); !slices.Equal(got, []string{"00-header", "50-motd-news"}) {
+		t.Fatalf("readDisabledMotdScripts() = %v", got)
+	}
+}
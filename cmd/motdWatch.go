@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/motd"
+	"github.com/saltyorg/sb-go/internal/signals"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// motdWatchModel renders the MOTD full-screen and refreshes it on an interval
+type motdWatchModel struct {
+	ctx         context.Context
+	config      *motdConfig
+	sources     []motd.InfoSource
+	interval    time.Duration
+	content     string
+	err         error
+	lastUpdated time.Time
+	refreshing  bool
+	generation  int // Incremented for every refresh so stale ticks can be ignored
+	width       int
+	height      int
+}
+
+// motdRenderedMsg carries the result of a MOTD refresh
+type motdRenderedMsg struct {
+	content string
+	err     error
+}
+
+// motdTickMsg is sent when the refresh interval has elapsed
+type motdTickMsg struct {
+	generation int
+}
+
+// runMotdWatch displays the MOTD in a full-screen view that refreshes every interval
+func runMotdWatch(ctx context.Context, config *motdConfig, sources []motd.InfoSource) error {
+	m := motdWatchModel{
+		ctx:        ctx,
+		config:     config,
+		sources:    sources,
+		interval:   time.Duration(config.watchInterval) * time.Second,
+		refreshing: true,
+	}
+
+	p := tea.NewProgram(m, tea.WithContext(ctx))
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("error running MOTD watch mode: %w", err)
+	}
+
+	return nil
+}
+
+// refresh collects the MOTD in the background.
+// Verbose output is disabled since debug lines would corrupt the full-screen view.
+func (m motdWatchModel) refresh() tea.Cmd {
+	return func() tea.Msg {
+		content, err := renderMotd(m.ctx, m.config, m.sources, false)
+		return motdRenderedMsg{content: content, err: err}
+	}
+}
+
+// tick schedules the next refresh for the current generation
+func (m motdWatchModel) tick() tea.Cmd {
+	generation := m.generation
+	return tea.Tick(m.interval, func(time.Time) tea.Msg {
+		return motdTickMsg{generation: generation}
+	})
+}
+
+func (m motdWatchModel) Init() tea.Cmd {
+	return m.refresh()
+}
+
+func (m motdWatchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			signals.GetGlobalManager().Shutdown(130)
+			return m, tea.Quit
+		case "q", "esc":
+			return m, tea.Quit
+		case "r":
+			if !m.refreshing {
+				m.refreshing = true
+				return m, m.refresh()
+			}
+		}
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		// Re-render so width-dependent parts (e.g., the header) fit the new size
+		if !m.refreshing && !m.lastUpdated.IsZero() {
+			m.refreshing = true
+			return m, m.refresh()
+		}
+
+	case motdRenderedMsg:
+		m.content = msg.content
+		m.err = msg.err
+		m.lastUpdated = time.Now()
+		m.refreshing = false
+		m.generation++
+		return m, m.tick()
+
+	case motdTickMsg:
+		// Ignore ticks scheduled before a manual or resize-triggered refresh
+		if msg.generation != m.generation || m.refreshing {
+			return m, nil
+		}
+		m.refreshing = true
+		return m, m.refresh()
+	}
+
+	return m, nil
+}
+
+func (m motdWatchModel) View() tea.View {
+	var body string
+	switch {
+	case m.err != nil:
+		body = motd.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err))
+	case m.content == "":
+		body = motd.DefaultStyle.Render("Collecting system information...")
+	default:
+		body = strings.TrimRight(m.content, "\n")
+	}
+
+	status := fmt.Sprintf("Refreshing every %s • r: refresh now • q: quit", m.interval)
+	if !m.lastUpdated.IsZero() {
+		status = fmt.Sprintf("Updated %s • %s", m.lastUpdated.Format("15:04:05"), status)
+	}
+	footer := motd.DefaultStyle.Render(status)
+
+	lines := strings.Split(body, "\n")
+	if m.height > 0 {
+		// Leave room for the blank line and the status footer
+		maxLines := max(m.height-2, 1)
+		if len(lines) > maxLines {
+			lines = lines[:maxLines]
+		}
+	}
+	if m.width > 0 {
+		for i, line := range lines {
+			lines[i] = ansi.Truncate(line, m.width, "")
+		}
+		footer = ansi.Truncate(footer, m.width, "…")
+	}
+
+	v := tea.NewView(strings.Join(lines, "\n") + "\n\n" + footer)
+	v.AltScreen = true
+	return v
+}
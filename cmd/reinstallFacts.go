@@ -19,7 +19,7 @@ var reinstallFactsCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		verbose, _ := cmd.Flags().GetBool("verbose")
 
-		runner := spinners.NewRunner(spinners.RunnerOptions{Verbose: verbose})
+		runner := spinners.NewRunner(runnerOptions(verbose))
 		return runner.Run(cmd.Context(), spinners.TaskSpec{
 			Running: "Reinstalling saltbox.fact",
 		}, func(ctx context.Context, task *spinners.Task) error {
@@ -35,7 +35,7 @@ func init() {
 }
 
 func handleReinstallPython(ctx context.Context, verbose bool) error {
-	runner := spinners.NewRunner(spinners.RunnerOptions{Verbose: verbose})
+	runner := spinners.NewRunner(runnerOptions(verbose))
 	return runner.Run(ctx, reinstallPythonTaskSpec(), func(ctx context.Context, task *spinners.Task) error {
 		return reinstallPython(ctx, task, verbose)
 	})
@@ -31,7 +31,7 @@ func init() {
 
 // handleReinstallVenv handles the reinstallation of the Ansible virtual environment.
 func handleReinstallVenv(ctx context.Context, verbose bool) error {
-	runner := spinners.NewRunner(spinners.RunnerOptions{Verbose: verbose})
+	runner := spinners.NewRunner(runnerOptions(verbose))
 
 	// Get Saltbox user
 	saltboxUser, err := utils.GetSaltboxUser()
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/utils"
+)
+
+// reportResourceUsage is set by the global --resource-usage flag
+var reportResourceUsage bool
+
+// processUsage is the memory and CPU time used by the sb process so far
+type processUsage struct {
+	peakRSS    uint64        // Peak resident set size in bytes
+	userCPU    time.Duration // CPU time spent in user mode
+	systemCPU  time.Duration // CPU time spent in the kernel
+	heapInUse  uint64        // Bytes of the Go heap in use now
+	totalAlloc uint64        // Bytes allocated on the Go heap over the lifetime of the process
+	numGC      uint32        // Completed garbage collections
+}
+
+// readProcessUsage reads the usage of the sb process from getrusage and the Go runtime
+func readProcessUsage() (processUsage, error) {
+	var rusage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &rusage); err != nil {
+		return processUsage{}, fmt.Errorf("failed to read resource usage: %w", err)
+	}
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	return processUsage{
+		peakRSS:    uint64(rusage.Maxrss) * 1024, // Reported in KiB on Linux
+		userCPU:    time.Duration(rusage.Utime.Nano()),
+		systemCPU:  time.Duration(rusage.Stime.Nano()),
+		heapInUse:  memStats.HeapInuse,
+		totalAlloc: memStats.TotalAlloc,
+		numGC:      memStats.NumGC,
+	}, nil
+}
+
+// String formats the usage as a single line
+func (u processUsage) String() string {
+	return fmt.Sprintf("Resource usage: peak RSS %s, CPU %s user + %s system, heap %s in use (%s allocated, %d GCs)",
+		utils.FormatBytes(u.peakRSS),
+		u.userCPU.Round(time.Millisecond), u.systemCPU.Round(time.Millisecond),
+		utils.FormatBytes(u.heapInUse), utils.FormatBytes(u.totalAlloc), u.numGC)
+}
+
+// ReportResourceUsage writes the peak memory and CPU time of the sb process to w when
+// --resource-usage was given. It is called by main once the command has finished, whether
+// or not it failed, to check that long sessions like the log viewers stay bounded.
+func ReportResourceUsage(w io.Writer) {
+	if !reportResourceUsage {
+		return
+	}
+	usage, err := readProcessUsage()
+	if err != nil {
+		_, _ = fmt.Fprintln(w, err)
+		return
+	}
+	_, _ = fmt.Fprintln(w, usage)
+}
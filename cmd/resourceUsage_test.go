@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProcessUsageString(t *testing.T) {
+	usage := processUsage{
+		peakRSS:    48 * 1024 * 1024,
+		userCPU:    1234567 * time.Microsecond,
+		systemCPU:  250 * time.Millisecond,
+		heapInUse:  12 * 1024 * 1024,
+		totalAlloc: 3 * 1024 * 1024 * 1024,
+		numGC:      14,
+	}
+	want := "Resource usage: peak RSS 48.0 MiB, CPU 1.235s user + 250ms system, heap 12.0 MiB in use (3.0 GiB allocated, 14 GCs)"
+	if got := usage.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestReportResourceUsage(t *testing.T) {
+	t.Cleanup(func() { reportResourceUsage = false })
+
+	var out bytes.Buffer
+	ReportResourceUsage(&out)
+	if out.Len() != 0 {
+		t.Fatalf("reported usage without --resource-usage: %q", out.String())
+	}
+
+	reportResourceUsage = true
+	ReportResourceUsage(&out)
+	if !strings.HasPrefix(out.String(), "Resource usage: peak RSS ") || strings.Contains(out.String(), "peak RSS 0 B") {
+		t.Errorf("output = %q, want the usage of the test process", out.String())
+	}
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/saltyorg/sb-go/internal/constants"
 	"github.com/saltyorg/sb-go/internal/signals"
+	"github.com/saltyorg/sb-go/internal/utils"
 
 	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
@@ -371,6 +372,7 @@ func validateAndRestore(ctx context.Context, user, password, restoreURL, dir, fo
 
 	fmt.Printf("Fetching files from %s...\n", restoreURL)
 	successfulDownloads := 0
+	var ownershipErr error
 	for _, file := range files {
 		fmt.Printf("%-20.20s", file)
 		url := fmt.Sprintf("%s/load/%s/%s", restoreURL, userHash, file)
@@ -407,9 +409,17 @@ func validateAndRestore(ctx context.Context, user, password, restoreURL, dir, fo
 			continue
 		}
 
+		// The Saltbox directory belongs to the user, so restored files shouldn't end up root-owned
+		if err := utils.ChownToInvokingUser(filepath.Join(dir, file)); err != nil {
+			ownershipErr = err
+		}
+
 		fmt.Println(" [DONE]")
 		successfulDownloads++
 	}
+	if ownershipErr != nil {
+		fmt.Printf("Warning: %v\n", ownershipErr)
+	}
 	return successfulDownloads, nil
 }
 
@@ -2,8 +2,12 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/saltyorg/sb-go/internal/config"
 	"github.com/saltyorg/sb-go/internal/errors"
+	"github.com/saltyorg/sb-go/internal/spinners"
+	"github.com/saltyorg/sb-go/internal/ubuntu"
 
 	"github.com/spf13/cobra"
 )
@@ -16,6 +20,35 @@ var rootCmd = &cobra.Command{
 	CompletionOptions: cobra.CompletionOptions{
 		DisableDefaultCmd: true, // removes cmd - we use custom completion installation
 	},
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if verbose := cmd.Flags().Lookup("verbose"); quietOutput && verbose != nil && verbose.Changed {
+			return fmt.Errorf("--quiet and --verbose can't be used together")
+		}
+		// Overrides passed on by the sudo relaunch, as sudo resets the environment
+		envOverrides, _ := cmd.Flags().GetStringArray("env")
+		for _, assignment := range envOverrides {
+			if err := config.SetEnvOverride(assignment); err != nil {
+				return err
+			}
+		}
+		configPath, _ := cmd.Flags().GetString("config")
+		cfg, err := config.LoadSBConfig(configPath)
+		if err != nil {
+			return err
+		}
+		config.SetSB(cfg)
+		return nil
+	},
+}
+
+// quietOutput is set by the global --quiet flag
+var quietOutput bool
+
+// runnerOptions returns the progress options for a command, combining its
+// --verbose value with the global --quiet flag. The two are never both set,
+// as the root command rejects --quiet together with --verbose.
+func runnerOptions(verbose bool) spinners.RunnerOptions {
+	return spinners.RunnerOptions{Verbose: verbose, Quiet: quietOutput}
 }
 
 // GetRootCommand returns the root command for use with fang.Execute
@@ -33,6 +66,18 @@ func ExecuteContext(ctx context.Context) error {
 
 func init() {
 	rootCmd.SetHelpCommand(&cobra.Command{Hidden: true}) // -h/--help flags are sufficient
+	// Evaluated by main before command parsing; registered so it's accepted and shown in help
+	rootCmd.PersistentFlags().Bool(ubuntu.AllowUnsupportedFlag, false,
+		"Continue on unsupported Ubuntu releases with a warning (also "+ubuntu.AllowUnsupportedEnv+"=1)")
+	rootCmd.PersistentFlags().BoolVarP(&quietOutput, "quiet", "q", false,
+		"Only print errors, warnings and the final status of tasks")
+	rootCmd.PersistentFlags().String("config", "",
+		"Path of the sb config file (default "+config.DefaultSBConfigPath+", also "+config.SBConfigEnv+")")
+	rootCmd.PersistentFlags().StringArray("env", nil,
+		"Set an sb config override as NAME=value, like the environment variable of that name")
+	_ = rootCmd.PersistentFlags().MarkHidden("env")
+	rootCmd.PersistentFlags().BoolVar(&reportResourceUsage, "resource-usage", false,
+		"Print the peak memory and CPU time of sb to stderr when the command finishes")
 }
 
 // handleInterruptError checks if the error is from a user interrupt and triggers shutdown.
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/executor"
+	"github.com/saltyorg/sb-go/internal/spinners"
+
+	"github.com/spf13/cobra"
+)
+
+// selfTestScript writes a known line to stdout and stderr
+const selfTestScript = "echo out; echo err >&2"
+
+// selfTestTimeout bounds each executor check so a broken shell cannot hang the self-test
+const selfTestTimeout = 10 * time.Second
+
+// selfTestTools lists the external tools sb depends on
+var selfTestTools = []string{"git", "docker", "systemctl", "ansible-playbook", "rclone"}
+
+// selfTestCase runs a harmless command through the executor and reports any discrepancy
+// between the result and the expected output
+type selfTestCase struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// selfTestCases exercises every non-interactive output mode, a failing exit code and a missing command
+var selfTestCases = []selfTestCase{
+	{name: "combined output mode", run: checkCombinedMode},
+	{name: "capture output mode", run: checkCaptureMode},
+	{name: "stream output mode", run: checkStreamMode},
+	{name: "discard output mode", run: checkDiscardMode},
+	{name: "non-zero exit code", run: checkExitCode},
+	{name: "missing command", run: checkMissingCommand},
+}
+
+// selfTestCmd represents the self-test command
+var selfTestCmd = &cobra.Command{
+	Use:   "self-test",
+	Short: "Check that sb can run commands in this environment",
+	Long: `Runs a few harmless commands through sb's command executor in each output
+mode and checks their exit codes and captured output, then looks up the
+tools sb depends on (git, docker, systemctl, ansible-playbook and rclone)
+in PATH and reports any that are missing.
+
+Exits with an error when an executor check fails. Missing tools are only
+reported, as some are not installed until Saltbox itself is.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		return handleSelfTest(cmd.Context(), verbose)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(selfTestCmd)
+	selfTestCmd.Flags().BoolP("verbose", "v", false, "Enable verbose output")
+}
+
+func handleSelfTest(ctx context.Context, verbose bool) error {
+	runner := spinners.NewRunner(runnerOptions(verbose))
+	return runner.Run(ctx, spinners.TaskSpec{
+		Running:      "Running self-test",
+		Success:      "Self-test passed",
+		Failure:      "Self-test",
+		ChildDisplay: spinners.RetainChildTasks,
+	}, func(ctx context.Context, task *spinners.Task) error {
+		var failed int
+		for _, tc := range selfTestCases {
+			if err := task.Run(ctx, spinners.TaskSpec{
+				Running: "Checking " + tc.name,
+				Success: "Checked " + tc.name,
+				Failure: "Checking " + tc.name,
+			}, func(ctx context.Context, _ *spinners.Task) error {
+				checkCtx, cancel := context.WithTimeout(ctx, selfTestTimeout)
+				defer cancel()
+				return tc.run(checkCtx)
+			}); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				failed++
+			}
+		}
+
+		found, missing := lookupTools(selfTestTools, exec.LookPath)
+		for _, tool := range found {
+			task.Info(fmt.Sprintf("Found %s at %s", tool.name, tool.path))
+		}
+		if len(missing) > 0 {
+			task.Warning("Missing from PATH: " + strings.Join(missing, ", "))
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d executor checks failed", failed, len(selfTestCases))
+		}
+		return nil
+	})
+}
+
+// toolPath is a tool found in PATH
+type toolPath struct {
+	name string
+	path string
+}
+
+// lookupTools resolves each tool with lookPath, returning the found tools and the names of the missing ones
+func lookupTools(tools []string, lookPath func(string) (string, error)) ([]toolPath, []string) {
+	var found []toolPath
+	var missing []string
+	for _, tool := range tools {
+		path, err := lookPath(tool)
+		if err != nil {
+			missing = append(missing, tool)
+			continue
+		}
+		found = append(found, toolPath{name: tool, path: path})
+	}
+	return found, missing
+}
+
+// runSelfTestScript runs selfTestScript through the shell and fails on a non-zero exit
+func runSelfTestScript(ctx context.Context, options ...executor.Option) (*executor.Result, error) {
+	options = append([]executor.Option{executor.WithArgs("-c", selfTestScript)}, options...)
+	result, err := executor.Run(ctx, "sh", options...)
+	if err != nil {
+		return nil, fmt.Errorf("expected exit code 0: %w", err)
+	}
+	return result, nil
+}
+
+// expectOutput compares captured output with the expected value
+func expectOutput(stream string, got []byte, want string) error {
+	if string(got) != want {
+		return fmt.Errorf("expected %s %q, got %q", stream, want, got)
+	}
+	return nil
+}
+
+func checkCombinedMode(ctx context.Context) error {
+	result, err := runSelfTestScript(ctx, executor.WithOutputMode(executor.OutputModeCombined))
+	if err != nil {
+		return err
+	}
+	// The order of the interleaved streams is not guaranteed
+	combined := string(result.Combined)
+	if !strings.Contains(combined, "out\n") || !strings.Contains(combined, "err\n") {
+		return fmt.Errorf("expected combined output to hold both streams, got %q", combined)
+	}
+	return nil
+}
+
+func checkCaptureMode(ctx context.Context) error {
+	result, err := runSelfTestScript(ctx, executor.WithOutputMode(executor.OutputModeCapture))
+	if err != nil {
+		return err
+	}
+	return errors.Join(
+		expectOutput("stdout", result.Stdout, "out\n"),
+		expectOutput("stderr", result.Stderr, "err\n"),
+	)
+}
+
+func checkStreamMode(ctx context.Context) error {
+	var stdout, stderr bytes.Buffer
+	result, err := runSelfTestScript(ctx,
+		executor.WithOutputMode(executor.OutputModeStream),
+		executor.WithStdout(&stdout),
+		executor.WithStderr(&stderr),
+	)
+	if err != nil {
+		return err
+	}
+	return errors.Join(
+		expectOutput("streamed stdout", stdout.Bytes(), "out\n"),
+		expectOutput("streamed stderr", stderr.Bytes(), "err\n"),
+		expectOutput("captured stdout", result.Stdout, "out\n"),
+	)
+}
+
+func checkDiscardMode(ctx context.Context) error {
+	result, err := runSelfTestScript(ctx, executor.WithOutputMode(executor.OutputModeDiscard))
+	if err != nil {
+		return err
+	}
+	return errors.Join(
+		expectOutput("stdout", result.Stdout, ""),
+		expectOutput("stderr", result.Stderr, "err\n"),
+	)
+}
+
+func checkExitCode(ctx context.Context) error {
+	result, err := executor.Run(ctx, "sh",
+		executor.WithArgs("-c", "exit 3"),
+		executor.WithOutputMode(executor.OutputModeCapture),
+	)
+	cmdErr, ok := errors.AsType[*executor.CommandError](err)
+	if !ok {
+		return fmt.Errorf("expected a command error for exit code 3, got %v", err)
+	}
+	if cmdErr.ExitCode != 3 || result.ExitCode != 3 {
+		return fmt.Errorf("expected exit code 3, got %d", result.ExitCode)
+	}
+	return nil
+}
+
+func checkMissingCommand(ctx context.Context) error {
+	result, err := executor.Run(ctx, "sb-self-test-missing-command",
+		executor.WithOutputMode(executor.OutputModeCapture),
+	)
+	if err == nil {
+		return fmt.Errorf("expected an error for a missing command")
+	}
+	if result.ExitCode != -1 {
+		return fmt.Errorf("expected exit code -1 for a missing command, got %d", result.ExitCode)
+	}
+	return nil
+}
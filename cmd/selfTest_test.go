@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSelfTestCases(t *testing.T) {
+	for _, tc := range selfTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.run(context.Background()); err != nil {
+				t.Errorf("unexpected discrepancy: %v", err)
+			}
+		})
+	}
+}
+
+func TestLookupTools(t *testing.T) {
+	lookPath := func(name string) (string, error) {
+		if name == "git" {
+			return "/usr/bin/git", nil
+		}
+		return "", errors.New("not found")
+	}
+
+	found, missing := lookupTools([]string{"git", "rclone", "docker"}, lookPath)
+	if len(found) != 1 || found[0] != (toolPath{name: "git", path: "/usr/bin/git"}) {
+		t.Errorf("unexpected found tools: %v", found)
+	}
+	if !slices.Equal(missing, []string{"rclone", "docker"}) {
+		t.Errorf("missing = %v, want [rclone docker]", missing)
+	}
+}
+
+func TestExpectOutput(t *testing.T) {
+	if err := expectOutput("stdout", []byte("out\n"), "out\n"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := expectOutput("stdout", []byte("other"), "out\n"); err == nil {
+		t.Error("expected an error for mismatched output")
+	}
+}
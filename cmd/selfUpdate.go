@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -17,6 +16,7 @@ import (
 	"github.com/saltyorg/sb-go/internal/releaseproxy"
 	"github.com/saltyorg/sb-go/internal/runtime"
 	"github.com/saltyorg/sb-go/internal/spinners"
+	"github.com/saltyorg/sb-go/internal/tty"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/creativeprojects/go-selfupdate"
@@ -40,7 +40,7 @@ var selfUpdateCmd = &cobra.Command{
 	Long:   `Update Saltbox CLI`,
 	Args:   cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		runner := spinners.NewRunner(spinners.RunnerOptions{Verbose: debug})
+		runner := spinners.NewRunner(runnerOptions(debug))
 		// Check if self-update is disabled at build time (unless the force flag is used)
 		if runtime.DisableSelfUpdate == "true" && !forceUpdate {
 			runner.Warning("Self-update is disabled in this build")
@@ -65,20 +65,6 @@ func init() {
 	}
 }
 
-// promptForConfirmation asks the user for confirmation (y/n)
-func promptForConfirmation(prompt string) (bool, error) {
-	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("%s [y/n]: ", prompt)
-
-	response, err := reader.ReadString('\n')
-	if err != nil {
-		return false, fmt.Errorf("error reading input: %w", err)
-	}
-
-	response = strings.ToLower(strings.TrimSpace(response))
-	return response == "y" || response == "yes", nil
-}
-
 func doSelfUpdate(ctx context.Context, runner *spinners.Runner, autoUpdate bool, verbose bool, optionalMessage string, force bool) (bool, error) {
 	// Check if self-update is disabled at build time (unless force is true)
 	if runtime.DisableSelfUpdate == "true" && !force {
@@ -159,7 +145,7 @@ func doSelfUpdate(ctx context.Context, runner *spinners.Runner, autoUpdate bool,
 
 	// If autoUpdate is false, ask for confirmation
 	if !autoUpdate {
-		confirmed, err := promptForConfirmation("Do you want to update")
+		confirmed, err := tty.Confirm("Do you want to update", false)
 		if err != nil {
 			return false, err
 		}
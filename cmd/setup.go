@@ -1,16 +1,17 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/saltyorg/sb-go/internal/constants"
 	"github.com/saltyorg/sb-go/internal/git"
 	"github.com/saltyorg/sb-go/internal/setup"
 	"github.com/saltyorg/sb-go/internal/spinners"
+	"github.com/saltyorg/sb-go/internal/tty"
 	"github.com/saltyorg/sb-go/internal/utils"
 
 	"github.com/spf13/cobra"
@@ -27,22 +28,24 @@ var setupCmd = &cobra.Command{
 		ctx := cmd.Context()
 		verbose, _ := cmd.Flags().GetBool("verbose")
 		branch, _ := cmd.Flags().GetString("branch")
-		runner := spinners.NewRunner(spinners.RunnerOptions{Verbose: verbose})
+		assumeYes, _ := cmd.Flags().GetBool("yes")
+		skipValues, _ := cmd.Flags().GetStringSlice("skip")
+		skip, err := parseSetupSkip(skipValues)
+		if err != nil {
+			return err
+		}
+		runner := spinners.NewRunner(runnerOptions(verbose))
 
 		// Check if Saltbox installation was already installed and prompt for confirmation.
 		if info, err := os.Stat(constants.SaltboxRepoPath); err == nil {
 			if !info.IsDir() {
 				return fmt.Errorf("%s exists but is not a directory", constants.SaltboxRepoPath)
 			}
-			reader := bufio.NewReader(os.Stdin)
-			fmt.Printf("\n%s folder already exists. Continuing may reset your installation. Are you sure you want to continue? (yes/no): ", constants.SaltboxRepoPath)
-			response, err := reader.ReadString('\n')
+			confirmed, err := tty.Confirm(fmt.Sprintf("\n%s folder already exists. Continuing may reset your installation. Are you sure you want to continue?", constants.SaltboxRepoPath), assumeYes)
 			if err != nil {
 				return fmt.Errorf("read setup confirmation: %w", err)
 			}
-			response = strings.TrimSpace(strings.ToLower(response))
-
-			if response != "yes" && response != "y" {
+			if !confirmed {
 				fmt.Println("Setup aborted by user.")
 				return nil
 			}
@@ -51,13 +54,16 @@ var setupCmd = &cobra.Command{
 		}
 
 		selectedBranch := branch
-		if _, err := os.Stat(constants.SaltboxRepoPath + "/.git"); err == nil {
-			selectedBranch, err = git.ResolveUpdateBranch(ctx, runner, constants.SaltboxRepoPath, branch, nil, "Saltbox")
-			if err != nil {
-				return err
+		// A skipped repository is left as is, so there's no branch to resolve
+		if !skip["repo"] {
+			if _, err := os.Stat(constants.SaltboxRepoPath + "/.git"); err == nil {
+				selectedBranch, err = git.ResolveUpdateBranch(ctx, runner, constants.SaltboxRepoPath, branch, nil, "Saltbox")
+				if err != nil {
+					return err
+				}
+			} else if !os.IsNotExist(err) {
+				return fmt.Errorf("inspect existing Saltbox Git repository: %w", err)
 			}
-		} else if !os.IsNotExist(err) {
-			return fmt.Errorf("inspect existing Saltbox Git repository: %w", err)
 		}
 
 		return runner.Run(ctx, spinners.TaskSpec{
@@ -65,12 +71,12 @@ var setupCmd = &cobra.Command{
 			Success: "Saltbox installation completed",
 			Failure: "Saltbox installation",
 		}, func(ctx context.Context, task *spinners.Task) error {
-			return runSetup(ctx, task, verbose, selectedBranch)
+			return runSetup(ctx, task, verbose, selectedBranch, skip)
 		})
 	},
 }
 
-func runSetup(ctx context.Context, task *spinners.Task, verbose bool, branch string) error {
+func runSetup(ctx context.Context, task *spinners.Task, verbose bool, branch string, skip map[string]bool) error {
 	if err := runSetupPhase(ctx, task, "Checking system compatibility", func(ctx context.Context, phase *spinners.Task) error {
 		if err := phase.Run(ctx, spinners.TaskSpec{Running: "Checking Ubuntu version"}, func(context.Context, *spinners.Task) error {
 			return utils.CheckUbuntuSupport()
@@ -100,7 +106,9 @@ func runSetup(ctx context.Context, task *spinners.Task, verbose bool, branch str
 		return err
 	}
 
-	if err := runSetupPhase(ctx, task, "Installing system prerequisites", func(ctx context.Context, phase *spinners.Task) error {
+	if skip["initial"] {
+		task.Info("Skipping system prerequisites (--skip initial)")
+	} else if err := runSetupPhase(ctx, task, "Installing system prerequisites", func(ctx context.Context, phase *spinners.Task) error {
 		if err := setup.InitialSetup(ctx, phase, verbose); err != nil {
 			return fmt.Errorf("error during initial setup: %w", err)
 		}
@@ -109,7 +117,9 @@ func runSetup(ctx context.Context, task *spinners.Task, verbose bool, branch str
 		return err
 	}
 
-	if err := runSetupPhase(ctx, task, "Configuring system locale", func(ctx context.Context, phase *spinners.Task) error {
+	if skip["locale"] {
+		task.Info("Skipping system locale (--skip locale)")
+	} else if err := runSetupPhase(ctx, task, "Configuring system locale", func(ctx context.Context, phase *spinners.Task) error {
 		if err := setup.ConfigureLocale(ctx, phase); err != nil {
 			return fmt.Errorf("error configuring locale: %w", err)
 		}
@@ -118,7 +128,9 @@ func runSetup(ctx context.Context, task *spinners.Task, verbose bool, branch str
 		return err
 	}
 
-	if err := runSetupPhase(ctx, task, "Installing Python runtime", func(ctx context.Context, phase *spinners.Task) error {
+	if skip["venv"] {
+		task.Info("Skipping Python runtime (--skip venv)")
+	} else if err := runSetupPhase(ctx, task, "Installing Python runtime", func(ctx context.Context, phase *spinners.Task) error {
 		if err := setup.PythonVenv(ctx, phase, verbose); err != nil {
 			return fmt.Errorf("error setting up Python venv: %w", err)
 		}
@@ -127,7 +139,9 @@ func runSetup(ctx context.Context, task *spinners.Task, verbose bool, branch str
 		return err
 	}
 
-	if err := runSetupPhase(ctx, task, "Preparing Saltbox repository", func(ctx context.Context, phase *spinners.Task) error {
+	if skip["repo"] {
+		task.Info("Skipping Saltbox repository (--skip repo)")
+	} else if err := runSetupPhase(ctx, task, "Preparing Saltbox repository", func(ctx context.Context, phase *spinners.Task) error {
 		if err := setup.SaltboxRepo(ctx, phase, verbose, branch); err != nil {
 			return fmt.Errorf("error setting up Saltbox repository: %w", err)
 		}
@@ -139,11 +153,17 @@ func runSetup(ctx context.Context, task *spinners.Task, verbose bool, branch str
 		return err
 	}
 
-	if err := runSetupPhase(ctx, task, "Installing Ansible dependencies", func(ctx context.Context, phase *spinners.Task) error {
-		if err := setup.InstallPipDependencies(ctx, phase, verbose); err != nil {
+	if skip["pip"] && skip["binaries"] {
+		task.Info("Skipping Ansible dependencies (--skip pip,binaries)")
+	} else if err := runSetupPhase(ctx, task, "Installing Ansible dependencies", func(ctx context.Context, phase *spinners.Task) error {
+		if skip["pip"] {
+			phase.Info("Skipping pip dependencies (--skip pip)")
+		} else if err := setup.InstallPipDependencies(ctx, phase, verbose); err != nil {
 			return fmt.Errorf("error installing pip dependencies: %w", err)
 		}
-		if err := setup.CopyRequiredBinaries(ctx, phase); err != nil {
+		if skip["binaries"] {
+			phase.Info("Skipping binaries (--skip binaries)")
+		} else if err := setup.CopyRequiredBinaries(ctx, phase); err != nil {
 			return fmt.Errorf("error copying binaries: %w", err)
 		}
 		return nil
@@ -153,6 +173,25 @@ func runSetup(ctx context.Context, task *spinners.Task, verbose bool, branch str
 	return nil
 }
 
+// setupPhases lists the setup phases that can be skipped with --skip, in the order they run
+var setupPhases = []string{"initial", "locale", "venv", "repo", "pip", "binaries"}
+
+// parseSetupSkip validates the phase names passed to --skip and returns them as a set
+func parseSetupSkip(values []string) (map[string]bool, error) {
+	skip := make(map[string]bool, len(values))
+	for _, value := range values {
+		phase := strings.ToLower(strings.TrimSpace(value))
+		if phase == "" {
+			continue
+		}
+		if !slices.Contains(setupPhases, phase) {
+			return nil, fmt.Errorf("unknown setup phase %q in --skip (valid phases: %s)", value, strings.Join(setupPhases, ", "))
+		}
+		skip[phase] = true
+	}
+	return skip, nil
+}
+
 func runSetupPhase(
 	ctx context.Context,
 	parent *spinners.Task,
@@ -170,4 +209,6 @@ func init() {
 	rootCmd.AddCommand(setupCmd)
 	setupCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
 	setupCmd.PersistentFlags().StringP("branch", "b", "master", "Branch to use for Saltbox repository")
+	setupCmd.PersistentFlags().BoolP("yes", "y", false, "Continue without confirmation if Saltbox is already installed")
+	setupCmd.PersistentFlags().StringSlice("skip", []string{}, "Setup phases to skip when re-running a partial setup ("+strings.Join(setupPhases, ", ")+")")
 }
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestParseSetupSkip(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[string]bool
+		wantErr bool
+	}{
+		{name: "none", values: nil, want: map[string]bool{}},
+		{name: "phases", values: []string{"locale", "venv"}, want: map[string]bool{"locale": true, "venv": true}},
+		{name: "case and spaces", values: []string{" Repo ", "PIP"}, want: map[string]bool{"repo": true, "pip": true}},
+		{name: "empty entries", values: []string{"", "binaries"}, want: map[string]bool{"binaries": true}},
+		{name: "unknown phase", values: []string{"locale", "docker"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSetupSkip(tt.values)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseSetupSkip(%q) expected error, got %v", tt.values, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSetupSkip(%q) unexpected error: %v", tt.values, err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("parseSetupSkip(%q) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
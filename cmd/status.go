@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+
+	sbErrors "github.com/saltyorg/sb-go/internal/errors"
+	"github.com/saltyorg/sb-go/internal/motd"
+
+	"github.com/spf13/cobra"
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Check Saltbox health and exit non-zero on problems",
+	Long: `Checks containers, Saltbox systemd services, disk usage, Traefik routers
+and pending reboots, printing one line per issue found.
+
+Exits with status 0 when everything is healthy and 1 when any issue is found,
+making it suitable for cron jobs and monitoring hooks. Use --quiet to print
+nothing and only set the exit code.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		// The global --quiet flag makes the status exit code the only output
+		return handleStatus(cmd, quietOutput, verbose)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+	statusCmd.Flags().BoolP("verbose", "v", false, "Enable verbose output")
+}
+
+func handleStatus(cmd *cobra.Command, exitCodeOnly, verbose bool) error {
+	issues := motd.CheckHealth(cmd.Context(), verbose)
+
+	if !exitCodeOnly {
+		if len(issues) == 0 {
+			fmt.Println("All checks passed")
+		}
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+	}
+
+	if len(issues) > 0 {
+		// The issues are the only output, without an error message
+		return sbErrors.WithExitCode(fmt.Errorf("%w: %d issues found", sbErrors.ErrSilent, len(issues)), 1)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/saltyorg/sb-go/internal/motd"
+	"github.com/saltyorg/sb-go/internal/styles"
+
+	"charm.land/lipgloss/v2"
+	"github.com/aquasecurity/table"
+	"github.com/spf13/cobra"
+)
+
+// traefikCmd represents the traefik command
+var traefikCmd = &cobra.Command{
+	Use:   "traefik",
+	Short: "Inspect the Saltbox Traefik instance",
+}
+
+// traefikRoutersCmd represents the traefik routers command
+var traefikRoutersCmd = &cobra.Command{
+	Use:   "routers",
+	Short: "List the Traefik HTTP routers and their status",
+	Long: `Lists every HTTP router known to Traefik with its status, rule, service and error.
+
+The Traefik API is reached at http://traefik:8080 by default. Set url, user,
+password and timeout in the traefik section of motd.yml to use another address
+or basic auth.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		problemsOnly, _ := cmd.Flags().GetBool("problems-only")
+
+		routers, err := motd.FetchTraefikRouters(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failed to fetch routers from %s: %w", motd.TraefikAPIURL(), err)
+		}
+
+		total := len(routers)
+		if problemsOnly {
+			routers = problemRouters(routers)
+		}
+		if len(routers) == 0 {
+			if problemsOnly {
+				fmt.Println(styles.SuccessStyle.Render(fmt.Sprintf("All %d routers are healthy", total)))
+			} else {
+				fmt.Println("Traefik has no routers configured")
+			}
+			return nil
+		}
+
+		sort.Slice(routers, func(i, j int) bool {
+			return routers[i].Name < routers[j].Name
+		})
+
+		t := table.New(cmd.OutOrStdout())
+		t.SetHeaders("Router", "Status", "Rule", "Service", "Error")
+		t.SetHeaderStyle(table.StyleBold)
+		t.SetAlignment(table.AlignLeft, table.AlignLeft, table.AlignLeft, table.AlignLeft, table.AlignLeft)
+		t.SetBorders(true)
+		t.SetRowLines(true)
+		t.SetDividers(table.UnicodeRoundedDividers)
+		t.SetLineStyle(table.StyleBlue)
+		t.SetPadding(1)
+		t.SetColumnMaxWidth(60)
+
+		for _, router := range routers {
+			t.AddRow(router.Name, routerStatusStyle(router).Render(router.Status), router.Rule, router.Service, router.Problem())
+		}
+		t.Render()
+
+		if problems := len(problemRouters(routers)); problems > 0 && !problemsOnly {
+			fmt.Println(styles.WarningStyle.Render(fmt.Sprintf("%d of %d routers need attention", problems, total)))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(traefikCmd)
+	traefikCmd.AddCommand(traefikRoutersCmd)
+	traefikRoutersCmd.Flags().Bool("problems-only", false, "Only list routers that need attention")
+}
+
+// problemRouters returns the routers that have an error or are disabled
+func problemRouters(routers []motd.TraefikRouter) []motd.TraefikRouter {
+	var problems []motd.TraefikRouter
+	for _, router := range routers {
+		if router.Problem() != "" {
+			problems = append(problems, router)
+		}
+	}
+	return problems
+}
+
+// routerStatusStyle colors a router's status by whether it needs attention
+func routerStatusStyle(router motd.TraefikRouter) lipgloss.Style {
+	switch {
+	case router.Problem() != "" && strings.EqualFold(router.Status, "warning"):
+		return styles.WarningStyle
+	case router.Problem() != "":
+		return styles.ErrorStyle
+	default:
+		return styles.SuccessStyle
+	}
+}
@@ -76,7 +76,7 @@ func handleUpdate(ctx context.Context, verbose bool, branchReset *bool, skipSelf
 		return err
 	}
 
-	runner := spinners.NewRunner(spinners.RunnerOptions{Verbose: verbose})
+	runner := spinners.NewRunner(runnerOptions(verbose))
 
 	if !skipSelfUpdate {
 		updated, err := doSelfUpdate(ctx, runner, true, verbose, "Re-run the update command to update Saltbox", false)
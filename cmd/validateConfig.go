@@ -12,11 +12,20 @@ import (
 var configCmd = &cobra.Command{
 	Use:   "validate-config",
 	Short: "Validate Saltbox configuration files",
-	Long:  `Validate Saltbox configuration files`,
-	Args:  cobra.NoArgs,
+	Long: `Validate Saltbox configuration files.
+
+Cloudflare and Docker Hub credentials are checked against their APIs and
+hostnames marked for it are looked up in DNS. Use --offline to only check
+the structure of the files. Hostnames that don't resolve are reported as
+warnings unless --strict-dns is set.`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		verbose, _ := cmd.Flags().GetBool("verbose")
-		runner := spinners.NewRunner(spinners.RunnerOptions{Verbose: verbose})
+		offline, _ := cmd.Flags().GetBool("offline")
+		strictDNS, _ := cmd.Flags().GetBool("strict-dns")
+		validate.SetOffline(offline)
+		validate.SetDNSFailuresFatal(strictDNS)
+		runner := spinners.NewRunner(runnerOptions(verbose))
 		return runner.Run(cmd.Context(), spinners.TaskSpec{
 			Running:      "Validating Saltbox configuration",
 			Success:      "Saltbox configuration validated",
@@ -30,4 +39,6 @@ var configCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(configCmd)
 	configCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
+	configCmd.Flags().Bool("offline", false, "Skip the checks that need network access, such as API credentials and DNS")
+	configCmd.Flags().Bool("strict-dns", false, "Fail validation when a hostname doesn't resolve instead of warning")
 }
@@ -0,0 +1,419 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/saltyorg/sb-go/internal/apt"
+	"github.com/saltyorg/sb-go/internal/spinners"
+	"github.com/saltyorg/sb-go/internal/styles"
+	"github.com/saltyorg/sb-go/internal/tty"
+	"github.com/saltyorg/sb-go/internal/wireguard"
+
+	"github.com/spf13/cobra"
+)
+
+// wireguardEndpointPlaceholder is used in client configs when no endpoint is known
+const wireguardEndpointPlaceholder = "<server-address>"
+
+// wireguardDefaultClient is the client created when the server is first deployed
+const wireguardDefaultClient = "default"
+
+// wireguardCmd represents the wireguard command
+var wireguardCmd = &cobra.Command{
+	Use:   "wireguard",
+	Short: "Manage WireGuard tunnels",
+	Long:  `Manage WireGuard tunnels`,
+}
+
+// wireguardServerCmd represents the wireguard server command
+var wireguardServerCmd = &cobra.Command{
+	Use:   "server",
+	Short: "Deploy a WireGuard server",
+	Long: `Deploy a WireGuard server.
+
+Installs WireGuard, generates server keys, writes the interface config,
+enables IPv4 forwarding and brings the interface up with wg-quick.
+A client named "default" is created on the first deployment; use add-client for more.
+Running the command again keeps existing keys unless --force is given.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		opts := wireguardServerOptions{}
+		opts.subnet, _ = cmd.Flags().GetString("subnet")
+		opts.port, _ = cmd.Flags().GetInt("port")
+		opts.iface, _ = cmd.Flags().GetString("interface")
+		opts.endpoint, _ = cmd.Flags().GetString("endpoint")
+		opts.wanInterface, _ = cmd.Flags().GetString("wan-interface")
+		opts.force, _ = cmd.Flags().GetBool("force")
+		opts.verbose, _ = cmd.Flags().GetBool("verbose")
+		opts.assumeYes, _ = cmd.Flags().GetBool("yes")
+		return handleWireguardServer(ctx, opts)
+	},
+}
+
+// wireguardAddClientCmd represents the wireguard add-client command
+var wireguardAddClientCmd = &cobra.Command{
+	Use:   "add-client <name>",
+	Short: "Add a WireGuard client and print its config",
+	Long: `Add a WireGuard client and print its config.
+
+Generates a client key pair, assigns the next free address in the tunnel subnet
+and adds the client as a peer of the running server.
+Adding an existing client prints its config again.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		iface, _ := cmd.Flags().GetString("interface")
+		qr, _ := cmd.Flags().GetBool("qr")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		return handleWireguardAddClient(cmd.Context(), iface, args[0], qr, verbose)
+	},
+}
+
+// wireguardRemoveClientCmd represents the wireguard remove-client command
+var wireguardRemoveClientCmd = &cobra.Command{
+	Use:   "remove-client <name>",
+	Short: "Revoke a WireGuard client",
+	Long:  `Revoke a WireGuard client by removing its peer from the server and deleting its keys`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		iface, _ := cmd.Flags().GetString("interface")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		assumeYes, _ := cmd.Flags().GetBool("yes")
+		return handleWireguardRemoveClient(cmd.Context(), iface, args[0], verbose, assumeYes)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(wireguardCmd)
+	wireguardCmd.AddCommand(wireguardServerCmd, wireguardAddClientCmd, wireguardRemoveClientCmd)
+	wireguardCmd.PersistentFlags().String("interface", wireguard.DefaultInterface, "WireGuard interface name")
+	wireguardCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
+	wireguardCmd.PersistentFlags().BoolP("yes", "y", false, "Skip confirmation of destructive changes")
+	wireguardServerCmd.Flags().String("subnet", wireguard.DefaultSubnet, "IPv4 subnet of the tunnel")
+	wireguardServerCmd.Flags().Int("port", wireguard.DefaultPort, "UDP port to listen on")
+	wireguardServerCmd.Flags().String("endpoint", "", "Public hostname or IP of this server for client configs")
+	wireguardServerCmd.Flags().String("wan-interface", "", "Interface to route tunnel traffic through (default: interface of the default route)")
+	wireguardServerCmd.Flags().Bool("force", false, "Regenerate server keys even if they already exist")
+	wireguardAddClientCmd.Flags().Bool("qr", false, "Also print the client config as a QR code")
+}
+
+type wireguardServerOptions struct {
+	subnet       string
+	port         int
+	iface        string
+	endpoint     string
+	wanInterface string
+	force        bool
+	verbose      bool
+	assumeYes    bool
+}
+
+func handleWireguardServer(ctx context.Context, opts wireguardServerOptions) error {
+	subnet, err := wireguard.ParseSubnet(opts.subnet)
+	if err != nil {
+		return err
+	}
+	if opts.port < 1 || opts.port > 65535 {
+		return fmt.Errorf("invalid port %d: must be between 1 and 65535", opts.port)
+	}
+	if opts.iface == "" {
+		return fmt.Errorf("interface name cannot be empty")
+	}
+	if opts.wanInterface == "" {
+		if opts.wanInterface, err = wireguard.DefaultRouteInterface(); err != nil {
+			return fmt.Errorf("failed to detect WAN interface, use --wan-interface: %w", err)
+		}
+	}
+
+	state, err := loadWireguardState(opts.iface)
+	if errors.Is(err, os.ErrNotExist) {
+		state = &wireguard.State{}
+	} else if err != nil {
+		return err
+	}
+	for _, client := range state.Clients {
+		if !subnet.Contains(client.Address) {
+			return fmt.Errorf("client %q (%s) is outside subnet %s, remove it first", client.Name, client.Address, subnet)
+		}
+	}
+	if opts.force && len(state.Clients) > 0 {
+		confirmed, err := tty.Confirm(fmt.Sprintf("Regenerating the server keys disconnects all %d clients of %s until their configs are updated. Continue?", len(state.Clients), opts.iface), opts.assumeYes)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Aborted, server keys left unchanged.")
+			return nil
+		}
+	}
+	state.Subnet = subnet
+	state.Port = opts.port
+	state.WANInterface = opts.wanInterface
+	if opts.endpoint != "" {
+		state.Endpoint = opts.endpoint
+	}
+
+	var cfg wireguard.ServerConfig
+	runner := spinners.NewRunner(runnerOptions(opts.verbose))
+	if err := runner.Run(ctx, spinners.TaskSpec{
+		Running:      fmt.Sprintf("Deploying WireGuard server on %s", opts.iface),
+		Success:      fmt.Sprintf("WireGuard server running on %s", opts.iface),
+		Failure:      "WireGuard server deployment",
+		ChildDisplay: spinners.RetainChildTasks,
+	}, func(ctx context.Context, task *spinners.Task) error {
+		var err error
+		cfg, err = deployWireguardServer(ctx, task, state, opts)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("Server public key: %s\n", cfg.Server.PublicKey)
+
+	if _, ok := state.Client(wireguardDefaultClient); !ok {
+		fmt.Println()
+		fmt.Println(styles.DimStyle.Render(fmt.Sprintf("Use 'sb wireguard add-client <name> --interface %s' to add clients", opts.iface)))
+		return nil
+	}
+	return printWireguardClient(ctx, cfg, state, wireguardDefaultClient, false)
+}
+
+func deployWireguardServer(ctx context.Context, task *spinners.Task, state *wireguard.State, opts wireguardServerOptions) (wireguard.ServerConfig, error) {
+	cfg := wireguard.ServerConfig{}
+
+	if err := task.RunStreaming(ctx, spinners.TaskSpec{Running: "Updating apt package cache"}, func(taskCtx context.Context) error {
+		updateCache := apt.UpdatePackageLists(taskCtx, opts.verbose)
+		return updateCache()
+	}); err != nil {
+		return cfg, fmt.Errorf("error updating apt cache: %w", err)
+	}
+
+	if err := task.RunStreaming(ctx, spinners.TaskSpec{Running: "Installing WireGuard"}, func(taskCtx context.Context) error {
+		install := apt.InstallPackage(taskCtx, []string{"wireguard"}, opts.verbose)
+		return install()
+	}); err != nil {
+		return cfg, fmt.Errorf("error installing WireGuard: %w", err)
+	}
+
+	// Keys changing means the config changes, which restarts the interface below
+	var serverKeys wireguard.KeyPair
+	if err := task.Run(ctx, spinners.TaskSpec{Running: "Ensuring WireGuard keys"}, func(context.Context, *spinners.Task) error {
+		var err error
+		if serverKeys, _, err = wireguard.EnsureKeyPair(ctx, wireguard.ConfigDir, opts.iface+"-server", opts.force); err != nil {
+			return err
+		}
+		if len(state.Clients) > 0 {
+			return nil
+		}
+		clientKeys, _, err := wireguard.EnsureKeyPair(ctx, wireguard.ClientKeyDir(opts.iface), wireguardDefaultClient, true)
+		if err != nil {
+			return err
+		}
+		_, err = state.AddClient(wireguardDefaultClient, clientKeys.PublicKey)
+		return err
+	}); err != nil {
+		return cfg, fmt.Errorf("error generating keys: %w", err)
+	}
+
+	cfg = wireguardServerConfig(opts.iface, state, serverKeys)
+	configPath := wireguard.ConfigPath(opts.iface)
+	changed := false
+	if err := task.Run(ctx, spinners.TaskSpec{Running: fmt.Sprintf("Writing %s", configPath)}, func(context.Context, *spinners.Task) error {
+		var err error
+		if changed, err = wireguard.WriteConfig(configPath, wireguard.RenderServerConfig(cfg)); err != nil {
+			return err
+		}
+		return state.Save(wireguard.StatePath(opts.iface))
+	}); err != nil {
+		return cfg, fmt.Errorf("error writing config: %w", err)
+	}
+
+	if err := task.Run(ctx, spinners.TaskSpec{Running: "Enabling IPv4 forwarding"}, func(context.Context, *spinners.Task) error {
+		return wireguard.EnableIPForwarding(ctx)
+	}); err != nil {
+		return cfg, fmt.Errorf("error enabling IPv4 forwarding: %w", err)
+	}
+
+	if err := task.RunStreaming(ctx, spinners.TaskSpec{Running: fmt.Sprintf("Bringing up %s", opts.iface)}, func(taskCtx context.Context) error {
+		return wireguard.BringUp(taskCtx, opts.iface, changed, opts.verbose)
+	}); err != nil {
+		return cfg, fmt.Errorf("error bringing up interface: %w", err)
+	}
+
+	return cfg, nil
+}
+
+func handleWireguardAddClient(ctx context.Context, iface, name string, qr, verbose bool) error {
+	if err := wireguard.ValidateClientName(name); err != nil {
+		return err
+	}
+	state, err := loadWireguardState(iface)
+	if err != nil {
+		return err
+	}
+	serverKeys, err := loadWireguardServerKeys(iface)
+	if err != nil {
+		return err
+	}
+
+	cfg := wireguardServerConfig(iface, state, serverKeys)
+	if _, exists := state.Client(name); !exists {
+		runner := spinners.NewRunner(runnerOptions(verbose))
+		if err := runner.Run(ctx, spinners.TaskSpec{
+			Running:      fmt.Sprintf("Adding WireGuard client %s", name),
+			Success:      fmt.Sprintf("WireGuard client %s added", name),
+			Failure:      fmt.Sprintf("Adding WireGuard client %s", name),
+			ChildDisplay: spinners.RetainChildTasks,
+		}, func(ctx context.Context, task *spinners.Task) error {
+			if err := task.Run(ctx, spinners.TaskSpec{Running: "Generating client keys"}, func(context.Context, *spinners.Task) error {
+				clientKeys, _, err := wireguard.EnsureKeyPair(ctx, wireguard.ClientKeyDir(iface), name, true)
+				if err != nil {
+					return err
+				}
+				_, err = state.AddClient(name, clientKeys.PublicKey)
+				return err
+			}); err != nil {
+				return fmt.Errorf("error generating keys: %w", err)
+			}
+
+			cfg = wireguardServerConfig(iface, state, serverKeys)
+			return applyWireguardPeers(ctx, task, cfg, state, verbose)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return printWireguardClient(ctx, cfg, state, name, qr)
+}
+
+func handleWireguardRemoveClient(ctx context.Context, iface, name string, verbose, assumeYes bool) error {
+	state, err := loadWireguardState(iface)
+	if err != nil {
+		return err
+	}
+	if !state.RemoveClient(name) {
+		return fmt.Errorf("client %q not found on %s", name, iface)
+	}
+	confirmed, err := tty.Confirm(fmt.Sprintf("Revoke WireGuard client %s and delete its keys?", name), assumeYes)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Println("Aborted, client left unchanged.")
+		return nil
+	}
+	serverKeys, err := loadWireguardServerKeys(iface)
+	if err != nil {
+		return err
+	}
+
+	runner := spinners.NewRunner(runnerOptions(verbose))
+	return runner.Run(ctx, spinners.TaskSpec{
+		Running:      fmt.Sprintf("Removing WireGuard client %s", name),
+		Success:      fmt.Sprintf("WireGuard client %s removed", name),
+		Failure:      fmt.Sprintf("Removing WireGuard client %s", name),
+		ChildDisplay: spinners.RetainChildTasks,
+	}, func(ctx context.Context, task *spinners.Task) error {
+		if err := applyWireguardPeers(ctx, task, wireguardServerConfig(iface, state, serverKeys), state, verbose); err != nil {
+			return err
+		}
+		if err := task.Run(ctx, spinners.TaskSpec{Running: "Deleting client keys"}, func(context.Context, *spinners.Task) error {
+			return wireguard.RemoveKeyPair(wireguard.ClientKeyDir(iface), name)
+		}); err != nil {
+			return fmt.Errorf("error deleting keys: %w", err)
+		}
+		return nil
+	})
+}
+
+// applyWireguardPeers writes the server config and ledger, then reloads the running interface
+func applyWireguardPeers(ctx context.Context, task *spinners.Task, cfg wireguard.ServerConfig, state *wireguard.State, verbose bool) error {
+	configPath := wireguard.ConfigPath(cfg.Interface)
+	if err := task.Run(ctx, spinners.TaskSpec{Running: fmt.Sprintf("Writing %s", configPath)}, func(context.Context, *spinners.Task) error {
+		if _, err := wireguard.WriteConfig(configPath, wireguard.RenderServerConfig(cfg)); err != nil {
+			return err
+		}
+		return state.Save(wireguard.StatePath(cfg.Interface))
+	}); err != nil {
+		return fmt.Errorf("error writing config: %w", err)
+	}
+
+	if err := task.RunStreaming(ctx, spinners.TaskSpec{Running: fmt.Sprintf("Reloading %s", cfg.Interface)}, func(taskCtx context.Context) error {
+		return wireguard.Reload(taskCtx, cfg.Interface, verbose)
+	}); err != nil {
+		return fmt.Errorf("error reloading interface: %w", err)
+	}
+	return nil
+}
+
+// loadWireguardState loads the ledger of an interface, pointing at the server command if it's missing
+func loadWireguardState(iface string) (*wireguard.State, error) {
+	state, err := wireguard.LoadState(wireguard.StatePath(iface))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no WireGuard server deployed on %s, run 'sb wireguard server' first: %w", iface, err)
+	}
+	return state, err
+}
+
+// loadWireguardServerKeys loads the existing server keys of an interface
+func loadWireguardServerKeys(iface string) (wireguard.KeyPair, error) {
+	keys, err := wireguard.LoadKeyPair(wireguard.ConfigDir, iface+"-server")
+	if err != nil {
+		return keys, fmt.Errorf("server keys of %s are missing, run 'sb wireguard server' to redeploy: %w", iface, err)
+	}
+	return keys, nil
+}
+
+func wireguardServerConfig(iface string, state *wireguard.State, serverKeys wireguard.KeyPair) wireguard.ServerConfig {
+	return wireguard.ServerConfig{
+		Interface:    iface,
+		Subnet:       state.Subnet,
+		Port:         state.Port,
+		WANInterface: state.WANInterface,
+		Server:       serverKeys,
+		Peers:        state.Clients,
+	}
+}
+
+// printWireguardClient prints the config of a client, optionally followed by a QR code of it
+func printWireguardClient(ctx context.Context, cfg wireguard.ServerConfig, state *wireguard.State, name string, qr bool) error {
+	client, ok := state.Client(name)
+	if !ok {
+		return fmt.Errorf("client %q not found on %s", name, cfg.Interface)
+	}
+	clientKeys, err := wireguard.LoadKeyPair(wireguard.ClientKeyDir(cfg.Interface), name)
+	if err != nil {
+		return fmt.Errorf("keys of client %q are missing, remove and add it again: %w", name, err)
+	}
+	if clientKeys.PublicKey != client.PublicKey {
+		return fmt.Errorf("keys of client %q don't match the server config, remove and add it again", name)
+	}
+
+	endpoint := state.Endpoint
+	if endpoint == "" {
+		endpoint = wireguardEndpointPlaceholder
+	}
+	clientConfig := wireguard.RenderClientConfig(cfg, clientKeys, client.Address, endpoint)
+
+	fmt.Println()
+	fmt.Printf("Client configuration (%s):\n", name)
+	fmt.Println()
+	fmt.Print(clientConfig)
+	if qr {
+		code, err := wireguard.RenderQRCode(clientConfig)
+		if err != nil {
+			return err
+		}
+		fmt.Println()
+		fmt.Print(code)
+	}
+	if state.Endpoint == "" {
+		fmt.Println()
+		fmt.Println(styles.WarningStyle.Render(fmt.Sprintf("Replace %s with the public address of this server or use 'sb wireguard server --endpoint'", wireguardEndpointPlaceholder)))
+	}
+	return nil
+}
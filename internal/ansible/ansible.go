@@ -1,69 +1,47 @@
 package ansible
 
 import (
+	"bytes"
 	"context"
-	"errors"
 	"fmt"
-	"os/exec"
 	"regexp"
 	"strings"
 
 	"github.com/saltyorg/sb-go/internal/cache"
 	"github.com/saltyorg/sb-go/internal/constants"
 	sbErrors "github.com/saltyorg/sb-go/internal/errors"
-	"github.com/saltyorg/sb-go/internal/executor"
 	"github.com/saltyorg/sb-go/internal/git"
 	"github.com/saltyorg/sb-go/internal/logging"
 )
 
 // RunAnsiblePlaybook executes an Ansible playbook using the specified binary and arguments.
 // It constructs the command based on the provided playbook path, extra arguments, and repository directory.
-// If verbose is true, the command output is streamed directly to the console; otherwise, output is captured for error reporting.
-// On error, it returns a detailed error message including the exit code and, if available, the captured stderr.
-// The function uses the provided context for cancellation support, allowing graceful interruption via signals.
+// If verbose is true, the terminal is passed through to the playbook (OutputModeInteractive) so colors and
+// terminal width are preserved; otherwise, output is captured for error reporting.
+// On failure, the returned error carries the playbook's exit code (see sbErrors.ExitCode) so it can be
+// surfaced as sb's exit code. The context allows graceful interruption via signals.
 func RunAnsiblePlaybook(ctx context.Context, repoPath, playbookPath, ansibleBinaryPath string, extraArgs []string, verbose bool) error {
-	command := []string{ansibleBinaryPath, playbookPath, "--become"}
-	command = append(command, extraArgs...)
+	args := buildAnsibleCommand(playbookPath, extraArgs)
 
 	if verbose {
-		fmt.Println("Executing Ansible playbook with command:", strings.Join(command, " "))
+		fmt.Println("Executing Ansible playbook with command:", strings.Join(append([]string{ansibleBinaryPath}, args...), " "))
 	}
 
-	// Use the appropriate output mode based on verbosity
-	var outputMode executor.OutputMode
+	var err error
+	var stderrBuf *bytes.Buffer
 	if verbose {
-		outputMode = executor.OutputModeInteractive
+		err = defaultExecutor.ExecuteInteractive(ctx, repoPath, ansibleBinaryPath, args...)
 	} else {
-		outputMode = executor.OutputModeCapture
+		stderrBuf = &bytes.Buffer{}
+		err = defaultExecutor.ExecuteWithIO(ctx, repoPath, ansibleBinaryPath, args, nil, stderrBuf, nil)
 	}
 
-	result, err := executor.Run(ctx, command[0],
-		executor.WithArgs(command[1:]...),
-		executor.WithWorkingDir(repoPath),
-		executor.WithOutputMode(outputMode),
-		executor.WithInheritEnv())
-
 	if err != nil {
 		// Check if the error is due to context cancellation (signal interruption)
 		if sbErrors.HandleInterruptError(err) {
 			return fmt.Errorf("playbook execution interrupted by user")
 		}
-
-		if exitErr, ok := errors.AsType[*exec.ExitError](err); ok {
-			if exitErr.ExitCode() < 0 {
-				if sbErrors.HandleInterruptError(err) {
-					return fmt.Errorf("playbook execution interrupted by user")
-				}
-			}
-			if !verbose && len(result.Stderr) > 0 {
-				return fmt.Errorf("playbook %s run failed, scroll up to the failed task to review.\nExit code: %d\nStderr:\n%s", playbookPath, exitErr.ExitCode(), string(result.Stderr))
-			}
-			return fmt.Errorf("playbook %s run failed, scroll up to the failed task to review.\nExit code: %d", playbookPath, exitErr.ExitCode())
-		}
-		if !verbose && len(result.Stderr) > 0 {
-			return fmt.Errorf("playbook %s run failed: %w\nStderr:\n%s", playbookPath, err, string(result.Stderr))
-		}
-		return fmt.Errorf("playbook %s run failed: %w", playbookPath, err)
+		return formatPlaybookError(playbookPath, err, stderrBuf, verbose)
 	}
 
 	if verbose {
@@ -73,6 +51,24 @@ func RunAnsiblePlaybook(ctx context.Context, repoPath, playbookPath, ansibleBina
 	return nil
 }
 
+// RunAnsiblePlaybookWithRecap runs a playbook like RunAnsiblePlaybook with verbose output and
+// returns the task counts from its PLAY RECAP. The output is still passed through to the terminal
+// while it runs, and copied to the log set by WithOutputLog. The recap is returned on failure
+// as well, with Found set if one was printed.
+func RunAnsiblePlaybookWithRecap(ctx context.Context, repoPath, playbookPath, ansibleBinaryPath string, extraArgs []string) (PlaybookRecap, error) {
+	var recap PlaybookRecap
+	outputLog := outputLogFromContext(ctx)
+	ctx = withLineCallback(ctx, func(line string) {
+		recap.parseLine(line)
+		if outputLog != nil {
+			// A full disk shouldn't fail the run itself
+			_, _ = fmt.Fprintln(outputLog, line)
+		}
+	})
+	err := RunAnsiblePlaybook(ctx, repoPath, playbookPath, ansibleBinaryPath, extraArgs, true)
+	return recap, err
+}
+
 // PrepareAnsibleListTags configures the command for listing tags from an Ansible playbook
 // and returns a parser function to extract the tags from the command output.
 // It builds the command using repoPath, playbookPath, and extraSkipTags. Additionally, if a cache is provided,
@@ -15,6 +15,7 @@ import (
 
 	"github.com/saltyorg/sb-go/internal/cache"
 	"github.com/saltyorg/sb-go/internal/constants"
+	sbErrors "github.com/saltyorg/sb-go/internal/errors"
 )
 
 // TestRunAnsiblePlaybook_Success tests successful playbook execution
@@ -76,6 +77,72 @@ func TestRunAnsiblePlaybook_ErrorHandling(t *testing.T) {
 	}
 }
 
+// TestRunAnsiblePlaybook_Interactive tests that verbose runs pass the terminal through
+// and that a failing playbook's exit code is surfaced
+func TestRunAnsiblePlaybook_Interactive(t *testing.T) {
+	original := GetExecutor()
+	defer SetExecutor(original)
+
+	// Produce a real *exec.ExitError with exit code 3
+	exitErr := exec.Command("sh", "-c", "exit 3").Run()
+
+	var gotDir, gotName string
+	var gotArgs []string
+	SetExecutor(&MockCommandExecutor{
+		ExecuteInteractiveFunc: func(ctx context.Context, dir string, name string, args ...string) error {
+			gotDir, gotName, gotArgs = dir, name, args
+			return exitErr
+		},
+		ExecuteWithIOFunc: func(ctx context.Context, dir string, name string, args []string, stdout, stderr, stdin any) error {
+			t.Error("verbose runs should not capture output")
+			return nil
+		},
+	})
+
+	err := RunAnsiblePlaybook(context.Background(), "/srv/git/saltbox", "/srv/git/saltbox/saltbox.yml",
+		constants.AnsiblePlaybookBinaryPath, []string{"--tags", "sonarr"}, true)
+	if err == nil {
+		t.Fatal("Expected error for failing playbook")
+	}
+
+	if gotDir != "/srv/git/saltbox" || gotName != constants.AnsiblePlaybookBinaryPath {
+		t.Errorf("Unexpected invocation: dir=%q name=%q", gotDir, gotName)
+	}
+	if want := []string{"/srv/git/saltbox/saltbox.yml", "--become", "--tags", "sonarr"}; strings.Join(gotArgs, " ") != strings.Join(want, " ") {
+		t.Errorf("Args = %v, want %v", gotArgs, want)
+	}
+	if code := sbErrors.ExitCode(err); code != 3 {
+		t.Errorf("ExitCode() = %d, want 3", code)
+	}
+}
+
+// TestRunAnsiblePlaybook_CapturesStderr tests that quiet runs include stderr in the error
+func TestRunAnsiblePlaybook_CapturesStderr(t *testing.T) {
+	original := GetExecutor()
+	defer SetExecutor(original)
+
+	exitErr := exec.Command("sh", "-c", "exit 2").Run()
+
+	SetExecutor(&MockCommandExecutor{
+		ExecuteWithIOFunc: func(ctx context.Context, dir string, name string, args []string, stdout, stderr, stdin any) error {
+			_, _ = stderr.(*bytes.Buffer).WriteString("ERROR! the playbook could not be found")
+			return exitErr
+		},
+	})
+
+	err := RunAnsiblePlaybook(context.Background(), "/test/repo", "/test/repo/playbook.yml",
+		constants.AnsiblePlaybookBinaryPath, nil, false)
+	if err == nil {
+		t.Fatal("Expected error for failing playbook")
+	}
+	if !strings.Contains(err.Error(), "ERROR! the playbook could not be found") {
+		t.Errorf("Error should include stderr, got: %v", err)
+	}
+	if code := sbErrors.ExitCode(err); code != 2 {
+		t.Errorf("ExitCode() = %d, want 2", code)
+	}
+}
+
 // TestRunAnsiblePlaybook_ContextCancellation tests context cancellation handling
 func TestRunAnsiblePlaybook_ContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
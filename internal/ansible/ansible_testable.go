@@ -113,8 +113,11 @@ func cacheTagsWithCommit(ctx context.Context, repoPath string, tags []string, ca
 	return cache.SetRepoCache(repoPath, repoCache)
 }
 
-// formatPlaybookError formats an error message for playbook execution failures
+// formatPlaybookError formats an error message for playbook execution failures.
+// Errors from a playbook that exited with a non-zero code carry that exit code.
 func formatPlaybookError(playbookPath string, err error, stderrBuf *bytes.Buffer, verbose bool) error {
+	hasStderr := !verbose && stderrBuf != nil && stderrBuf.Len() > 0
+
 	if exitErr, ok := errors.AsType[*exec.ExitError](err); ok {
 		// Check if the exit code indicates the process was killed by a signal
 		if exitErr.ExitCode() < 0 {
@@ -122,15 +125,15 @@ func formatPlaybookError(playbookPath string, err error, stderrBuf *bytes.Buffer
 				return fmt.Errorf("playbook execution interrupted by user")
 			}
 		}
-		if !verbose && stderrBuf != nil {
-			return fmt.Errorf("playbook %s run failed, scroll up to the failed task to review.\nExit code: %d\nStderr:\n%s",
-				playbookPath, exitErr.ExitCode(), stderrBuf.String())
+		if hasStderr {
+			return sbErrors.WithExitCode(fmt.Errorf("playbook %s run failed, scroll up to the failed task to review.\nExit code: %d\nStderr:\n%s",
+				playbookPath, exitErr.ExitCode(), stderrBuf.String()), exitErr.ExitCode())
 		}
-		return fmt.Errorf("playbook %s run failed, scroll up to the failed task to review.\nExit code: %d",
-			playbookPath, exitErr.ExitCode())
+		return sbErrors.WithExitCode(fmt.Errorf("playbook %s run failed, scroll up to the failed task to review.\nExit code: %d",
+			playbookPath, exitErr.ExitCode()), exitErr.ExitCode())
 	}
 
-	if !verbose && stderrBuf != nil {
+	if hasStderr {
 		return fmt.Errorf("playbook %s run failed: %w\nStderr:\n%s", playbookPath, err, stderrBuf.String())
 	}
 	return fmt.Errorf("playbook %s run failed: %w", playbookPath, err)
@@ -3,8 +3,12 @@ package ansible
 import (
 	"context"
 	"io"
+	"os"
+	"strconv"
 
 	"github.com/saltyorg/sb-go/internal/executor"
+
+	"golang.org/x/term"
 )
 
 // CommandExecutor is an interface for executing commands
@@ -12,6 +16,7 @@ import (
 type CommandExecutor interface {
 	ExecuteContext(ctx context.Context, dir string, name string, args ...string) ([]byte, error)
 	ExecuteWithIO(ctx context.Context, dir string, name string, args []string, stdout, stderr, stdin any) error
+	ExecuteInteractive(ctx context.Context, dir string, name string, args ...string) error
 }
 
 // RealCommandExecutor implements CommandExecutor using the unified executor
@@ -64,10 +69,35 @@ func (e *RealCommandExecutor) ExecuteWithIO(ctx context.Context, dir string, nam
 	return err
 }
 
+// ExecuteInteractive executes a command with stdin, stdout and stderr passed through to the terminal.
+// Nothing is captured, so the command keeps TTY properties like colors and terminal width.
+// If the context carries a line callback (see withLineCallback), it receives a copy of stdout. Ansible
+// then no longer sees a terminal on stdout, so colors and the terminal width are passed on explicitly.
+func (e *RealCommandExecutor) ExecuteInteractive(ctx context.Context, dir string, name string, args ...string) error {
+	config := &executor.Config{
+		Context:    ctx,
+		Command:    name,
+		Args:       args,
+		WorkingDir: dir,
+		OutputMode: executor.OutputModeInteractive,
+	}
+
+	if onLine := lineCallbackFromContext(ctx); onLine != nil {
+		config.LineCallback = onLine
+		if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+			config.Env = append(os.Environ(), "ANSIBLE_FORCE_COLOR=1", "COLUMNS="+strconv.Itoa(width))
+		}
+	}
+
+	_, err := e.executor.Execute(config)
+	return err
+}
+
 // MockCommandExecutor is a mock implementation for testing
 type MockCommandExecutor struct {
-	ExecuteContextFunc func(ctx context.Context, dir string, name string, args ...string) ([]byte, error)
-	ExecuteWithIOFunc  func(ctx context.Context, dir string, name string, args []string, stdout, stderr, stdin any) error
+	ExecuteContextFunc     func(ctx context.Context, dir string, name string, args ...string) ([]byte, error)
+	ExecuteWithIOFunc      func(ctx context.Context, dir string, name string, args []string, stdout, stderr, stdin any) error
+	ExecuteInteractiveFunc func(ctx context.Context, dir string, name string, args ...string) error
 }
 
 // ExecuteContext mock implementation
@@ -92,6 +122,14 @@ func (m *MockCommandExecutor) ExecuteWithIO(ctx context.Context, dir string, nam
 	return nil
 }
 
+// ExecuteInteractive mock implementation
+func (m *MockCommandExecutor) ExecuteInteractive(ctx context.Context, dir string, name string, args ...string) error {
+	if m.ExecuteInteractiveFunc != nil {
+		return m.ExecuteInteractiveFunc(ctx, dir, name, args...)
+	}
+	return nil
+}
+
 // defaultExecutor is the default executor used by the package
 var defaultExecutor CommandExecutor = &RealCommandExecutor{
 	executor: executor.NewExecutor(),
@@ -0,0 +1,86 @@
+package ansible
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// PlaybookRecap holds the task counts from the PLAY RECAP of a playbook run, summed over all hosts
+type PlaybookRecap struct {
+	Found       bool // Whether a recap line was seen at all
+	Ok          int
+	Changed     int
+	Unreachable int
+	Failed      int
+	Skipped     int
+	Rescued     int
+	Ignored     int
+}
+
+// recapLinePattern matches a host line of the PLAY RECAP, e.g.
+// "localhost                  : ok=34   changed=3    unreachable=0    failed=0    skipped=12   rescued=0    ignored=0"
+var recapLinePattern = regexp.MustCompile(`^\S+\s+:\s+((?:[a-z]+=\d+\s*)+)$`)
+
+// Add sums the counts of another recap into r
+func (r *PlaybookRecap) Add(other PlaybookRecap) {
+	r.Found = r.Found || other.Found
+	r.Ok += other.Ok
+	r.Changed += other.Changed
+	r.Unreachable += other.Unreachable
+	r.Failed += other.Failed
+	r.Skipped += other.Skipped
+	r.Rescued += other.Rescued
+	r.Ignored += other.Ignored
+}
+
+// parseLine adds the counts of a PLAY RECAP host line to r, ignoring any other output.
+// Ansible colors the recap when it's attached to a terminal, so escape sequences are stripped first.
+func (r *PlaybookRecap) parseLine(line string) bool {
+	match := recapLinePattern.FindStringSubmatch(strings.TrimSpace(ansi.Strip(line)))
+	if match == nil {
+		return false
+	}
+
+	for field := range strings.FieldsSeq(match[1]) {
+		name, value, _ := strings.Cut(field, "=")
+		count, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+		switch name {
+		case "ok":
+			r.Ok += count
+		case "changed":
+			r.Changed += count
+		case "unreachable":
+			r.Unreachable += count
+		case "failed":
+			r.Failed += count
+		case "skipped":
+			r.Skipped += count
+		case "rescued":
+			r.Rescued += count
+		case "ignored":
+			r.Ignored += count
+		}
+	}
+	r.Found = true
+	return true
+}
+
+type lineCallbackContextKey struct{}
+
+// withLineCallback makes the executor pass each line of the playbook's output to fn
+func withLineCallback(ctx context.Context, fn func(line string)) context.Context {
+	return context.WithValue(ctx, lineCallbackContextKey{}, fn)
+}
+
+// lineCallbackFromContext returns the line callback set by withLineCallback, if any
+func lineCallbackFromContext(ctx context.Context) func(line string) {
+	fn, _ := ctx.Value(lineCallbackContextKey{}).(func(line string))
+	return fn
+}
@@ -0,0 +1,56 @@
+package ansible
+
+import (
+	"context"
+	"testing"
+
+	"github.com/saltyorg/sb-go/internal/constants"
+)
+
+func TestPlaybookRecapParseLine(t *testing.T) {
+	var recap PlaybookRecap
+	for _, line := range []string{
+		"PLAY RECAP *********************************************************************",
+		"TASK [sonarr : Create directories] *********************************************",
+		"\x1b[0;33mlocalhost\x1b[0m                  : \x1b[0;32mok=34  \x1b[0m \x1b[0;33mchanged=3   \x1b[0m unreachable=0    failed=0    skipped=12   rescued=0    ignored=1   ",
+		"media-server               : ok=2    changed=0    unreachable=1    failed=1    skipped=0    rescued=0    ignored=0",
+	} {
+		recap.parseLine(line)
+	}
+
+	want := PlaybookRecap{Found: true, Ok: 36, Changed: 3, Unreachable: 1, Failed: 1, Skipped: 12, Ignored: 1}
+	if recap != want {
+		t.Errorf("recap = %+v, want %+v", recap, want)
+	}
+
+	var none PlaybookRecap
+	if none.parseLine("ok: [localhost] => (item=key=value)") || none.Found {
+		t.Error("parseLine matched a task result line")
+	}
+}
+
+func TestRunAnsiblePlaybookWithRecap(t *testing.T) {
+	original := GetExecutor()
+	defer SetExecutor(original)
+
+	SetExecutor(&MockCommandExecutor{
+		ExecuteInteractiveFunc: func(ctx context.Context, dir string, name string, args ...string) error {
+			onLine := lineCallbackFromContext(ctx)
+			if onLine == nil {
+				t.Fatal("playbook run has no line callback")
+			}
+			onLine("PLAY RECAP *****")
+			onLine("localhost : ok=5 changed=2 unreachable=0 failed=0 skipped=1 rescued=0 ignored=0")
+			return nil
+		},
+	})
+
+	recap, err := RunAnsiblePlaybookWithRecap(context.Background(), "/srv/git/saltbox", "/srv/git/saltbox/saltbox.yml",
+		constants.AnsiblePlaybookBinaryPath, []string{"--tags", "sonarr"})
+	if err != nil {
+		t.Fatalf("RunAnsiblePlaybookWithRecap unexpected error: %v", err)
+	}
+	if want := (PlaybookRecap{Found: true, Ok: 5, Changed: 2, Skipped: 1}); recap != want {
+		t.Errorf("recap = %+v, want %+v", recap, want)
+	}
+}
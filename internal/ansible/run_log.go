@@ -0,0 +1,169 @@
+package ansible
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// RunLogSectionKind identifies the part of a playbook's output a RunLogSection holds
+type RunLogSectionKind int
+
+const (
+	SectionOther   RunLogSectionKind = iota // Output outside of any play, like warnings and errors
+	SectionPlay                             // A "PLAY [...]" header
+	SectionTask                             // A "TASK [...]" with its results
+	SectionHandler                          // A "RUNNING HANDLER [...]" with its results
+	SectionRecap                            // The "PLAY RECAP" with a line per host
+)
+
+// TaskStatus is the outcome of a task, from the results it printed
+type TaskStatus int
+
+// Task outcomes, ordered so the most notable result of a task wins
+const (
+	TaskPending TaskStatus = iota // No result was printed, e.g. the run stopped during the task
+	TaskSkipped
+	TaskOk
+	TaskChanged
+	TaskIgnored // Failed with ignore_errors set
+	TaskFailed
+)
+
+// RunLogSection is a part of the output of a playbook run
+type RunLogSection struct {
+	Kind   RunLogSectionKind
+	Title  string   // The name in the header, e.g. "sonarr : Create directories"
+	Lines  []string // The header followed by the output, without escape sequences
+	Status TaskStatus
+}
+
+// Failed reports whether the section shows a failure: a failed task, a recap with failed
+// or unreachable hosts, or an error printed outside of a task
+func (s RunLogSection) Failed() bool {
+	switch s.Kind {
+	case SectionTask, SectionHandler:
+		return s.Status == TaskFailed
+	case SectionRecap:
+		for _, line := range s.Lines[1:] {
+			var recap PlaybookRecap
+			if recap.parseLine(line) && (recap.Failed > 0 || recap.Unreachable > 0) {
+				return true
+			}
+		}
+		return false
+	default:
+		for _, line := range s.Lines {
+			if strings.HasPrefix(line, "ERROR!") {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ParseRunLog splits the output of playbook runs into sections at the play, task, handler and
+// recap headers. Output of several runs can follow each other.
+func ParseRunLog(lines []string) []RunLogSection {
+	var sections []RunLogSection
+	current := -1
+
+	for _, line := range lines {
+		line = strings.TrimRight(ansi.Strip(line), " \r")
+
+		if kind, title, ok := parseSectionHeader(line); ok {
+			sections = append(sections, RunLogSection{Kind: kind, Title: title, Lines: []string{line}})
+			current = len(sections) - 1
+			continue
+		}
+
+		// Output before the first header, or after a recap when another playbook runs
+		if current < 0 || (sections[current].Kind == SectionRecap && line != "" && !recapLinePattern.MatchString(strings.TrimSpace(line))) {
+			sections = append(sections, RunLogSection{Kind: SectionOther})
+			current = len(sections) - 1
+		}
+
+		section := &sections[current]
+		section.Lines = append(section.Lines, line)
+		if section.Kind == SectionTask || section.Kind == SectionHandler {
+			section.Status = max(section.Status, ResultLineStatus(line))
+			if strings.HasPrefix(line, "...ignoring") && section.Status == TaskFailed {
+				section.Status = TaskIgnored
+			}
+		}
+	}
+
+	// Drop the blank lines ansible prints between sections, and sections of nothing else
+	trimmed := sections[:0]
+	for _, section := range sections {
+		for len(section.Lines) > 0 && section.Lines[len(section.Lines)-1] == "" {
+			section.Lines = section.Lines[:len(section.Lines)-1]
+		}
+		if len(section.Lines) > 0 {
+			trimmed = append(trimmed, section)
+		}
+	}
+	return trimmed
+}
+
+// sectionHeaders maps the header prefixes ansible prints to the sections they start
+var sectionHeaders = []struct {
+	prefix string
+	kind   RunLogSectionKind
+}{
+	{"PLAY RECAP", SectionRecap},
+	{"PLAY [", SectionPlay},
+	{"TASK [", SectionTask},
+	{"RUNNING HANDLER [", SectionHandler},
+}
+
+// parseSectionHeader returns the kind and title of a header line like "TASK [role : name] *****"
+func parseSectionHeader(line string) (RunLogSectionKind, string, bool) {
+	for _, header := range sectionHeaders {
+		rest, ok := strings.CutPrefix(line, header.prefix)
+		if !ok {
+			continue
+		}
+		if header.kind == SectionRecap {
+			return header.kind, "PLAY RECAP", true
+		}
+		end := strings.LastIndex(rest, "]")
+		if end < 0 {
+			return 0, "", false
+		}
+		return header.kind, rest[:end], true
+	}
+	return 0, "", false
+}
+
+// ResultLineStatus returns the status a task result line like "changed: [localhost]" reports,
+// or TaskPending for any other line
+func ResultLineStatus(line string) TaskStatus {
+	switch {
+	case strings.HasPrefix(line, "fatal: "), strings.HasPrefix(line, "failed: "), strings.HasPrefix(line, "unreachable: "):
+		return TaskFailed
+	case strings.HasPrefix(line, "changed: "):
+		return TaskChanged
+	case strings.HasPrefix(line, "ok: "), strings.HasPrefix(line, "included: "):
+		return TaskOk
+	case strings.HasPrefix(line, "skipping: "):
+		return TaskSkipped
+	}
+	return TaskPending
+}
+
+type outputLogContextKey struct{}
+
+// WithOutputLog makes RunAnsiblePlaybookWithRecap copy each line of the playbook's output to w,
+// so the run can be reviewed after it scrolled by
+func WithOutputLog(ctx context.Context, w io.Writer) context.Context {
+	return context.WithValue(ctx, outputLogContextKey{}, w)
+}
+
+// outputLogFromContext returns the log set by WithOutputLog, if any
+func outputLogFromContext(ctx context.Context) io.Writer {
+	w, _ := ctx.Value(outputLogContextKey{}).(io.Writer)
+	return w
+}
@@ -0,0 +1,109 @@
+package ansible
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/saltyorg/sb-go/internal/constants"
+)
+
+const sampleRunLog = `[WARNING]: Collection community.general does not support Ansible version 2.17
+
+PLAY [Saltbox] *****************************************************************
+
+TASK [Gathering Facts] *********************************************************
+ok: [localhost]
+
+TASK [settings : Check settings] ***********************************************
+skipping: [localhost]
+
+TASK [sonarr : Create directories] *********************************************
+changed: [localhost] => (item=/opt/sonarr)
+
+TASK [sonarr : Optional cleanup] ***********************************************
+fatal: [localhost]: FAILED! => {"changed": false, "msg": "not found"}
+...ignoring
+
+TASK [plex : Wait for Plex] ****************************************************
+` + "\x1b[0;31mfatal: [localhost]: FAILED! => {\"msg\": \"Status code was -1:\x1b[0m" + `
+  Connection refused"}
+
+PLAY RECAP *********************************************************************
+localhost                  : ok=3    changed=1    unreachable=0    failed=1    skipped=1    rescued=0    ignored=1
+`
+
+func TestParseRunLog(t *testing.T) {
+	sections := ParseRunLog(strings.Split(sampleRunLog, "\n"))
+
+	want := []struct {
+		kind   RunLogSectionKind
+		title  string
+		status TaskStatus
+		failed bool
+	}{
+		{SectionOther, "", TaskPending, false},
+		{SectionPlay, "Saltbox", TaskPending, false},
+		{SectionTask, "Gathering Facts", TaskOk, false},
+		{SectionTask, "settings : Check settings", TaskSkipped, false},
+		{SectionTask, "sonarr : Create directories", TaskChanged, false},
+		{SectionTask, "sonarr : Optional cleanup", TaskIgnored, false},
+		{SectionTask, "plex : Wait for Plex", TaskFailed, true},
+		{SectionRecap, "PLAY RECAP", TaskPending, true},
+	}
+	if len(sections) != len(want) {
+		t.Fatalf("ParseRunLog() returned %d sections, want %d: %+v", len(sections), len(want), sections)
+	}
+	for i, w := range want {
+		s := sections[i]
+		if s.Kind != w.kind || s.Title != w.title || s.Status != w.status || s.Failed() != w.failed {
+			t.Errorf("section %d = {%v %q %v failed=%v}, want {%v %q %v failed=%v}",
+				i, s.Kind, s.Title, s.Status, s.Failed(), w.kind, w.title, w.status, w.failed)
+		}
+	}
+
+	plex := sections[6]
+	if got := strings.Join(plex.Lines, "\n"); strings.Contains(got, "\x1b") || !strings.HasSuffix(got, "  Connection refused\"}") {
+		t.Errorf("failed task lines = %q, want the output without escape sequences or trailing blank lines", got)
+	}
+}
+
+func TestParseRunLogSeveralRuns(t *testing.T) {
+	log := "PLAY RECAP ***\nlocalhost : ok=1 changed=0 unreachable=0 failed=0 skipped=0 rescued=0 ignored=0\n\nERROR! the playbook: sandbox.yml could not be found\n"
+
+	sections := ParseRunLog(strings.Split(log, "\n"))
+	if len(sections) != 2 {
+		t.Fatalf("ParseRunLog() returned %d sections, want 2: %+v", len(sections), sections)
+	}
+	if sections[0].Failed() {
+		t.Error("successful recap reported as failed")
+	}
+	if sections[1].Kind != SectionOther || !sections[1].Failed() {
+		t.Errorf("output after the recap = %+v, want a failed section of its own", sections[1])
+	}
+}
+
+func TestRunAnsiblePlaybookWithRecapOutputLog(t *testing.T) {
+	original := GetExecutor()
+	defer SetExecutor(original)
+
+	SetExecutor(&MockCommandExecutor{
+		ExecuteInteractiveFunc: func(ctx context.Context, dir string, name string, args ...string) error {
+			onLine := lineCallbackFromContext(ctx)
+			onLine("TASK [sonarr : Create directories] ***")
+			onLine("changed: [localhost]")
+			return nil
+		},
+	})
+
+	var log bytes.Buffer
+	ctx := WithOutputLog(context.Background(), &log)
+	if _, err := RunAnsiblePlaybookWithRecap(ctx, "/srv/git/saltbox", "/srv/git/saltbox/saltbox.yml",
+		constants.AnsiblePlaybookBinaryPath, nil); err != nil {
+		t.Fatalf("RunAnsiblePlaybookWithRecap unexpected error: %v", err)
+	}
+	if want := "TASK [sonarr : Create directories] ***\nchanged: [localhost]\n"; log.String() != want {
+		t.Errorf("output log = %q, want %q", log.String(), want)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -12,88 +13,162 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/saltyorg/sb-go/internal/config"
 	"github.com/saltyorg/sb-go/internal/executor"
 	"github.com/saltyorg/sb-go/internal/logging"
+	"github.com/saltyorg/sb-go/internal/ubuntu"
 )
 
-// aptLockFile is the primary lock file used by dpkg/apt operations.
-const aptLockFile = "/var/lib/dpkg/lock-frontend"
+// aptLockFile is the primary lock file used by dpkg/apt operations, replaced in tests.
+var aptLockFile = "/var/lib/dpkg/lock-frontend"
 
-// isAptLocked checks if the apt/dpkg lock file is currently held by another process.
-// It attempts to acquire a non-blocking exclusive lock on the lock file.
-// Returns true if the lock is held by another process, false if available.
-func isAptLocked() (bool, error) {
+// aptLockHolder checks if the apt/dpkg lock file is currently held by another process.
+// apt and dpkg take a POSIX record lock, which also tells which process holds it; a flock
+// on the file is checked as well. holder describes the process holding the lock, if known.
+func aptLockHolder() (holder string, locked bool, err error) {
 	f, err := os.Open(aptLockFile)
 	if err != nil {
 		if os.IsNotExist(err) {
 			// Lock file doesn't exist, apt is not locked
-			return false, nil
+			return "", false, nil
 		}
-		return false, fmt.Errorf("failed to open lock file: %w", err)
+		return "", false, fmt.Errorf("failed to open lock file: %w", err)
 	}
 	defer func() { _ = f.Close() }()
 
+	lock := syscall.Flock_t{Type: syscall.F_WRLCK, Whence: io.SeekStart}
+	if err := syscall.FcntlFlock(f.Fd(), syscall.F_GETLK, &lock); err == nil && lock.Type != syscall.F_UNLCK {
+		return processDescription(int(lock.Pid)), true, nil
+	}
+
 	err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
 	if err != nil {
 		if errors.Is(err, syscall.EWOULDBLOCK) {
-			return true, nil // locked by another process
+			return "", true, nil // locked by another process
 		}
-		return false, fmt.Errorf("failed to check lock: %w", err)
+		return "", false, fmt.Errorf("failed to check lock: %w", err)
 	}
 
 	// We got the lock, release it immediately
 	_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
-	return false, nil // not locked
+	return "", false, nil // not locked
 }
 
-// WaitForAptLock waits for the apt/dpkg lock to be released before proceeding.
-// It checks the lock file and waits with exponential backoff until it is released
-// or the context is cancelled/times out.
-// The verbose flag controls whether waiting messages are printed.
+// processDescription describes a process like apt does in its lock errors, e.g. "apt-get (pid 42)"
+func processDescription(pid int) string {
+	if pid <= 0 {
+		return ""
+	}
+	name, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+	if err != nil {
+		return fmt.Sprintf("pid %d", pid)
+	}
+	return fmt.Sprintf("%s (pid %d)", strings.TrimSpace(string(name)), pid)
+}
+
+// WaitForAptLock waits for the apt/dpkg lock to be released before proceeding, for at most the
+// apt_lock_timeout of the sb config. The process holding the lock is reported through the
+// spinner when one is running, or printed when verbose is true.
 func WaitForAptLock(ctx context.Context, verbose bool) error {
-	const maxRetries = 24 // ~2 minutes total with exponential backoff
-	const initialDelay = 5 * time.Second
+	timeout := config.SB().AptLockTimeout
+	deadline := time.Now().Add(timeout)
+	for waited := false; ; waited = true {
+		holder, locked, err := aptLockHolder()
+		if err != nil {
+			return fmt.Errorf("failed to check apt lock: %w", err)
+		}
 
-	delay := initialDelay
+		if !locked {
+			if waited {
+				executor.ReportStatus(ctx, "")
+				logging.DebugBool(verbose, "Apt lock released, proceeding...")
+			} else {
+				logging.DebugBool(verbose, "Apt lock is available, proceeding...")
+			}
+			return nil // Lock is available, proceed
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %v waiting for apt lock", timeout)
+		}
 
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		// Check if context is already cancelled
+		reportLockWait(ctx, holder, verbose)
 		select {
 		case <-ctx.Done():
 			return fmt.Errorf("context cancelled while waiting for apt lock: %w", ctx.Err())
-		default:
+		case <-time.After(lockRetryDelay):
 		}
+	}
+}
 
-		locked, err := isAptLocked()
-		if err != nil {
-			return fmt.Errorf("failed to check apt lock: %w", err)
+// reportLockWait reports waiting for the apt lock through the spinner, or prints it when verbose
+func reportLockWait(ctx context.Context, holder string, verbose bool) {
+	status := "waiting for apt lock"
+	if holder != "" {
+		status += " held by " + holder
+	}
+	if !executor.ReportStatus(ctx, status) && verbose {
+		fmt.Printf("%s, retrying in %v...\n", status, lockRetryDelay)
+	}
+}
+
+// lockRetryDelay is the delay between checks of the apt lock while another process holds it
+var lockRetryDelay = 5 * time.Second
+
+// aptExecutor runs the apt commands, replaced by a mock in tests
+var aptExecutor = executor.NewExecutor()
+
+// lockHolderRegex matches apt's description of the process holding the lock, e.g.
+// "E: Could not get lock /var/lib/dpkg/lock-frontend. It is held by process 1234 (unattended-upgr)"
+var lockHolderRegex = regexp.MustCompile(`held by process (\d+)(?: \(([^)]+)\))?`)
+
+// lockError reports whether err is an apt command failing because another process holds the lock.
+// holder describes that process if apt named it.
+func lockError(err error) (holder string, ok bool) {
+	cmdErr, isCmdErr := errors.AsType[*executor.CommandError](err)
+	if !isCmdErr {
+		return "", false
+	}
+	stderr := string(cmdErr.Stderr)
+	if !strings.Contains(stderr, "Could not get lock") && !strings.Contains(stderr, "Unable to acquire the dpkg frontend lock") {
+		return "", false
+	}
+
+	if match := lockHolderRegex.FindStringSubmatch(stderr); match != nil {
+		if match[2] != "" {
+			return fmt.Sprintf("%s (pid %s)", match[2], match[1]), true
 		}
+		return "pid " + match[1], true
+	}
+	return "", true
+}
 
+// runAptCommand runs an apt command through sudo, like executor.RunVerbose.
+// WaitForAptLock can't rule out another process taking the lock just before the command starts,
+// so a command failing to get the lock is retried until the apt_lock_timeout of the sb config,
+// reporting the wait like WaitForAptLock.
+func runAptCommand(ctx context.Context, args []string, verbose bool, options ...executor.Option) error {
+	timeout := config.SB().AptLockTimeout
+	deadline := time.Now().Add(timeout)
+	for waited := false; ; waited = true {
+		if waited {
+			executor.ReportStatus(ctx, "")
+		}
+		err := executor.RunVerboseWith(ctx, aptExecutor, "sudo", args, verbose, options...)
+		holder, locked := lockError(err)
 		if !locked {
-			if attempt == 1 {
-				logging.DebugBool(verbose, "Apt lock is available, proceeding...")
-			} else {
-				logging.DebugBool(verbose, "Apt lock released, proceeding...")
-			}
-			return nil // Lock is available, proceed
+			return err
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %v waiting for apt lock: %w", timeout, err)
 		}
 
-		// Lock is held, wait and retry
-		logging.DebugBool(verbose, "Waiting for apt lock to be released (attempt %d/%d), retrying in %v...",
-			attempt, maxRetries, delay)
-
+		reportLockWait(ctx, holder, verbose)
 		select {
 		case <-ctx.Done():
 			return fmt.Errorf("context cancelled while waiting for apt lock: %w", ctx.Err())
-		case <-time.After(delay):
-			// Cap delay at 10 seconds to avoid waiting too long between checks
-			if delay < 10*time.Second {
-				delay = min(delay*2, 10*time.Second)
-			}
+		case <-time.After(lockRetryDelay):
 		}
 	}
-
-	return fmt.Errorf("timed out waiting for apt lock after %d attempts", maxRetries)
 }
 
 // InstallPackage returns a function that installs one or more apt packages using "apt-get install".
@@ -115,9 +190,20 @@ func InstallPackage(ctx context.Context, packages []string, verbose bool) func()
 		// Build the command arguments starting with "apt-get install -y"
 		args := append([]string{"apt-get", "install", "-y"}, packages...)
 
-		// Run the command with the unified executor
-		err := executor.RunVerbose(ctx, "sudo", args, verbose,
-			executor.WithInheritEnv("DEBIAN_FRONTEND=noninteractive"))
+		options := []executor.Option{executor.WithInheritEnv("DEBIAN_FRONTEND=noninteractive")}
+
+		// Behind a spinner, show the package apt is working on instead of its full output.
+		// The generic status remains if apt's output format isn't recognized.
+		if !verbose && executor.ReportStatus(ctx, "installing...") {
+			options = append(options, executor.WithLineCallback(func(line string) {
+				if status, ok := parseInstallProgress(line); ok {
+					executor.ReportStatus(ctx, status)
+				}
+			}))
+		}
+
+		// Run the command, waiting out the apt lock if another process takes it
+		err := runAptCommand(ctx, args, verbose, options...)
 
 		// Handle command execution errors.
 		if err != nil {
@@ -135,6 +221,83 @@ func InstallPackage(ctx context.Context, packages []string, verbose bool) func()
 	}
 }
 
+// UpgradePackages returns a function that upgrades all installed packages using "apt-get upgrade".
+// Modified configuration files are kept, so the upgrade never prompts about them.
+// Like InstallPackage, the package being worked on is shown behind a spinner, and output is
+// streamed to the console when verbose is true.
+// The context parameter allows for cancellation of the upgrade.
+func UpgradePackages(ctx context.Context, verbose bool) func() error {
+	return func() error {
+		// Wait for apt lock to be available
+		if err := WaitForAptLock(ctx, verbose); err != nil {
+			return fmt.Errorf("failed waiting for apt lock: %w", err)
+		}
+
+		args := []string{
+			"apt-get", "upgrade", "-y",
+			"-o", "Dpkg::Options::=--force-confdef",
+			"-o", "Dpkg::Options::=--force-confold",
+		}
+
+		options := []executor.Option{executor.WithInheritEnv("DEBIAN_FRONTEND=noninteractive")}
+		if !verbose && executor.ReportStatus(ctx, "upgrading...") {
+			options = append(options, executor.WithLineCallback(func(line string) {
+				if status, ok := parseInstallProgress(line); ok {
+					executor.ReportStatus(ctx, status)
+				}
+			}))
+		}
+
+		// Run the command, waiting out the apt lock if another process takes it
+		if err := runAptCommand(ctx, args, verbose, options...); err != nil {
+			return fmt.Errorf("failed to upgrade packages: %w", err)
+		}
+
+		if verbose {
+			fmt.Println("Packages upgraded successfully.")
+		}
+
+		return nil
+	}
+}
+
+// parseInstallProgress turns an apt-get install progress line into a short status naming the package.
+// Recognizes download ("Get:"), unpack ("Unpacking") and configure ("Setting up") lines.
+// Returns false for any other line.
+func parseInstallProgress(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+
+	// Get:3 http://archive.ubuntu.com/ubuntu noble/main amd64 jq amd64 1.7.1-3build1 [65.7 kB]
+	if strings.HasPrefix(line, "Get:") {
+		if i := strings.LastIndex(line, " ["); i > 0 {
+			line = line[:i]
+		}
+		// The package is followed by its architecture and version
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			return "", false
+		}
+		return "Downloading " + fields[len(fields)-3], true
+	}
+
+	// Unpacking jq (1.7.1-3build1) ...
+	// Setting up libjq1:amd64 (1.7.1-3build1) ...
+	for _, action := range []string{"Unpacking", "Setting up"} {
+		rest, ok := strings.CutPrefix(line, action+" ")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			return "", false
+		}
+		name, _, _ := strings.Cut(fields[0], ":")
+		return action + " " + name, true
+	}
+
+	return "", false
+}
+
 // UpdatePackageLists returns a function that updates the system's apt package lists.
 // When executed, it runs the "sudo apt-get update" command with the non-interactive environment.
 // The verbose flag determines whether the command output is streamed to the console or discarded.
@@ -166,8 +329,8 @@ func UpdatePackageLists(ctx context.Context, verbose bool) func() error {
 			// Create a timeout context for this attempt
 			attemptCtx, cancel := context.WithTimeout(ctx, attemptTimeout)
 
-			// Run the command with the unified executor
-			err := executor.RunVerbose(attemptCtx, "sudo", []string{"apt-get", "update"}, verbose,
+			// Run the command, waiting out the apt lock if another process takes it
+			err := runAptCommand(attemptCtx, []string{"apt-get", "update"}, verbose,
 				executor.WithInheritEnv("DEBIAN_FRONTEND=noninteractive"))
 
 			// Clean up the timeout context
@@ -227,14 +390,25 @@ func UpdatePackageLists(ctx context.Context, verbose bool) func() error {
 	}
 }
 
+// currentRelease detects the running release, replaced in tests
+var currentRelease = ubuntu.CurrentRelease
+
+// aptSourcesFile and aptSourcesDir hold the apt repository configuration, replaced in tests
+var (
+	aptSourcesFile = "/etc/apt/sources.list"
+	aptSourcesDir  = "/etc/apt/sources.list.d/"
+)
+
 // AddAptRepositories configures the system's apt repositories based on the Ubuntu release codename.
-// It first retrieves the current Ubuntu codename using "lsb_release -sc".
+// It first retrieves the current Ubuntu codename from /etc/os-release. Ubuntu derivatives keep their
+// own repository configuration, which already includes the Ubuntu archives they are based on, so
+// nothing is changed on them.
 // Then it resets the repository configuration by removing and recreating the "/etc/apt/sources.list.d/" directory.
 // Depending on the codename (e.g., matching "jammy" or "noble"), it adds a predefined list of repository entries
 // to the main sources file ("/etc/apt/sources.list") using the helper function addRepo.
 // If the release codename is unsupported or any step fails, an error is returned.
-// The context parameter is used for external command execution but not for local file I/O
-// operations, as Go's standard library does not provide context-aware file operations.
+// The context parameter is accepted for consistency with the other apt helpers; all steps are local
+// file operations, for which Go's standard library does not provide context-aware variants.
 // The verbose flag controls whether informative messages about the configuration process are printed.
 //
 //goland:noinspection HttpUrlsUsage
@@ -243,24 +417,29 @@ func AddAptRepositories(ctx context.Context, verbose bool) error {
 	if verbose {
 		fmt.Println("Detecting Ubuntu release codename...")
 	}
-	result, err := executor.Run(ctx, "lsb_release",
-		executor.WithArgs("-sc"))
+	ubuntuRelease, err := currentRelease()
 	if err != nil {
 		return fmt.Errorf("error getting Ubuntu release codename: %w", err)
 	}
-	release := strings.TrimSpace(string(result.Combined))
+	if ubuntuRelease.Derivative {
+		if verbose {
+			fmt.Printf("Keeping the repository configuration of %s\n", ubuntuRelease.Name)
+		}
+		return nil
+	}
+	release := ubuntuRelease.Codename
 	if verbose {
 		fmt.Printf("Detected Ubuntu release: %s\n", release)
 	}
 
-	sourcesFile := "/etc/apt/sources.list"
+	sourcesFile := aptSourcesFile
 
 	// Define regex patterns to identify specific Ubuntu releases.
 	jammyRegex := regexp.MustCompile(`(jammy)$`)
 	nobleRegex := regexp.MustCompile(`(noble)$`)
 
 	// Remove repository configuration files, but preserve ubuntu.sources on Noble
-	sourcesDir := "/etc/apt/sources.list.d/"
+	sourcesDir := aptSourcesDir
 	if verbose {
 		fmt.Printf("Cleaning up existing repository configuration files in %s\n", sourcesDir)
 	}
@@ -555,8 +734,8 @@ func AddPPA(ctx context.Context, ppa string, verbose bool) func() error {
 			return fmt.Errorf("failed waiting for apt lock: %w", err)
 		}
 
-		// Run the command with the unified executor
-		err := executor.RunVerbose(ctx, "sudo", []string{"add-apt-repository", ppa, "--yes"}, verbose,
+		// Run the command, waiting out the apt lock if another process takes it
+		err := runAptCommand(ctx, []string{"add-apt-repository", ppa, "--yes"}, verbose,
 			executor.WithInheritEnv("DEBIAN_FRONTEND=noninteractive"))
 
 		// Handle errors during PPA addition.
@@ -2,9 +2,19 @@ package apt
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
+
+	"github.com/saltyorg/sb-go/internal/config"
+	"github.com/saltyorg/sb-go/internal/executor"
+	"github.com/saltyorg/sb-go/internal/ubuntu"
 )
 
 // TestInstallPackage_NonExistentPackage tests that we get proper error information
@@ -98,3 +108,249 @@ func TestInstallPackage_VerboseMode(t *testing.T) {
 
 	t.Logf("Verbose mode error message:\n%s", errMsg)
 }
+
+func TestParseInstallProgress(t *testing.T) {
+	tests := []struct {
+		line   string
+		want   string
+		wantOK bool
+	}{
+		{line: "Get:3 http://archive.ubuntu.com/ubuntu noble/main amd64 jq amd64 1.7.1-3build1 [65.7 kB]", want: "Downloading jq", wantOK: true},
+		{line: "Get:1 http://archive.ubuntu.com/ubuntu focal-updates/main amd64 libjq1 amd64 1.6-1ubuntu0.20.04.1 [121 kB]", want: "Downloading libjq1", wantOK: true},
+		{line: "Unpacking jq (1.7.1-3build1) ...", want: "Unpacking jq", wantOK: true},
+		{line: "Unpacking libssl3t64:amd64 (3.0.13-0ubuntu3.5) over (3.0.13-0ubuntu3.4) ...", want: "Unpacking libssl3t64", wantOK: true},
+		{line: "Setting up libjq1:amd64 (1.7.1-3build1) ...", want: "Setting up libjq1", wantOK: true},
+		{line: "Preparing to unpack .../jq_1.7.1-3build1_amd64.deb ...", wantOK: false},
+		{line: "Reading package lists...", wantOK: false},
+		{line: "Get:1 incomplete", wantOK: false},
+		{line: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseInstallProgress(tt.line)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("parseInstallProgress(%q) = %q, %v; want %q, %v", tt.line, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+// lockedResult simulates apt-get failing because another process holds the dpkg lock
+func lockedResult(config *executor.Config) (*executor.Result, error) {
+	stderr := []byte("E: Could not get lock /var/lib/dpkg/lock-frontend. It is held by process 1234 (unattended-upgr)\n" +
+		"E: Unable to acquire the dpkg frontend lock (/var/lib/dpkg/lock-frontend), is another process using it?\n")
+	err := &executor.CommandError{Command: config.Command, Args: config.Args, ExitCode: 100, Stderr: stderr, Err: errors.New("exit status 100")}
+	return &executor.Result{ExitCode: 100, Stderr: stderr, Error: err}, err
+}
+
+// withMockAptExecutor replaces the apt executor and removes the retry delay for the duration of a test
+func withMockAptExecutor(t *testing.T, mock *executor.MockExecutor) {
+	t.Helper()
+	originalExecutor, originalDelay := aptExecutor, lockRetryDelay
+	aptExecutor, lockRetryDelay = mock, 0
+	t.Cleanup(func() { aptExecutor, lockRetryDelay = originalExecutor, originalDelay })
+}
+
+// withAptLockTimeout sets the apt_lock_timeout of the sb config for the duration of a test
+func withAptLockTimeout(t *testing.T, timeout time.Duration) {
+	t.Helper()
+	original := config.SB()
+	cfg := original
+	cfg.AptLockTimeout = timeout
+	config.SetSB(cfg)
+	t.Cleanup(func() { config.SetSB(original) })
+}
+
+func TestRunAptCommand_WaitsForLock(t *testing.T) {
+	mock := executor.NewMockExecutor()
+	mock.ExecuteFunc = func(config *executor.Config) (*executor.Result, error) {
+		if len(mock.Calls) < 2 {
+			return lockedResult(config)
+		}
+		return &executor.Result{}, nil
+	}
+	withMockAptExecutor(t, mock)
+
+	var statuses []string
+	ctx := executor.WithStatusReporter(context.Background(), func(status string) {
+		statuses = append(statuses, status)
+	})
+
+	if err := runAptCommand(ctx, []string{"apt-get", "install", "-y", "jq"}, false); err != nil {
+		t.Fatalf("runAptCommand() unexpected error: %v", err)
+	}
+	if mock.CallCount() != 3 {
+		t.Errorf("expected 3 attempts, got %d", mock.CallCount())
+	}
+	if !slices.Contains(statuses, "waiting for apt lock held by unattended-upgr (pid 1234)") {
+		t.Errorf("expected lock holder in reported status, got %q", statuses)
+	}
+	if last := statuses[len(statuses)-1]; last != "" {
+		t.Errorf("expected status cleared once the lock is released, got %q", last)
+	}
+}
+
+func TestRunAptCommand_LockTimeout(t *testing.T) {
+	mock := executor.NewMockExecutor()
+	mock.ExecuteFunc = lockedResult
+	withMockAptExecutor(t, mock)
+
+	withAptLockTimeout(t, 0)
+
+	err := runAptCommand(context.Background(), []string{"apt-get", "update"}, false)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	if mock.CallCount() != 1 {
+		t.Errorf("expected 1 attempt, got %d", mock.CallCount())
+	}
+}
+
+func TestWaitForAptLock_Timeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lock-frontend")
+	holder, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create lock file: %v", err)
+	}
+	defer func() { _ = holder.Close() }()
+	if err := syscall.Flock(int(holder.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		t.Fatalf("failed to lock: %v", err)
+	}
+
+	originalLockFile, originalDelay := aptLockFile, lockRetryDelay
+	aptLockFile, lockRetryDelay = path, time.Millisecond
+	t.Cleanup(func() { aptLockFile, lockRetryDelay = originalLockFile, originalDelay })
+	withAptLockTimeout(t, 0)
+
+	err = WaitForAptLock(context.Background(), false)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+}
+
+func TestWaitForAptLock_ReportsWait(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lock-frontend")
+	holder, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create lock file: %v", err)
+	}
+	defer func() { _ = holder.Close() }()
+	if err := syscall.Flock(int(holder.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		t.Fatalf("failed to lock: %v", err)
+	}
+
+	originalLockFile, originalDelay := aptLockFile, lockRetryDelay
+	aptLockFile, lockRetryDelay = path, time.Millisecond
+	t.Cleanup(func() { aptLockFile, lockRetryDelay = originalLockFile, originalDelay })
+	withAptLockTimeout(t, time.Minute)
+
+	var statuses []string
+	ctx := executor.WithStatusReporter(context.Background(), func(status string) {
+		// Release the lock once the wait has been reported
+		if len(statuses) == 0 {
+			_ = syscall.Flock(int(holder.Fd()), syscall.LOCK_UN)
+		}
+		statuses = append(statuses, status)
+	})
+
+	if err := WaitForAptLock(ctx, false); err != nil {
+		t.Fatalf("WaitForAptLock() unexpected error: %v", err)
+	}
+	if len(statuses) < 2 || !strings.HasPrefix(statuses[0], "waiting for apt lock") {
+		t.Fatalf("expected the wait to be reported, got %q", statuses)
+	}
+	if last := statuses[len(statuses)-1]; last != "" {
+		t.Errorf("expected status cleared once the lock is released, got %q", last)
+	}
+}
+
+func TestProcessDescription(t *testing.T) {
+	if got := processDescription(0); got != "" {
+		t.Errorf("processDescription(0) = %q, want empty", got)
+	}
+	pid := os.Getpid()
+	if got := processDescription(pid); !strings.HasSuffix(got, fmt.Sprintf(" (pid %d)", pid)) {
+		t.Errorf("processDescription(%d) = %q, want name and pid", pid, got)
+	}
+}
+
+func TestRunAptCommand_OtherErrorsNotRetried(t *testing.T) {
+	mock := executor.NewMockExecutor()
+	mock.ExecuteFunc = func(config *executor.Config) (*executor.Result, error) {
+		stderr := []byte("E: Unable to locate package nope\n")
+		err := &executor.CommandError{Command: config.Command, ExitCode: 100, Stderr: stderr, Err: errors.New("exit status 100")}
+		return &executor.Result{ExitCode: 100, Stderr: stderr, Error: err}, err
+	}
+	withMockAptExecutor(t, mock)
+
+	if err := runAptCommand(context.Background(), []string{"apt-get", "install", "-y", "nope"}, false); err == nil {
+		t.Fatal("expected error")
+	}
+	if mock.CallCount() != 1 {
+		t.Errorf("expected 1 attempt, got %d", mock.CallCount())
+	}
+}
+
+func TestAddAptRepositories_Derivative(t *testing.T) {
+	original := currentRelease
+	currentRelease = func() (ubuntu.Release, error) {
+		return ubuntu.Release{Name: "Linux Mint 22", Version: "24.04", Codename: "noble", Derivative: true}, nil
+	}
+	t.Cleanup(func() { currentRelease = original })
+
+	dir := t.TempDir()
+	sourcesDir := filepath.Join(dir, "sources.list.d")
+	sourcesFile := filepath.Join(dir, "sources.list")
+	mintSources := filepath.Join(sourcesDir, "official-package-repositories.list")
+	if err := os.MkdirAll(sourcesDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{sourcesFile, mintSources} {
+		if err := os.WriteFile(path, []byte("deb http://packages.linuxmint.com wilma main\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	originalFile, originalDir := aptSourcesFile, aptSourcesDir
+	aptSourcesFile, aptSourcesDir = sourcesFile, sourcesDir
+	t.Cleanup(func() { aptSourcesFile, aptSourcesDir = originalFile, originalDir })
+
+	if err := AddAptRepositories(context.Background(), false); err != nil {
+		t.Fatalf("AddAptRepositories() unexpected error on a derivative: %v", err)
+	}
+	for _, path := range []string{sourcesFile, mintSources} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("expected %s to be kept: %v", path, err)
+		}
+		if !strings.Contains(string(data), "packages.linuxmint.com") {
+			t.Errorf("%s was rewritten: %q", path, data)
+		}
+	}
+}
+
+func TestLockError(t *testing.T) {
+	tests := []struct {
+		name       string
+		stderr     string
+		wantHolder string
+		wantLocked bool
+	}{
+		{name: "named holder", stderr: "E: Could not get lock /var/lib/apt/lists/lock. It is held by process 42 (apt-get)", wantHolder: "apt-get (pid 42)", wantLocked: true},
+		{name: "pid only", stderr: "E: Could not get lock /var/lib/dpkg/lock. It is held by process 42", wantHolder: "pid 42", wantLocked: true},
+		{name: "no holder", stderr: "E: Unable to acquire the dpkg frontend lock (/var/lib/dpkg/lock-frontend)", wantLocked: true},
+		{name: "other error", stderr: "E: Unable to locate package nope"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("command failed: %w", &executor.CommandError{Stderr: []byte(tt.stderr), Err: errors.New("exit status 100")})
+			holder, locked := lockError(err)
+			if holder != tt.wantHolder || locked != tt.wantLocked {
+				t.Errorf("lockError() = %q, %v; want %q, %v", holder, locked, tt.wantHolder, tt.wantLocked)
+			}
+		})
+	}
+
+	if _, locked := lockError(errors.New("Could not get lock")); locked {
+		t.Error("expected plain errors not to be treated as lock errors")
+	}
+}
@@ -147,7 +147,7 @@ func ansibleBoolValidator(fl validator.FieldLevel) bool {
 // custom validator for timezone or "auto"
 func timezoneOrAutoValidator(fl validator.FieldLevel) bool {
 	tz := fl.Field().String()
-	if strings.ToLower(tz) == "auto" {
+	if IsTimezoneAuto(tz) {
 		return true
 	}
 	_, err := time.LoadLocation(tz)
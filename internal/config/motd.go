@@ -3,25 +3,152 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 // MOTDConfig represents the MOTD configuration structure
 type MOTDConfig struct {
-	Sonarr      *AppSection         `yaml:"sonarr"`
-	Radarr      *AppSection         `yaml:"radarr"`
-	Lidarr      *AppSection         `yaml:"lidarr"`
-	Readarr     *AppSection         `yaml:"readarr"`
-	Plex        *PlexSection        `yaml:"plex"`
-	Jellyfin    *JellyfinSection    `yaml:"jellyfin"`
-	Emby        *EmbySection        `yaml:"emby"`
-	Sabnzbd     *AppSection         `yaml:"sabnzbd"`
-	Nzbget      *UserPassAppSection `yaml:"nzbget"`
-	Qbittorrent *UserPassAppSection `yaml:"qbittorrent"`
-	Rtorrent    *UserPassAppSection `yaml:"rtorrent"`
-	Systemd     *SystemdConfig      `yaml:"systemd"`
-	Colors      *MOTDColors         `yaml:"colors"`
+	Sonarr       *AppSection         `yaml:"sonarr"`
+	Radarr       *AppSection         `yaml:"radarr"`
+	Lidarr       *AppSection         `yaml:"lidarr"`
+	Readarr      *AppSection         `yaml:"readarr"`
+	Plex         *PlexSection        `yaml:"plex"`
+	Jellyfin     *JellyfinSection    `yaml:"jellyfin"`
+	Emby         *EmbySection        `yaml:"emby"`
+	Sabnzbd      *AppSection         `yaml:"sabnzbd"`
+	Nzbget       *UserPassAppSection `yaml:"nzbget"`
+	Qbittorrent  *UserPassAppSection `yaml:"qbittorrent"`
+	Rtorrent     *UserPassAppSection `yaml:"rtorrent"`
+	Systemd      *SystemdConfig      `yaml:"systemd"`
+	Colors       *MOTDColors         `yaml:"colors"`
+	Sections     *SectionsConfig     `yaml:"sections"`
+	Header       *HeaderConfig       `yaml:"header"`
+	DiskHealth   *DiskHealthConfig   `yaml:"disk_health"`
+	RcloneMounts *RcloneMountsConfig `yaml:"rclone_mounts"`
+	Disk         *DiskConfig         `yaml:"disk"`
+	BarStyle     string              `yaml:"bar_style" validate:"omitempty,oneof=block smooth ascii"`
+	LastLogin    *LastLoginConfig    `yaml:"last_login"`
+	Traefik      *TraefikAPIConfig   `yaml:"traefik"`
+	Docker       *DockerMOTDConfig   `yaml:"docker"`
+	GPU          *GPUMOTDConfig      `yaml:"gpu"`
+	QuietHours   *QuietHoursConfig   `yaml:"quiet_hours"`
+	OnlyProblems bool                `yaml:"only_problems"`
+	KeyWidth     int                 `yaml:"key_width" validate:"omitempty,min=1,max=40"`
+	KeySeparator string              `yaml:"key_separator"`
+}
+
+// QuietHoursConfig represents a daily window during which MOTD sections that make network
+// requests are skipped. Times are HH:MM in local time, and a window ending before it starts
+// spans midnight, e.g. 22:00 to 07:00.
+type QuietHoursConfig struct {
+	Start string `yaml:"start" validate:"required,datetime=15:04"`
+	End   string `yaml:"end" validate:"required,datetime=15:04"`
+}
+
+// Contains reports whether t falls within the quiet hours, including the start minute and
+// excluding the end minute. A window starting and ending at the same time is never active.
+func (c *QuietHoursConfig) Contains(t time.Time) (bool, error) {
+	start, err := time.Parse("15:04", c.Start)
+	if err != nil {
+		return false, fmt.Errorf("invalid quiet hours start %q: %w", c.Start, err)
+	}
+	end, err := time.Parse("15:04", c.End)
+	if err != nil {
+		return false, fmt.Errorf("invalid quiet hours end %q: %w", c.End, err)
+	}
+
+	minute := func(t time.Time) int { return t.Hour()*60 + t.Minute() }
+	now, from, until := minute(t), minute(start), minute(end)
+	if from <= until {
+		return now >= from && now < until, nil
+	}
+	return now >= from || now < until, nil
+}
+
+// GPUMOTDConfig represents configuration for the GPU section. Adapters are matched by case-insensitive
+// substrings of their lspci name, e.g. "Matrox".
+type GPUMOTDConfig struct {
+	// Exclude hides adapters in addition to the built-in list of server management controllers
+	Exclude []string `yaml:"exclude" validate:"dive,required"`
+	// Include shows adapters even when an exclusion matches them
+	Include []string `yaml:"include" validate:"dive,required"`
+}
+
+// DockerMOTDConfig represents configuration for the Docker section
+type DockerMOTDConfig struct {
+	// Expected lists the names of containers that should exist; missing ones are reported
+	Expected []string `yaml:"expected" validate:"dive,required"`
+}
+
+// TraefikAPIConfig represents how to reach the Traefik API for router status.
+// The defaults match the Saltbox Traefik container, so it's only needed for custom setups.
+type TraefikAPIConfig struct {
+	URL      string `yaml:"url" validate:"omitempty,url"`
+	User     string `yaml:"user" validate:"required_with=Password"`
+	Password string `yaml:"password" validate:"required_with=User"`
+	Timeout  int    `yaml:"timeout" validate:"omitempty,gt=0"`
+}
+
+// LastLoginConfig represents configuration for enriching the last login source address
+type LastLoginConfig struct {
+	ReverseDNS bool `yaml:"reverse_dns"`
+	GeoIP      bool `yaml:"geoip"`
+	Timeout    int  `yaml:"timeout" validate:"omitempty,gt=0"`
+}
+
+// DiskConfig represents configuration for the disk usage section.
+// Thresholds are usage percentages; zero values fall back to the defaults.
+type DiskConfig struct {
+	Warning  int                       `yaml:"warning" validate:"omitempty,min=1,max=100"`
+	Critical int                       `yaml:"critical" validate:"omitempty,min=1,max=100"`
+	Mounts   map[string]DiskThresholds `yaml:"mounts" validate:"dive"`
+}
+
+// DiskThresholds overrides the usage thresholds for a single mount point
+type DiskThresholds struct {
+	Warning  int `yaml:"warning" validate:"omitempty,min=1,max=100"`
+	Critical int `yaml:"critical" validate:"omitempty,min=1,max=100"`
+}
+
+// DiskHealthConfig represents configuration for the SMART disk health section.
+// The section is opt-in since it requires smartmontools and root privileges.
+type DiskHealthConfig struct {
+	Enabled *bool `yaml:"enabled,omitempty"`
+}
+
+// IsEnabled returns true if the section is enabled (defaults to true if not set)
+func (c *DiskHealthConfig) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// HeaderConfig represents configuration for the hostname header shown above the MOTD
+type HeaderConfig struct {
+	Enabled *bool  `yaml:"enabled,omitempty"`
+	Text    string `yaml:"text"`
+}
+
+// IsEnabled returns true if the header is enabled (defaults to true if not set)
+func (c *HeaderConfig) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// RcloneMountsConfig represents configuration for the rclone mount health section
+type RcloneMountsConfig struct {
+	Enabled *bool    `yaml:"enabled,omitempty"`
+	Paths   []string `yaml:"paths"`
+}
+
+// IsEnabled returns true if the section is enabled (defaults to true if not set)
+func (c *RcloneMountsConfig) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// SectionsConfig controls which MOTD sections are displayed and in which order
+type SectionsConfig struct {
+	Order    []string `yaml:"order"`
+	Disabled []string `yaml:"disabled"`
 }
 
 // AppSection wraps app instances with a section-level enabled toggle
@@ -163,6 +290,7 @@ func (s *UserPassAppSection) IsEnabled() bool {
 type SystemdConfig struct {
 	Enabled            *bool             `yaml:"enabled,omitempty"`
 	AdditionalServices []string          `yaml:"additional_services"`
+	LogPrefixes        []string          `yaml:"log_prefixes"` // Extra service name prefixes listed by sb logs
 	DisplayNames       map[string]string `yaml:"display_names"`
 }
 
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/constants"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultSBConfigPath is the system-wide configuration file of sb itself
+	DefaultSBConfigPath = "/etc/sb/config.yml"
+	// SBConfigEnv selects another configuration file, like the --config flag
+	SBConfigEnv = "SB_CONFIG"
+
+	minFollowInterval = 100 * time.Millisecond
+	minAPITimeout     = time.Second
+)
+
+// SBConfig represents the configuration of sb itself, as opposed to the Saltbox configuration files.
+// Values are layered: the defaults, then the config file, then SB_* environment variables.
+type SBConfig struct {
+	// MOTDConfig is the path of the MOTD config used by sb motd, sb status, sb logs and sb traefik
+	MOTDConfig string `yaml:"motd_config"`
+	// FollowInterval is the default poll interval of follow mode in sb logs and sb docker logs
+	FollowInterval time.Duration `yaml:"follow_interval"`
+	// APITimeout is the timeout of each request made when validating Cloudflare and Docker Hub credentials
+	APITimeout time.Duration `yaml:"api_timeout"`
+	// AptLockTimeout is how long apt commands wait while another process holds the apt/dpkg lock
+	AptLockTimeout time.Duration `yaml:"apt_lock_timeout"`
+}
+
+// sbConfigEnvOverrides maps environment variables to the setting they override
+var sbConfigEnvOverrides = map[string]func(cfg *SBConfig, value string) error{
+	"SB_MOTD_CONFIG": func(cfg *SBConfig, value string) error {
+		cfg.MOTDConfig = value
+		return nil
+	},
+	"SB_FOLLOW_INTERVAL": func(cfg *SBConfig, value string) error {
+		interval, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		cfg.FollowInterval = interval
+		return nil
+	},
+	"SB_API_TIMEOUT": func(cfg *SBConfig, value string) error {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		cfg.APITimeout = timeout
+		return nil
+	},
+	"SB_APT_LOCK_TIMEOUT": func(cfg *SBConfig, value string) error {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		cfg.AptLockTimeout = timeout
+		return nil
+	},
+}
+
+// EnvOverrideNames returns the environment variables that override settings, sorted by name
+func EnvOverrideNames() []string {
+	return slices.Sorted(maps.Keys(sbConfigEnvOverrides))
+}
+
+// SetEnvOverride sets an environment override given as NAME=value. sb passes its overrides on
+// this way with --env when relaunching itself with sudo, which resets the environment.
+func SetEnvOverride(assignment string) error {
+	name, value, ok := strings.Cut(assignment, "=")
+	if _, known := sbConfigEnvOverrides[name]; !ok || !known {
+		return fmt.Errorf("invalid --env %q (must be NAME=value with NAME one of: %s)", assignment, strings.Join(EnvOverrideNames(), ", "))
+	}
+	return os.Setenv(name, value)
+}
+
+// DefaultSBConfig returns the configuration used when no config file or overrides are present
+func DefaultSBConfig() SBConfig {
+	return SBConfig{
+		MOTDConfig:     constants.SaltboxMOTDConfigPath,
+		FollowInterval: 500 * time.Millisecond,
+		APITimeout:     10 * time.Second,
+		AptLockTimeout: 10 * time.Minute,
+	}
+}
+
+// Validate checks the configuration after all layers have been applied
+func (c SBConfig) Validate() error {
+	if c.MOTDConfig == "" {
+		return fmt.Errorf("motd_config must not be empty")
+	}
+	if c.FollowInterval < minFollowInterval {
+		return fmt.Errorf("follow_interval %s must be at least %s", c.FollowInterval, minFollowInterval)
+	}
+	if c.APITimeout < minAPITimeout {
+		return fmt.Errorf("api_timeout %s must be at least %s", c.APITimeout, minAPITimeout)
+	}
+	if c.AptLockTimeout < 0 {
+		return fmt.Errorf("apt_lock_timeout %s must not be negative", c.AptLockTimeout)
+	}
+	return nil
+}
+
+// LoadSBConfig loads the sb configuration from path, layered over the defaults and under the
+// environment overrides. An empty path uses $SB_CONFIG or DefaultSBConfigPath. The default file
+// is optional, but a file that was asked for explicitly must exist.
+func LoadSBConfig(path string) (SBConfig, error) {
+	cfg := DefaultSBConfig()
+
+	explicit := path != ""
+	if !explicit {
+		path = os.Getenv(SBConfigEnv)
+		explicit = path != ""
+	}
+	if !explicit {
+		path = DefaultSBConfigPath
+	}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist) && !explicit:
+		// No config file, so the defaults apply
+	case err != nil:
+		return SBConfig{}, fmt.Errorf("failed to read sb config: %w", err)
+	default:
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+			return SBConfig{}, fmt.Errorf("failed to parse sb config %s: %w", path, err)
+		}
+	}
+
+	for env, apply := range sbConfigEnvOverrides {
+		if value := os.Getenv(env); value != "" {
+			if err := apply(&cfg, value); err != nil {
+				return SBConfig{}, fmt.Errorf("invalid %s: %w", env, err)
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return SBConfig{}, fmt.Errorf("invalid sb config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+var (
+	sbConfigMu sync.RWMutex
+	sbConfig   = DefaultSBConfig()
+)
+
+// SetSB sets the sb configuration used for the rest of the process
+func SetSB(cfg SBConfig) {
+	sbConfigMu.Lock()
+	defer sbConfigMu.Unlock()
+	sbConfig = cfg
+}
+
+// SB returns the sb configuration of this process, or the defaults if none was loaded
+func SB() SBConfig {
+	sbConfigMu.RLock()
+	defer sbConfigMu.RUnlock()
+	return sbConfig
+}
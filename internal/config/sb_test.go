@@ -0,0 +1,135 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeSBConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestLoadSBConfig(t *testing.T) {
+	t.Run("defaults without file", func(t *testing.T) {
+		t.Setenv(SBConfigEnv, "")
+		cfg, err := LoadSBConfig("")
+		if _, statErr := os.Stat(DefaultSBConfigPath); statErr == nil {
+			t.Skip("system sb config present")
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg != DefaultSBConfig() {
+			t.Errorf("got %+v, want defaults %+v", cfg, DefaultSBConfig())
+		}
+	})
+
+	t.Run("file with env override", func(t *testing.T) {
+		path := writeSBConfig(t, "motd_config: /tmp/motd.yml\nfollow_interval: 2s\n")
+		t.Setenv(SBConfigEnv, path)
+		t.Setenv("SB_FOLLOW_INTERVAL", "250ms")
+		t.Setenv("SB_API_TIMEOUT", "30s")
+		t.Setenv("SB_APT_LOCK_TIMEOUT", "1m")
+
+		cfg, err := LoadSBConfig("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.MOTDConfig != "/tmp/motd.yml" {
+			t.Errorf("MOTDConfig = %q, want /tmp/motd.yml", cfg.MOTDConfig)
+		}
+		if cfg.FollowInterval != 250*time.Millisecond {
+			t.Errorf("FollowInterval = %s, want 250ms", cfg.FollowInterval)
+		}
+		if cfg.APITimeout != 30*time.Second {
+			t.Errorf("APITimeout = %s, want 30s", cfg.APITimeout)
+		}
+		if cfg.AptLockTimeout != time.Minute {
+			t.Errorf("AptLockTimeout = %s, want 1m", cfg.AptLockTimeout)
+		}
+	})
+
+	t.Run("empty file keeps defaults", func(t *testing.T) {
+		cfg, err := LoadSBConfig(writeSBConfig(t, ""))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg != DefaultSBConfig() {
+			t.Errorf("got %+v, want defaults", cfg)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		path    func(t *testing.T) string
+		wantErr string
+	}{
+		{
+			name:    "missing explicit file",
+			path:    func(t *testing.T) string { return filepath.Join(t.TempDir(), "missing.yml") },
+			wantErr: "failed to read sb config",
+		},
+		{
+			name:    "unknown field",
+			path:    func(t *testing.T) string { return writeSBConfig(t, "motd_path: /tmp/motd.yml\n") },
+			wantErr: "field motd_path not found",
+		},
+		{
+			name:    "follow interval too short",
+			path:    func(t *testing.T) string { return writeSBConfig(t, "follow_interval: 10ms\n") },
+			wantErr: "must be at least",
+		},
+		{
+			name:    "api timeout too short",
+			path:    func(t *testing.T) string { return writeSBConfig(t, "api_timeout: 500ms\n") },
+			wantErr: "api_timeout 500ms must be at least",
+		},
+		{
+			name:    "negative apt lock timeout",
+			path:    func(t *testing.T) string { return writeSBConfig(t, "apt_lock_timeout: -1m\n") },
+			wantErr: "apt_lock_timeout -1m0s must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadSBConfig(tt.path(t))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("invalid env override", func(t *testing.T) {
+		t.Setenv("SB_FOLLOW_INTERVAL", "soon")
+		_, err := LoadSBConfig(writeSBConfig(t, ""))
+		if err == nil || !strings.Contains(err.Error(), "invalid SB_FOLLOW_INTERVAL") {
+			t.Errorf("error = %v, want invalid SB_FOLLOW_INTERVAL", err)
+		}
+	})
+}
+
+func TestSetEnvOverride(t *testing.T) {
+	t.Setenv("SB_FOLLOW_INTERVAL", "")
+
+	if err := SetEnvOverride("SB_FOLLOW_INTERVAL=2s"); err != nil {
+		t.Fatalf("SetEnvOverride() error = %v", err)
+	}
+	if got := os.Getenv("SB_FOLLOW_INTERVAL"); got != "2s" {
+		t.Errorf("SB_FOLLOW_INTERVAL = %q, want 2s", got)
+	}
+
+	for _, assignment := range []string{"SB_FOLLOW_INTERVAL", "PATH=/tmp", "=1s"} {
+		if err := SetEnvOverride(assignment); err == nil {
+			t.Errorf("SetEnvOverride(%q) expected an error", assignment)
+		}
+	}
+}
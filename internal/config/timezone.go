@@ -0,0 +1,112 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/executor"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TimezoneAuto is the timezone value in adv_settings.yml that uses the timezone of the system
+const TimezoneAuto = "auto"
+
+// System sources of the timezone, replaced in tests
+var (
+	timezoneFile  = "/etc/timezone"
+	localtimeLink = "/etc/localtime"
+)
+
+// detectTimezoneWithTimedatectl asks systemd for the timezone, replaced in tests
+var detectTimezoneWithTimedatectl = func(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := executor.Run(ctx, "timedatectl",
+		executor.WithArgs("show", "--property=Timezone", "--value"),
+		executor.WithOutputMode(executor.OutputModeCapture),
+	)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(result.Stdout)), nil
+}
+
+// IsTimezoneAuto reports whether tz is the "auto" timezone sentinel, ignoring case
+func IsTimezoneAuto(tz string) bool {
+	return strings.EqualFold(tz, TimezoneAuto)
+}
+
+// ResolveTimezone returns the IANA timezone that tz stands for. A concrete zone is returned as is
+// once validated, and "auto" is resolved to the timezone detected on the system.
+func ResolveTimezone(ctx context.Context, tz string) (string, error) {
+	if !IsTimezoneAuto(tz) {
+		if _, err := time.LoadLocation(tz); err != nil {
+			return "", fmt.Errorf("invalid timezone: %s", tz)
+		}
+		return tz, nil
+	}
+	return DetectSystemTimezone(ctx)
+}
+
+// ConfiguredTimezone returns the IANA timezone that the system.timezone setting of the
+// adv_settings.yml at path stands for, resolving "auto" to the timezone of the system
+func ConfiguredTimezone(ctx context.Context, path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read adv_settings.yml: %w", err)
+	}
+
+	var settings AdvSettingsConfig
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return "", fmt.Errorf("failed to unmarshal adv_settings.yml: %w", err)
+	}
+	if settings.System.Timezone == "" {
+		return "", fmt.Errorf("system.timezone not found in adv_settings.yml")
+	}
+	return ResolveTimezone(ctx, settings.System.Timezone)
+}
+
+// DetectSystemTimezone returns the IANA timezone of the system, read from /etc/timezone,
+// the /etc/localtime symlink or timedatectl, in that order. The first zone that loads wins.
+func DetectSystemTimezone(ctx context.Context) (string, error) {
+	var candidates []string
+	if data, err := os.ReadFile(timezoneFile); err == nil {
+		candidates = append(candidates, strings.TrimSpace(string(data)))
+	}
+	if target, err := filepath.EvalSymlinks(localtimeLink); err == nil {
+		// The link points into the zoneinfo tree, e.g. /usr/share/zoneinfo/Europe/Oslo
+		if _, zone, found := strings.Cut(target, "/zoneinfo/"); found {
+			candidates = append(candidates, zone)
+		}
+	}
+
+	for _, candidate := range candidates {
+		if isLoadableTimezone(candidate) {
+			return candidate, nil
+		}
+	}
+
+	zone, err := detectTimezoneWithTimedatectl(ctx)
+	if err != nil {
+		return "", fmt.Errorf("could not detect the system timezone: %w", err)
+	}
+	if !isLoadableTimezone(zone) {
+		return "", fmt.Errorf("could not detect the system timezone: timedatectl reported %q", zone)
+	}
+	return zone, nil
+}
+
+// isLoadableTimezone reports whether zone is a named IANA timezone known to this system
+func isLoadableTimezone(zone string) bool {
+	if zone == "" || zone == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(zone)
+	return err == nil
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useTimezoneSources points the timezone detection at the given /etc/timezone content,
+// /etc/localtime symlink target and timedatectl result. Empty values leave the source missing.
+func useTimezoneSources(t *testing.T, timezone, localtime, timedatectl string) {
+	t.Helper()
+	dir := t.TempDir()
+
+	previousFile, previousLink, previousTimedatectl := timezoneFile, localtimeLink, detectTimezoneWithTimedatectl
+	t.Cleanup(func() {
+		timezoneFile, localtimeLink, detectTimezoneWithTimedatectl = previousFile, previousLink, previousTimedatectl
+	})
+
+	timezoneFile = filepath.Join(dir, "timezone")
+	if timezone != "" {
+		if err := os.WriteFile(timezoneFile, []byte(timezone+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	localtimeLink = filepath.Join(dir, "localtime")
+	if localtime != "" {
+		target := filepath.Join(dir, "zoneinfo", localtime)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(target, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(target, localtimeLink); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	detectTimezoneWithTimedatectl = func(context.Context) (string, error) {
+		if timedatectl == "" {
+			return "", errors.New("timedatectl not found")
+		}
+		return timedatectl, nil
+	}
+}
+
+func TestResolveTimezone(t *testing.T) {
+	tests := []struct {
+		name                             string
+		value                            string
+		timezone, localtime, timedatectl string
+		want                             string
+		wantErr                          string
+	}{
+		{name: "concrete zone", value: "Europe/London", want: "Europe/London"},
+		{name: "invalid zone", value: "Mars/Olympus", wantErr: "invalid timezone"},
+		{name: "auto from /etc/timezone", value: "auto", timezone: "Europe/Oslo", localtime: "Asia/Tokyo", want: "Europe/Oslo"},
+		{name: "auto from /etc/localtime", value: "AUTO", localtime: "America/New_York", want: "America/New_York"},
+		{name: "auto skips invalid file", value: "auto", timezone: "garbage", timedatectl: "Australia/Sydney", want: "Australia/Sydney"},
+		{name: "auto from timedatectl", value: "auto", timedatectl: "Etc/UTC", want: "Etc/UTC"},
+		{name: "auto undetectable", value: "auto", wantErr: "could not detect the system timezone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTimezoneSources(t, tt.timezone, tt.localtime, tt.timedatectl)
+
+			got, err := ResolveTimezone(context.Background(), tt.value)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveTimezone(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfiguredTimezone(t *testing.T) {
+	useTimezoneSources(t, "Europe/Oslo", "", "")
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "adv_settings.yml")
+	if err := os.WriteFile(path, []byte("system:\n  timezone: auto\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ConfiguredTimezone(context.Background(), path); err != nil || got != "Europe/Oslo" {
+		t.Errorf("ConfiguredTimezone() = %q, %v, want the system timezone", got, err)
+	}
+
+	if err := os.WriteFile(path, []byte("dns:\n  ipv4: yes\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ConfiguredTimezone(context.Background(), path); err == nil || !strings.Contains(err.Error(), "system.timezone not found") {
+		t.Errorf("error = %v, want the missing setting reported", err)
+	}
+
+	if _, err := ConfiguredTimezone(context.Background(), filepath.Join(dir, "missing.yml")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -22,6 +22,7 @@ const (
 	SaltboxMOTDSchemaPath             = "/srv/git/saltbox/schema/motd.schema.yml"
 	SaltboxInventoryConfigPath        = "/srv/git/saltbox/inventories/host_vars/localhost.yml"
 	SaltboxCacheFile                  = "/srv/git/saltbox/cache.json"
+	SaltboxInstallLogFile             = "/var/log/sb/install.log"
 	AnsibleVenvPath                   = "/srv/ansible"
 	AnsibleRequirementsPath           = "/srv/git/saltbox/requirements/requirements-saltbox.txt"
 	AnsibleVenvPythonVersion          = "3.12"
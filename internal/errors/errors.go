@@ -39,3 +39,42 @@ func ExitWithError(format string, args ...any) {
 	sigManager := signals.GetGlobalManager()
 	sigManager.Shutdown(1)
 }
+
+// ErrSilent marks an error of a command that already reported its outcome, e.g. sb status
+// finding issues. sb exits with the error's exit code without printing it.
+var ErrSilent = errors.New("exiting without an error message")
+
+// ExitCodeError wraps an error with the exit code sb should exit with,
+// e.g., to surface the exit code of a failed ansible-playbook run.
+type ExitCodeError struct {
+	Err  error
+	Code int
+}
+
+// Error returns the message of the wrapped error
+func (e *ExitCodeError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error
+func (e *ExitCodeError) Unwrap() error {
+	return e.Err
+}
+
+// WithExitCode wraps err so that sb exits with the given code when it is returned from a command.
+// Returns nil if err is nil.
+func WithExitCode(err error, code int) error {
+	if err == nil {
+		return nil
+	}
+	return &ExitCodeError{Err: err, Code: code}
+}
+
+// ExitCode returns the exit code for an error returned from a command.
+// This is the code of a wrapped ExitCodeError if it is positive, otherwise 1.
+func ExitCode(err error) int {
+	if exitErr, ok := errors.AsType[*ExitCodeError](err); ok && exitErr.Code > 0 {
+		return exitErr.Code
+	}
+	return 1
+}
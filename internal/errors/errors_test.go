@@ -300,3 +300,34 @@ func TestHandleInterruptError_NonInterruptErrors(t *testing.T) {
 		})
 	}
 }
+
+// TestExitCode tests exit codes carried by errors
+func TestExitCode(t *testing.T) {
+	base := fmt.Errorf("playbook failed")
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"plain error", base, 1},
+		{"exit code error", WithExitCode(base, 4), 4},
+		{"wrapped exit code error", fmt.Errorf("install: %w", WithExitCode(base, 2)), 2},
+		{"non-positive exit code", WithExitCode(base, 0), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.expected {
+				t.Errorf("ExitCode() = %d, expected %d", got, tt.expected)
+			}
+		})
+	}
+
+	if WithExitCode(nil, 2) != nil {
+		t.Error("WithExitCode(nil) should return nil")
+	}
+	if err := WithExitCode(base, 2); err.Error() != base.Error() || !errors.Is(err, base) {
+		t.Errorf("WithExitCode() should preserve the wrapped error, got %v", err)
+	}
+}
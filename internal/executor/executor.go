@@ -234,12 +234,64 @@ type Result struct {
 	// the command could not be started (e.g., command not found).
 	ExitCode int
 
-	// Error is the original error returned by the command execution, if any.
+	// Error is the error returned by the command execution, if any.
 	// This will be nil if the command completed successfully (exit code 0).
-	// For non-zero exit codes, this will typically be an *exec.ExitError.
+	// Otherwise it is a *CommandError, which typically wraps an *exec.ExitError.
 	Error error
 }
 
+// CommandError is the error returned by Execute and Run when a command fails to start
+// or exits with a non-zero code. It exposes the details of the failed command so callers
+// can branch on them with errors.As, for example to retry only on a specific exit code:
+//
+//	if cmdErr, ok := errors.AsType[*executor.CommandError](err); ok && cmdErr.ExitCode == 100 {
+//	    // retry
+//	}
+//
+// Its message is the message of the underlying error, so wrapping it reads the same as
+// wrapping the *exec.ExitError did, and errors.As still finds the *exec.ExitError.
+type CommandError struct {
+	// Command is the name or path of the command that failed.
+	Command string
+
+	// Args contains the arguments the command was run with.
+	Args []string
+
+	// ExitCode is the exit code of the command, or -1 if it could not be started.
+	ExitCode int
+
+	// Stderr contains the captured standard error output, if any.
+	// Empty for OutputModeInteractive, and the combined output for OutputModeCombined.
+	Stderr []byte
+
+	// Err is the underlying error, typically an *exec.ExitError.
+	Err error
+}
+
+// Error returns the message of the underlying error
+func (e *CommandError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error for errors.Is and errors.As
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// setCommandError replaces a failed result's error with a *CommandError describing the command
+func setCommandError(config *Config, result *Result) {
+	if result.Error == nil {
+		return
+	}
+	result.Error = &CommandError{
+		Command:  config.Command,
+		Args:     config.Args,
+		ExitCode: result.ExitCode,
+		Stderr:   result.Stderr,
+		Err:      result.Error,
+	}
+}
+
 // Config contains all configuration options for command execution.
 // Use the With* option functions to populate this struct rather than
 // creating it directly, as the option functions provide validation and
@@ -286,6 +338,12 @@ type Config struct {
 	// PseudoTerminal makes terminal-aware commands retain their interactive
 	// progress formatting while their output is captured.
 	PseudoTerminal bool
+
+	// LineCallback receives each line of standard output as it is produced.
+	// Stdout is then captured and passed to the callback instead of being displayed,
+	// except in OutputModeInteractive and with PseudoTerminal, where it is still
+	// written to the terminal or Stdout.
+	LineCallback func(line string)
 }
 
 type managedOutputContextKey struct{}
@@ -301,6 +359,25 @@ func WithManagedOutput(ctx context.Context, stdout, stderr io.Writer) context.Co
 	return context.WithValue(ctx, managedOutputContextKey{}, managedOutput{stdout: stdout, stderr: stderr})
 }
 
+type statusReporterContextKey struct{}
+
+// WithStatusReporter carries a function that shows a short status for the
+// running work, such as the package being installed, through package boundaries.
+func WithStatusReporter(ctx context.Context, report func(status string)) context.Context {
+	return context.WithValue(ctx, statusReporterContextKey{}, report)
+}
+
+// ReportStatus shows status through the reporter carried by ctx.
+// Returns false if ctx carries no reporter, so callers can fall back to plain output.
+func ReportStatus(ctx context.Context, status string) bool {
+	report, ok := ctx.Value(statusReporterContextKey{}).(func(string))
+	if !ok {
+		return false
+	}
+	report(status)
+	return true
+}
+
 // Option is a functional option for configuring command execution.
 // Options are applied in the order they are provided to Run or Execute.
 // Later options can override earlier ones.
@@ -472,6 +549,27 @@ func WithStderr(stderr io.Writer) Option {
 	}
 }
 
+// WithLineCallback passes each line of standard output to fn as it is produced,
+// e.g., to turn a command's progress output into a status line. Stdout is still
+// captured into the Result, but it is no longer displayed or written to a custom
+// or managed stdout. With OutputModeInteractive, stdout is still written to the
+// terminal and the callback receives a copy; the command then no longer sees a
+// TTY on stdout. With a pseudo-terminal, the combined output is still written to
+// Stdout and the callback receives a copy.
+//
+// Example:
+//
+//	result, err := executor.Run(ctx, "apt-get",
+//	    executor.WithArgs("install", "-y", "jq"),
+//	    executor.WithLineCallback(func(line string) {
+//	        fmt.Println("apt:", line)
+//	    }))
+func WithLineCallback(fn func(line string)) Option {
+	return func(c *Config) {
+		c.LineCallback = fn
+	}
+}
+
 // WithPseudoTerminal runs the command with stdout and stderr connected to a
 // terminal. The merged terminal stream is forwarded through Stdout.
 func WithPseudoTerminal() Option {
@@ -561,6 +659,7 @@ func NewExecutor() Executor {
 //
 //	result - Always returned (even on error) containing exit code and any captured output.
 //	error  - Non-nil if the command failed to start or returned a non-zero exit code.
+//	         Such failures are returned as a *CommandError.
 //	         The error is also stored in result.Error for convenience.
 //
 // Error Behavior:
@@ -613,22 +712,32 @@ func (e *DefaultExecutor) Execute(config *Config) (*Result, error) {
 			return nil, fmt.Errorf("starting command in pseudo-terminal: %w", err)
 		}
 
-		output := io.Writer(&stdoutBuf)
+		outputs := []io.Writer{&stdoutBuf}
 		if config.Stdout != nil {
-			output = io.MultiWriter(&stdoutBuf, config.Stdout)
+			outputs = append(outputs, config.Stdout)
+		}
+		// The terminal combines both streams, so the callback sees stderr lines too
+		var lines *lineWriter
+		if config.LineCallback != nil {
+			lines = &lineWriter{callback: config.LineCallback}
+			outputs = append(outputs, lines)
 		}
 		copyDone := make(chan struct{})
 		go func() {
-			_, _ = io.Copy(output, terminal)
+			_, _ = io.Copy(io.MultiWriter(outputs...), terminal)
 			close(copyDone)
 		}()
 
 		result.Error = cmd.Wait()
 		_ = terminal.Close()
 		<-copyDone
+		if lines != nil {
+			lines.Flush()
+		}
 		result.Stdout = stdoutBuf.Bytes()
 		result.Combined = result.Stdout
 		setExitCode(result)
+		setCommandError(config, result)
 		return result, result.Error
 	}
 
@@ -637,6 +746,11 @@ func (e *DefaultExecutor) Execute(config *Config) (*Result, error) {
 		cmd.Stdin = config.Stdin
 	}
 
+	// Lines can't be delivered from CombinedOutput, so capture the streams separately instead
+	if config.LineCallback != nil && config.OutputMode == OutputModeCombined {
+		config.OutputMode = OutputModeCapture
+	}
+
 	// Configure output handling based on mode
 	// The mode controls what's displayed, but we always capture internally
 	switch config.OutputMode {
@@ -684,6 +798,7 @@ func (e *DefaultExecutor) Execute(config *Config) (*Result, error) {
 			} else {
 				result.ExitCode = 0
 			}
+			setCommandError(config, result)
 			return result, result.Error
 		}
 
@@ -733,8 +848,23 @@ func (e *DefaultExecutor) Execute(config *Config) (*Result, error) {
 		// Note: stdoutBuf and stderrBuf remain empty for this mode
 	}
 
+	// Hand stdout to the line callback instead of the display. Interactive
+	// commands keep writing to the terminal and the callback receives a copy.
+	var lines *lineWriter
+	if config.LineCallback != nil {
+		lines = &lineWriter{callback: config.LineCallback}
+		if config.OutputMode == OutputModeInteractive {
+			cmd.Stdout = io.MultiWriter(cmd.Stdout, lines)
+		} else {
+			cmd.Stdout = io.MultiWriter(&stdoutBuf, lines)
+		}
+	}
+
 	// Run the command
 	result.Error = cmd.Run()
+	if lines != nil {
+		lines.Flush()
+	}
 
 	// Always populate result fields with captured data
 	result.Stdout = stdoutBuf.Bytes()
@@ -747,6 +877,7 @@ func (e *DefaultExecutor) Execute(config *Config) (*Result, error) {
 	}
 
 	setExitCode(result)
+	setCommandError(config, result)
 
 	return result, result.Error
 }
@@ -884,6 +1015,12 @@ func Run(ctx context.Context, command string, options ...Option) (*Result, error
 //	err := executor.RunVerbose(ctx, "apt-get", []string{"install", "-y", "git"}, verbose,
 //	    executor.WithInheritEnv("DEBIAN_FRONTEND=noninteractive"))
 func RunVerbose(ctx context.Context, command string, args []string, verbose bool, options ...Option) error {
+	return RunVerboseWith(ctx, NewExecutor(), command, args, verbose, options...)
+}
+
+// RunVerboseWith is RunVerbose using the given executor, so packages can substitute a
+// MockExecutor in tests.
+func RunVerboseWith(ctx context.Context, executor Executor, command string, args []string, verbose bool, options ...Option) error {
 	config := &Config{
 		Context: ctx,
 		Command: command,
@@ -902,7 +1039,6 @@ func RunVerbose(ctx context.Context, command string, args []string, verbose bool
 		opt(config)
 	}
 
-	executor := NewExecutor()
 	result, err := executor.Execute(config)
 
 	if err != nil {
@@ -1008,3 +1144,31 @@ func (r *Result) String() string {
 	}
 	return strings.Join(parts, ", ")
 }
+
+// lineWriter splits written output into lines for a line callback.
+// Carriage returns also end a line, so in-place progress updates are reported as they happen.
+type lineWriter struct {
+	callback func(line string)
+	partial  []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	for _, b := range p {
+		if b == '\n' || b == '\r' {
+			w.Flush()
+			continue
+		}
+		w.partial = append(w.partial, b)
+	}
+	return len(p), nil
+}
+
+// Flush passes any unterminated output to the callback
+func (w *lineWriter) Flush() {
+	if len(w.partial) == 0 {
+		return
+	}
+	line := string(w.partial)
+	w.partial = w.partial[:0]
+	w.callback(line)
+}
@@ -173,6 +173,41 @@ func TestExecuteWithExitCode(t *testing.T) {
 	}
 }
 
+func TestExecuteCommandError(t *testing.T) {
+	ctx := context.Background()
+
+	for _, mode := range []OutputMode{OutputModeCapture, OutputModeCombined, OutputModeDiscard} {
+		_, err := Run(ctx, "sh", WithArgs("-c", "echo locked >&2; exit 100"), WithOutputMode(mode))
+
+		cmdErr, ok := errors.AsType[*CommandError](err)
+		if !ok {
+			t.Fatalf("mode %d: expected *CommandError, got %T", mode, err)
+		}
+		if cmdErr.Command != "sh" || len(cmdErr.Args) != 2 || cmdErr.Args[0] != "-c" {
+			t.Errorf("mode %d: unexpected command %q %q", mode, cmdErr.Command, cmdErr.Args)
+		}
+		if cmdErr.ExitCode != 100 {
+			t.Errorf("mode %d: expected exit code 100, got %d", mode, cmdErr.ExitCode)
+		}
+		if !strings.Contains(string(cmdErr.Stderr), "locked") {
+			t.Errorf("mode %d: expected stderr to contain 'locked', got %q", mode, cmdErr.Stderr)
+		}
+		// The message is unchanged from the underlying *exec.ExitError
+		if err.Error() != "exit status 100" {
+			t.Errorf("mode %d: expected message 'exit status 100', got %q", mode, err.Error())
+		}
+	}
+
+	_, err := Run(ctx, "nonexistent-command-12345")
+	if cmdErr, ok := errors.AsType[*CommandError](err); !ok || cmdErr.ExitCode != -1 {
+		t.Errorf("expected *CommandError with exit code -1 for command not found, got %v", err)
+	}
+
+	if _, err := Run(ctx, "true"); err != nil {
+		t.Errorf("expected no error for successful command, got %v", err)
+	}
+}
+
 func TestRunVerbose(t *testing.T) {
 	ctx := context.Background()
 
@@ -339,3 +374,100 @@ func TestWithOptions(t *testing.T) {
 		t.Error("WithInheritEnv failed, TEST=value not found in env")
 	}
 }
+
+func TestLineCallbackReplacesDisplayedStdout(t *testing.T) {
+	var managed bytes.Buffer
+	ctx := WithManagedOutput(context.Background(), &managed, &managed)
+
+	var lines []string
+	result, err := Run(ctx, "printf",
+		WithArgs("first\nsecond\rthird"),
+		WithOutputMode(OutputModeDiscard),
+		WithLineCallback(func(line string) { lines = append(lines, line) }),
+	)
+	if err != nil {
+		t.Fatalf("run command with line callback: %v", err)
+	}
+	if want := []string{"first", "second", "third"}; !slices.Equal(lines, want) {
+		t.Errorf("lines = %q, want %q", lines, want)
+	}
+	if managed.Len() != 0 {
+		t.Errorf("stdout still displayed: %q", managed.String())
+	}
+	if string(result.Stdout) != "first\nsecond\rthird" {
+		t.Errorf("stdout not captured: %q", result.Stdout)
+	}
+}
+
+func TestLineCallbackWithCombinedMode(t *testing.T) {
+	var lines []string
+	_, err := Run(context.Background(), "printf",
+		WithArgs("a\nb\n"),
+		WithLineCallback(func(line string) { lines = append(lines, line) }),
+	)
+	if err != nil {
+		t.Fatalf("run command with line callback: %v", err)
+	}
+	if want := []string{"a", "b"}; !slices.Equal(lines, want) {
+		t.Errorf("lines = %q, want %q", lines, want)
+	}
+}
+
+func TestLineCallbackWithInteractiveMode(t *testing.T) {
+	var terminal bytes.Buffer
+	var lines []string
+	result, err := Run(context.Background(), "printf",
+		WithArgs("PLAY RECAP\nlocalhost : ok=1\n"),
+		WithOutputMode(OutputModeInteractive),
+		WithStdout(&terminal),
+		WithLineCallback(func(line string) { lines = append(lines, line) }),
+	)
+	if err != nil {
+		t.Fatalf("run command with line callback: %v", err)
+	}
+	if want := []string{"PLAY RECAP", "localhost : ok=1"}; !slices.Equal(lines, want) {
+		t.Errorf("lines = %q, want %q", lines, want)
+	}
+	if terminal.String() != "PLAY RECAP\nlocalhost : ok=1\n" {
+		t.Errorf("interactive stdout not displayed: %q", terminal.String())
+	}
+	if len(result.Stdout) != 0 {
+		t.Errorf("interactive stdout captured: %q", result.Stdout)
+	}
+}
+
+func TestLineCallbackWithPseudoTerminal(t *testing.T) {
+	var stdout bytes.Buffer
+	var lines []string
+	_, err := Run(context.Background(), "sh",
+		WithArgs("-c", `printf 'Collecting jq\r\nDownloading jq.whl\n'; echo oops >&2`),
+		WithOutputMode(OutputModeStream),
+		WithStdout(&stdout),
+		WithPseudoTerminal(),
+		WithLineCallback(func(line string) { lines = append(lines, line) }),
+	)
+	if err != nil {
+		t.Fatalf("run command with line callback: %v", err)
+	}
+	if want := []string{"Collecting jq", "Downloading jq.whl", "oops"}; !slices.Equal(lines, want) {
+		t.Errorf("lines = %q, want %q", lines, want)
+	}
+	if !strings.Contains(stdout.String(), "Downloading jq.whl") {
+		t.Errorf("pseudo-terminal output not written to stdout: %q", stdout.String())
+	}
+}
+
+func TestReportStatus(t *testing.T) {
+	if ReportStatus(context.Background(), "ignored") {
+		t.Error("ReportStatus without reporter = true, want false")
+	}
+
+	var got string
+	ctx := WithStatusReporter(context.Background(), func(status string) { got = status })
+	if !ReportStatus(ctx, "Unpacking jq") {
+		t.Error("ReportStatus with reporter = false, want true")
+	}
+	if got != "Unpacking jq" {
+		t.Errorf("reported status = %q, want %q", got, "Unpacking jq")
+	}
+}
@@ -2,17 +2,20 @@ package fact
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/saltyorg/sb-go/internal/constants"
-	"github.com/saltyorg/sb-go/internal/executor"
 	"github.com/saltyorg/sb-go/internal/releaseproxy"
 	"github.com/saltyorg/sb-go/internal/spinners"
 
@@ -100,22 +103,9 @@ func validateBinary(filePath string, expectedSize int64, verbose bool) error {
 
 // getCurrentFactVersion runs the existing saltbox.fact and extracts its version
 func getCurrentFactVersion(ctx context.Context, targetPath string) (string, error) {
-	// Use context with timeout for executing the binary
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-
-	result, err := executor.Run(ctx, targetPath,
-		executor.WithOutputMode(executor.OutputModeCombined),
-	)
+	currentData, err := runFact(ctx, targetPath)
 	if err != nil {
-		return "", fmt.Errorf("failed to run saltbox.fact: %w", err)
-	}
-
-	output := result.Combined
-
-	var currentData map[string]any
-	if err = json.Unmarshal(output, &currentData); err != nil {
-		return "", fmt.Errorf("failed to parse output: %w", err)
+		return "", err
 	}
 
 	currentVersion, ok := currentData["saltbox_facts_version"].(string)
@@ -169,57 +159,81 @@ func checkIfUpdateNeeded(ctx context.Context, task *spinners.Task, targetPath, l
 type latestReleaseInfo struct {
 	TagName string `json:"tag_name"`
 	Assets  []struct {
-		Name string `json:"name"`
-		Size int64  `json:"size"`
+		Name   string `json:"name"`
+		Size   int64  `json:"size"`
+		Digest string `json:"digest"`
 	} `json:"assets"`
 }
 
+// factRelease describes the saltbox-facts asset of a release
+type factRelease struct {
+	version string
+	size    int64
+	sha256  string // Hex encoded SHA-256 of the asset as published by GitHub
+}
+
+// parseSHA256Digest extracts the hex encoded checksum from a GitHub asset digest ("sha256:<hex>")
+func parseSHA256Digest(digest string) (string, error) {
+	sum, ok := strings.CutPrefix(digest, "sha256:")
+	if !ok {
+		return "", fmt.Errorf("unsupported digest %q", digest)
+	}
+	if decoded, err := hex.DecodeString(sum); err != nil || len(decoded) != sha256.Size {
+		return "", fmt.Errorf("malformed SHA-256 digest %q", digest)
+	}
+	return strings.ToLower(sum), nil
+}
+
 // fetchLatestReleaseInfoFromURL fetches the latest release metadata from a single URL.
-func fetchLatestReleaseInfoFromURL(ctx context.Context, client *http.Client, apiURL string) (string, int64, error) {
+// Releases without a SHA-256 digest for the asset are rejected, since the download can't be verified.
+func fetchLatestReleaseInfoFromURL(ctx context.Context, client *http.Client, apiURL string) (factRelease, error) {
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
 	if err != nil {
-		return "", 0, fmt.Errorf("error creating latest release request: %w", err)
+		return factRelease{}, fmt.Errorf("error creating latest release request: %w", err)
 	}
 	response, err := client.Do(request)
 	if err != nil {
-		return "", 0, fmt.Errorf("error fetching latest release info: %w", err)
+		return factRelease{}, fmt.Errorf("error fetching latest release info: %w", err)
 	}
 	defer func() {
 		_ = response.Body.Close()
 	}()
 
 	if response.StatusCode != http.StatusOK {
-		return "", 0, releaseproxy.HTTPStatus(response.StatusCode)
+		return factRelease{}, releaseproxy.HTTPStatus(response.StatusCode)
 	}
 
 	var latestRelease latestReleaseInfo
 	if err := json.NewDecoder(response.Body).Decode(&latestRelease); err != nil {
-		return "", 0, releaseproxy.InvalidResponse("returned invalid JSON", err)
+		return factRelease{}, releaseproxy.InvalidResponse("returned invalid JSON", err)
 	}
 	if strings.TrimSpace(latestRelease.TagName) == "" {
-		return "", 0, releaseproxy.InvalidResponse("response is missing tag_name", nil)
+		return factRelease{}, releaseproxy.InvalidResponse("response is missing tag_name", nil)
 	}
 
-	// Find the saltbox-facts asset and get its size.
+	// Find the saltbox-facts asset and get its size and checksum.
 	for _, asset := range latestRelease.Assets {
 		if asset.Name == "saltbox-facts" {
 			if asset.Size <= 0 {
-				return "", 0, releaseproxy.InvalidResponse(
+				return factRelease{}, releaseproxy.InvalidResponse(
 					fmt.Sprintf("saltbox-facts asset has invalid size %d", asset.Size),
 					nil,
 				)
 			}
-			return latestRelease.TagName, asset.Size, nil
+			sum, err := parseSHA256Digest(asset.Digest)
+			if err != nil {
+				return factRelease{}, releaseproxy.InvalidResponse("saltbox-facts asset has no usable checksum", err)
+			}
+			return factRelease{version: latestRelease.TagName, size: asset.Size, sha256: sum}, nil
 		}
 	}
 
-	return "", 0, releaseproxy.InvalidResponse("response is missing the saltbox-facts asset", nil)
+	return factRelease{}, releaseproxy.InvalidResponse("response is missing the saltbox-facts asset", nil)
 }
 
 // fetchLatestReleaseInfo fetches latest release info through SVM first, then falls back to direct GitHub API.
-func fetchLatestReleaseInfo(ctx context.Context, task *spinners.Task, proxyURL, githubURL string, verbose bool) (string, int64, error) {
-	var latestVersion string
-	var expectedSize int64
+func fetchLatestReleaseInfo(ctx context.Context, task *spinners.Task, proxyURL, githubURL string, verbose bool) (factRelease, error) {
+	var latest factRelease
 	var fallbackNotified bool
 
 	err := task.Run(ctx, spinners.TaskSpec{Running: "Fetching latest saltbox.fact release info"}, func(taskCtx context.Context, _ *spinners.Task) error {
@@ -228,10 +242,9 @@ func fetchLatestReleaseInfo(ctx context.Context, task *spinners.Task, proxyURL,
 				Timeout: 30 * time.Second,
 			}
 
-			version, size, proxyErr := fetchLatestReleaseInfoFromURL(taskCtx, client, proxyURL)
+			release, proxyErr := fetchLatestReleaseInfoFromURL(taskCtx, client, proxyURL)
 			if proxyErr == nil {
-				latestVersion = version
-				expectedSize = size
+				latest = release
 				return nil
 			}
 
@@ -244,13 +257,12 @@ func fetchLatestReleaseInfo(ctx context.Context, task *spinners.Task, proxyURL,
 				fallbackNotified = true
 			}
 
-			version, size, githubErr := fetchLatestReleaseInfoFromURL(taskCtx, client, githubURL)
+			release, githubErr := fetchLatestReleaseInfoFromURL(taskCtx, client, githubURL)
 			if githubErr != nil {
 				return fmt.Errorf("proxy request failed: %w; fallback GitHub API request failed: %w", proxyErr, githubErr)
 			}
 
-			latestVersion = version
-			expectedSize = size
+			latest = release
 			if verbose {
 				fmt.Println("Direct GitHub API fallback succeeded")
 			} else {
@@ -260,7 +272,59 @@ func fetchLatestReleaseInfo(ctx context.Context, task *spinners.Task, proxyURL,
 		}, 3, 1*time.Second) // 3 retries with 1-second base delay
 	})
 
-	return latestVersion, expectedSize, err
+	return latest, err
+}
+
+// downloadFact downloads saltbox.fact into a temporary file in dir, so nothing is installed before
+// it's verified. Returns the path of the temporary file and the hex encoded SHA-256 of its content.
+func downloadFact(ctx context.Context, client *http.Client, downloadURL, dir string) (string, string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("error creating download request: %w", err)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return "", "", fmt.Errorf("error downloading saltbox.fact: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	if response.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("unexpected status code: %d", response.StatusCode)
+	}
+
+	// Ensure the directory exists
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", "", fmt.Errorf("error creating directory: %w", err)
+	}
+
+	file, err := os.CreateTemp(dir, ".saltbox.fact-*")
+	if err != nil {
+		return "", "", fmt.Errorf("error creating temporary file: %w", err)
+	}
+
+	hash := sha256.New()
+	_, copyErr := io.Copy(io.MultiWriter(file, hash), response.Body)
+	closeErr := file.Close()
+	if copyErr != nil || closeErr != nil {
+		_ = os.Remove(file.Name())
+		return "", "", fmt.Errorf("error writing file: %w", errors.Join(copyErr, closeErr))
+	}
+
+	return file.Name(), hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// verifyFact checks a downloaded saltbox.fact against the checksum and size published with the release
+// before it's installed and executed
+func verifyFact(path, actualSHA256 string, release factRelease, verbose bool) error {
+	if verbose {
+		fmt.Printf("Checksum check: expected %s, actual %s\n", release.sha256, actualSHA256)
+	}
+	if actualSHA256 != release.sha256 {
+		return fmt.Errorf("checksum mismatch for saltbox.fact %s: expected SHA-256 %s, got %s", release.version, release.sha256, actualSHA256)
+	}
+	return validateBinary(path, release.size, verbose)
 }
 
 // DownloadAndInstallSaltboxFact downloads and installs the latest saltbox.fact file.
@@ -274,16 +338,19 @@ func DownloadAndInstallSaltboxFact(
 }
 
 func downloadAndInstallSaltboxFact(ctx context.Context, task *spinners.Task, alwaysUpdate bool, verbose bool) error {
-	downloadURL := "https://github.com/saltyorg/ansible-facts/releases/latest/download/saltbox-facts"
-	targetPath := "/srv/git/saltbox/ansible_facts.d/saltbox.fact"
+	targetPath := saltboxFactPath
 	githubURL := "https://api.github.com/repos/saltyorg/ansible-facts/releases/latest"
 	proxyURL := fmt.Sprintf("%s?url=%s", constants.SVMVersionProxyURL, githubURL)
 
 	// Fetch the latest release info from GitHub with retry logic
-	latestVersion, expectedSize, err := fetchLatestReleaseInfo(ctx, task, proxyURL, githubURL, verbose)
+	release, err := fetchLatestReleaseInfo(ctx, task, proxyURL, githubURL, verbose)
 	if err != nil {
 		return err
 	}
+	latestVersion := release.version
+
+	// Download the asset of the exact release the checksum belongs to
+	downloadURL := fmt.Sprintf("https://github.com/saltyorg/ansible-facts/releases/download/%s/saltbox-facts", url.PathEscape(latestVersion))
 
 	// Check if we need to update
 	needsUpdate, currentVersion, err := checkIfUpdateNeeded(ctx, task, targetPath, latestVersion, alwaysUpdate)
@@ -304,57 +371,30 @@ func downloadAndInstallSaltboxFact(ctx context.Context, task *spinners.Task, alw
 				client := &http.Client{
 					Timeout: 30 * time.Second,
 				}
-				response, err := client.Get(downloadURL)
-				if err != nil {
-					return fmt.Errorf("error downloading saltbox.fact: %w", err)
-				}
-				defer func() {
-					if err := response.Body.Close(); err != nil {
-						downloadTask.Warning(fmt.Sprintf("Error closing response body: %v", err))
-					}
-				}()
-
-				if response.StatusCode != http.StatusOK {
-					return fmt.Errorf("unexpected status code: %d", response.StatusCode)
-				}
-
-				// Ensure the directory exists
-				if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
-					return fmt.Errorf("error creating directory: %w", err)
-				}
-
-				// Write the content to the file
-				file, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+				tempPath, actualSHA256, err := downloadFact(ctx, client, downloadURL, filepath.Dir(targetPath))
 				if err != nil {
-					return fmt.Errorf("error opening file: %w", err)
+					return err
 				}
 				defer func() {
-					if err := file.Close(); err != nil {
-						downloadTask.Warning(fmt.Sprintf("Error closing file: %v", err))
-					}
+					// Removes the download unless it was installed
+					_ = os.Remove(tempPath)
 				}()
 
-				_, err = io.Copy(file, response.Body)
-				if err != nil {
-					return fmt.Errorf("error writing file: %w", err)
+				// Verify the downloaded binary before installing it
+				if err := downloadTask.Run(ctx, spinners.TaskSpec{Running: "Validating downloaded saltbox.fact"}, func(context.Context, *spinners.Task) error {
+					return verifyFact(tempPath, actualSHA256, release, verbose)
+				}); err != nil {
+					return fmt.Errorf("downloaded binary validation failed: %w", err)
 				}
 
-				// Make the file executable
-				err = os.Chmod(targetPath, 0755)
-				if err != nil {
+				// Make the file executable and move it into place
+				if err := os.Chmod(tempPath, 0755); err != nil {
 					return fmt.Errorf("error setting file permissions: %w", err)
 				}
-
-				// Validate the downloaded binary
-				if err := downloadTask.Run(ctx, spinners.TaskSpec{Running: "Validating downloaded saltbox.fact"}, func(context.Context, *spinners.Task) error {
-					return validateBinary(targetPath, expectedSize, verbose)
-				}); err != nil {
-					// Clean up the invalid file
-					if removeErr := os.Remove(targetPath); removeErr != nil {
-						return fmt.Errorf("downloaded binary validation failed (%w) and cleanup failed (%v)", err, removeErr)
-					}
-					return fmt.Errorf("downloaded binary validation failed: %w", err)
+				if err := os.Rename(tempPath, targetPath); err != nil {
+					return fmt.Errorf("error installing saltbox.fact: %w", err)
 				}
+				resetFactOutputCache()
 
 				return nil
 			}, 3, 2*time.Second) // 3 retries with 2-second base delay for downloads
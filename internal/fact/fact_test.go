@@ -1,53 +1,76 @@
 package fact
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/saltyorg/sb-go/internal/spinners"
 )
 
+// testDigest is an arbitrary well-formed SHA-256 used in release fixtures
+const testDigest = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
 func TestFetchLatestReleaseInfoFromURL(t *testing.T) {
 	t.Run("returns version and size for valid release", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			_, _ = w.Write([]byte(`{"tag_name":"v1.2.3","assets":[{"name":"saltbox-facts","size":12345}]}`))
+			_, _ = w.Write([]byte(`{"tag_name":"v1.2.3","assets":[{"name":"saltbox-facts","size":12345,"digest":"sha256:` + testDigest + `"}]}`))
 		}))
 		defer server.Close()
 
-		version, size, err := fetchLatestReleaseInfoFromURL(context.Background(), server.Client(), server.URL)
+		release, err := fetchLatestReleaseInfoFromURL(context.Background(), server.Client(), server.URL)
 		if err != nil {
 			t.Fatalf("fetchLatestReleaseInfoFromURL() returned error: %v", err)
 		}
-		if version != "v1.2.3" {
-			t.Fatalf("expected version v1.2.3, got %q", version)
+		if release.version != "v1.2.3" {
+			t.Fatalf("expected version v1.2.3, got %q", release.version)
+		}
+		if release.size != 12345 {
+			t.Fatalf("expected size 12345, got %d", release.size)
 		}
-		if size != 12345 {
-			t.Fatalf("expected size 12345, got %d", size)
+		if release.sha256 != testDigest {
+			t.Fatalf("expected checksum %s, got %q", testDigest, release.sha256)
 		}
 	})
 
 	t.Run("rejects missing tag_name", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			_, _ = w.Write([]byte(`{"tag_name":"","assets":[{"name":"saltbox-facts","size":12345}]}`))
+			_, _ = w.Write([]byte(`{"tag_name":"","assets":[{"name":"saltbox-facts","size":12345,"digest":"sha256:` + testDigest + `"}]}`))
 		}))
 		defer server.Close()
 
-		_, _, err := fetchLatestReleaseInfoFromURL(context.Background(), server.Client(), server.URL)
+		_, err := fetchLatestReleaseInfoFromURL(context.Background(), server.Client(), server.URL)
 		if err == nil {
 			t.Fatal("expected error for missing tag_name")
 		}
 	})
 
+	t.Run("rejects asset without checksum", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"tag_name":"v1.2.3","assets":[{"name":"saltbox-facts","size":12345}]}`))
+		}))
+		defer server.Close()
+
+		_, err := fetchLatestReleaseInfoFromURL(context.Background(), server.Client(), server.URL)
+		if err == nil {
+			t.Fatal("expected error for missing checksum")
+		}
+	})
+
 	t.Run("rejects missing expected asset", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 			_, _ = w.Write([]byte(`{"tag_name":"v1.2.3","assets":[{"name":"other","size":12345}]}`))
 		}))
 		defer server.Close()
 
-		_, _, err := fetchLatestReleaseInfoFromURL(context.Background(), server.Client(), server.URL)
+		_, err := fetchLatestReleaseInfoFromURL(context.Background(), server.Client(), server.URL)
 		if err == nil {
 			t.Fatal("expected error for missing saltbox-facts asset")
 		}
@@ -57,26 +80,25 @@ func TestFetchLatestReleaseInfoFromURL(t *testing.T) {
 func TestFetchLatestReleaseInfoFallback(t *testing.T) {
 	runFetch := func(proxyURL, githubURL string) (string, int64, error) {
 		runner := spinners.NewRunner(spinners.RunnerOptions{Verbose: true, Output: io.Discard})
-		var version string
-		var size int64
+		var release factRelease
 		err := runner.Run(context.Background(), spinners.TaskSpec{Running: "test"}, func(ctx context.Context, task *spinners.Task) error {
 			var err error
-			version, size, err = fetchLatestReleaseInfo(ctx, task, proxyURL, githubURL, true)
+			release, err = fetchLatestReleaseInfo(ctx, task, proxyURL, githubURL, true)
 			return err
 		})
-		return version, size, err
+		return release.version, release.size, err
 	}
 
 	t.Run("uses proxy response when usable", func(t *testing.T) {
 		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			_, _ = w.Write([]byte(`{"tag_name":"v2.0.0","assets":[{"name":"saltbox-facts","size":222}]}`))
+			_, _ = w.Write([]byte(`{"tag_name":"v2.0.0","assets":[{"name":"saltbox-facts","size":222,"digest":"sha256:` + testDigest + `"}]}`))
 		}))
 		defer proxy.Close()
 
 		githubCalled := false
 		github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 			githubCalled = true
-			_, _ = w.Write([]byte(`{"tag_name":"v9.9.9","assets":[{"name":"saltbox-facts","size":999}]}`))
+			_, _ = w.Write([]byte(`{"tag_name":"v9.9.9","assets":[{"name":"saltbox-facts","size":999,"digest":"sha256:` + testDigest + `"}]}`))
 		}))
 		defer github.Close()
 
@@ -99,7 +121,7 @@ func TestFetchLatestReleaseInfoFallback(t *testing.T) {
 		defer proxy.Close()
 
 		github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			_, _ = w.Write([]byte(`{"tag_name":"v3.1.4","assets":[{"name":"saltbox-facts","size":314}]}`))
+			_, _ = w.Write([]byte(`{"tag_name":"v3.1.4","assets":[{"name":"saltbox-facts","size":314,"digest":"sha256:` + testDigest + `"}]}`))
 		}))
 		defer github.Close()
 
@@ -112,3 +134,74 @@ func TestFetchLatestReleaseInfoFallback(t *testing.T) {
 		}
 	})
 }
+
+// testFactBinary is a minimal file with an ELF header, enough to pass validateBinary
+var testFactBinary = []byte("\x7fELF saltbox.fact")
+
+func serveFact(t *testing.T, content []byte) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(content)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDownloadAndVerifyFact(t *testing.T) {
+	sum := sha256.Sum256(testFactBinary)
+	release := factRelease{version: "v1.2.3", size: int64(len(testFactBinary)), sha256: hex.EncodeToString(sum[:])}
+
+	t.Run("accepts matching checksum", func(t *testing.T) {
+		server := serveFact(t, testFactBinary)
+
+		path, actual, err := downloadFact(context.Background(), server.Client(), server.URL, t.TempDir())
+		if err != nil {
+			t.Fatalf("downloadFact() returned error: %v", err)
+		}
+		if err := verifyFact(path, actual, release, false); err != nil {
+			t.Fatalf("verifyFact() returned error: %v", err)
+		}
+	})
+
+	t.Run("rejects tampered file", func(t *testing.T) {
+		// Same size and a valid ELF header, so only the checksum can catch it
+		tampered := bytes.Clone(testFactBinary)
+		tampered[len(tampered)-1] = 'X'
+		server := serveFact(t, tampered)
+
+		path, actual, err := downloadFact(context.Background(), server.Client(), server.URL, t.TempDir())
+		if err != nil {
+			t.Fatalf("downloadFact() returned error: %v", err)
+		}
+		err = verifyFact(path, actual, release, false)
+		if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+			t.Fatalf("expected checksum mismatch error, got %v", err)
+		}
+	})
+
+	t.Run("rejects failed download", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		dir := t.TempDir()
+		if _, _, err := downloadFact(context.Background(), server.Client(), server.URL, dir); err == nil {
+			t.Fatal("expected error for failed download")
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+			t.Fatalf("expected no files left behind, got %d", len(entries))
+		}
+	})
+}
+
+func TestParseSHA256Digest(t *testing.T) {
+	if got, err := parseSHA256Digest("sha256:" + strings.ToUpper(testDigest)); err != nil || got != testDigest {
+		t.Fatalf("parseSHA256Digest() = %q, %v; want %q", got, err, testDigest)
+	}
+	for _, digest := range []string{"", testDigest, "sha512:" + testDigest, "sha256:abc", "sha256:" + strings.Repeat("z", 64)} {
+		if _, err := parseSHA256Digest(digest); err == nil {
+			t.Errorf("parseSHA256Digest(%q) expected error", digest)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package fact
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/executor"
+)
+
+// saltboxFactPath is where saltbox.fact is installed for Ansible to pick up as a local fact
+var saltboxFactPath = "/srv/git/saltbox/ansible_facts.d/saltbox.fact"
+
+// factRunTimeout bounds a single run of saltbox.fact
+const factRunTimeout = 10 * time.Second
+
+// factOutputCache holds the parsed output of saltbox.fact for the rest of the process
+var factOutputCache struct {
+	mu     sync.Mutex
+	output map[string]any
+}
+
+// Load runs the installed saltbox.fact and returns its parsed JSON output.
+// The first successful result is cached and shared by all callers in this process; concurrent
+// callers wait for the same run instead of starting their own. Failures aren't cached.
+func Load(ctx context.Context) (map[string]any, error) {
+	factOutputCache.mu.Lock()
+	defer factOutputCache.mu.Unlock()
+
+	if factOutputCache.output != nil {
+		return factOutputCache.output, nil
+	}
+
+	output, err := runFact(ctx, saltboxFactPath)
+	if err != nil {
+		return nil, err
+	}
+	factOutputCache.output = output
+	return output, nil
+}
+
+// resetFactOutputCache drops the cached output, e.g. after saltbox.fact was replaced
+func resetFactOutputCache() {
+	factOutputCache.mu.Lock()
+	defer factOutputCache.mu.Unlock()
+	factOutputCache.output = nil
+}
+
+// runFact runs the saltbox.fact at path and parses its JSON output
+func runFact(ctx context.Context, path string) (map[string]any, error) {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("saltbox.fact is not installed at %s, run 'sb reinstall-facts' to install it", path)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, factRunTimeout)
+	defer cancel()
+
+	result, err := executor.Run(ctx, path,
+		executor.WithOutputMode(executor.OutputModeCapture),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run saltbox.fact: %w", err)
+	}
+
+	var output map[string]any
+	if err := json.Unmarshal(result.Stdout, &output); err != nil {
+		return nil, fmt.Errorf("saltbox.fact did not produce valid JSON: %w", err)
+	}
+	return output, nil
+}
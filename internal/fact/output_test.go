@@ -0,0 +1,71 @@
+package fact
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// writeFakeFact installs a script as saltbox.fact for the duration of the test.
+// Each run appends a line to the returned counter file.
+func writeFakeFact(t *testing.T, output string) string {
+	t.Helper()
+	dir := t.TempDir()
+	counter := filepath.Join(dir, "runs")
+	script := "#!/bin/sh\necho run >> " + counter + "\ncat <<'JSON'\n" + output + "\nJSON\n"
+	path := filepath.Join(dir, "saltbox.fact")
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	original := saltboxFactPath
+	saltboxFactPath = path
+	resetFactOutputCache()
+	t.Cleanup(func() {
+		saltboxFactPath = original
+		resetFactOutputCache()
+	})
+	return counter
+}
+
+func TestLoadCachesOutput(t *testing.T) {
+	counter := writeFakeFact(t, `{"saltbox_facts_version": "1.2.3", "user": {"name": "seed"}}`)
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Go(func() {
+			output, err := Load(context.Background())
+			if err != nil {
+				t.Errorf("Load unexpected error: %v", err)
+				return
+			}
+			if output["saltbox_facts_version"] != "1.2.3" {
+				t.Errorf("output = %v", output)
+			}
+		})
+	}
+	wg.Wait()
+
+	runs, err := os.ReadFile(counter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(runs), "run"); got != 1 {
+		t.Errorf("saltbox.fact ran %d times, want once", got)
+	}
+}
+
+func TestLoadReportsInvalidOutput(t *testing.T) {
+	writeFakeFact(t, "Traceback (most recent call last):")
+	if _, err := Load(context.Background()); err == nil || !strings.Contains(err.Error(), "valid JSON") {
+		t.Errorf("Load error = %v, want an invalid JSON error", err)
+	}
+
+	saltboxFactPath = filepath.Join(t.TempDir(), "missing.fact")
+	if _, err := Load(context.Background()); err == nil || !strings.Contains(err.Error(), "not installed") {
+		t.Errorf("Load error = %v, want a not installed error", err)
+	}
+}
@@ -1,18 +1,28 @@
 package git
 
 import (
-	"bufio"
 	"context"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/saltyorg/sb-go/internal/executor"
+	"github.com/saltyorg/sb-go/internal/retry"
 	"github.com/saltyorg/sb-go/internal/spinners"
 	"github.com/saltyorg/sb-go/internal/tty"
 )
 
+// cloneAttempts is the number of times CloneRepository tries to clone before giving up
+const cloneAttempts = 3
+
+// cloneRetryDelay is the delay before the first retry, doubled for every further retry
+var cloneRetryDelay = 5 * time.Second
+
 // CloneRepository clones a Git repository to a specified path and branch.
+// The clone is shallow to reduce the data transferred on slow connections.
+// A failed clone is retried with backoff, removing any partial clone first so a
+// failure never leaves behind a directory that looks like an existing checkout.
 // The verbose flag controls whether stdout and stderr are directly outputted.
 // The context parameter allows for cancellation of the clone operation.
 func CloneRepository(ctx context.Context, repoURL, destPath, branch string, verbose bool) error {
@@ -20,33 +30,61 @@ func CloneRepository(ctx context.Context, repoURL, destPath, branch string, verb
 		return fmt.Errorf("destination path '%s' already exists", destPath)
 	}
 
-	cloneArgs := []string{"clone", "--progress", "--depth", "1", "-b", branch, repoURL, destPath}
+	err := retry.Do(ctx, retry.Options{
+		MaxAttempts: cloneAttempts,
+		BaseDelay:   cloneRetryDelay,
+		OnRetry: func(attempt int, err error, delay time.Duration) {
+			if verbose {
+				fmt.Printf("Clone failed (attempt %d/%d), retrying in %v...\n", attempt, cloneAttempts, delay)
+			}
+		},
+	}, func(ctx context.Context) error {
+		err := cloneOnce(ctx, repoURL, destPath, branch, verbose)
+		if err == nil {
+			return nil
+		}
+		// Remove the partial clone so the retry, or the next setup run, starts clean
+		if rmErr := os.RemoveAll(destPath); rmErr != nil {
+			return retry.Permanent(fmt.Errorf("%w (failed to remove partial clone: %v)", err, rmErr))
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
 
-	// Use executor to handle verbose/non-verbose output
-	var mode executor.OutputMode
 	if verbose {
-		mode = executor.OutputModeStream
-	} else {
-		mode = executor.OutputModeCapture
+		fmt.Printf("Repository '%s' (branch: '%s') cloned successfully to '%s'\n", repoURL, branch, destPath)
 	}
 
-	result, err := executor.Run(ctx, "git",
-		executor.WithArgs(cloneArgs...),
-		executor.WithOutputMode(mode))
+	return nil
+}
 
-	if err != nil {
-		if !verbose && len(result.Stderr) > 0 {
-			return fmt.Errorf("failed to clone repository '%s' (branch: '%s') to '%s' (exit code %d)\nStderr:\n%s",
-				repoURL, branch, destPath, result.ExitCode, string(result.Stderr))
-		}
-		return fmt.Errorf("failed to clone repository '%s' (branch: '%s') to '%s' (exit code %d): %w",
-			repoURL, branch, destPath, result.ExitCode, err)
-	}
+// cloneOnce makes a single clone attempt.
+// Output is streamed in verbose mode and otherwise included in the error.
+func cloneOnce(ctx context.Context, repoURL, destPath, branch string, verbose bool) error {
+	cloneArgs := BuildCloneArgs(repoURL, destPath, branch)
 
 	if verbose {
-		fmt.Printf("Repository '%s' (branch: '%s') cloned successfully to '%s'\n", repoURL, branch, destPath)
+		result, err := executor.Run(ctx, "git",
+			executor.WithArgs(append([]string{cloneArgs[0], "--progress"}, cloneArgs[1:]...)...),
+			executor.WithOutputMode(executor.OutputModeStream))
+		if err != nil {
+			return fmt.Errorf("failed to clone repository '%s' (branch: '%s') to '%s' (exit code %d): %w",
+				repoURL, branch, destPath, result.ExitCode, err)
+		}
+		return nil
 	}
 
+	output, err := defaultExecutor.ExecuteCommand(ctx, "", "git", cloneArgs...)
+	if err != nil {
+		if len(output) > 0 {
+			return fmt.Errorf("failed to clone repository '%s' (branch: '%s') to '%s': %w\nOutput:\n%s",
+				repoURL, branch, destPath, err, string(output))
+		}
+		return fmt.Errorf("failed to clone repository '%s' (branch: '%s') to '%s': %w",
+			repoURL, branch, destPath, err)
+	}
 	return nil
 }
 
@@ -114,12 +152,12 @@ func ResolveUpdateBranch(
 				branch = currentBranch
 			} else {
 				// TTY available: prompt user
-				reader := bufio.NewReader(os.Stdin)
-				fmt.Printf("%s: Do you want to reset to the '%s' branch? (y/n): ", repoName, defaultBranch)
-				input, _ := reader.ReadString('\n')
-				input = strings.TrimSpace(strings.ToLower(input))
+				reset, err := tty.Confirm(fmt.Sprintf("%s: Do you want to reset to the '%s' branch?", repoName, defaultBranch), false)
+				if err != nil {
+					return "", err
+				}
 
-				if input != "y" {
+				if !reset {
 					runner.Info(fmt.Sprintf("%s: Updating the current branch '%s'", repoName, currentBranch))
 					branch = currentBranch
 				} else {
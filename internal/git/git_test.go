@@ -437,6 +437,7 @@ func TestCloneRepository_ErrorMessageFormat(t *testing.T) {
 	// Test that errors are returned properly
 	ctx := context.Background()
 	destPath := filepath.Join(t.TempDir(), "test-repo-error")
+	withoutCloneRetryDelay(t)
 
 	// Use invalid git URL to trigger error
 	err := CloneRepository(ctx, "invalid://url", destPath, "main", false)
@@ -453,6 +454,80 @@ func TestCloneRepository_ErrorMessageFormat(t *testing.T) {
 	}
 }
 
+// withoutCloneRetryDelay disables the backoff between clone attempts for the duration of a test
+func withoutCloneRetryDelay(t *testing.T) {
+	t.Helper()
+	original := cloneRetryDelay
+	cloneRetryDelay = 0
+	t.Cleanup(func() { cloneRetryDelay = original })
+}
+
+// TestCloneRepository_RetryCleansPartialClone tests that a failed clone is removed before retrying
+func TestCloneRepository_RetryCleansPartialClone(t *testing.T) {
+	withoutCloneRetryDelay(t)
+	originalExecutor := defaultExecutor
+	defer SetExecutor(originalExecutor)
+
+	destPath := filepath.Join(t.TempDir(), "saltbox")
+	attempts := 0
+	mock := &MockCommandExecutor{
+		ExecuteFunc: func(ctx context.Context, dir string, name string, args ...string) ([]byte, error) {
+			attempts++
+			if _, err := os.Stat(destPath); !os.IsNotExist(err) {
+				t.Errorf("attempt %d: partial clone still present at %s", attempts, destPath)
+			}
+			if attempts == 1 {
+				// Simulate a connection drop halfway through the clone
+				if err := os.MkdirAll(filepath.Join(destPath, ".git", "objects"), 0755); err != nil {
+					t.Fatal(err)
+				}
+				return []byte("fatal: early EOF"), errors.New("exit status 128")
+			}
+			return nil, os.MkdirAll(filepath.Join(destPath, ".git"), 0755)
+		},
+	}
+	SetExecutor(mock)
+
+	if err := CloneRepository(context.Background(), "https://github.com/user/repo.git", destPath, "master", false); err != nil {
+		t.Fatalf("CloneRepository() unexpected error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 clone attempts, got %d", attempts)
+	}
+	if _, err := os.Stat(filepath.Join(destPath, ".git")); err != nil {
+		t.Errorf("expected cloned repository at %s: %v", destPath, err)
+	}
+}
+
+// TestCloneRepository_GivesUp tests that clone errors are returned after the last attempt without leftovers
+func TestCloneRepository_GivesUp(t *testing.T) {
+	withoutCloneRetryDelay(t)
+	originalExecutor := defaultExecutor
+	defer SetExecutor(originalExecutor)
+
+	destPath := filepath.Join(t.TempDir(), "saltbox")
+	mock := &MockCommandExecutor{
+		ExecuteFunc: func(ctx context.Context, dir string, name string, args ...string) ([]byte, error) {
+			if err := os.MkdirAll(destPath, 0755); err != nil {
+				t.Fatal(err)
+			}
+			return []byte("fatal: unable to access"), errors.New("exit status 128")
+		},
+	}
+	SetExecutor(mock)
+
+	err := CloneRepository(context.Background(), "https://github.com/user/repo.git", destPath, "master", false)
+	if err == nil || !strings.Contains(err.Error(), "fatal: unable to access") {
+		t.Fatalf("expected clone error with git output, got %v", err)
+	}
+	if calls := len(mock.GetCalls()); calls != cloneAttempts {
+		t.Errorf("expected %d clone attempts, got %d", cloneAttempts, calls)
+	}
+	if _, err := os.Stat(destPath); !os.IsNotExist(err) {
+		t.Errorf("partial clone left behind at %s", destPath)
+	}
+}
+
 // TestMockCommandExecutor_CallTracking tests that mock tracks calls
 func TestMockCommandExecutor_CallTracking(t *testing.T) {
 	mock := &MockCommandExecutor{
@@ -85,7 +85,7 @@ func ListAvailableFonts() []string {
 }
 
 // GenerateBanner generates the banner using toilet and boxes
-func GenerateBanner(title, font, boxType string) string {
+func GenerateBanner(ctx context.Context, title, font, boxType string) string {
 	// First check if toilet is installed
 	if _, err := exec.LookPath("toilet"); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: toilet command not found, using fallback banner\n")
@@ -94,7 +94,7 @@ func GenerateBanner(title, font, boxType string) string {
 
 	// If no box type or "none", just use toilet
 	if boxType == "" || boxType == "none" {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 		defer cancel()
 
 		result, err := executor.Run(ctx, "toilet",
@@ -112,7 +112,7 @@ func GenerateBanner(title, font, boxType string) string {
 	// Check if boxes is installed
 	if _, err := exec.LookPath("boxes"); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: boxes command not found, using toilet only\n")
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 		defer cancel()
 
 		result, err := executor.Run(ctx, "toilet",
@@ -129,10 +129,10 @@ func GenerateBanner(title, font, boxType string) string {
 
 	// Use toilet and pipe to boxes
 	// First get toilet output
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	toiletCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	toiletResult, err := executor.Run(ctx, "toilet",
+	toiletResult, err := executor.Run(toiletCtx, "toilet",
 		executor.WithArgs("-f", font, title),
 		executor.WithOutputMode(executor.OutputModeCapture),
 	)
@@ -142,11 +142,11 @@ func GenerateBanner(title, font, boxType string) string {
 	}
 
 	// Pipe toilet output to boxes
-	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	boxesCtx, boxesCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer boxesCancel()
 
 	var boxesOutput bytes.Buffer
-	boxesResult, err := executor.Run(ctx, "boxes",
+	boxesResult, err := executor.Run(boxesCtx, "boxes",
 		executor.WithArgs("-d", boxType, "-a", "hc", "-p", "h8"),
 		executor.WithStdin(bytes.NewReader(toiletResult.Stdout)),
 		executor.WithStdout(&boxesOutput),
@@ -164,13 +164,13 @@ func GenerateBanner(title, font, boxType string) string {
 }
 
 // GenerateBannerFromFile processes the content of a file with toilet
-func GenerateBannerFromFile(content string, toiletArgs string) string {
+func GenerateBannerFromFile(ctx context.Context, content string, toiletArgs string) string {
 	if _, err := exec.LookPath("toilet"); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: toilet command not found. Cannot process banner file.\n")
 		return content // Fallback to raw content if toilet isn't installed
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	args, err := splitShellArgs(toiletArgs)
@@ -0,0 +1,82 @@
+package motd
+
+import (
+	"math"
+	"strings"
+
+	"charm.land/bubbles/v2/progress"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/colorprofile"
+)
+
+// Bar styles for usage bars
+const (
+	BarStyleBlock  = "block"  // Full block characters (default)
+	BarStyleSmooth = "smooth" // Full blocks with partial blocks for finer resolution
+	BarStyleASCII  = "ascii"  // Plain ASCII characters for terminals without Unicode support
+)
+
+// barEmptyColor matches the empty color of the bubbles progress bar
+const barEmptyColor = "#606060"
+
+// smoothBarPartials are the partial blocks used for the last filled cell, in eighths
+var smoothBarPartials = []rune{' ', '▏', '▎', '▍', '▌', '▋', '▊', '▉'}
+
+// resolveBarStyle returns the bar style to use from the MOTD config.
+// The ascii style is always used when the color profile is ascii or lower, since such
+// terminals are unlikely to render block characters properly.
+func resolveBarStyle() string {
+	if lipgloss.Writer != nil && lipgloss.Writer.Profile <= colorprofile.ASCII {
+		return BarStyleASCII
+	}
+
+	cfg := loadMOTDConfig()
+	if cfg == nil {
+		return BarStyleBlock
+	}
+
+	switch cfg.BarStyle {
+	case BarStyleSmooth, BarStyleASCII:
+		return cfg.BarStyle
+	default:
+		return BarStyleBlock
+	}
+}
+
+// renderUsageBar renders a usage bar of the given width filled to percent (0-1) in the given color
+func renderUsageBar(style string, percent float64, width int, color string) string {
+	percent = math.Max(0, math.Min(1, percent))
+
+	switch style {
+	case BarStyleSmooth:
+		// Each cell is split into eighths so partially filled cells use a partial block
+		eighths := int(math.Round(percent * float64(width) * 8))
+		full := eighths / 8
+		partial := eighths % 8
+
+		var filled strings.Builder
+		filled.WriteString(strings.Repeat("█", full))
+		empty := width - full
+		if partial > 0 {
+			filled.WriteRune(smoothBarPartials[partial])
+			empty--
+		}
+
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(filled.String()) +
+			lipgloss.NewStyle().Foreground(lipgloss.Color(barEmptyColor)).Render(strings.Repeat("░", empty))
+
+	case BarStyleASCII:
+		full := int(math.Round(percent * float64(width)))
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(strings.Repeat("=", full)) +
+			lipgloss.NewStyle().Foreground(lipgloss.Color(barEmptyColor)).Render(strings.Repeat("-", width-full))
+
+	default:
+		prog := progress.New(
+			progress.WithColors(lipgloss.Color(color)),
+			progress.WithFillCharacters(progress.DefaultFullCharFullBlock, progress.DefaultEmptyCharBlock),
+			progress.WithoutPercentage(),
+		)
+		prog.SetWidth(width)
+		return prog.ViewAs(percent)
+	}
+}
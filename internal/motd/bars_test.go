@@ -0,0 +1,34 @@
+package motd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRenderUsageBarKeepsWidth(t *testing.T) {
+	for _, style := range []string{BarStyleBlock, BarStyleSmooth, BarStyleASCII} {
+		for _, percent := range []float64{0, 0.37, 0.5, 1} {
+			bar := renderUsageBar(style, percent, 50, ProgressBarLow)
+			if got := ansi.StringWidth(bar); got != 50 {
+				t.Errorf("renderUsageBar(%q, %.2f) width = %d, want 50", style, percent, got)
+			}
+		}
+	}
+}
+
+func TestRenderUsageBarSmoothUsesPartialBlock(t *testing.T) {
+	// 37% of 50 cells is 18.5 cells, so the last filled cell is half a block
+	bar := ansi.Strip(renderUsageBar(BarStyleSmooth, 0.37, 50, ProgressBarLow))
+	if !strings.HasPrefix(bar, strings.Repeat("█", 18)+"▌") {
+		t.Fatalf("unexpected smooth bar: %q", bar)
+	}
+}
+
+func TestRenderUsageBarASCII(t *testing.T) {
+	bar := ansi.Strip(renderUsageBar(BarStyleASCII, 0.5, 10, ProgressBarLow))
+	if bar != "=====-----" {
+		t.Fatalf("unexpected ascii bar: %q", bar)
+	}
+}
@@ -14,6 +14,8 @@ import (
 // This is needed because YAML maps don't preserve key order.
 var sectionOrder = []string{
 	"colors",
+	"disk",
+	"disk_health",
 	"emby",
 	"jellyfin",
 	"lidarr",
@@ -21,6 +23,7 @@ var sectionOrder = []string{
 	"plex",
 	"qbittorrent",
 	"radarr",
+	"rclone_mounts",
 	"readarr",
 	"rtorrent",
 	"sabnzbd",
@@ -79,7 +82,7 @@ func generateSection(buf *strings.Builder, rule *validate.SchemaRule, indent str
 	}
 
 	// Define field order for section properties
-	fieldOrder := []string{"enabled", "instances", "additional_services", "display_names"}
+	fieldOrder := []string{"enabled", "instances", "additional_services", "log_prefixes", "display_names", "warning", "critical"}
 
 	for _, fieldName := range fieldOrder {
 		propRule, exists := rule.Properties[fieldName]
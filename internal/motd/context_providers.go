@@ -239,7 +239,7 @@ func GetGpuInfoWithContext(ctx context.Context, verbose bool) string {
 }
 
 // GetMemoryInfoWithContext provides memory usage info with context/timeout support
-func GetMemoryInfoWithContext(ctx context.Context, _ bool) string {
+func GetMemoryInfoWithContext(ctx context.Context, verbose bool) string {
 	ch := make(chan string, 1)
 
 	go func() {
@@ -248,7 +248,7 @@ func GetMemoryInfoWithContext(ctx context.Context, _ bool) string {
 				ch <- fmt.Sprintf("Error: panic in memory info provider (%v)", r)
 			}
 		}()
-		ch <- GetMemoryInfo()
+		ch <- GetMemoryInfo(ctx, verbose)
 	}()
 
 	select {
@@ -367,3 +367,38 @@ func GetTraefikInfoWithContext(ctx context.Context, verbose bool) string {
 func GetSystemdServicesInfoWithContext(ctx context.Context, verbose bool) string {
 	return runSectionProvider(ctx, verbose, "Systemd services info", GetSystemdServicesInfo)
 }
+
+// GetTemperaturesWithContext provides temperature info with context/timeout support
+func GetTemperaturesWithContext(ctx context.Context, verbose bool) string {
+	return runSectionProvider(ctx, verbose, "Temperature info", GetTemperatures)
+}
+
+// GetPressureInfoWithContext provides pressure stall info with context/timeout support
+func GetPressureInfoWithContext(ctx context.Context, verbose bool) string {
+	return runSectionProvider(ctx, verbose, "Pressure info", GetPressureInfo)
+}
+
+// GetDiskHealthWithContext provides disk health info with context/timeout support
+func GetDiskHealthWithContext(ctx context.Context, verbose bool) string {
+	return runSectionProvider(ctx, verbose, "Disk health info", GetDiskHealth)
+}
+
+// GetPoolStatusWithContext provides pool status info with context/timeout support
+func GetPoolStatusWithContext(ctx context.Context, verbose bool) string {
+	return runSectionProvider(ctx, verbose, "Pool status info", GetPoolStatus)
+}
+
+// GetMountHealthWithContext provides mount health info with context/timeout support
+func GetMountHealthWithContext(ctx context.Context, verbose bool) string {
+	return runSectionProvider(ctx, verbose, "Mount health info", GetMountHealth)
+}
+
+// GetNetworkThroughputWithContext provides network throughput info with context/timeout support
+func GetNetworkThroughputWithContext(ctx context.Context, verbose bool) string {
+	return runSectionProvider(ctx, verbose, "Network throughput info", GetNetworkThroughput)
+}
+
+// GetResourceHistoryWithContext provides recent load and memory usage with context/timeout support
+func GetResourceHistoryWithContext(ctx context.Context, verbose bool) string {
+	return runSectionProvider(ctx, verbose, "Resource history", GetResourceHistory)
+}
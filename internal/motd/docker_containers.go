@@ -0,0 +1,161 @@
+package motd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+)
+
+// maxHealthcheckOutputLength is the number of characters of healthcheck output shown per container
+const maxHealthcheckOutputLength = 80
+
+// traefikContainerName is the name of the Traefik container deployed by Saltbox
+const traefikContainerName = "traefik"
+
+// dockerNotRunningMessage describes a Docker daemon that is installed but can't be reached
+const dockerNotRunningMessage = "Docker is installed but not running"
+
+// exitCodeRegex extracts the exit code from the status of an exited container
+var exitCodeRegex = regexp.MustCompile(`Exited \((\d+)\)`)
+
+// errDockerNotRunning is returned by listDockerContainers when the Docker socket isn't reachable
+var errDockerNotRunning = errors.New("docker daemon is not reachable")
+
+// listContainers lists the Docker containers for the MOTD and health checks, replaced in tests
+var listContainers = listDockerContainers
+
+// dockerContainer is a single container as listed by the Docker API
+type dockerContainer struct {
+	name        string
+	state       container.ContainerState
+	health      container.HealthStatus // Empty for containers without a healthcheck
+	exitCode    int                    // Exit code of an exited container, from its status text
+	healthcheck string                 // Output of the last healthcheck of an unhealthy container, if known
+}
+
+// listDockerContainers lists all containers sorted by name. Unhealthy containers are inspected
+// for the output of their last healthcheck, which the list doesn't include.
+// errDockerNotRunning is returned when the daemon can't be reached.
+func listDockerContainers(ctx context.Context) ([]dockerContainer, error) {
+	cli, err := client.New(client.FromEnv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer func() { _ = cli.Close() }()
+
+	list, err := cli.ContainerList(ctx, client.ContainerListOptions{All: true})
+	if err != nil {
+		if client.IsErrConnectionFailed(err) {
+			return nil, fmt.Errorf("%w: %w", errDockerNotRunning, err)
+		}
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	containers := make([]dockerContainer, 0, len(list.Items))
+	for _, summary := range list.Items {
+		c := newDockerContainer(summary)
+		if c.health == container.Unhealthy {
+			// A failed inspect only costs the detail, not the container
+			if inspect, err := cli.ContainerInspect(ctx, summary.ID, client.ContainerInspectOptions{}); err == nil && inspect.Container.State != nil {
+				c.healthcheck = lastHealthcheckOutput(inspect.Container.State.Health)
+			}
+		}
+		containers = append(containers, c)
+	}
+
+	sort.Slice(containers, func(i, j int) bool { return containers[i].name < containers[j].name })
+	return containers, nil
+}
+
+// traefikContainerRunning reports whether the Traefik container exists and is running
+func traefikContainerRunning(ctx context.Context) (bool, error) {
+	containers, err := listContainers(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, c := range containers {
+		if c.name == traefikContainerName {
+			return c.state == container.StateRunning, nil
+		}
+	}
+	return false, nil
+}
+
+// newDockerContainer converts a container from the list endpoint. The exit code is only part of
+// the status text, e.g. "Exited (1) 3 minutes ago". Daemons older than API v1.52 don't report
+// health in the list, so it's taken from the status text too.
+func newDockerContainer(summary container.Summary) dockerContainer {
+	c := dockerContainer{
+		name:  containerName(summary.ID, summary.Names),
+		state: summary.State,
+	}
+	if match := exitCodeRegex.FindStringSubmatch(summary.Status); match != nil {
+		c.exitCode, _ = strconv.Atoi(match[1])
+	}
+	switch {
+	case summary.Health != nil:
+		if summary.Health.Status != container.NoHealthcheck {
+			c.health = summary.Health.Status
+		}
+	case strings.Contains(summary.Status, "(unhealthy)"):
+		c.health = container.Unhealthy
+	case strings.Contains(summary.Status, "(healthy)"):
+		c.health = container.Healthy
+	case strings.Contains(summary.Status, "(health: starting)"):
+		c.health = container.Starting
+	}
+	return c
+}
+
+// dockerUnavailableMessage describes why the Docker daemon couldn't be reached
+func dockerUnavailableMessage() string {
+	for _, binary := range []string{"dockerd", "docker"} {
+		if _, err := exec.LookPath(binary); err == nil {
+			return dockerNotRunningMessage
+		}
+	}
+	return "Docker is not installed or not detected"
+}
+
+// containerName returns the name of a container without the leading slash the API adds,
+// falling back to its short ID for containers without a name
+func containerName(id string, names []string) string {
+	if len(names) > 0 {
+		return strings.TrimPrefix(names[0], "/")
+	}
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// lastHealthcheckOutput returns the output of the most recent healthcheck as a single line,
+// shortened to maxHealthcheckOutputLength characters
+func lastHealthcheckOutput(health *container.Health) string {
+	if health == nil || len(health.Log) == 0 {
+		return ""
+	}
+	last := health.Log[len(health.Log)-1]
+	if last == nil {
+		return ""
+	}
+
+	output := strings.Join(strings.Fields(last.Output), " ")
+	if output == "" {
+		return fmt.Sprintf("exit code %d", last.ExitCode)
+	}
+	if utf8.RuneCountInString(output) > maxHealthcheckOutputLength {
+		runes := []rune(output)
+		output = string(runes[:maxHealthcheckOutputLength-1]) + "…"
+	}
+	return output
+}
@@ -0,0 +1,129 @@
+package motd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+)
+
+func TestDockerContainerStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		container   dockerContainer
+		want        string
+		problematic bool
+	}{
+		{"running", dockerContainer{state: container.StateRunning}, "running", false},
+		{"healthy", dockerContainer{state: container.StateRunning, health: container.Healthy}, "running (healthy)", false},
+		{"starting", dockerContainer{state: container.StateRunning, health: container.Starting}, "running", false},
+		{"unhealthy without healthcheck output", dockerContainer{state: container.StateRunning, health: container.Unhealthy}, "running (unhealthy)", true},
+		{"unhealthy with healthcheck output", dockerContainer{state: container.StateRunning, health: container.Unhealthy, healthcheck: "connection refused"}, "unhealthy — healthcheck: connection refused", true},
+		{"exited with error", dockerContainer{state: container.StateExited, exitCode: 137}, "stopped (error: 137)", true},
+		{"exited cleanly", dockerContainer{state: container.StateExited}, "stopped", true},
+		{"restarting", dockerContainer{state: container.StateRestarting}, "restarting", true},
+		{"paused", dockerContainer{state: container.StatePaused}, "paused", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, problematic := dockerContainerStatus(tt.container)
+			if got != tt.want || problematic != tt.problematic {
+				t.Errorf("dockerContainerStatus() = (%q, %v), want (%q, %v)", got, problematic, tt.want, tt.problematic)
+			}
+		})
+	}
+}
+
+func TestNewDockerContainer(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary container.Summary
+		want    dockerContainer
+	}{
+		{
+			"health reported by the daemon",
+			container.Summary{Names: []string{"/plex"}, State: container.StateRunning, Status: "Up 2 hours (unhealthy)", Health: &container.HealthSummary{Status: container.Unhealthy}},
+			dockerContainer{name: "plex", state: container.StateRunning, health: container.Unhealthy},
+		},
+		{
+			"no healthcheck",
+			container.Summary{Names: []string{"/sonarr"}, State: container.StateRunning, Status: "Up 2 hours", Health: &container.HealthSummary{Status: container.NoHealthcheck}},
+			dockerContainer{name: "sonarr", state: container.StateRunning},
+		},
+		{
+			"health from the status of older daemons",
+			container.Summary{Names: []string{"/radarr"}, State: container.StateRunning, Status: "Up 5 minutes (healthy)"},
+			dockerContainer{name: "radarr", state: container.StateRunning, health: container.Healthy},
+		},
+		{
+			"exit code from the status",
+			container.Summary{Names: []string{"/bazarr"}, State: container.StateExited, Status: "Exited (137) 3 minutes ago"},
+			dockerContainer{name: "bazarr", state: container.StateExited, exitCode: 137},
+		},
+		{
+			"clean exit",
+			container.Summary{Names: []string{"/tautulli"}, State: container.StateExited, Status: "Exited (0) 2 days ago"},
+			dockerContainer{name: "tautulli", state: container.StateExited},
+		},
+		{
+			"unhealthy from the status of older daemons",
+			container.Summary{Names: []string{"/lidarr"}, State: container.StateRunning, Status: "Up 5 minutes (unhealthy)"},
+			dockerContainer{name: "lidarr", state: container.StateRunning, health: container.Unhealthy},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newDockerContainer(tt.summary); got != tt.want {
+				t.Errorf("newDockerContainer() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerName(t *testing.T) {
+	if got := containerName("abc", []string{"/plex", "/plex-alias"}); got != "plex" {
+		t.Errorf("containerName() = %q, want %q", got, "plex")
+	}
+	if got := containerName("0123456789abcdef", nil); got != "0123456789ab" {
+		t.Errorf("containerName() without names = %q, want the short ID", got)
+	}
+}
+
+func TestLastHealthcheckOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		health *container.Health
+		want   string
+	}{
+		{"no healthcheck", nil, ""},
+		{"no results yet", &container.Health{}, ""},
+		{
+			"last result is used as one line",
+			&container.Health{Log: []*container.HealthcheckResult{
+				{ExitCode: 0, Output: "ok"},
+				{ExitCode: 1, Output: "curl: (7) Failed to connect\n  connection refused\n"},
+			}},
+			"curl: (7) Failed to connect connection refused",
+		},
+		{
+			"empty output falls back to the exit code",
+			&container.Health{Log: []*container.HealthcheckResult{{ExitCode: 2}}},
+			"exit code 2",
+		},
+		{
+			"long output is shortened",
+			&container.Health{Log: []*container.HealthcheckResult{{ExitCode: 1, Output: strings.Repeat("é", 100)}}},
+			strings.Repeat("é", maxHealthcheckOutputLength-1) + "…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lastHealthcheckOutput(tt.health); got != tt.want {
+				t.Errorf("lastHealthcheckOutput() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -13,7 +13,6 @@ import (
 	"time"
 
 	"github.com/saltyorg/sb-go/internal/config"
-	"github.com/saltyorg/sb-go/internal/constants"
 )
 
 // EmbyStreamInfo contains detailed information about Emby streams
@@ -42,7 +41,7 @@ type EmbySessionInfo struct {
 
 // GetEmbyInfo fetches and formats Emby streaming information
 func GetEmbyInfo(ctx context.Context, verbose bool) string {
-	configPath := constants.SaltboxMOTDConfigPath
+	configPath := config.SB().MOTDConfig
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		if verbose {
 			fmt.Printf("DEBUG: Config file %s does not exist\n", configPath)
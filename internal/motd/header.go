@@ -0,0 +1,50 @@
+package motd
+
+import (
+	"os"
+
+	"github.com/saltyorg/sb-go/internal/config"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// LoadHeaderConfig loads the header settings from the MOTD config file.
+// Returns nil if the config file doesn't exist or has no header configured.
+func LoadHeaderConfig() *config.HeaderConfig {
+	cfg := loadMOTDConfig()
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Header
+}
+
+// GenerateHeader renders a bordered header with the given text.
+// If text is empty, the system hostname is used instead.
+func GenerateHeader(text string) string {
+	if text == "" {
+		hostname, err := os.Hostname()
+		if err != nil || hostname == "" {
+			return ""
+		}
+		text = hostname
+	}
+
+	return renderHeader(text, TerminalWidth())
+}
+
+// renderHeader renders the header text within the given width.
+// The border is dropped and the text truncated when the terminal is too narrow to fit it.
+func renderHeader(text string, width int) string {
+	textStyle := KeyStyle.Bold(true)
+	boxStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(ValueStyle.GetForeground()).
+		Padding(0, 2)
+
+	if lipgloss.Width(text)+boxStyle.GetHorizontalFrameSize() > width {
+		return textStyle.Render(ansi.Truncate(text, max(width, 1), "…"))
+	}
+
+	return boxStyle.Render(textStyle.Render(text))
+}
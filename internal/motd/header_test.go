@@ -0,0 +1,23 @@
+package motd
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestRenderHeaderFitsWidth(t *testing.T) {
+	wide := renderHeader("saltbox-host", 80)
+	if !strings.Contains(wide, "╭") || !strings.Contains(wide, "saltbox-host") {
+		t.Fatalf("expected bordered header, got %q", wide)
+	}
+
+	narrow := renderHeader("saltbox-host", 8)
+	if strings.Contains(narrow, "╭") {
+		t.Fatalf("expected border to be dropped on narrow terminals, got %q", narrow)
+	}
+	if w := lipgloss.Width(narrow); w > 8 {
+		t.Fatalf("header width = %d, want <= 8", w)
+	}
+}
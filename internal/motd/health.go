@@ -0,0 +1,153 @@
+package motd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/saltyorg/sb-go/internal/systemd"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// HealthIssue describes a single problem found by CheckHealth
+type HealthIssue struct {
+	Source  string // The area the issue was found in (e.g., "docker", "disk")
+	Message string
+}
+
+// String returns the issue as a single "source: message" line
+func (i HealthIssue) String() string {
+	return fmt.Sprintf("%s: %s", i.Source, i.Message)
+}
+
+// healthCheck collects the issues of a single area
+type healthCheck func(ctx context.Context, verbose bool) []HealthIssue
+
+// sectionHealthCheck is the health check of the MOTD section with the same name
+type sectionHealthCheck struct {
+	section string
+	check   healthCheck
+}
+
+// healthChecks lists the checks run by CheckHealth in display order
+var healthChecks = []sectionHealthCheck{
+	{"docker", checkDockerHealth},
+	{"systemd", checkSystemdHealth},
+	{"disk", checkDiskHealth},
+	{"traefik", checkTraefikHealth},
+	{"reboot", checkRebootHealth},
+}
+
+// CheckHealth runs the MOTD health checks and returns all issues found.
+// An empty result means everything is healthy.
+func CheckHealth(ctx context.Context, verbose bool) []HealthIssue {
+	var issues []HealthIssue
+	for _, hc := range healthChecks {
+		issues = append(issues, hc.check(ctx, verbose)...)
+	}
+	return issues
+}
+
+// checkDockerHealth reports an installed Docker daemon that isn't running, or the containers
+// that need attention. Hosts without Docker and failures to list the containers aren't issues.
+func checkDockerHealth(ctx context.Context, verbose bool) []HealthIssue {
+	containers, err := listContainers(ctx)
+	if err != nil {
+		if verbose {
+			fmt.Printf("DEBUG: %v\n", err)
+		}
+		if message := dockerErrorMessage(err); message == dockerNotRunningMessage {
+			return []HealthIssue{{Source: "docker", Message: message}}
+		}
+		return nil
+	}
+
+	var issues []HealthIssue
+	for _, problem := range dockerContainerProblems(containers, loadExpectedContainers()) {
+		issues = append(issues, HealthIssue{Source: "docker", Message: fmt.Sprintf("%s: %s", problem.name, problem.status)})
+	}
+	return issues
+}
+
+// checkTraefikHealth reports a running Traefik container whose API can't be reached, or the
+// routers that need attention. Hosts without a running Traefik container aren't issues.
+func checkTraefikHealth(ctx context.Context, verbose bool) []HealthIssue {
+	running, err := traefikContainerRunning(ctx)
+	if err != nil {
+		if verbose {
+			fmt.Printf("DEBUG: %v\n", err)
+		}
+		return nil
+	}
+	if !running {
+		return nil
+	}
+
+	routers, err := FetchTraefikRouters(ctx)
+	if err != nil {
+		if verbose {
+			fmt.Printf("DEBUG: Failed to fetch Traefik routers: %v\n", err)
+		}
+		return []HealthIssue{{Source: "traefik", Message: "Traefik container is running but API is not accessible"}}
+	}
+
+	var issues []HealthIssue
+	for _, router := range routers {
+		if problem := router.Problem(); problem != "" {
+			issues = append(issues, HealthIssue{Source: "traefik", Message: fmt.Sprintf("%s: %s", router.Name, problem)})
+		}
+	}
+	return issues
+}
+
+// checkSystemdHealth reports failed Saltbox systemd services
+func checkSystemdHealth(ctx context.Context, verbose bool) []HealthIssue {
+	var additionalServices []string
+	if cfg := loadMOTDConfig(); cfg != nil && cfg.Systemd != nil {
+		additionalServices = cfg.Systemd.AdditionalServices
+	}
+
+	services, err := systemd.GetFilteredServices(ctx, systemd.FiltersWithAdditional(additionalServices))
+	if err != nil {
+		if verbose {
+			fmt.Printf("DEBUG: Failed to get systemd services: %v\n", err)
+		}
+		return nil
+	}
+
+	var issues []HealthIssue
+	for _, svc := range services {
+		if svc.Active == "failed" {
+			issues = append(issues, HealthIssue{Source: "systemd", Message: fmt.Sprintf("%s failed", svc.Name)})
+		}
+	}
+	return issues
+}
+
+// checkDiskHealth reports partitions at or above their critical usage threshold
+func checkDiskHealth(ctx context.Context, verbose bool) []HealthIssue {
+	usages, ok := readDiskUsage(ctx)
+	if !ok {
+		return nil
+	}
+
+	diskCfg := loadDiskConfig()
+	var issues []HealthIssue
+	for _, usage := range usages {
+		if _, critical := diskThresholds(diskCfg, usage.mountPoint); usage.usagePercent >= critical {
+			issues = append(issues, HealthIssue{
+				Source:  "disk",
+				Message: fmt.Sprintf("%s is %d%% full (run 'sb disk usage %s' to find what is filling it)", usage.mountPoint, usage.usagePercent, usage.mountPoint),
+			})
+		}
+	}
+	return issues
+}
+
+// checkRebootHealth reports a pending reboot using the reboot getter
+func checkRebootHealth(ctx context.Context, verbose bool) []HealthIssue {
+	if output := ansi.Strip(GetRebootRequired(ctx, verbose)); output != "" {
+		return []HealthIssue{{Source: "reboot", Message: output}}
+	}
+	return nil
+}
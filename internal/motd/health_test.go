@@ -0,0 +1,112 @@
+package motd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+)
+
+// useContainers makes the Docker checks see the given containers, or err when it's set
+func useContainers(t *testing.T, containers []dockerContainer, err error) {
+	t.Helper()
+	previous := listContainers
+	t.Cleanup(func() { listContainers = previous })
+	listContainers = func(context.Context) ([]dockerContainer, error) {
+		return containers, err
+	}
+}
+
+func TestCheckDockerHealth(t *testing.T) {
+	// Only an installed daemon that isn't running is an issue
+	var daemonNotRunning []string
+	if dockerUnavailableMessage() == dockerNotRunningMessage {
+		daemonNotRunning = []string{"docker: " + dockerNotRunningMessage}
+	}
+
+	tests := []struct {
+		name       string
+		containers []dockerContainer
+		err        error
+		want       []string
+	}{
+		{
+			name: "healthy containers",
+			containers: []dockerContainer{
+				{name: "plex", state: container.StateRunning, health: container.Healthy},
+				{name: "sonarr", state: container.StateRunning},
+			},
+		},
+		{
+			name: "containers that need attention",
+			containers: []dockerContainer{
+				{name: "plex", state: container.StateRunning},
+				{name: "radarr", state: container.StateExited, exitCode: 1},
+				{name: "sonarr", state: container.StateRestarting},
+			},
+			want: []string{"docker: radarr: stopped (error: 1)", "docker: sonarr: restarting"},
+		},
+		{
+			name: "daemon not reachable",
+			err:  fmt.Errorf("%w: connection refused", errDockerNotRunning),
+			want: daemonNotRunning,
+		},
+		{
+			// Not an issue, like hosts without Docker
+			name: "container list unavailable",
+			err:  errors.New("permission denied"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useContainers(t, tt.containers, tt.err)
+
+			issues := checkDockerHealth(context.Background(), false)
+			if fmt.Sprint(issues) != fmt.Sprint(tt.want) {
+				t.Errorf("checkDockerHealth() = %v, want %v", issues, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckTraefikHealthContainer(t *testing.T) {
+	tests := []struct {
+		name       string
+		containers []dockerContainer
+		err        error
+		want       []string
+	}{
+		// Hosts without a running Traefik container aren't unhealthy
+		{
+			name:       "container missing",
+			containers: []dockerContainer{{name: "plex", state: container.StateRunning}},
+		},
+		{
+			name:       "container stopped",
+			containers: []dockerContainer{{name: "traefik", state: container.StateExited}},
+		},
+		{
+			name: "container state unavailable",
+			err:  errors.New("permission denied"),
+		},
+		{
+			// Already reported by the docker check
+			name: "daemon not reachable",
+			err:  fmt.Errorf("%w: connection refused", errDockerNotRunning),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useContainers(t, tt.containers, tt.err)
+
+			issues := checkTraefikHealth(context.Background(), false)
+			if fmt.Sprint(issues) != fmt.Sprint(tt.want) {
+				t.Errorf("checkTraefikHealth() = %v, want %v", issues, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package motd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// resourceHistoryPath stores recent load and memory samples between MOTD renders
+const resourceHistoryPath = "/var/cache/sb/motd-history.json"
+
+const (
+	historyMaxSamples   = 24              // Samples kept, oldest are dropped first
+	historyMinSamples   = 4               // Samples needed before the section is shown
+	historySampleSpread = 5 * time.Minute // Minimum time between samples, so frequent renders don't flood the history
+)
+
+// Sparkline levels from lowest to highest
+var (
+	sparklineBlocks = []rune("▁▂▃▄▅▆▇█")
+	sparklineASCII  = []rune("_.-=+*#@")
+)
+
+// resourceSample is a single load and memory reading
+type resourceSample struct {
+	Time   int64   `json:"t"` // Unix time in seconds
+	Load   float64 `json:"l"` // 1 minute load average
+	Memory float64 `json:"m"` // Memory used in percent
+}
+
+// resourceHistory is the persisted ring buffer of samples, oldest first
+type resourceHistory struct {
+	Samples []resourceSample `json:"samples"`
+}
+
+// GetResourceHistory records the current load and memory usage and returns sparklines of the recent samples.
+// Samples are only recorded every few minutes and the history is bounded, so the file stays tiny.
+// Returns an empty string to hide the section until enough samples exist.
+func GetResourceHistory(ctx context.Context, verbose bool) string {
+	sample, ok := readResourceSample(time.Now())
+	if !ok {
+		return ""
+	}
+
+	history := loadResourceHistory(resourceHistoryPath)
+	if history.record(sample) {
+		// Best effort, e.g. users without write access still see the existing history
+		if err := saveResourceHistory(resourceHistoryPath, history); err != nil && verbose {
+			fmt.Printf("DEBUG: Failed to save resource history: %v\n", err)
+		}
+	}
+
+	if len(history.Samples) < historyMinSamples {
+		return ""
+	}
+	return formatResourceHistory(history, sample, runtime.NumCPU(), resolveBarStyle() == BarStyleASCII)
+}
+
+// readResourceSample reads the current load average and memory usage
+func readResourceSample(now time.Time) (resourceSample, bool) {
+	loads, ok := parseLoadAverages(readProcFile("loadavg"))
+	if !ok {
+		return resourceSample{}, false
+	}
+	load, _ := strconv.ParseFloat(loads[0], 64)
+
+	usage, ok := parseMeminfo(readProcFile("meminfo"))
+	if !ok {
+		return resourceSample{}, false
+	}
+	used := usage.total - min(usage.available, usage.total)
+
+	return resourceSample{Time: now.Unix(), Load: load, Memory: float64(used) / float64(usage.total) * 100}, true
+}
+
+// loadResourceHistory reads the history file, returning an empty history if it's missing or invalid
+func loadResourceHistory(path string) resourceHistory {
+	var history resourceHistory
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return history
+	}
+	if err := json.Unmarshal(data, &history); err != nil {
+		return resourceHistory{}
+	}
+	return history
+}
+
+// saveResourceHistory atomically writes the history file, creating its directory if needed
+func saveResourceHistory(path string, history resourceHistory) error {
+	data, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".motd-history-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// record appends sample unless the newest sample is too recent, dropping the oldest samples
+// beyond historyMaxSamples. Reports whether the history changed.
+func (h *resourceHistory) record(sample resourceSample) bool {
+	if n := len(h.Samples); n > 0 {
+		elapsed := time.Duration(sample.Time-h.Samples[n-1].Time) * time.Second
+		// A sample from the future means the clock went back, so start over from now
+		if elapsed >= 0 && elapsed < historySampleSpread {
+			return false
+		}
+		if elapsed < 0 {
+			h.Samples = nil
+		}
+	}
+
+	h.Samples = append(h.Samples, sample)
+	if len(h.Samples) > historyMaxSamples {
+		h.Samples = h.Samples[len(h.Samples)-historyMaxSamples:]
+	}
+	return true
+}
+
+// formatResourceHistory renders load and memory sparklines of the history along with the peak and current values.
+// Load is scaled against the number of CPUs so a full bar means every core was busy.
+func formatResourceHistory(history resourceHistory, current resourceSample, cpus int, ascii bool) string {
+	loads := make([]float64, len(history.Samples))
+	memory := make([]float64, len(history.Samples))
+	var peakLoad, peakMemory float64
+	for i, sample := range history.Samples {
+		loads[i] = sample.Load
+		memory[i] = sample.Memory
+		peakLoad = math.Max(peakLoad, sample.Load)
+		peakMemory = math.Max(peakMemory, sample.Memory)
+	}
+
+	levels := sparklineBlocks
+	if ascii {
+		levels = sparklineASCII
+	}
+	loadScale := math.Max(peakLoad, float64(max(cpus, 1)))
+
+	first := time.Unix(history.Samples[0].Time, 0)
+	last := time.Unix(history.Samples[len(history.Samples)-1].Time, 0)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s %s\n",
+		DefaultStyle.Render("Load  "),
+		ValueStyle.Render(renderSparkline(loads, loadScale, levels)),
+		DefaultStyle.Render(fmt.Sprintf("peak %.2f, now %.2f", peakLoad, current.Load)))
+	fmt.Fprintf(&b, "%s %s %s\n",
+		DefaultStyle.Render("Memory"),
+		ValueStyle.Render(renderSparkline(memory, 100, levels)),
+		DefaultStyle.Render(fmt.Sprintf("peak %.0f%%, now %.0f%%", peakMemory, current.Memory)))
+	b.WriteString(DefaultStyle.Render(fmt.Sprintf("%d samples over the last %s", len(history.Samples), formatHistorySpan(last.Sub(first)))))
+	return b.String()
+}
+
+// renderSparkline renders one character per value, scaled so scale maps to the highest level
+func renderSparkline(values []float64, scale float64, levels []rune) string {
+	var b strings.Builder
+	for _, value := range values {
+		level := 0
+		if scale > 0 {
+			level = int(math.Round(value / scale * float64(len(levels)-1)))
+		}
+		b.WriteRune(levels[max(0, min(level, len(levels)-1))])
+	}
+	return b.String()
+}
+
+// formatHistorySpan formats the time covered by the history, e.g. "2h 15m" or "3d 4h"
+func formatHistorySpan(span time.Duration) string {
+	span = span.Round(time.Minute)
+	days := int(span.Hours()) / 24
+	hours := int(span.Hours()) % 24
+	minutes := int(span.Minutes()) % 60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
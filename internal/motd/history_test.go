@@ -0,0 +1,90 @@
+package motd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResourceHistoryRecord(t *testing.T) {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC).Unix()
+	spread := int64(historySampleSpread / time.Second)
+
+	var history resourceHistory
+	if !history.record(resourceSample{Time: start, Load: 1}) {
+		t.Fatal("first sample not recorded")
+	}
+	if history.record(resourceSample{Time: start + spread - 1, Load: 2}) {
+		t.Error("sample within the minimum spread recorded")
+	}
+
+	for i := int64(1); i <= historyMaxSamples+5; i++ {
+		history.record(resourceSample{Time: start + i*spread, Load: float64(i)})
+	}
+	if len(history.Samples) != historyMaxSamples {
+		t.Fatalf("len(Samples) = %d, want %d", len(history.Samples), historyMaxSamples)
+	}
+	if got, want := history.Samples[len(history.Samples)-1].Load, float64(historyMaxSamples+5); got != want {
+		t.Errorf("newest sample load = %v, want %v", got, want)
+	}
+
+	// A clock that went back restarts the history
+	if !history.record(resourceSample{Time: start, Load: 9}) || len(history.Samples) != 1 {
+		t.Errorf("history after clock change = %+v, want only the new sample", history.Samples)
+	}
+}
+
+func TestResourceHistorySaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "motd-history.json")
+	if got := loadResourceHistory(path); len(got.Samples) != 0 {
+		t.Errorf("missing file loaded as %+v", got)
+	}
+
+	want := resourceHistory{Samples: []resourceSample{{Time: 1, Load: 0.5, Memory: 40}, {Time: 301, Load: 1.5, Memory: 42.5}}}
+	if err := saveResourceHistory(path, want); err != nil {
+		t.Fatalf("saveResourceHistory unexpected error: %v", err)
+	}
+	got := loadResourceHistory(path)
+	if len(got.Samples) != 2 || got.Samples[1] != want.Samples[1] {
+		t.Errorf("loaded history = %+v, want %+v", got, want)
+	}
+}
+
+func TestRenderSparkline(t *testing.T) {
+	if got, want := renderSparkline([]float64{0, 2, 4, 8, 16}, 8, sparklineBlocks), "▁▃▅██"; got != want {
+		t.Errorf("renderSparkline = %q, want %q", got, want)
+	}
+	if got, want := renderSparkline([]float64{0, 100}, 100, sparklineASCII), "_@"; got != want {
+		t.Errorf("renderSparkline ascii = %q, want %q", got, want)
+	}
+}
+
+func TestFormatResourceHistory(t *testing.T) {
+	history := resourceHistory{Samples: []resourceSample{
+		{Time: 0, Load: 0.5, Memory: 30},
+		{Time: 3600, Load: 3.25, Memory: 80},
+		{Time: 7500, Load: 1, Memory: 50},
+	}}
+	output := formatResourceHistory(history, resourceSample{Load: 0.4, Memory: 45}, 4, false)
+
+	for _, want := range []string{"peak 3.25, now 0.40", "peak 80%, now 45%", "3 samples over the last 2h 5m"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatHistorySpan(t *testing.T) {
+	tests := map[time.Duration]string{
+		20 * time.Minute:                "20m",
+		2*time.Hour + 15*time.Minute:    "2h 15m",
+		76*time.Hour + 30*time.Minute:   "3d 4h",
+		59*time.Minute + 40*time.Second: "1h 0m",
+	}
+	for span, want := range tests {
+		if got := formatHistorySpan(span); got != want {
+			t.Errorf("formatHistorySpan(%s) = %q, want %q", span, got, want)
+		}
+	}
+}
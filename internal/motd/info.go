@@ -3,18 +3,22 @@ package motd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"os"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"sync/atomic"
 	timepkg "time"
 
-	"charm.land/bubbles/v2/progress"
+	"github.com/saltyorg/sb-go/internal/config"
+
 	"charm.land/lipgloss/v2"
+	"github.com/moby/moby/api/types/container"
 )
 
 // shareMode controls whether to obscure sensitive information like IP addresses
@@ -111,32 +115,21 @@ func GetUptime(ctx context.Context, verbose bool) string {
 // GetCpuAverages returns the system load averages
 func GetCpuAverages(ctx context.Context, verbose bool) string {
 	// Try to read from /proc/loadavg first
-	content, err := os.ReadFile("/proc/loadavg")
-	if err == nil {
-		fields := strings.Fields(string(content))
-		if len(fields) >= 3 {
-			return fmt.Sprintf("%s: %s | %s: %s | %s: %s",
-				DefaultStyle.Render("1 min"), ValueStyle.Render(fields[0]),
-				DefaultStyle.Render("5 min"), ValueStyle.Render(fields[1]),
-				DefaultStyle.Render("15 min"), ValueStyle.Render(fields[2]),
-			)
-		}
+	if loads, ok := parseLoadAverages(readProcFile("loadavg")); ok {
+		return formatLoadAverages(loads)
 	}
 
 	// Fallback to uptime command if /proc/loadavg can't be read
+	if verbose {
+		fmt.Printf("DEBUG: /proc/loadavg unavailable, falling back to uptime\n")
+	}
 	loadInfo := ExecCommand(ctx, "uptime")
 	if loadInfo != "Not available" {
 		// Extract load averages from uptime output
 		// Typical output: "... load average: 0.00, 0.01, 0.05"
-		if idx := strings.Index(loadInfo, "load average:"); idx != -1 {
-			loadPart := loadInfo[idx+14:]
-			loads := strings.Split(loadPart, ", ")
-			if len(loads) >= 3 {
-				return fmt.Sprintf("%s: %s | %s: %s | %s: %s",
-					DefaultStyle.Render("1 min"), ValueStyle.Render(strings.TrimSpace(loads[0])),
-					DefaultStyle.Render("5 min"), ValueStyle.Render(strings.TrimSpace(loads[1])),
-					DefaultStyle.Render("15 min"), ValueStyle.Render(strings.TrimSpace(loads[2])),
-				)
+		if _, loadPart, found := strings.Cut(loadInfo, "load average:"); found {
+			if loads, ok := parseLoadAverages(strings.ReplaceAll(loadPart, ",", " ")); ok {
+				return formatLoadAverages(loads)
 			}
 		}
 	}
@@ -144,45 +137,55 @@ func GetCpuAverages(ctx context.Context, verbose bool) string {
 	return DefaultStyle.Render("Not available")
 }
 
+// parseLoadAverages returns the 1, 5 and 15 minute load averages leading the given fields
+func parseLoadAverages(data string) ([]string, bool) {
+	fields := strings.Fields(data)
+	if len(fields) < 3 {
+		return nil, false
+	}
+	for _, field := range fields[:3] {
+		if _, err := strconv.ParseFloat(field, 64); err != nil {
+			return nil, false
+		}
+	}
+	return fields[:3], true
+}
+
+func formatLoadAverages(loads []string) string {
+	return fmt.Sprintf("%s: %s | %s: %s | %s: %s",
+		DefaultStyle.Render("1 min"), ValueStyle.Render(loads[0]),
+		DefaultStyle.Render("5 min"), ValueStyle.Render(loads[1]),
+		DefaultStyle.Render("15 min"), ValueStyle.Render(loads[2]),
+	)
+}
+
 // GetLastLogin returns the last login information
 func GetLastLogin(ctx context.Context, verbose bool) string {
-	// Try the last command to get the most recent login
-	lastOutput := ExecCommand(ctx, "last", "-n", "5")
+	// Try the last command to get the most recent login.
+	// -w keeps long hostnames and IPv6 addresses from being truncated.
+	lastOutput := ExecCommand(ctx, "last", "-w", "-n", "5")
 	if lastOutput != "Not available" && lastOutput != "" {
-		lines := strings.SplitSeq(lastOutput, "\n")
-		for line := range lines {
-			trimmed := strings.TrimSpace(line)
-			if trimmed == "" {
-				continue
-			}
-
-			fields := strings.Fields(trimmed)
-			if isSkippableLastLine(trimmed, fields) || len(fields) < 5 {
+		for line := range strings.SplitSeq(lastOutput, "\n") {
+			login, ok := parseLastLine(line)
+			if !ok {
 				continue
 			}
 
-			// First check if "still logged in" is present
-			stillLoggedIn := strings.Contains(trimmed, "still logged in")
-
-			user := fields[0]
-			// Color the username
-			coloredUser := ValueStyle.Render(user)
-
-			// Extract from IP
-			fromIP := fields[2]
-
-			// Initialize variables for the time components
-			var day, month, date, time, logoutTime, duration string
+			// Color the username and date/time components
+			coloredUser := ValueStyle.Render(login.user)
+			coloredDateTime := ValueStyle.Render(login.dateTime)
 
-			// Find the start of the date/time information
-			timeIndex := 3 // Default position for date/time components
-
-			// Check if the IP address actually contains an IP (sometimes it might not)
-			if !strings.Contains(fromIP, ".") && !strings.Contains(fromIP, ":") {
-				timeIndex = 2
+			fromIP := login.source
+			if fromIP == "" {
 				fromIP = "local"
 			}
 
+			// Look up the hostname and country of remote logins if enabled (never in share mode)
+			var enrichment string
+			if fromIP != "local" && !shareMode.Load() {
+				enrichment = enrichLoginIP(ctx, fromIP, loadLastLoginConfig())
+			}
+
 			// Obscure IP if share mode is enabled
 			if shareMode.Load() {
 				fromIP = obscureIP(fromIP)
@@ -190,62 +193,25 @@ func GetLastLogin(ctx context.Context, verbose bool) string {
 
 			// Color the IP address
 			coloredIP := ValueStyle.Render(fromIP)
+			if enrichment != "" {
+				coloredIP += DefaultStyle.Render(fmt.Sprintf(" (%s)", enrichment))
+			}
 
-			// Extract date and time if we have enough fields
-			if len(fields) >= timeIndex+4 {
-				day = fields[timeIndex]
-				month = fields[timeIndex+1]
-				date = fields[timeIndex+2]
-				time = fields[timeIndex+3]
-
-				// Color the date/time components
-				dateTimeStr := fmt.Sprintf("%s %s %s %s", day, month, date, time)
-				coloredDateTime := ValueStyle.Render(dateTimeStr)
-
-				// For entries that are still logged in
-				if stillLoggedIn {
-					return fmt.Sprintf("%s at %s (still logged in) from %s",
-						coloredUser, coloredDateTime, coloredIP)
-				}
-
-				// For entries that have logged out, find the logout time and duration
-				dashIndex := -1
-				for i, field := range fields {
-					if field == "-" {
-						dashIndex = i
-						break
-					}
-				}
-
-				if dashIndex != -1 && dashIndex+1 < len(fields) {
-					logoutTime = fields[dashIndex+1]
-					// Color the logout time
-					coloredLogoutTime := ValueStyle.Render(logoutTime)
-
-					// Find the duration which is in parentheses
-					for i, field := range fields {
-						if after, ok := strings.CutPrefix(field, "("); ok {
-							// Start building duration from this field
-							duration = after
-
-							// May need to join with the next field if it's a multipart duration
-							if !strings.HasSuffix(duration, ")") && i+1 < len(fields) {
-								duration += " " + strings.TrimSuffix(fields[i+1], ")")
-							} else {
-								duration = strings.TrimSuffix(duration, ")")
-							}
-							break
-						}
-					}
-
-					return fmt.Sprintf("%s at %s until %s (%s) from %s",
-						coloredUser, coloredDateTime, coloredLogoutTime, duration, coloredIP)
-				}
-
-				// If we couldn't parse the logout info but have login info
-				return fmt.Sprintf("%s at %s from %s",
+			// For entries that are still logged in
+			if login.stillLoggedIn {
+				return fmt.Sprintf("%s at %s (still logged in) from %s",
 					coloredUser, coloredDateTime, coloredIP)
 			}
+
+			// For entries that have logged out, show the logout time and duration
+			if login.logoutTime != "" {
+				return fmt.Sprintf("%s at %s until %s (%s) from %s",
+					coloredUser, coloredDateTime, ValueStyle.Render(login.logoutTime), login.duration, coloredIP)
+			}
+
+			// If we couldn't parse the logout info but have login info
+			return fmt.Sprintf("%s at %s from %s",
+				coloredUser, coloredDateTime, coloredIP)
 		}
 	}
 
@@ -329,43 +295,57 @@ func GetUserSessions(ctx context.Context, verbose bool) string {
 
 // GetProcessCount returns the number of running processes
 func GetProcessCount(ctx context.Context, verbose bool) string {
-	// Method 1: Count directories in /proc that are numeric
-	entries, err := os.ReadDir("/proc")
-	if err == nil {
-		count := 0
-		for _, entry := range entries {
-			if entry.IsDir() {
-				// Check if the directory name is a number (process ID)
-				if _, err := strconv.Atoi(entry.Name()); err == nil {
-					count++
-				}
-			}
+	// Method 1: Count the numeric (process ID) directories in /proc
+	count, ok := countProcesses(hostPath("/proc"))
+
+	// Method 2: Use ps command (fallback)
+	if !ok {
+		if verbose {
+			fmt.Printf("DEBUG: /proc has no process directories, falling back to ps\n")
 		}
-		// Color the process count
-		coloredCount := ValueStyle.Render(fmt.Sprintf("%d", count))
-		return fmt.Sprintf("%s running processes", coloredCount)
+		count, ok = countPsProcesses(ExecCommand(ctx, "ps", "ax"))
+	}
+	if !ok {
+		return "Not available"
 	}
 
-	// Method 2: Use ps command (fallback)
-	psOutput := ExecCommand(ctx, "ps", "ax")
-	if psOutput != "Not available" {
-		lines := strings.Split(psOutput, "\n")
-		count := 0
-		for _, line := range lines {
-			if strings.TrimSpace(line) != "" {
+	// Color the process count
+	coloredCount := ValueStyle.Render(fmt.Sprintf("%d", count))
+	return fmt.Sprintf("%s running processes", coloredCount)
+}
+
+// countProcesses counts the process directories in a procfs root.
+// A masked /proc has no process directories at all, which is reported as a failure.
+func countProcesses(root string) (int, bool) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return 0, false
+	}
+	count := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			// Check if the directory name is a number (process ID)
+			if _, err := strconv.Atoi(entry.Name()); err == nil {
 				count++
 			}
 		}
-		// Subtract 1 for the header line
-		if count > 0 {
-			count--
-		}
-		// Color the process count
-		coloredCount := ValueStyle.Render(fmt.Sprintf("%d", count))
-		return fmt.Sprintf("%s running processes", coloredCount)
 	}
+	return count, count > 0
+}
 
-	return "Not available"
+// countPsProcesses counts the processes listed in ps output, excluding the header line
+func countPsProcesses(output string) (int, bool) {
+	if output == "Not available" {
+		return 0, false
+	}
+	count := 0
+	for line := range strings.Lines(output) {
+		if strings.TrimSpace(line) != "" {
+			count++
+		}
+	}
+	// Subtract 1 for the header line
+	return count - 1, count > 1
 }
 
 // GetAptStatus returns the apt package status
@@ -571,32 +551,14 @@ func GetAptStatus(ctx context.Context, verbose bool) string {
 func GetRebootRequired(ctx context.Context, verbose bool) string {
 	// Method 1: Go native implementation
 	// Checks if the reboot-required file exists
-	rebootFile := "/var/run/reboot-required"
-	_, err := os.Stat(rebootFile)
-	if err == nil {
-		// File exists, a reboot is required
-
-		// Try to get the specific packages requiring reboot
-		pkgFile := "/var/run/reboot-required.pkgs"
-		pkgData, err := os.ReadFile(pkgFile)
-		if err == nil && len(pkgData) > 0 {
-			pkgs := strings.Split(string(pkgData), "\n")
-			// Filter out empty lines
-			var validPkgs []string
-			for _, pkg := range pkgs {
-				if pkg != "" {
-					validPkgs = append(validPkgs, pkg)
-				}
-			}
-
-			if len(validPkgs) > 0 {
-				if len(validPkgs) == 1 {
-					// Use yellow for the entire message when reboot is required
-					return WarningStyle.Render(fmt.Sprintf("Reboot required (package: %s)", validPkgs[0]))
-				} else {
-					// Use yellow for the entire message with package count
-					return WarningStyle.Render(fmt.Sprintf("Reboot required (%d packages)", len(validPkgs)))
-				}
+	if required, validPkgs := RebootRequired(); required {
+		if len(validPkgs) > 0 {
+			if len(validPkgs) == 1 {
+				// Use yellow for the entire message when reboot is required
+				return WarningStyle.Render(fmt.Sprintf("Reboot required (package: %s)", validPkgs[0]))
+			} else {
+				// Use yellow for the entire message with package count
+				return WarningStyle.Render(fmt.Sprintf("Reboot required (%d packages)", len(validPkgs)))
 			}
 		}
 
@@ -618,17 +580,66 @@ func GetRebootRequired(ctx context.Context, verbose bool) string {
 	return ""
 }
 
+// Paths of the files written by update-notifier when installed packages require a reboot
+const (
+	rebootRequiredFile     = "/var/run/reboot-required"
+	rebootRequiredPkgsFile = "/var/run/reboot-required.pkgs"
+)
+
+// RebootRequired reports whether installed updates require a reboot,
+// along with the packages requiring it when they are known.
+func RebootRequired() (bool, []string) {
+	return rebootRequired(rebootRequiredFile, rebootRequiredPkgsFile)
+}
+
+func rebootRequired(rebootFile, pkgFile string) (bool, []string) {
+	if _, err := os.Stat(rebootFile); err != nil {
+		return false, nil
+	}
+
+	// Try to get the specific packages requiring reboot
+	pkgData, err := os.ReadFile(pkgFile)
+	if err != nil {
+		return true, nil
+	}
+
+	// Filter out empty lines
+	var validPkgs []string
+	for pkg := range strings.SplitSeq(string(pkgData), "\n") {
+		if pkg != "" {
+			validPkgs = append(validPkgs, pkg)
+		}
+	}
+	return true, validPkgs
+}
+
+// cpuSummary describes the installed CPUs
+type cpuSummary struct {
+	model   string
+	sockets int
+	cores   int // Physical cores across all sockets
+	threads int // Logical processors across all sockets
+}
+
 // GetCpuInfo returns information about the CPU model and core count
 func GetCpuInfo(ctx context.Context, verbose bool) string {
 	// Try to read from /proc/cpuinfo
-	content, err := os.ReadFile("/proc/cpuinfo")
-	if err != nil {
+	summary, ok := parseCpuInfo(readProcFile("cpuinfo"))
+	if !ok {
+		if verbose {
+			fmt.Printf("DEBUG: /proc/cpuinfo unavailable, falling back to lscpu\n")
+		}
+		summary, ok = parseLscpu(ExecCommand(ctx, "lscpu"))
+	}
+	if !ok {
 		return DefaultStyle.Render("Not available")
 	}
+	return formatCpuSummary(summary)
+}
 
-	// Parse the cpuinfo content
-	cpuInfo := string(content)
-	lines := strings.Split(cpuInfo, "\n")
+// parseCpuInfo parses /proc/cpuinfo content. It fails when no CPU model is listed.
+func parseCpuInfo(content string) (cpuSummary, bool) {
+	lines := strings.Split(content, "\n")
 
 	modelName := ""
 	logicalProcessors := 0
@@ -696,6 +707,10 @@ func GetCpuInfo(ctx context.Context, verbose bool) string {
 		}
 	}
 
+	if modelName == "" {
+		return cpuSummary{}, false
+	}
+
 	// Calculate the number of physical CPU sockets
 	numSockets := len(physicalIds)
 	if numSockets == 0 {
@@ -718,73 +733,140 @@ func GetCpuInfo(ctx context.Context, verbose bool) string {
 		}
 	}
 
-	// Format the result
-	if modelName != "" {
-		// Remove any core count information from the model name if present
-		// (e.g., "AMD Ryzen 5 3600 6-Core Processor" -> "AMD Ryzen 5 3600")
-		modelName = regexp.MustCompile(`\s+\d+-Core.*`).ReplaceAllString(modelName, "")
-		modelName = strings.TrimSpace(modelName)
+	return cpuSummary{model: modelName, sockets: numSockets, cores: totalPhysicalCores, threads: logicalProcessors}, true
+}
 
-		// If we have multiple sockets, format each on its own line
-		if numSockets > 1 {
-			var output strings.Builder
-			coresPerSocket := totalPhysicalCores / numSockets
-			threadsPerSocket := logicalProcessors / numSockets
+// parseLscpu parses the output of lscpu. It fails when no CPU model is listed.
+func parseLscpu(output string) (cpuSummary, bool) {
+	if output == "Not available" {
+		return cpuSummary{}, false
+	}
 
-			for i := 0; i < numSockets; i++ {
-				if i > 0 {
-					output.WriteString("\n")
-				}
-				if threadsPerSocket > coresPerSocket {
-					// We have hyperthreading/SMT
-					output.WriteString(fmt.Sprintf("%s (%s cores, %s threads)",
-						DefaultStyle.Render(modelName),
-						ValueStyle.Render(fmt.Sprintf("%d", coresPerSocket)),
-						ValueStyle.Render(fmt.Sprintf("%d", threadsPerSocket))))
-				} else {
-					// No hyperthreading
-					output.WriteString(fmt.Sprintf("%s (%s cores)",
-						DefaultStyle.Render(modelName),
-						ValueStyle.Render(fmt.Sprintf("%d", coresPerSocket))))
-				}
+	fields := make(map[string]string)
+	for line := range strings.Lines(output) {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		// Hybrid CPUs list a model per core type, keep the first one
+		if _, seen := fields[key]; !seen {
+			fields[key] = strings.TrimSpace(value)
+		}
+	}
+
+	summary := cpuSummary{model: fields["Model name"], sockets: 1}
+	if summary.model == "" {
+		return cpuSummary{}, false
+	}
+	if sockets, err := strconv.Atoi(fields["Socket(s)"]); err == nil && sockets > 0 {
+		summary.sockets = sockets
+	}
+	summary.threads, _ = strconv.Atoi(fields["CPU(s)"])
+	if coresPerSocket, err := strconv.Atoi(fields["Core(s) per socket"]); err == nil {
+		summary.cores = coresPerSocket * summary.sockets
+	}
+	if summary.cores == 0 {
+		summary.cores = summary.threads
+	}
+	return summary, summary.threads > 0
+}
+
+// formatCpuSummary formats the CPU model and core counts, one line per socket
+func formatCpuSummary(summary cpuSummary) string {
+	// Remove any core count information from the model name if present
+	// (e.g., "AMD Ryzen 5 3600 6-Core Processor" -> "AMD Ryzen 5 3600")
+	modelName := regexp.MustCompile(`\s+\d+-Core.*`).ReplaceAllString(summary.model, "")
+	modelName = strings.TrimSpace(modelName)
+
+	// If we have multiple sockets, format each on its own line
+	if summary.sockets > 1 {
+		var output strings.Builder
+		coresPerSocket := summary.cores / summary.sockets
+		threadsPerSocket := summary.threads / summary.sockets
+
+		for i := 0; i < summary.sockets; i++ {
+			if i > 0 {
+				output.WriteString("\n")
+			}
+			if threadsPerSocket > coresPerSocket {
+				// We have hyperthreading/SMT
+				output.WriteString(fmt.Sprintf("%s (%s cores, %s threads)",
+					DefaultStyle.Render(modelName),
+					ValueStyle.Render(fmt.Sprintf("%d", coresPerSocket)),
+					ValueStyle.Render(fmt.Sprintf("%d", threadsPerSocket))))
+			} else {
+				// No hyperthreading
+				output.WriteString(fmt.Sprintf("%s (%s cores)",
+					DefaultStyle.Render(modelName),
+					ValueStyle.Render(fmt.Sprintf("%d", coresPerSocket))))
 			}
-			return output.String()
 		}
+		return output.String()
+	}
 
-		// Single socket - use original format
-		if logicalProcessors > totalPhysicalCores {
-			// We have hyperthreading/SMT
-			return fmt.Sprintf("%s (%s cores, %s threads)",
-				DefaultStyle.Render(modelName),
-				ValueStyle.Render(fmt.Sprintf("%d", totalPhysicalCores)),
-				ValueStyle.Render(fmt.Sprintf("%d", logicalProcessors)))
-		} else {
-			// No hyperthreading
-			return fmt.Sprintf("%s (%s cores)",
-				DefaultStyle.Render(modelName),
-				ValueStyle.Render(fmt.Sprintf("%d", totalPhysicalCores)))
+	// Single socket - use original format
+	if summary.threads > summary.cores {
+		// We have hyperthreading/SMT
+		return fmt.Sprintf("%s (%s cores, %s threads)",
+			DefaultStyle.Render(modelName),
+			ValueStyle.Render(fmt.Sprintf("%d", summary.cores)),
+			ValueStyle.Render(fmt.Sprintf("%d", summary.threads)))
+	}
+	// No hyperthreading
+	return fmt.Sprintf("%s (%s cores)",
+		DefaultStyle.Render(modelName),
+		ValueStyle.Render(fmt.Sprintf("%d", summary.cores)))
+}
+
+// defaultExcludedGPUs lists the GPU vendors/models hidden by default (IPMI, server management, etc.)
+var defaultExcludedGPUs = []string{
+	"ASPEED",         // ASPEED BMC/IPMI controllers
+	"Matrox MGA",     // Matrox G200/G400 series (server management)
+	"Cirrus Logic",   // Cirrus Logic CL-GD series (legacy/server)
+	"XGI",            // XGI Volari series (legacy)
+	"Silicon Motion", // SM750/SM712 (embedded/server)
+	"Hisilicon",      // HiSilicon Hi171x series (server BMC)
+	"ServerEngines",  // ServerEngines Pilot series
+	"Nuvoton",        // Nuvoton WPCM450 (server management)
+	"Pilot",          // Pilot series BMC controllers
+}
+
+// loadGPUConfig returns the GPU section settings from the MOTD config, or nil if not configured
+func loadGPUConfig() *config.GPUMOTDConfig {
+	cfg := loadMOTDConfig()
+	if cfg == nil {
+		return nil
+	}
+	return cfg.GPU
+}
+
+// isExcludedGPU reports whether a GPU is hidden from the MOTD. The built-in exclusions are
+// extended by the configured ones, and configured inclusions take precedence over both.
+// Patterns match case-insensitively anywhere in the name.
+func isExcludedGPU(gpuInfo string, cfg *config.GPUMOTDConfig) bool {
+	matches := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			if pattern != "" && strings.Contains(strings.ToUpper(gpuInfo), strings.ToUpper(pattern)) {
+				return true
+			}
 		}
+		return false
 	}
 
-	return DefaultStyle.Render("Not available")
+	if cfg == nil {
+		return matches(defaultExcludedGPUs)
+	}
+	if matches(cfg.Include) {
+		return false
+	}
+	return matches(defaultExcludedGPUs) || matches(cfg.Exclude)
 }
 
 // GetGpuInfo returns information about the GPU(s) in the system
 func GetGpuInfo(ctx context.Context, verbose bool) string {
 	var gpus []string
-
-	// List of GPU vendors/models to exclude (IPMI, server management, etc.)
-	excludedGPUs := []string{
-		"ASPEED",         // ASPEED BMC/IPMI controllers
-		"Matrox MGA",     // Matrox G200/G400 series (server management)
-		"Cirrus Logic",   // Cirrus Logic CL-GD series (legacy/server)
-		"XGI",            // XGI Volari series (legacy)
-		"Silicon Motion", // SM750/SM712 (embedded/server)
-		"Hisilicon",      // HiSilicon Hi171x series (server BMC)
-		"ServerEngines",  // ServerEngines Pilot series
-		"Nuvoton",        // Nuvoton WPCM450 (server management)
-		"Pilot",          // Pilot series BMC controllers
-	}
+	gpuConfig := loadGPUConfig()
 
 	// Use lspci to detect GPUs (works for NVIDIA, AMD, Intel, etc.)
 	lspciOutput := ExecCommand(ctx, "lspci")
@@ -811,19 +893,8 @@ func GetGpuInfo(ctx context.Context, verbose bool) string {
 					gpuInfo = strings.TrimSpace(gpuInfo)
 				}
 
-				if gpuInfo != "" {
-					// Check if this GPU should be excluded
-					shouldExclude := false
-					for _, excluded := range excludedGPUs {
-						if strings.Contains(strings.ToUpper(gpuInfo), strings.ToUpper(excluded)) {
-							shouldExclude = true
-							break
-						}
-					}
-
-					if !shouldExclude {
-						gpus = append(gpus, DefaultStyle.Render(gpuInfo))
-					}
+				if gpuInfo != "" && !isExcludedGPU(gpuInfo, gpuConfig) {
+					gpus = append(gpus, DefaultStyle.Render(gpuInfo))
 				}
 			}
 		}
@@ -842,177 +913,148 @@ func GetGpuInfo(ctx context.Context, verbose bool) string {
 	return strings.Join(gpus, "\n")
 }
 
+// memoryUsage holds memory statistics in kB
+type memoryUsage struct {
+	total     uint64
+	free      uint64
+	cached    uint64 // Page cache and buffers
+	available uint64
+}
+
 // GetMemoryInfo returns the system memory usage in a simple text format
-func GetMemoryInfo() string {
+func GetMemoryInfo(ctx context.Context, verbose bool) string {
 	// Try to read from /proc/meminfo first
-	content, err := os.ReadFile("/proc/meminfo")
-	if err != nil {
+	usage, ok := parseMeminfo(readProcFile("meminfo"))
+	if !ok {
+		if verbose {
+			fmt.Printf("DEBUG: /proc/meminfo unavailable, falling back to free\n")
+		}
+		usage, ok = parseFree(ExecCommand(ctx, "free", "-k"))
+	}
+	if !ok {
 		return DefaultStyle.Render("Not available")
 	}
 
-	// Parse the meminfo content
-	memInfo := make(map[string]uint64)
-	lines := strings.Split(string(content), "\n")
+	// Convert all values to GB for consistent formatting
+	toGB := func(kb uint64) string {
+		return ValueStyle.Render(fmt.Sprintf("%.1fG", float64(kb)/1024.0/1024.0))
+	}
+	used := usage.total - min(usage.available, usage.total)
 
+	// Format as a simple text string
+	return fmt.Sprintf("%s used, %s free, %s cached, %s available, %s total",
+		toGB(used), toGB(usage.free), toGB(usage.cached), toGB(usage.available), toGB(usage.total))
+}
+
+// parseMeminfo parses /proc/meminfo content. It fails when the total memory isn't listed.
+func parseMeminfo(content string) (memoryUsage, bool) {
+	memInfo := make(map[string]uint64)
 	re := regexp.MustCompile(`^(\S+):\s+(\d+)`)
-	for _, line := range lines {
+	for line := range strings.Lines(content) {
 		matches := re.FindStringSubmatch(line)
 		if len(matches) == 3 {
-			key := matches[1]
-			valueStr := matches[2]
-			value, err := strconv.ParseUint(valueStr, 10, 64)
-			if err == nil {
-				memInfo[key] = value
+			if value, err := strconv.ParseUint(matches[2], 10, 64); err == nil {
+				memInfo[matches[1]] = value
 			}
 		}
 	}
 
-	// Check if we have the required fields
-	if memTotal, ok := memInfo["MemTotal"]; ok {
-		var memAvailable, memFree, memCached uint64
+	if memInfo["MemTotal"] == 0 {
+		return memoryUsage{}, false
+	}
 
-		if free, ok := memInfo["MemFree"]; ok {
-			memFree = free
-		}
+	usage := memoryUsage{
+		total:  memInfo["MemTotal"],
+		free:   memInfo["MemFree"],
+		cached: memInfo["Cached"] + memInfo["Buffers"],
+	}
+	if avail, ok := memInfo["MemAvailable"]; ok {
+		usage.available = avail
+	} else {
+		// Fallback calculation if MemAvailable is not present
+		usage.available = usage.free + usage.cached
+	}
+	return usage, true
+}
 
-		if cached, ok := memInfo["Cached"]; ok {
-			memCached = cached
-		}
+// parseFree parses the output of free -k. Columns are looked up by header name,
+// since older versions report buffers and cached separately and have no available column.
+func parseFree(output string) (memoryUsage, bool) {
+	if output == "Not available" {
+		return memoryUsage{}, false
+	}
 
-		if buffers, ok := memInfo["Buffers"]; ok {
-			memCached += buffers
+	var header []string
+	values := make(map[string]uint64)
+	for line := range strings.Lines(output) {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
 		}
-
-		if avail, ok := memInfo["MemAvailable"]; ok {
-			memAvailable = avail
-		} else {
-			// Fallback calculation if MemAvailable is not present
-			memAvailable = memFree + memCached
+		if header == nil {
+			header = fields
+			continue
 		}
+		if fields[0] != "Mem:" {
+			continue
+		}
+		for i, name := range header {
+			if i+1 >= len(fields) {
+				break
+			}
+			if value, err := strconv.ParseUint(fields[i+1], 10, 64); err == nil {
+				values[name] = value
+			}
+		}
+	}
 
-		// Convert all values to GB for consistent formatting
-		totalGB := float64(memTotal) / 1024.0 / 1024.0
-		usedGB := float64(memTotal-memAvailable) / 1024.0 / 1024.0
-		freeGB := float64(memFree) / 1024.0 / 1024.0
-		cachedGB := float64(memCached) / 1024.0 / 1024.0
-		availableGB := float64(memAvailable) / 1024.0 / 1024.0
-
-		// Format as a simple text string
-		return fmt.Sprintf("%s used, %s free, %s cached, %s available, %s total",
-			ValueStyle.Render(fmt.Sprintf("%.1fG", usedGB)),
-			ValueStyle.Render(fmt.Sprintf("%.1fG", freeGB)),
-			ValueStyle.Render(fmt.Sprintf("%.1fG", cachedGB)),
-			ValueStyle.Render(fmt.Sprintf("%.1fG", availableGB)),
-			ValueStyle.Render(fmt.Sprintf("%.1fG", totalGB)))
+	if values["total"] == 0 {
+		return memoryUsage{}, false
 	}
 
-	return DefaultStyle.Render("Not available")
+	usage := memoryUsage{
+		total:  values["total"],
+		free:   values["free"],
+		cached: values["buff/cache"] + values["buffers"] + values["cached"],
+	}
+	if avail, ok := values["available"]; ok {
+		usage.available = avail
+	} else {
+		usage.available = usage.free + usage.cached
+	}
+	return usage, true
 }
 
 // GetDockerInfo returns information about Docker containers
 func GetDockerInfo(ctx context.Context, verbose bool) string {
 	var output strings.Builder
 
-	// Check if Docker service is running
-	statusOutput := ExecCommand(ctx, "systemctl", "is-active", "docker")
-	if statusOutput != "active" {
-		// Check if Docker is installed but not running
-		installedCheck := ExecCommand(ctx, "which", "docker")
-		if installedCheck != "Not available" {
-			return DefaultStyle.Render("Docker is installed but not running")
+	containers, err := listContainers(ctx)
+	if err != nil {
+		if verbose {
+			fmt.Printf("DEBUG: %v\n", err)
 		}
-		return DefaultStyle.Render("Docker is not installed or not detected")
-	}
-
-	// Get container list with detailed format
-	containerOutput := ExecCommand(ctx, "docker", "ps", "-a", "--format", "{{.Names}}|{{.Status}}|{{.State}}")
-	if containerOutput == "Not available" {
-		return DefaultStyle.Render("Docker is running but container list is unavailable")
-	}
-	if containerOutput == "" {
-		return DefaultStyle.Render("Docker is running but no containers found")
+		return DefaultStyle.Render(dockerErrorMessage(err))
 	}
 
-	containerLines := strings.Split(containerOutput, "\n")
-	if len(containerLines) == 0 || (len(containerLines) == 1 && containerLines[0] == "") {
+	// Without expected containers there's nothing to report when no containers exist
+	expected := loadExpectedContainers()
+	if len(containers) == 0 && len(expected) == 0 {
 		return DefaultStyle.Render("Docker is running but no containers found")
 	}
 
-	// Process container statuses
-	var problemContainers []string
 	runningCount := 0
-	totalCount := 0
-
-	// Sort containers alphabetically by name
-	sort.Strings(containerLines)
-
-	for _, line := range containerLines {
-		if line == "" {
-			continue
-		}
-
-		totalCount++
-
-		parts := strings.Split(line, "|")
-		if len(parts) < 3 {
-			continue
-		}
-
-		name := parts[0]
-		statusText := parts[1]
-		stateText := parts[2]
-
-		// Determine the container state based on the state field directly
-		isProblematic := false
-		status := ""
-
-		// Use the State field (3rd column) to determine the basic state
-		switch stateText {
-		case "running":
+	totalCount := len(containers)
+	for _, c := range containers {
+		if c.state == container.StateRunning {
 			runningCount++
-			// Check for health status
-			if strings.Contains(statusText, "unhealthy") {
-				isProblematic = true
-				status = "running (unhealthy)"
-			} else if strings.Contains(statusText, "healthy") {
-				status = "running (healthy)"
-			} else {
-				status = "running"
-			}
-		case "restarting":
-			isProblematic = true
-			status = "restarting"
-		case "exited":
-			// Extract exit code for stopped containers
-			exitCodeMatch := regexp.MustCompile(`Exited \((\d+)\)`).FindStringSubmatch(statusText)
-			if len(exitCodeMatch) > 1 && exitCodeMatch[1] != "0" {
-				isProblematic = true
-				status = fmt.Sprintf("stopped (error: %s)", exitCodeMatch[1])
-			} else {
-				status = "stopped"
-				isProblematic = true // Consider stopped containers as problematic
-			}
-		case "created":
-			isProblematic = true
-			status = "created"
-		case "paused":
-			isProblematic = true
-			status = "paused"
-		case "dead":
-			isProblematic = true
-			status = "dead"
-		default:
-			// For any other state, consider it problematic
-			isProblematic = true
-			status = stateText // Use the raw state
 		}
+	}
 
-		// Only add problematic containers to the result
-		if isProblematic {
-			// Use DefaultStyle for the container name and the specific status style
-			formattedLine := fmt.Sprintf("%s: %s", DefaultStyle.Render(name), ErrorStyle.Render(status))
-			problemContainers = append(problemContainers, formattedLine)
-		}
+	// Only problematic containers are listed, using DefaultStyle for the name and ErrorStyle for the status
+	var problemContainers []string
+	for _, problem := range dockerContainerProblems(containers, expected) {
+		problemContainers = append(problemContainers, fmt.Sprintf("%s: %s", DefaultStyle.Render(problem.name), ErrorStyle.Render(problem.status)))
 	}
 
 	// Create a simple summary line - always show total and running
@@ -1043,51 +1085,179 @@ func GetDockerInfo(ctx context.Context, verbose bool) string {
 	return output.String()
 }
 
-// GetDiskInfo returns the disk usage for all real partitions with visual bars
-func GetDiskInfo(ctx context.Context, verbose bool) string {
-	var output strings.Builder
+// containerProblem is a container that needs attention, with its status
+type containerProblem struct {
+	name   string
+	status string
+}
 
-	// Constants for disk usage bar
-	const (
-		maxUsageThreshold = 90 // Percentage at which disk usage is considered high
-		barWidth          = 50 // Width of the usage bar in characters
-	)
+// dockerContainerProblems returns the containers that need attention, followed by the expected
+// containers that don't exist at all, e.g. after a failed deploy
+func dockerContainerProblems(containers []dockerContainer, expected []string) []containerProblem {
+	var problems []containerProblem
+	present := make(map[string]bool)
+	for _, c := range containers {
+		present[c.name] = true
+		if status, isProblematic := dockerContainerStatus(c); isProblematic {
+			problems = append(problems, containerProblem{name: c.name, status: status})
+		}
+	}
+	for _, name := range missingContainers(expected, present) {
+		problems = append(problems, containerProblem{name: name, status: "not found"})
+	}
+	return problems
+}
+
+// dockerErrorMessage describes why the containers couldn't be listed
+func dockerErrorMessage(err error) string {
+	if errors.Is(err, errDockerNotRunning) {
+		return dockerUnavailableMessage()
+	}
+	return "Docker is running but container list is unavailable"
+}
+
+// dockerContainerStatus describes the state of a container and reports whether it needs attention
+func dockerContainerStatus(c dockerContainer) (string, bool) {
+	switch c.state {
+	case container.StateRunning:
+		switch c.health {
+		case container.Unhealthy:
+			if c.healthcheck != "" {
+				return "unhealthy — healthcheck: " + c.healthcheck, true
+			}
+			return "running (unhealthy)", true
+		case container.Healthy:
+			return "running (healthy)", false
+		}
+		return "running", false
+	case container.StateExited:
+		if c.exitCode != 0 {
+			return fmt.Sprintf("stopped (error: %d)", c.exitCode), true
+		}
+		// Consider stopped containers as problematic
+		return "stopped", true
+	default:
+		// Restarting, created, paused, dead and any other state are problematic, shown as the raw state
+		return string(c.state), true
+	}
+}
+
+// loadExpectedContainers returns the names of the containers the MOTD config expects to exist
+func loadExpectedContainers() []string {
+	cfg := loadMOTDConfig()
+	if cfg == nil || cfg.Docker == nil {
+		return nil
+	}
+	return cfg.Docker.Expected
+}
 
-	// Run df command to get disk usage with the proper exclusions
+// missingContainers returns the sorted, unique names of expected containers that aren't present
+func missingContainers(expected []string, present map[string]bool) []string {
+	var missing []string
+	for _, name := range expected {
+		name = strings.TrimPrefix(strings.TrimSpace(name), "/")
+		if name != "" && !present[name] && !slices.Contains(missing, name) {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// Default disk usage thresholds in percent
+const (
+	defaultDiskWarningThreshold  = 80
+	defaultDiskCriticalThreshold = 90
+)
+
+// diskThresholds returns the warning and critical usage thresholds for a mount point.
+// Per-mount settings override the global settings, which override the defaults.
+func diskThresholds(cfg *config.DiskConfig, mountPoint string) (warning, critical int) {
+	warning, critical = defaultDiskWarningThreshold, defaultDiskCriticalThreshold
+	if cfg == nil {
+		return warning, critical
+	}
+
+	if cfg.Warning > 0 {
+		warning = cfg.Warning
+	}
+	if cfg.Critical > 0 {
+		critical = cfg.Critical
+	}
+	if mount, ok := cfg.Mounts[mountPoint]; ok {
+		if mount.Warning > 0 {
+			warning = mount.Warning
+		}
+		if mount.Critical > 0 {
+			critical = mount.Critical
+		}
+	}
+
+	// A warning band above the critical band would never be shown
+	if warning > critical {
+		warning = critical
+	}
+	return warning, critical
+}
+
+// loadDiskConfig returns the disk usage thresholds from the MOTD config, or nil if not configured
+func loadDiskConfig() *config.DiskConfig {
+	cfg := loadMOTDConfig()
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Disk
+}
+
+// diskUsage represents the usage of a single mounted partition
+type diskUsage struct {
+	mountPoint   string
+	usagePercent int
+	size         string
+}
+
+// readDiskUsage returns the usage of all real partitions as reported by df.
+// Returns false if df couldn't be run.
+func readDiskUsage(ctx context.Context) ([]diskUsage, bool) {
+	// Run df command to get disk usage with the proper exclusions.
+	// The target column goes last so mount points containing spaces can be read as the rest of the line.
 	dfOutput := ExecCommand(ctx, "df", "-H", "-x", "tmpfs", "-x", "overlay", "-x", "fuse.mergerfs", "-x", "fuse.rclone",
-		"--output=target,pcent,size")
+		"--output=pcent,size,target")
 	if dfOutput == "Not available" {
-		return DefaultStyle.Render("Not available")
+		return nil, false
 	}
 
-	// Process df output
-	lines := strings.Split(dfOutput, "\n")
+	return parseDfOutput(dfOutput), true
+}
+
+// parseDfOutput parses `df --output=pcent,size,target` output, skipping the header,
+// pseudo filesystems and lines that don't have a valid percentage and size.
+func parseDfOutput(output string) []diskUsage {
+	lines := strings.Split(output, "\n")
 	if len(lines) <= 1 { // If there's only one line (the header), then no valid partitions
-		return DefaultStyle.Render("No valid disk partitions found")
+		return nil
 	}
 
-	// Skip the header line
-	lines = lines[1:]
+	var usages []diskUsage
 
-	type partitionInfo struct {
-		mountPoint   string
-		usagePercent int
-		size         string
-		formattedBar string
-		percentStyle lipgloss.Style // Store the style directly with each partition
-	}
-
-	var partitions []partitionInfo
+	// Skip the header line
+	for _, line := range lines[1:] {
+		percent, rest, ok := cutField(line)
+		if !ok {
+			continue
+		}
+		size, rest, ok := cutField(rest)
+		if !ok {
+			continue
+		}
 
-	// Process each partition
-	for _, line := range lines {
-		fields := strings.Fields(line)
-		if len(fields) < 3 {
+		// The target is the rest of the line, keeping any spaces inside the mount point
+		mountPoint := strings.TrimSpace(rest)
+		if !strings.HasPrefix(mountPoint, "/") {
 			continue
 		}
 
 		// Skip specific mount points
-		mountPoint := fields[0]
 		if strings.HasPrefix(mountPoint, "/dev") ||
 			strings.HasPrefix(mountPoint, "/sys") ||
 			strings.HasPrefix(mountPoint, "/proc") ||
@@ -1096,55 +1266,85 @@ func GetDiskInfo(ctx context.Context, verbose bool) string {
 		}
 
 		// Get percentage (remove the '%' character)
-		usagePercentStr := strings.TrimSuffix(fields[1], "%")
-		usagePercent, err := strconv.Atoi(usagePercentStr)
-		if err != nil {
+		usagePercent, err := strconv.Atoi(strings.TrimSuffix(percent, "%"))
+		if err != nil || usagePercent < 0 || usagePercent > 100 {
 			continue
 		}
 
-		// Get size
-		size := fields[2]
+		usages = append(usages, diskUsage{
+			mountPoint:   mountPoint,
+			usagePercent: usagePercent,
+			size:         size,
+		})
+	}
+
+	return usages
+}
+
+// cutField splits the first whitespace separated field off line, returning the field and the remainder
+func cutField(line string) (field, rest string, ok bool) {
+	line = strings.TrimLeft(line, " \t")
+	end := strings.IndexAny(line, " \t")
+	if end <= 0 {
+		return "", "", false
+	}
+	return line[:end], line[end:], true
+}
+
+// GetDiskInfo returns the disk usage for all real partitions with visual bars
+func GetDiskInfo(ctx context.Context, verbose bool) string {
+	var output strings.Builder
+
+	diskCfg := loadDiskConfig()
+	barStyle := resolveBarStyle()
+
+	usages, ok := readDiskUsage(ctx)
+	if !ok {
+		return DefaultStyle.Render("Not available")
+	}
+
+	type partitionInfo struct {
+		mountPoint   string
+		usagePercent int
+		size         string
+		barColor     string
+		percentStyle lipgloss.Style // Store the style directly with each partition
+	}
+
+	var partitions []partitionInfo
+
+	// Process each partition
+	for _, usage := range usages {
+		mountPoint := usage.mountPoint
+		usagePercent := usage.usagePercent
+		size := usage.size
 
 		// Create progress bar with appropriate color for usage level
-		// Low (0-79%), High (80-89%), Critical (90-100%)
-		var prog progress.Model
+		// Low (below warning), High (warning to critical), Critical (critical and above)
+		warning, critical := diskThresholds(diskCfg, mountPoint)
+		var barColor string
 		var percentStyle lipgloss.Style
 
-		if usagePercent < 80 {
-			// 0-79%: Low usage (good)
-			prog = progress.New(
-				progress.WithColors(lipgloss.Color(ProgressBarLow)),
-				progress.WithFillCharacters(progress.DefaultFullCharFullBlock, progress.DefaultEmptyCharBlock),
-				progress.WithoutPercentage(),
-			)
+		if usagePercent < warning {
+			// Low usage (good)
+			barColor = ProgressBarLow
 			percentStyle = ValueStyle
-		} else if usagePercent < 90 {
-			// 80-89%: High usage (warning)
-			prog = progress.New(
-				progress.WithColors(lipgloss.Color(ProgressBarHigh)),
-				progress.WithFillCharacters(progress.DefaultFullCharFullBlock, progress.DefaultEmptyCharBlock),
-				progress.WithoutPercentage(),
-			)
+		} else if usagePercent < critical {
+			// High usage (warning)
+			barColor = ProgressBarHigh
 			percentStyle = WarningStyle
 		} else {
-			// 90-100%: Critical usage (danger)
-			prog = progress.New(
-				progress.WithColors(lipgloss.Color(ProgressBarCritical)),
-				progress.WithFillCharacters(progress.DefaultFullCharFullBlock, progress.DefaultEmptyCharBlock),
-				progress.WithoutPercentage(),
-			)
+			// Critical usage (danger)
+			barColor = ProgressBarCritical
 			percentStyle = ErrorStyle
 		}
 
-		prog.SetWidth(barWidth)
-		completeBar := prog.ViewAs(float64(usagePercent) / 100.0)
-
 		// Add to partition slice
 		partitions = append(partitions, partitionInfo{
 			mountPoint:   mountPoint,
 			usagePercent: usagePercent,
 			size:         size,
-			formattedBar: completeBar,
+			barColor:     barColor,
 			percentStyle: percentStyle,
 		})
 	}
@@ -1153,28 +1353,25 @@ func GetDiskInfo(ctx context.Context, verbose bool) string {
 		return DefaultStyle.Render("No valid disk partitions found")
 	}
 
+	// Size the bars and labels to the space left after the key column
+	longestMount := 0
+	for _, p := range partitions {
+		longestMount = max(longestMount, lipgloss.Width(p.mountPoint))
+	}
+	barWidth, labelWidth := diskLayout(TerminalWidth()-int(valueIndent.Load()), longestMount)
+
 	// Format the results
 	for i, p := range partitions {
-		// Format the percentage and size first while preserving alignment
-		percentStr := fmt.Sprintf("%3d%%", p.usagePercent)
-		sizeStr := fmt.Sprintf("%4s", p.size)
-
-		// Then color them
-		coloredPercent := p.percentStyle.Render(percentStr)
-		coloredSize := ValueStyle.Render(sizeStr)
-
-		// For the first partition, add it directly to the output
-		if i == 0 {
-			// Format using the original format with wide fixed spacing and mountpoint
-			infoLine := fmt.Sprintf("%-30s%s used out of %s", p.mountPoint, coloredPercent, coloredSize)
-			output.WriteString(DefaultStyle.Render(infoLine))
-			output.WriteString(fmt.Sprintf("\n%s", p.formattedBar))
-		} else {
-			// For later partitions, add line breaks before
-			infoLine := fmt.Sprintf("%-30s%s used out of %s", p.mountPoint, coloredPercent, coloredSize)
-			output.WriteString(fmt.Sprintf("\n%s", DefaultStyle.Render(infoLine)))
-			output.WriteString(fmt.Sprintf("\n%s", p.formattedBar))
+		// Pad the mountpoint to the label width so the usage columns line up
+		infoLine := diskInfoLine(labelWidth, p.mountPoint, p.usagePercent, p.size, p.percentStyle)
+		formattedBar := renderUsageBar(barStyle, float64(p.usagePercent)/100.0, barWidth, p.barColor)
+
+		// Later partitions are separated by a line break
+		if i > 0 {
+			output.WriteString("\n")
 		}
+		output.WriteString(DefaultStyle.Render(infoLine))
+		output.WriteString(fmt.Sprintf("\n%s", formattedBar))
 	}
 
 	return output.String()
@@ -1184,41 +1381,27 @@ func GetDiskInfo(ctx context.Context, verbose bool) string {
 func GetTraefikInfo(ctx context.Context, verbose bool) string {
 	var output strings.Builder
 
-	// Check if Docker service is running
-	statusOutput := ExecCommand(ctx, "systemctl", "is-active", "docker")
-	if statusOutput != "active" {
-		return DefaultStyle.Render("Docker service is not running")
-	}
-
 	// Check if Traefik container is running
-	containerStatus := ExecCommand(ctx, "docker", "ps", "--filter", "name=^traefik$", "--format", "{{.Names}}")
-	if containerStatus == "Not available" || containerStatus == "" {
+	running, err := traefikContainerRunning(ctx)
+	if err != nil {
+		if verbose {
+			fmt.Printf("DEBUG: %v\n", err)
+		}
+		return DefaultStyle.Render(dockerErrorMessage(err))
+	}
+	if !running {
 		return DefaultStyle.Render("Traefik container is not running")
 	}
 
 	// Check if Traefik API is accessible
-	routersOutput := ExecCommand(ctx, "curl", "-s", "--connect-timeout", "3", "http://traefik:8080/api/http/routers")
-	if routersOutput == "Not available" || strings.Contains(routersOutput, "Connection refused") || strings.Contains(routersOutput, "curl:") {
+	routers, err := FetchTraefikRouters(ctx)
+	if err != nil {
+		if verbose {
+			fmt.Printf("DEBUG: Failed to fetch Traefik routers: %v\n", err)
+		}
 		return DefaultStyle.Render("Traefik container is running but API is not accessible")
 	}
 
-	// If we get here, the API call succeeded, but check if it's valid JSON
-	if strings.TrimSpace(routersOutput) == "" || routersOutput == "[]" {
-		return DefaultStyle.Render("Traefik is running with no routers configured")
-	}
-
-	// Parse JSON properly
-	type Router struct {
-		Name   string          `json:"name"`
-		Status string          `json:"status"`
-		Error  json.RawMessage `json:"error,omitempty"`
-	}
-
-	var routers []Router
-	if err := json.Unmarshal([]byte(routersOutput), &routers); err != nil {
-		return DefaultStyle.Render("Failed to parse Traefik router response")
-	}
-
 	totalRouters := len(routers)
 	if totalRouters == 0 {
 		return DefaultStyle.Render("Traefik is running with no routers configured")
@@ -1228,14 +1411,10 @@ func GetTraefikInfo(ctx context.Context, verbose bool) string {
 	healthyRouters := 0
 
 	for _, router := range routers {
-		if errMsg := extractTraefikRouterError(router.Error); errMsg != "" {
-			problemRouters = append(problemRouters, fmt.Sprintf("%s: %s",
-				DefaultStyle.Render(router.Name),
-				ErrorStyle.Render(errMsg)))
-		} else if router.Status == "disabled" {
+		if problem := router.Problem(); problem != "" {
 			problemRouters = append(problemRouters, fmt.Sprintf("%s: %s",
 				DefaultStyle.Render(router.Name),
-				ErrorStyle.Render("router is disabled")))
+				ErrorStyle.Render(problem)))
 		} else {
 			healthyRouters++
 		}
@@ -2,8 +2,13 @@ package motd
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+
+	"github.com/saltyorg/sb-go/internal/config"
 )
 
 func TestExtractTraefikRouterError(t *testing.T) {
@@ -65,3 +70,130 @@ func TestExtractTraefikRouterError(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDfOutput(t *testing.T) {
+	output := `Use%  Size Mounted on
+  42%  500G /
+   3%  1.1G /boot
+  17%   12T /mnt/local media
+   0%  8.4G /dev/shm
+  --     - /mnt/unknown
+  88%  2.0T /srv/nas backup 
+`
+
+	want := []diskUsage{
+		{mountPoint: "/", usagePercent: 42, size: "500G"},
+		{mountPoint: "/boot", usagePercent: 3, size: "1.1G"},
+		{mountPoint: "/mnt/local media", usagePercent: 17, size: "12T"},
+		{mountPoint: "/srv/nas backup", usagePercent: 88, size: "2.0T"},
+	}
+	if got := parseDfOutput(output); !slices.Equal(got, want) {
+		t.Errorf("parseDfOutput() = %+v, want %+v", got, want)
+	}
+
+	if got := parseDfOutput("Use%  Size Mounted on\n"); len(got) != 0 {
+		t.Errorf("parseDfOutput() with only a header = %+v, want none", got)
+	}
+}
+
+func TestDiskThresholds(t *testing.T) {
+	cfg := &config.DiskConfig{
+		Warning:  85,
+		Critical: 95,
+		Mounts: map[string]config.DiskThresholds{
+			"/":          {Critical: 80},
+			"/mnt/cache": {Warning: 97, Critical: 99},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		cfg          *config.DiskConfig
+		mountPoint   string
+		wantWarning  int
+		wantCritical int
+	}{
+		{name: "defaults", cfg: nil, mountPoint: "/", wantWarning: 80, wantCritical: 90},
+		{name: "global", cfg: cfg, mountPoint: "/opt", wantWarning: 85, wantCritical: 95},
+		{name: "per-mount clamps warning", cfg: cfg, mountPoint: "/", wantWarning: 80, wantCritical: 80},
+		{name: "per-mount override", cfg: cfg, mountPoint: "/mnt/cache", wantWarning: 97, wantCritical: 99},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warning, critical := diskThresholds(tt.cfg, tt.mountPoint)
+			if warning != tt.wantWarning || critical != tt.wantCritical {
+				t.Fatalf("diskThresholds() = %d/%d, want %d/%d", warning, critical, tt.wantWarning, tt.wantCritical)
+			}
+		})
+	}
+}
+
+func TestRebootRequired(t *testing.T) {
+	dir := t.TempDir()
+	rebootFile := filepath.Join(dir, "reboot-required")
+	pkgFile := filepath.Join(dir, "reboot-required.pkgs")
+
+	if required, _ := rebootRequired(rebootFile, pkgFile); required {
+		t.Fatal("expected no reboot required without the reboot-required file")
+	}
+
+	if err := os.WriteFile(rebootFile, []byte("*** System restart required ***\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if required, pkgs := rebootRequired(rebootFile, pkgFile); !required || pkgs != nil {
+		t.Fatalf("rebootRequired() = %v, %q; want true without packages", required, pkgs)
+	}
+
+	if err := os.WriteFile(pkgFile, []byte("linux-image-6.8.0-50-generic\nlinux-base\n\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	required, pkgs := rebootRequired(rebootFile, pkgFile)
+	if want := []string{"linux-image-6.8.0-50-generic", "linux-base"}; !required || !slices.Equal(pkgs, want) {
+		t.Errorf("rebootRequired() = %v, %q; want true, %q", required, pkgs, want)
+	}
+}
+
+func TestMissingContainers(t *testing.T) {
+	present := map[string]bool{"traefik": true, "sonarr": true}
+
+	got := missingContainers([]string{"sonarr", "plex", " /radarr ", "plex", "", "traefik"}, present)
+	if want := []string{"plex", "radarr"}; !slices.Equal(got, want) {
+		t.Errorf("missingContainers() = %v, want %v", got, want)
+	}
+	if got := missingContainers(nil, present); len(got) != 0 {
+		t.Errorf("missingContainers() without expected containers = %v, want none", got)
+	}
+}
+
+func TestIsExcludedGPU(t *testing.T) {
+	const (
+		bmc    = "ASPEED Technology, Inc. ASPEED Graphics Family (rev 41)"
+		matrox = "Matrox Electronics Systems Ltd. MGA G200e [Pilot] ServerEngines (SEP1) (rev 05)"
+		nvidia = "NVIDIA Corporation GA104 [GeForce RTX 3070] (rev a1)"
+		newBMC = "Acme BMC Display Controller"
+	)
+	cfg := &config.GPUMOTDConfig{Exclude: []string{"acme bmc"}, Include: []string{"matrox"}}
+
+	tests := []struct {
+		name    string
+		gpuInfo string
+		cfg     *config.GPUMOTDConfig
+		want    bool
+	}{
+		{"built-in exclusion", bmc, nil, true},
+		{"not excluded", nvidia, nil, false},
+		{"unknown BMC without config", newBMC, nil, false},
+		{"configured exclusion", newBMC, cfg, true},
+		{"built-in exclusion kept with config", bmc, cfg, true},
+		{"inclusion overrides built-in exclusion", matrox, cfg, false},
+		{"not excluded with config", nvidia, cfg, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isExcludedGPU(tt.gpuInfo, tt.cfg); got != tt.want {
+				t.Errorf("isExcludedGPU(%q) = %v, want %v", tt.gpuInfo, got, tt.want)
+			}
+		})
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"time"
 
 	"github.com/saltyorg/sb-go/internal/config"
-	"github.com/saltyorg/sb-go/internal/constants"
 
 	jellyfin "github.com/sj14/jellyfin-go/api"
 )
@@ -29,7 +28,7 @@ type JellyfinStreamInfo struct {
 
 // GetJellyfinInfo fetches and formats Jellyfin streaming information
 func GetJellyfinInfo(ctx context.Context, verbose bool) string {
-	configPath := constants.SaltboxMOTDConfigPath
+	configPath := config.SB().MOTDConfig
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		if verbose {
 			fmt.Printf("DEBUG: Config file %s does not exist\n", configPath)
@@ -0,0 +1,120 @@
+package motd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/saltyorg/sb-go/internal/config"
+
+	"charm.land/lipgloss/v2"
+	"golang.org/x/term"
+)
+
+// fallbackTerminalWidth is used when the terminal width can't be determined (e.g., non-interactive logins)
+const fallbackTerminalWidth = 80
+
+// Limits for the disk usage bar and mount point label widths
+const (
+	minUsageBarWidth   = 20
+	maxUsageBarWidth   = 50
+	minMountLabelWidth = 12
+	maxMountLabelWidth = 30
+)
+
+// diskInfoSuffixWidth is the width of the " 80% used out of 1.8T" part following the mount point label,
+// as formatted by diskInfoLine
+const diskInfoSuffixWidth = 21
+
+// defaultKeySeparator is printed between the key column and the values unless key_separator is set
+const defaultKeySeparator = "  "
+
+// maxKeyWidth is the widest key_width used, matching its validation, as the MOTD is rendered
+// without validating motd.yml first
+const maxKeyWidth = 40
+
+// outputWidth overrides the detected terminal width when set (e.g., by --width)
+var outputWidth atomic.Int64
+
+// valueIndent is the width of the key column printed before each value
+var valueIndent atomic.Int64
+
+// SetOutputWidth sets a fixed output width to render for instead of the terminal width.
+// A width of 0 or less restores terminal width detection.
+func SetOutputWidth(width int) {
+	outputWidth.Store(int64(max(width, 0)))
+}
+
+// SetValueIndent sets the width of the key column, so values can be sized to the remaining space
+func SetValueIndent(indent int) {
+	valueIndent.Store(int64(max(indent, 0)))
+}
+
+// TerminalWidth returns the width to render for: the --width override, the terminal width,
+// or fallbackTerminalWidth when neither is available.
+func TerminalWidth() int {
+	if w := outputWidth.Load(); w > 0 {
+		return int(w)
+	}
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		return w
+	}
+	return fallbackTerminalWidth
+}
+
+// diskLayout returns the usage bar and mount point label widths for a value area of the given width.
+// The label is kept wide enough for the longest mount point so the usage columns stay aligned.
+func diskLayout(available, longestMount int) (barWidth, labelWidth int) {
+	barWidth = min(max(available, minUsageBarWidth), maxUsageBarWidth)
+	// The info line ends where its bar ends, unless the label limits get in the way
+	labelWidth = min(max(barWidth-diskInfoSuffixWidth, minMountLabelWidth), maxMountLabelWidth)
+	labelWidth = max(labelWidth, longestMount+1)
+	return barWidth, labelWidth
+}
+
+// diskInfoLine formats the line above a disk usage bar, with the mount point padded to labelWidth
+// so the usage columns line up. percent and size are styled after padding.
+func diskInfoLine(labelWidth int, mountPoint string, usagePercent int, size string, percentStyle lipgloss.Style) string {
+	return fmt.Sprintf("%-*s%s used out of %s", labelWidth, mountPoint,
+		percentStyle.Render(fmt.Sprintf("%3d%%", usagePercent)), ValueStyle.Render(fmt.Sprintf("%4s", size)))
+}
+
+// KeyColumn is the layout of the key printed before each section's value, shared by all
+// sections so their values start at the same column
+type KeyColumn struct {
+	Width     int    // Width the keys are padded to
+	Separator string // Printed between the padded key and the value
+}
+
+// NewKeyColumn returns the key column for sections with the given keys, as wide as the
+// longest key or key_width from the MOTD config, whichever is wider. key_width is capped
+// at maxKeyWidth.
+func NewKeyColumn(keys []string) KeyColumn {
+	return newKeyColumn(keys, loadMOTDConfig())
+}
+
+func newKeyColumn(keys []string, cfg *config.MOTDConfig) KeyColumn {
+	column := KeyColumn{Separator: defaultKeySeparator}
+	for _, key := range keys {
+		column.Width = max(column.Width, lipgloss.Width(key))
+	}
+	if cfg != nil {
+		column.Width = max(column.Width, min(cfg.KeyWidth, maxKeyWidth))
+		if cfg.KeySeparator != "" {
+			column.Separator = cfg.KeySeparator
+		}
+	}
+	return column
+}
+
+// Indent returns the column the values start at
+func (c KeyColumn) Indent() int {
+	return c.Width + lipgloss.Width(c.Separator)
+}
+
+// Render returns the styled key padded to the column width, followed by the separator
+func (c KeyColumn) Render(key string) string {
+	padding := strings.Repeat(" ", max(c.Width-lipgloss.Width(key), 0))
+	return KeyStyle.Render(key) + padding + c.Separator
+}
@@ -0,0 +1,101 @@
+package motd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/saltyorg/sb-go/internal/config"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestDiskLayout(t *testing.T) {
+	tests := []struct {
+		name         string
+		available    int
+		longestMount int
+		wantBar      int
+		wantLabel    int
+	}{
+		{"wide terminal is capped", 180, 5, maxUsageBarWidth, maxUsageBarWidth - diskInfoSuffixWidth},
+		{"80 column terminal", 64, 5, maxUsageBarWidth, maxUsageBarWidth - diskInfoSuffixWidth},
+		{"narrow terminal", 40, 5, 40, 19},
+		{"very narrow terminal", 10, 1, minUsageBarWidth, minMountLabelWidth},
+		{"long mount point widens label", 40, 25, 40, 26},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bar, label := diskLayout(tt.available, tt.longestMount)
+			if bar != tt.wantBar || label != tt.wantLabel {
+				t.Errorf("diskLayout(%d, %d) = (%d, %d), want (%d, %d)",
+					tt.available, tt.longestMount, bar, label, tt.wantBar, tt.wantLabel)
+			}
+		})
+	}
+}
+
+func TestTerminalWidthOverride(t *testing.T) {
+	SetOutputWidth(120)
+	defer SetOutputWidth(0)
+
+	if got := TerminalWidth(); got != 120 {
+		t.Fatalf("TerminalWidth() = %d, want 120", got)
+	}
+}
+
+func TestDiskInfoLineEndsWithBar(t *testing.T) {
+	for _, available := range []int{180, 64, 40} {
+		barWidth, labelWidth := diskLayout(available, len("/mnt/local"))
+		line := diskInfoLine(labelWidth, "/mnt/local", 80, "1.8T", ValueStyle)
+		bar := renderUsageBar(BarStyleASCII, 0.8, barWidth, ProgressBarLow)
+
+		if got, want := lipgloss.Width(line), lipgloss.Width(bar); got != want {
+			t.Errorf("available %d: info line is %d wide, bar is %d wide: %q", available, got, want, ansi.Strip(line))
+		}
+	}
+
+	// Usage columns of different mount points start at the same column
+	_, labelWidth := diskLayout(64, len("/mnt/local"))
+	first := ansi.Strip(diskInfoLine(labelWidth, "/", 5, "98G", ValueStyle))
+	second := ansi.Strip(diskInfoLine(labelWidth, "/mnt/local", 100, "1.8T", ValueStyle))
+	if strings.Index(first, "used") != strings.Index(second, "used") {
+		t.Errorf("usage columns not aligned:\n%q\n%q", first, second)
+	}
+}
+
+func TestKeyColumn(t *testing.T) {
+	keys := []string{"Distribution:", "Disk Usage:", "Memory Usage:", "Docker:"}
+
+	tests := []struct {
+		name       string
+		cfg        *config.MOTDConfig
+		wantIndent int
+		separator  string
+	}{
+		{"fits the longest key", nil, len("Distribution:") + 2, "  "},
+		{"configured width", &config.MOTDConfig{KeyWidth: 20}, 22, "  "},
+		{"width narrower than keys", &config.MOTDConfig{KeyWidth: 5}, len("Distribution:") + 2, "  "},
+		{"width above the maximum", &config.MOTDConfig{KeyWidth: 500}, maxKeyWidth + 2, "  "},
+		{"negative width", &config.MOTDConfig{KeyWidth: -3}, len("Distribution:") + 2, "  "},
+		{"configured separator", &config.MOTDConfig{KeySeparator: " │ "}, len("Distribution:") + 3, " │ "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			column := newKeyColumn(keys, tt.cfg)
+			if column.Indent() != tt.wantIndent {
+				t.Errorf("Indent() = %d, want %d", column.Indent(), tt.wantIndent)
+			}
+
+			// Every section's value starts at the indent, right after the separator
+			for _, key := range keys {
+				rendered := ansi.Strip(column.Render(key))
+				if lipgloss.Width(rendered) != tt.wantIndent || !strings.HasSuffix(rendered, tt.separator) {
+					t.Errorf("Render(%q) = %q, want %d wide ending in %q", key, rendered, tt.wantIndent, tt.separator)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package motd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/config"
+)
+
+// geoIPURL is the free GeoIP service used to look up the country code of an address
+const geoIPURL = "https://ipapi.co/%s/country/"
+
+// defaultLoginLookupTimeout bounds the reverse DNS and GeoIP lookups so they never block the MOTD
+const defaultLoginLookupTimeout = 2 * time.Second
+
+// loadLastLoginConfig returns the last login enrichment settings, or nil if none are enabled
+// or the lookups are paused for quiet hours
+func loadLastLoginConfig() *config.LastLoginConfig {
+	cfg := loadMOTDConfig()
+	if cfg == nil || cfg.LastLogin == nil || (!cfg.LastLogin.ReverseDNS && !cfg.LastLogin.GeoIP) {
+		return nil
+	}
+	if inQuietHours(cfg.QuietHours, time.Now()) {
+		return nil
+	}
+	return cfg.LastLogin
+}
+
+// enrichLoginIP returns the hostname and/or country of a login source address, e.g. "example.isp.net, US".
+// Lookups run concurrently and are abandoned when the timeout expires.
+// Returns an empty string for local, private or unparsable addresses and when nothing was found.
+func enrichLoginIP(ctx context.Context, ip string, cfg *config.LastLoginConfig) string {
+	if cfg == nil || !isPublicIP(ip) {
+		return ""
+	}
+
+	timeout := defaultLoginLookupTimeout
+	if cfg.Timeout > 0 {
+		timeout = time.Duration(cfg.Timeout) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var hostname, country string
+	var wg sync.WaitGroup
+	if cfg.ReverseDNS {
+		wg.Go(func() {
+			hostname = lookupHostname(ctx, ip)
+		})
+	}
+	if cfg.GeoIP {
+		wg.Go(func() {
+			country = lookupCountry(ctx, ip, timeout)
+		})
+	}
+	wg.Wait()
+
+	var parts []string
+	if hostname != "" {
+		parts = append(parts, hostname)
+	}
+	if country != "" {
+		parts = append(parts, country)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// isPublicIP reports whether ip is a globally routable address worth looking up
+func isPublicIP(ip string) bool {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	return !addr.IsLoopback() && !addr.IsPrivate() && !addr.IsLinkLocalUnicast() && !addr.IsUnspecified()
+}
+
+// lookupHostname returns the first reverse DNS name of ip without the trailing dot
+func lookupHostname(ctx context.Context, ip string) string {
+	names, err := net.DefaultResolver.LookupAddr(ctx, ip)
+	if err != nil || len(names) == 0 {
+		return ""
+	}
+	return strings.TrimSuffix(names[0], ".")
+}
+
+// lookupCountry returns the two-letter country code of ip from the GeoIP service
+func lookupCountry(ctx context.Context, ip string, timeout time.Duration) string {
+	client := &http.Client{Timeout: timeout}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf(geoIPURL, ip), nil)
+	if err != nil {
+		return ""
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64))
+	if err != nil {
+		return ""
+	}
+
+	return parseCountryCode(string(body))
+}
+
+// parseCountryCode validates a GeoIP response as an uppercase two-letter country code.
+// Rate limit and error responses are ignored.
+func parseCountryCode(body string) string {
+	code := strings.TrimSpace(body)
+	if len(code) != 2 || code[0] < 'A' || code[0] > 'Z' || code[1] < 'A' || code[1] > 'Z' {
+		return ""
+	}
+	return code
+}
+
+// lastLogin is a login parsed from a line of `last` output
+type lastLogin struct {
+	user          string
+	source        string // Remote address or hostname, empty for local logins
+	dateTime      string // Login time, e.g. "Mon Jun 2 14:03"
+	stillLoggedIn bool
+	logoutTime    string
+	duration      string
+}
+
+var (
+	lastWeekdays = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+	lastMonths   = []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+)
+
+// parseLastLine parses a login line of `last` output, e.g.
+// "root pts/0 2001:db8::1 Mon Jun  2 14:03 - 15:10  (01:07)".
+// The fields are located by anchoring on the login date and time rather than fixed positions,
+// since the source column is missing for local logins. Returns false for other lines.
+func parseLastLine(line string) (lastLogin, bool) {
+	fields := strings.Fields(line)
+	if isSkippableLastLine(line, fields) {
+		return lastLogin{}, false
+	}
+
+	// The date follows the user, the tty and the optional source
+	dateIndex := -1
+	for i := 2; i+4 <= len(fields); i++ {
+		if isLastDateTime(fields[i : i+4]) {
+			dateIndex = i
+			break
+		}
+	}
+	if dateIndex < 0 {
+		return lastLogin{}, false
+	}
+
+	login := lastLogin{
+		user:     fields[0],
+		source:   loginSource(strings.Join(fields[2:dateIndex], " ")),
+		dateTime: strings.Join(fields[dateIndex:dateIndex+4], " "),
+	}
+
+	rest := fields[dateIndex+4:]
+	if strings.Contains(strings.Join(rest, " "), "still logged in") {
+		login.stillLoggedIn = true
+		return login, true
+	}
+
+	// Logged out entries continue with "- 15:10  (01:07)" or "- 15:10 (1+02:03)"
+	for i, field := range rest {
+		if field == "-" && i+1 < len(rest) {
+			login.logoutTime = rest[i+1]
+			break
+		}
+	}
+	for _, field := range rest {
+		if after, ok := strings.CutPrefix(field, "("); ok {
+			login.duration = strings.TrimSuffix(after, ")")
+			break
+		}
+	}
+	return login, true
+}
+
+// isLastDateTime reports whether fields are a login date and time, e.g. "Mon Jun 2 14:03"
+func isLastDateTime(fields []string) bool {
+	if !slices.Contains(lastWeekdays, fields[0]) || !slices.Contains(lastMonths, fields[1]) {
+		return false
+	}
+	if day, err := strconv.Atoi(fields[2]); err != nil || day < 1 || day > 31 {
+		return false
+	}
+	// HH:MM, or HH:MM:SS with full times
+	parts := strings.Split(fields[3], ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return false
+	}
+	for _, part := range parts {
+		if _, err := strconv.Atoi(part); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// loginSource classifies the source column of `last`, returning the address or hostname of
+// remote logins and an empty string for local ones, such as X displays (":0") and terminal
+// multiplexer sessions ("tmux(1234).%0"). IPv6 addresses can start with a colon too, so
+// addresses are recognized before a leading colon is taken for a display.
+func loginSource(source string) string {
+	if source == "" || strings.ContainsAny(source, "() \t") {
+		return ""
+	}
+
+	address, _, _ := strings.Cut(strings.Trim(source, "[]"), "%")
+	if ip := net.ParseIP(address); ip != nil {
+		if ip.IsUnspecified() {
+			return ""
+		}
+		return address
+	}
+	if strings.HasPrefix(source, ":") {
+		return ""
+	}
+	return source
+}
@@ -0,0 +1,118 @@
+package motd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/saltyorg/sb-go/internal/config"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	tests := map[string]bool{
+		"8.8.8.8":     true,
+		"2001:db8::1": true,
+		"192.168.1.5": false,
+		"10.0.0.1":    false,
+		"127.0.0.1":   false,
+		"fe80::1":     false,
+		"local":       false,
+	}
+
+	for ip, want := range tests {
+		if got := isPublicIP(ip); got != want {
+			t.Errorf("isPublicIP(%q) = %v, want %v", ip, got, want)
+		}
+	}
+}
+
+func TestParseCountryCode(t *testing.T) {
+	tests := map[string]string{
+		"US\n":      "US",
+		"Undefined": "",
+		"us":        "",
+		`{"error": true, "reason": "RateLimited"}`: "",
+	}
+
+	for body, want := range tests {
+		if got := parseCountryCode(body); got != want {
+			t.Errorf("parseCountryCode(%q) = %q, want %q", body, got, want)
+		}
+	}
+}
+
+func TestEnrichLoginIPSkipsPrivateAddresses(t *testing.T) {
+	cfg := &config.LastLoginConfig{ReverseDNS: true, GeoIP: true}
+	if got := enrichLoginIP(context.Background(), "192.168.1.5", cfg); got != "" {
+		t.Fatalf("expected no enrichment for a private address, got %q", got)
+	}
+}
+
+func TestParseLastLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want lastLogin
+	}{
+		{
+			name: "ipv4",
+			line: "root     pts/0        203.0.113.7      Mon Jun  2 14:03 - 15:10  (01:07)",
+			want: lastLogin{user: "root", source: "203.0.113.7", dateTime: "Mon Jun 2 14:03", logoutTime: "15:10", duration: "01:07"},
+		},
+		{
+			name: "ipv6",
+			line: "seed     pts/1        2001:db8:85a3::8a2e:370:7334 Tue Jun  3 09:15   still logged in",
+			want: lastLogin{user: "seed", source: "2001:db8:85a3::8a2e:370:7334", dateTime: "Tue Jun 3 09:15", stillLoggedIn: true},
+		},
+		{
+			name: "ipv4-mapped ipv6",
+			line: "root     pts/0        ::ffff:203.0.113.5 Mon Jun  2 16:20 - 16:45  (00:25)",
+			want: lastLogin{user: "root", source: "::ffff:203.0.113.5", dateTime: "Mon Jun 2 16:20", logoutTime: "16:45", duration: "00:25"},
+		},
+		{
+			name: "ipv6 loopback",
+			line: "seed     pts/4        ::1              Tue Jun  3 11:00 - 11:05  (00:05)",
+			want: lastLogin{user: "seed", source: "::1", dateTime: "Tue Jun 3 11:00", logoutTime: "11:05", duration: "00:05"},
+		},
+		{
+			name: "hostname",
+			line: "seed     pts/2        vpn.example.com  Wed Jun  4 22:41 - 01:02  (1+02:21)",
+			want: lastLogin{user: "seed", source: "vpn.example.com", dateTime: "Wed Jun 4 22:41", logoutTime: "01:02", duration: "1+02:21"},
+		},
+		{
+			name: "local console",
+			line: "root     tty1                          Thu Jun  5 08:00 - crash  (00:12)",
+			want: lastLogin{user: "root", dateTime: "Thu Jun 5 08:00", logoutTime: "crash", duration: "00:12"},
+		},
+		{
+			name: "x display",
+			line: "seed     :0           :0               Fri Jun  6 10:30   still logged in",
+			want: lastLogin{user: "seed", dateTime: "Fri Jun 6 10:30", stillLoggedIn: true},
+		},
+		{
+			name: "tmux session",
+			line: "seed     pts/3        tmux(4321).%0    Sat Jun  7 12:00 - 12:30  (00:30)",
+			want: lastLogin{user: "seed", dateTime: "Sat Jun 7 12:00", logoutTime: "12:30", duration: "00:30"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseLastLine(tt.line)
+			if !ok {
+				t.Fatalf("parseLastLine(%q) failed", tt.line)
+			}
+			if got != tt.want {
+				t.Errorf("parseLastLine() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	for _, line := range []string{
+		"reboot   system boot  6.8.0-31-generic Mon Jun  2 13:59   still running",
+		"wtmp begins Sun Jun  1 00:00:01 2025",
+		"",
+	} {
+		if got, ok := parseLastLine(line); ok {
+			t.Errorf("parseLastLine(%q) = %+v, want no login", line, got)
+		}
+	}
+}
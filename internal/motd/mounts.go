@@ -0,0 +1,178 @@
+package motd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rcloneRemotePath is the directory Saltbox mounts rclone remotes under
+const rcloneRemotePath = "/mnt/remote"
+
+// procMountsPath is the kernel's list of mounted filesystems
+const procMountsPath = "/proc/mounts"
+
+// mountStatTimeout is how long a stat of a mount point may take before it's considered stale
+const mountStatTimeout = 2 * time.Second
+
+// Mount health states
+const (
+	mountHealthy   = "healthy"
+	mountStale     = "stale"
+	mountUnmounted = "unmounted"
+)
+
+// mountHealth represents the health of a single rclone mount point
+type mountHealth struct {
+	path   string
+	status string
+}
+
+// GetMountHealth reports whether the expected rclone mounts are mounted and responsive.
+// Expected mounts come from the rclone_mounts section of the MOTD config, defaulting to the
+// directories under /mnt/remote. Any other fuse.rclone mounts are included as well.
+// Returns an empty string to hide the section when no rclone mounts are expected or found.
+func GetMountHealth(ctx context.Context, verbose bool) string {
+	var expected []string
+	if cfg := loadMOTDConfig(); cfg != nil && cfg.RcloneMounts != nil {
+		if !cfg.RcloneMounts.IsEnabled() {
+			return ""
+		}
+		expected = cfg.RcloneMounts.Paths
+	}
+	if len(expected) == 0 {
+		expected = listRemoteDirs(rcloneRemotePath)
+	}
+
+	data, err := os.ReadFile(hostPath(procMountsPath))
+	if err != nil {
+		if verbose {
+			fmt.Printf("DEBUG: Failed to read %s: %v\n", procMountsPath, err)
+		}
+		return ""
+	}
+	mounted := parseRcloneMounts(string(data))
+
+	// Include rclone mounts outside the expected list so nothing is missed
+	paths := slices.Clone(expected)
+	for _, mountPoint := range mounted {
+		if !slices.Contains(paths, mountPoint) {
+			paths = append(paths, mountPoint)
+		}
+	}
+
+	if len(paths) == 0 {
+		if verbose {
+			fmt.Printf("DEBUG: No rclone mounts expected or found\n")
+		}
+		return ""
+	}
+
+	results := make([]mountHealth, len(paths))
+	var wg sync.WaitGroup
+	for i, path := range paths {
+		results[i].path = path
+		if !slices.Contains(mounted, path) {
+			results[i].status = mountUnmounted
+			continue
+		}
+		wg.Go(func() {
+			if statWithTimeout(ctx, path, mountStatTimeout) {
+				results[i].status = mountHealthy
+			} else {
+				results[i].status = mountStale
+			}
+		})
+	}
+	wg.Wait()
+
+	return formatMountHealth(results)
+}
+
+// listRemoteDirs returns the directories directly under root
+func listRemoteDirs(root string) []string {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil
+	}
+
+	var dirs []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirs = append(dirs, filepath.Join(root, entry.Name()))
+		}
+	}
+	return dirs
+}
+
+// parseRcloneMounts returns the mount points of fuse.rclone entries in /proc/mounts content
+func parseRcloneMounts(data string) []string {
+	var mountPoints []string
+	for line := range strings.Lines(data) {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[2] != "fuse.rclone" {
+			continue
+		}
+		mountPoints = append(mountPoints, unescapeMountPath(fields[1]))
+	}
+	return mountPoints
+}
+
+// unescapeMountPath decodes the octal escapes (e.g., "\040" for a space) used in /proc/mounts
+func unescapeMountPath(path string) string {
+	if !strings.Contains(path, `\`) {
+		return path
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] == '\\' && i+3 < len(path) {
+			var c byte
+			if _, err := fmt.Sscanf(path[i+1:i+4], "%03o", &c); err == nil {
+				b.WriteByte(c)
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(path[i])
+	}
+	return b.String()
+}
+
+// statWithTimeout reports whether path can be stat'ed within the timeout.
+// A hung FUSE mount blocks stat indefinitely, so the call runs in its own goroutine
+// which is abandoned if it doesn't return in time.
+func statWithTimeout(ctx context.Context, path string, timeout time.Duration) bool {
+	done := make(chan error, 1)
+	go func() {
+		_, err := os.Stat(path)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return err == nil
+	case <-time.After(timeout):
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// formatMountHealth formats one line per mount point with the status colored by health
+func formatMountHealth(results []mountHealth) string {
+	lines := make([]string, 0, len(results))
+	for _, r := range results {
+		statusStyle := ValueStyle
+		if r.status != mountHealthy {
+			statusStyle = ErrorStyle
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", DefaultStyle.Render(r.path), statusStyle.Render(r.status)))
+	}
+	return strings.Join(lines, "\n")
+}
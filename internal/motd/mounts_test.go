@@ -0,0 +1,34 @@
+package motd
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseRcloneMounts(t *testing.T) {
+	data := `/dev/sda1 / ext4 rw,relatime 0 0
+google: /mnt/remote/google fuse.rclone rw,nosuid,nodev,relatime,user_id=1000,group_id=1000 0 0
+mergerfs /mnt/unionfs fuse.mergerfs rw,relatime 0 0
+dropbox: /mnt/remote/my\040drive fuse.rclone rw,nosuid,nodev 0 0
+`
+
+	got := parseRcloneMounts(data)
+	want := []string{"/mnt/remote/google", "/mnt/remote/my drive"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("parseRcloneMounts() = %v, want %v", got, want)
+	}
+}
+
+func TestStatWithTimeout(t *testing.T) {
+	dir := t.TempDir()
+
+	if !statWithTimeout(context.Background(), dir, time.Second) {
+		t.Fatalf("expected existing directory to be responsive")
+	}
+	if statWithTimeout(context.Background(), filepath.Join(dir, "missing"), time.Second) {
+		t.Fatalf("expected missing path to be reported as unresponsive")
+	}
+}
@@ -0,0 +1,116 @@
+package motd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/utils"
+)
+
+// procNetDevPath contains per-interface traffic counters
+const procNetDevPath = "/proc/net/dev"
+
+// procNetRoutePath contains the IPv4 routing table
+const procNetRoutePath = "/proc/net/route"
+
+// throughputSampleInterval is the delay between the two /proc/net/dev samples
+const throughputSampleInterval = 500 * time.Millisecond
+
+// interfaceCounters holds the cumulative byte counters of a network interface
+type interfaceCounters struct {
+	rxBytes uint64
+	txBytes uint64
+}
+
+// GetNetworkThroughput returns the current receive and transmit rates of the primary interface.
+// The primary interface is the one holding the default route. Rates are computed by sampling
+// /proc/net/dev twice, which adds a bounded delay of about half a second.
+// Returns an empty string to hide the section when no default route is found.
+func GetNetworkThroughput(ctx context.Context, verbose bool) string {
+	routes, err := os.ReadFile(hostPath(procNetRoutePath))
+	if err != nil {
+		if verbose {
+			fmt.Printf("DEBUG: Failed to read %s: %v\n", procNetRoutePath, err)
+		}
+		return ""
+	}
+
+	iface := utils.ParseDefaultRouteInterface(string(routes))
+	if iface == "" {
+		if verbose {
+			fmt.Printf("DEBUG: No default route found, hiding network throughput\n")
+		}
+		return ""
+	}
+
+	before, ok := readInterfaceCounters(iface)
+	if !ok {
+		return ""
+	}
+	start := time.Now()
+
+	select {
+	case <-time.After(throughputSampleInterval):
+	case <-ctx.Done():
+		return ""
+	}
+
+	after, ok := readInterfaceCounters(iface)
+	if !ok {
+		return ""
+	}
+	elapsed := time.Since(start).Seconds()
+
+	// Counters can reset (e.g., interface restart), so never report negative rates
+	var rxRate, txRate float64
+	if after.rxBytes >= before.rxBytes {
+		rxRate = float64(after.rxBytes-before.rxBytes) / elapsed
+	}
+	if after.txBytes >= before.txBytes {
+		txRate = float64(after.txBytes-before.txBytes) / elapsed
+	}
+
+	return fmt.Sprintf("%s %s",
+		ValueStyle.Render(fmt.Sprintf("↓ %s/s ↑ %s/s", formatBytes(int64(rxRate)), formatBytes(int64(txRate)))),
+		DefaultStyle.Render(fmt.Sprintf("(%s)", iface)))
+}
+
+// readInterfaceCounters reads the byte counters of iface from /proc/net/dev
+func readInterfaceCounters(iface string) (interfaceCounters, bool) {
+	data, err := os.ReadFile(hostPath(procNetDevPath))
+	if err != nil {
+		return interfaceCounters{}, false
+	}
+	counters, ok := parseNetDev(string(data))[iface]
+	return counters, ok
+}
+
+// parseNetDev parses /proc/net/dev content into byte counters per interface
+func parseNetDev(data string) map[string]interfaceCounters {
+	counters := make(map[string]interfaceCounters)
+	for line := range strings.Lines(data) {
+		// Interface lines look like "  eth0: 1234 10 0 0 0 0 0 0 5678 20 ..."
+		name, stats, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		fields := strings.Fields(stats)
+		if len(fields) < 9 {
+			continue
+		}
+		rx, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		tx, err := strconv.ParseUint(fields[8], 10, 64)
+		if err != nil {
+			continue
+		}
+		counters[strings.TrimSpace(name)] = interfaceCounters{rxBytes: rx, txBytes: tx}
+	}
+	return counters
+}
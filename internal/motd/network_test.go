@@ -0,0 +1,22 @@
+package motd
+
+import (
+	"testing"
+)
+
+func TestParseNetDev(t *testing.T) {
+	data := `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo:   12345     100    0    0    0     0          0         0    12345     100    0    0    0     0       0          0
+  eth0: 987654321  5000    0    0    0     0          0         0 123456789  4000    0    0    0     0       0          0
+`
+
+	counters := parseNetDev(data)
+	eth0, ok := counters["eth0"]
+	if !ok {
+		t.Fatalf("expected eth0 counters, got %v", counters)
+	}
+	if eth0.rxBytes != 987654321 || eth0.txBytes != 123456789 {
+		t.Fatalf("unexpected eth0 counters: %+v", eth0)
+	}
+}
@@ -15,7 +15,6 @@ import (
 	"time"
 
 	"github.com/saltyorg/sb-go/internal/config"
-	"github.com/saltyorg/sb-go/internal/constants"
 )
 
 // NzbgetInfo holds processed information for an NZBGet instance
@@ -55,7 +54,7 @@ type listGroupsResponse struct {
 
 // GetNzbgetInfo fetches and formats NZBGet queue information
 func GetNzbgetInfo(ctx context.Context, verbose bool) string {
-	configPath := constants.SaltboxMOTDConfigPath
+	configPath := config.SB().MOTDConfig
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		if verbose {
 			fmt.Printf("DEBUG: Config file %s does not exist\n", configPath)
@@ -14,7 +14,6 @@ import (
 	"time"
 
 	"github.com/saltyorg/sb-go/internal/config"
-	"github.com/saltyorg/sb-go/internal/constants"
 )
 
 // PlexStreamInfo contains information about Plex streams
@@ -76,7 +75,7 @@ type PlexSession struct {
 // GetPlexInfo fetches and formats Plex streaming information
 func GetPlexInfo(ctx context.Context, verbose bool) string {
 	// Check if the configuration file exists
-	configPath := constants.SaltboxMOTDConfigPath
+	configPath := config.SB().MOTDConfig
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		if verbose {
 			fmt.Printf("DEBUG: Config file %s does not exist\n", configPath)
@@ -0,0 +1,247 @@
+package motd
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// poolStatus represents the health of a single ZFS pool or Btrfs filesystem
+type poolStatus struct {
+	name   string
+	health string // ONLINE, DEGRADED, FAULTED, etc.
+	used   string // Capacity used (e.g., "45%"), empty when unknown
+	scrub  string // Short scrub summary, empty when unknown
+}
+
+// healthy reports whether the pool is in a healthy state
+func (p poolStatus) healthy() bool {
+	return p.health == "ONLINE"
+}
+
+// GetPoolStatus returns the health and scrub status of ZFS pools and Btrfs filesystems.
+// Returns an empty string to hide the section when neither zpool nor btrfs is installed
+// or no pools are found.
+func GetPoolStatus(ctx context.Context, verbose bool) string {
+	var pools []poolStatus
+
+	if _, err := exec.LookPath("zpool"); err == nil {
+		pools = append(pools, getZFSPools(ctx, verbose)...)
+	} else if verbose {
+		fmt.Printf("DEBUG: zpool not found, skipping ZFS pools\n")
+	}
+
+	if _, err := exec.LookPath("btrfs"); err == nil {
+		pools = append(pools, getBtrfsPools(ctx, verbose)...)
+	} else if verbose {
+		fmt.Printf("DEBUG: btrfs not found, skipping Btrfs filesystems\n")
+	}
+
+	if len(pools) == 0 {
+		return ""
+	}
+
+	return formatPoolStatus(pools)
+}
+
+// getZFSPools returns the status of all imported ZFS pools
+func getZFSPools(ctx context.Context, verbose bool) []poolStatus {
+	output := ExecCommand(ctx, "zpool", "list", "-H", "-o", "name,health,cap")
+	if output == "Not available" || output == "" {
+		if verbose {
+			fmt.Printf("DEBUG: No ZFS pools found\n")
+		}
+		return nil
+	}
+
+	pools := parseZpoolList(output)
+	for i := range pools {
+		status := ExecCommand(ctx, "zpool", "status", pools[i].name)
+		if status != "Not available" {
+			pools[i].scrub = parseZpoolScrub(status)
+		}
+	}
+	return pools
+}
+
+// getBtrfsPools returns the status of all Btrfs filesystems
+func getBtrfsPools(ctx context.Context, verbose bool) []poolStatus {
+	output := ExecCommand(ctx, "btrfs", "filesystem", "show")
+	if output == "Not available" || output == "" {
+		if verbose {
+			fmt.Printf("DEBUG: No Btrfs filesystems found\n")
+		}
+		return nil
+	}
+
+	filesystems := parseBtrfsFilesystemShow(output)
+	pools := make([]poolStatus, 0, len(filesystems))
+	for _, fs := range filesystems {
+		if fs.device != "" {
+			status := ExecCommand(ctx, "btrfs", "scrub", "status", fs.device)
+			if status != "Not available" {
+				fs.scrub = parseBtrfsScrub(status)
+			}
+		}
+		pools = append(pools, fs.poolStatus)
+	}
+	return pools
+}
+
+// parseZpoolList parses tab-separated `zpool list -H -o name,health,cap` output
+func parseZpoolList(output string) []poolStatus {
+	var pools []poolStatus
+	for line := range strings.Lines(output) {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) < 3 || fields[0] == "" {
+			continue
+		}
+		pools = append(pools, poolStatus{
+			name:   fields[0],
+			health: fields[1],
+			used:   fields[2],
+		})
+	}
+	return pools
+}
+
+// parseZpoolScrub extracts a short scrub summary from the scan line of `zpool status` output
+func parseZpoolScrub(output string) string {
+	for line := range strings.Lines(output) {
+		scan, ok := strings.CutPrefix(strings.TrimSpace(line), "scan:")
+		if !ok {
+			continue
+		}
+		scan = strings.TrimSpace(scan)
+
+		switch {
+		case strings.HasPrefix(scan, "scrub in progress"):
+			return "scrub in progress"
+		case strings.HasPrefix(scan, "scrub canceled"):
+			return "scrub canceled"
+		case strings.HasPrefix(scan, "scrub repaired"):
+			// e.g., "scrub repaired 0B in 00:10:12 with 0 errors on Sun Oct 12 00:34:13 2025"
+			if _, after, found := strings.Cut(scan, " with "); found {
+				errors, _, _ := strings.Cut(after, " on ")
+				return "last scrub " + errors
+			}
+			return "last scrub completed"
+		case strings.HasPrefix(scan, "resilver in progress"):
+			return "resilver in progress"
+		case scan == "none requested":
+			return "never scrubbed"
+		}
+		return ""
+	}
+	return ""
+}
+
+// btrfsFilesystem is a Btrfs filesystem parsed from `btrfs filesystem show`
+type btrfsFilesystem struct {
+	poolStatus
+	device string // First device path, used to query scrub status
+}
+
+// parseBtrfsFilesystemShow parses `btrfs filesystem show` output.
+// A filesystem is reported as DEGRADED when btrfs flags missing devices.
+func parseBtrfsFilesystemShow(output string) []btrfsFilesystem {
+	var filesystems []btrfsFilesystem
+	var current *btrfsFilesystem
+
+	for line := range strings.Lines(output) {
+		line = strings.TrimSpace(line)
+
+		if rest, ok := strings.CutPrefix(line, "Label:"); ok {
+			filesystems = append(filesystems, btrfsFilesystem{poolStatus: poolStatus{health: "ONLINE"}})
+			current = &filesystems[len(filesystems)-1]
+
+			// e.g., "Label: 'data'  uuid: 0c2f...", or "Label: none  uuid: 0c2f..."
+			label, uuid, _ := strings.Cut(rest, "uuid:")
+			label = strings.Trim(strings.TrimSpace(label), "'")
+			if label == "" || label == "none" {
+				label = strings.TrimSpace(uuid)
+				if len(label) > 8 {
+					label = label[:8]
+				}
+			}
+			current.name = label
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		switch {
+		case strings.Contains(line, "devices missing") || strings.Contains(line, "<missing disk>"):
+			current.health = "DEGRADED"
+		case strings.HasPrefix(line, "devid") && current.device == "":
+			if _, path, found := strings.Cut(line, " path "); found {
+				current.device = strings.TrimSpace(path)
+			}
+		}
+	}
+
+	return filesystems
+}
+
+// parseBtrfsScrub extracts a short scrub summary from `btrfs scrub status` output
+func parseBtrfsScrub(output string) string {
+	var status, errors string
+	for line := range strings.Lines(output) {
+		line = strings.TrimSpace(line)
+		if value, ok := strings.CutPrefix(line, "Status:"); ok {
+			status = strings.TrimSpace(value)
+		}
+		if value, ok := strings.CutPrefix(line, "Error summary:"); ok {
+			errors = strings.TrimSpace(value)
+		}
+		if strings.Contains(line, "no stats available") {
+			return "never scrubbed"
+		}
+	}
+
+	switch status {
+	case "running":
+		return "scrub in progress"
+	case "aborted", "interrupted":
+		return "scrub " + status
+	case "finished":
+		if errors == "no errors found" {
+			return "last scrub 0 errors"
+		}
+		if errors != "" {
+			return "last scrub errors: " + errors
+		}
+		return "last scrub completed"
+	}
+	return ""
+}
+
+// formatPoolStatus formats one line per pool, coloring unhealthy pools red
+func formatPoolStatus(pools []poolStatus) string {
+	lines := make([]string, 0, len(pools))
+	for _, p := range pools {
+		healthStyle := ValueStyle
+		if !p.healthy() {
+			healthStyle = ErrorStyle
+		}
+
+		var details []string
+		if p.used != "" && p.used != "-" {
+			details = append(details, p.used+" used")
+		}
+		if p.scrub != "" {
+			details = append(details, p.scrub)
+		}
+
+		line := fmt.Sprintf("%s: %s", DefaultStyle.Render(p.name), healthStyle.Render(p.health))
+		if len(details) > 0 {
+			line += DefaultStyle.Render(fmt.Sprintf(" (%s)", strings.Join(details, ", ")))
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,70 @@
+package motd
+
+import (
+	"testing"
+)
+
+func TestParseZpoolList(t *testing.T) {
+	pools := parseZpoolList("tank\tONLINE\t45%\nbackup\tDEGRADED\t80%\n")
+
+	if len(pools) != 2 {
+		t.Fatalf("expected 2 pools, got %d", len(pools))
+	}
+	if pools[0].name != "tank" || pools[0].health != "ONLINE" || pools[0].used != "45%" {
+		t.Fatalf("unexpected first pool: %+v", pools[0])
+	}
+	if pools[1].healthy() {
+		t.Fatalf("expected degraded pool to be unhealthy")
+	}
+}
+
+func TestParseZpoolScrub(t *testing.T) {
+	tests := map[string]string{
+		"  scan: scrub repaired 0B in 00:10:12 with 0 errors on Sun Oct 12 00:34:13 2025": "last scrub 0 errors",
+		"  scan: scrub in progress since Sun Oct 12 00:24:01 2025":                        "scrub in progress",
+		"  scan: none requested": "never scrubbed",
+	}
+
+	for input, want := range tests {
+		output := "  pool: tank\n state: ONLINE\n" + input + "\nconfig:\n"
+		if got := parseZpoolScrub(output); got != want {
+			t.Errorf("parseZpoolScrub(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestParseBtrfsFilesystemShow(t *testing.T) {
+	output := `Label: 'data'  uuid: 0c2f5a4e-1d3b-4f6a-9c8e-2b7d1e0f3a5c
+	Total devices 2 FS bytes used 1.20TiB
+	devid    1 size 3.64TiB used 1.21TiB path /dev/sdb
+	devid    2 size 3.64TiB used 1.21TiB path /dev/sdc
+
+Label: none  uuid: 7e1a9b3c-5d2f-4a8e-b6c0-1f9d3e7a2b4c
+	Total devices 2 FS bytes used 100.00GiB
+	devid    1 size 500.00GiB used 101.00GiB path /dev/sdd
+	*** Some devices missing
+`
+
+	filesystems := parseBtrfsFilesystemShow(output)
+	if len(filesystems) != 2 {
+		t.Fatalf("expected 2 filesystems, got %d", len(filesystems))
+	}
+	if filesystems[0].name != "data" || filesystems[0].health != "ONLINE" || filesystems[0].device != "/dev/sdb" {
+		t.Fatalf("unexpected first filesystem: %+v", filesystems[0])
+	}
+	if filesystems[1].name != "7e1a9b3c" || filesystems[1].health != "DEGRADED" {
+		t.Fatalf("unexpected second filesystem: %+v", filesystems[1])
+	}
+}
+
+func TestParseBtrfsScrub(t *testing.T) {
+	output := `UUID:             0c2f5a4e-1d3b-4f6a-9c8e-2b7d1e0f3a5c
+Scrub started:    Sun Oct 12 00:00:01 2025
+Status:           finished
+Duration:         2:13:45
+Error summary:    no errors found
+`
+	if got := parseBtrfsScrub(output); got != "last scrub 0 errors" {
+		t.Fatalf("parseBtrfsScrub() = %q", got)
+	}
+}
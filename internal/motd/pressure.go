@@ -0,0 +1,108 @@
+package motd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Pressure thresholds for the 10 second stall average, in percent
+const (
+	pressureWarning  = 10.0
+	pressureCritical = 25.0
+)
+
+// pressureResources lists the PSI files under /proc/pressure with their display labels
+var pressureResources = []struct {
+	file  string
+	label string
+}{
+	{"cpu", "CPU"},
+	{"memory", "Memory"},
+	{"io", "IO"},
+}
+
+// pressureReading holds the avg10 stall percentages of a single PSI resource
+type pressureReading struct {
+	label   string
+	some    float64
+	full    float64
+	hasFull bool
+}
+
+// GetPressureInfo returns the pressure stall information for CPU, memory and IO.
+// It returns an empty string on kernels without PSI support so the section is hidden.
+func GetPressureInfo(ctx context.Context, verbose bool) string {
+	var readings []pressureReading
+	for _, resource := range pressureResources {
+		reading, ok := parsePressure(readProcFile("pressure/" + resource.file))
+		if !ok {
+			if verbose {
+				fmt.Printf("DEBUG: /proc/pressure/%s unavailable, skipping\n", resource.file)
+			}
+			continue
+		}
+		reading.label = resource.label
+		readings = append(readings, reading)
+	}
+
+	if len(readings) == 0 {
+		return ""
+	}
+
+	return formatPressure(readings)
+}
+
+// parsePressure extracts the some and full avg10 values from a /proc/pressure file.
+// Typical content: "some avg10=0.00 avg60=0.00 avg300=0.00 total=0"
+func parsePressure(data string) (pressureReading, bool) {
+	var reading pressureReading
+	var hasSome bool
+	for line := range strings.SplitSeq(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		value, ok := strings.CutPrefix(fields[1], "avg10=")
+		if !ok {
+			continue
+		}
+		avg, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "some":
+			reading.some = avg
+			hasSome = true
+		case "full":
+			reading.full = avg
+			reading.hasFull = true
+		}
+	}
+	return reading, hasSome
+}
+
+func formatPressure(readings []pressureReading) string {
+	parts := make([]string, 0, len(readings))
+	for _, r := range readings {
+		part := fmt.Sprintf("%s: some %s", DefaultStyle.Render(r.label), renderPressure(r.some))
+		if r.hasFull {
+			part += " full " + renderPressure(r.full)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " | ")
+}
+
+// renderPressure formats a stall percentage, coloring values above the thresholds
+func renderPressure(value float64) string {
+	style := ValueStyle
+	if value >= pressureCritical {
+		style = ErrorStyle
+	} else if value >= pressureWarning {
+		style = WarningStyle
+	}
+	return style.Render(fmt.Sprintf("%.1f%%", value))
+}
@@ -0,0 +1,49 @@
+package motd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestParsePressure(t *testing.T) {
+	reading, ok := parsePressure("some avg10=12.50 avg60=3.00 avg300=1.00 total=123\nfull avg10=0.75 avg60=0.20 avg300=0.05 total=45\n")
+	if !ok {
+		t.Fatal("expected pressure to parse")
+	}
+	if reading.some != 12.5 || !reading.hasFull || reading.full != 0.75 {
+		t.Fatalf("unexpected reading: %+v", reading)
+	}
+
+	reading, ok = parsePressure("some avg10=0.00 avg60=0.00 avg300=0.00 total=0\n")
+	if !ok || reading.hasFull {
+		t.Fatalf("expected some-only reading, got %+v (ok=%v)", reading, ok)
+	}
+
+	if _, ok := parsePressure("garbage\n"); ok {
+		t.Fatal("expected malformed pressure to be rejected")
+	}
+}
+
+func TestGetPressureInfo(t *testing.T) {
+	useFakeHostRoot(t, map[string]string{
+		"proc/pressure/cpu":    "some avg10=1.20 avg60=0.50 avg300=0.10 total=100\nfull avg10=0.00 avg60=0.00 avg300=0.00 total=0\n",
+		"proc/pressure/memory": "some avg10=30.00 avg60=10.00 avg300=2.00 total=100\nfull avg10=12.00 avg60=5.00 avg300=1.00 total=50\n",
+		"proc/pressure/io":     "",
+	})
+
+	got := ansi.Strip(GetPressureInfo(context.Background(), false))
+	want := "CPU: some 1.2% full 0.0% | Memory: some 30.0% full 12.0%"
+	if got != want {
+		t.Errorf("GetPressureInfo = %q, want %q", got, want)
+	}
+}
+
+func TestGetPressureInfoHiddenWithoutPSI(t *testing.T) {
+	useFakeHostRoot(t, nil)
+
+	if got := GetPressureInfo(context.Background(), false); got != "" {
+		t.Errorf("expected empty output without PSI support, got %q", got)
+	}
+}
@@ -0,0 +1,52 @@
+package motd
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// ProblemSources returns info sources showing only the health issues of the named sections,
+// and nothing for healthy ones, so a MOTD built from them is empty when all is well. All
+// sections with a health check are used when names is empty. Checks making network requests
+// are skipped while quietHours is set.
+func ProblemSources(names []string, quietHours bool) []InfoSource {
+	var sources []InfoSource
+	for _, hc := range healthChecks {
+		if len(names) > 0 && !slices.Contains(names, hc.section) {
+			continue
+		}
+		index := slices.IndexFunc(DefaultSections, func(s Section) bool { return s.Name == hc.section })
+		if index < 0 {
+			continue
+		}
+		section := DefaultSections[index]
+		if section.Network && quietHours {
+			continue
+		}
+		sources = append(sources, InfoSource{
+			Key:      section.Key,
+			Provider: problemProvider(hc.check),
+			Order:    len(sources) + 1,
+		})
+	}
+	return sources
+}
+
+// problemProvider renders the issues found by check, one per line
+func problemProvider(check healthCheck) InfoProvider {
+	return func(ctx context.Context, verbose bool) string {
+		issues := check(ctx, verbose)
+		lines := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			lines = append(lines, ErrorStyle.Render(issue.Message))
+		}
+		return strings.Join(lines, "\n")
+	}
+}
+
+// OnlyProblemsEnabled reports whether the MOTD config asks for only the sections with problems
+func OnlyProblemsEnabled() bool {
+	cfg := loadMOTDConfig()
+	return cfg != nil && cfg.OnlyProblems
+}
@@ -0,0 +1,53 @@
+package motd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestProblemSources(t *testing.T) {
+	original := healthChecks
+	defer func() { healthChecks = original }()
+
+	issues := func(section string, messages ...string) healthCheck {
+		return func(ctx context.Context, verbose bool) []HealthIssue {
+			var found []HealthIssue
+			for _, message := range messages {
+				found = append(found, HealthIssue{Source: section, Message: message})
+			}
+			return found
+		}
+	}
+	healthChecks = []sectionHealthCheck{
+		{"docker", issues("docker", "plex: unhealthy", "sonarr: stopped")},
+		{"disk", issues("disk")},
+		{"traefik", issues("traefik", "sonarr: 502")},
+	}
+
+	sources := ProblemSources(nil, false)
+	if len(sources) != 3 {
+		t.Fatalf("ProblemSources() returned %d sources, want 3", len(sources))
+	}
+	if sources[0].Key != "Docker:" || sources[1].Key != "Disk Usage:" || sources[2].Key != "Traefik:" {
+		t.Errorf("ProblemSources() keys = %q, %q, %q", sources[0].Key, sources[1].Key, sources[2].Key)
+	}
+	if got := ansi.Strip(sources[0].Provider(context.Background(), false)); got != "plex: unhealthy\nsonarr: stopped" {
+		t.Errorf("docker problems = %q", got)
+	}
+	if got := sources[1].Provider(context.Background(), false); got != "" {
+		t.Errorf("healthy disk shows %q, want nothing", got)
+	}
+
+	if sources := ProblemSources([]string{"disk", "uptime"}, false); len(sources) != 1 || sources[0].Key != "Disk Usage:" {
+		t.Errorf("ProblemSources() of enabled sections = %+v, want only the disk", sources)
+	}
+
+	// Traefik makes network requests, so it isn't checked during quiet hours
+	for _, source := range ProblemSources(nil, true) {
+		if source.Key == "Traefik:" {
+			t.Error("ProblemSources() checks Traefik during quiet hours")
+		}
+	}
+}
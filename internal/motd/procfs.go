@@ -0,0 +1,30 @@
+package motd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// hostRoot is the base path that procfs and sysfs paths are resolved against.
+// Tests point it at a fixture directory holding canned files such as proc/meminfo.
+var hostRoot = "/"
+
+// hostPath resolves an absolute host path such as /proc/meminfo against hostRoot
+func hostPath(path string) string {
+	return filepath.Join(hostRoot, path)
+}
+
+// readProcFile returns the content of a file under /proc, or an empty string if it can't be read.
+// Restricted containers often mask procfs files by mounting /dev/null or an empty file over them,
+// so an empty file is treated the same as a missing one and callers fall back to other sources.
+func readProcFile(name string) string {
+	content, err := os.ReadFile(hostPath(filepath.Join("/proc", name)))
+	if err != nil {
+		return ""
+	}
+	if strings.TrimSpace(string(content)) == "" {
+		return ""
+	}
+	return string(content)
+}
@@ -0,0 +1,179 @@
+package motd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// useFakeHostRoot points hostRoot at a temporary directory holding files, e.g. proc/meminfo.
+// Files with empty content simulate procfs entries masked by a restricted container.
+func useFakeHostRoot(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	previous := hostRoot
+	hostRoot = root
+	t.Cleanup(func() { hostRoot = previous })
+	return root
+}
+
+// useFakeCommands replaces PATH with a directory of scripts printing the given output,
+// so commands without a script are not found
+func useFakeCommands(t *testing.T, outputs map[string]string) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, output := range outputs {
+		// printf is a shell builtin, so the script works without PATH
+		script := "#!/bin/sh\nprintf '%s\\n' '" + strings.ReplaceAll(output, "'", `'\''`) + "'\n"
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir)
+}
+
+func TestHostPath(t *testing.T) {
+	if got := hostPath("/proc/meminfo"); got != "/proc/meminfo" {
+		t.Errorf("hostPath with the default root = %q, want /proc/meminfo", got)
+	}
+
+	root := useFakeHostRoot(t, nil)
+	if got, want := hostPath("/sys/class/hwmon"), filepath.Join(root, "sys", "class", "hwmon"); got != want {
+		t.Errorf("hostPath = %q, want %q", got, want)
+	}
+}
+
+func TestReadProcFileTreatsMaskedFilesAsMissing(t *testing.T) {
+	useFakeHostRoot(t, map[string]string{"proc/loadavg": "0.10 0.20 0.30 1/100 42\n", "proc/meminfo": "\n"})
+
+	if got := readProcFile("loadavg"); got == "" {
+		t.Error("readable file returned as empty")
+	}
+	if got := readProcFile("meminfo"); got != "" {
+		t.Errorf("masked file returned %q", got)
+	}
+	if got := readProcFile("cpuinfo"); got != "" {
+		t.Errorf("missing file returned %q", got)
+	}
+}
+
+func TestGetCpuAveragesFallback(t *testing.T) {
+	useFakeHostRoot(t, map[string]string{"proc/loadavg": "0.10 0.20 0.30 1/100 42\n"})
+	useFakeCommands(t, nil)
+	if got := ansi.Strip(GetCpuAverages(context.Background(), false)); got != "1 min: 0.10 | 5 min: 0.20 | 15 min: 0.30" {
+		t.Errorf("GetCpuAverages from procfs = %q", got)
+	}
+
+	useFakeHostRoot(t, map[string]string{"proc/loadavg": ""})
+	useFakeCommands(t, map[string]string{"uptime": " 12:00:00 up 3 days,  2 users,  load average: 1.50, 0.75, 0.25"})
+	if got := ansi.Strip(GetCpuAverages(context.Background(), false)); got != "1 min: 1.50 | 5 min: 0.75 | 15 min: 0.25" {
+		t.Errorf("GetCpuAverages from uptime = %q", got)
+	}
+
+	useFakeCommands(t, nil)
+	if got := ansi.Strip(GetCpuAverages(context.Background(), false)); got != "Not available" {
+		t.Errorf("GetCpuAverages without sources = %q, want Not available", got)
+	}
+}
+
+func TestGetProcessCountFallback(t *testing.T) {
+	root := useFakeHostRoot(t, map[string]string{"proc/1/stat": "x", "proc/42/stat": "x", "proc/sys/kernel": "x"})
+	useFakeCommands(t, nil)
+	if got := ansi.Strip(GetProcessCount(context.Background(), false)); got != "2 running processes" {
+		t.Errorf("GetProcessCount from procfs = %q", got)
+	}
+
+	// A masked /proc has no process directories
+	if err := os.RemoveAll(filepath.Join(root, "proc", "1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(root, "proc", "42")); err != nil {
+		t.Fatal(err)
+	}
+	useFakeCommands(t, map[string]string{"ps": "    PID TTY      STAT   TIME COMMAND\n      1 ?        Ss     0:01 /sbin/init\n     10 ?        S      0:00 sshd\n     11 pts/0    R+     0:00 ps ax"})
+	if got := ansi.Strip(GetProcessCount(context.Background(), false)); got != "3 running processes" {
+		t.Errorf("GetProcessCount from ps = %q", got)
+	}
+
+	useFakeCommands(t, nil)
+	if got := GetProcessCount(context.Background(), false); got != "Not available" {
+		t.Errorf("GetProcessCount without sources = %q, want Not available", got)
+	}
+}
+
+func TestGetMemoryInfoFallback(t *testing.T) {
+	const want = "2.0G used, 1.0G free, 3.0G cached, 6.0G available, 8.0G total"
+
+	useFakeHostRoot(t, map[string]string{"proc/meminfo": "MemTotal: 8388608 kB\nMemFree: 1048576 kB\nMemAvailable: 6291456 kB\nBuffers: 1048576 kB\nCached: 2097152 kB\n"})
+	useFakeCommands(t, nil)
+	if got := ansi.Strip(GetMemoryInfo(context.Background(), false)); got != want {
+		t.Errorf("GetMemoryInfo from procfs = %q, want %q", got, want)
+	}
+
+	useFakeHostRoot(t, map[string]string{"proc/meminfo": ""})
+	useFakeCommands(t, map[string]string{"free": "               total        used        free      shared  buff/cache   available\nMem:         8388608     2097152     1048576           0     3145728     6291456\nSwap:              0           0           0"})
+	if got := ansi.Strip(GetMemoryInfo(context.Background(), false)); got != want {
+		t.Errorf("GetMemoryInfo from free = %q, want %q", got, want)
+	}
+
+	useFakeCommands(t, nil)
+	if got := ansi.Strip(GetMemoryInfo(context.Background(), false)); got != "Not available" {
+		t.Errorf("GetMemoryInfo without sources = %q, want Not available", got)
+	}
+}
+
+func TestParseFreeWithoutAvailableColumn(t *testing.T) {
+	usage, ok := parseFree("             total       used       free     shared    buffers     cached\nMem:          1000        600        400          0        100        200\n")
+	if !ok {
+		t.Fatal("parseFree failed")
+	}
+	if want := (memoryUsage{total: 1000, free: 400, cached: 300, available: 700}); usage != want {
+		t.Errorf("parseFree = %+v, want %+v", usage, want)
+	}
+}
+
+func TestGetCpuInfoFallback(t *testing.T) {
+	cpuinfo := strings.Repeat("processor\t: 0\nmodel name\t: AMD Ryzen 5 3600 6-Core Processor\nphysical id\t: 0\ncpu cores\t: 6\n\n", 12)
+	useFakeHostRoot(t, map[string]string{"proc/cpuinfo": cpuinfo})
+	useFakeCommands(t, nil)
+	if got := ansi.Strip(GetCpuInfo(context.Background(), false)); got != "AMD Ryzen 5 3600 (6 cores, 12 threads)" {
+		t.Errorf("GetCpuInfo from procfs = %q", got)
+	}
+
+	useFakeHostRoot(t, map[string]string{"proc/cpuinfo": ""})
+	useFakeCommands(t, map[string]string{"lscpu": "Architecture:             x86_64\nCPU(s):                   16\n  On-line CPU(s) list:    0-15\nModel name:               Intel(R) Xeon(R) E-2288G CPU @ 3.70GHz\n    Thread(s) per core:   2\n    Core(s) per socket:   8\n    Socket(s):            1"})
+	if got := ansi.Strip(GetCpuInfo(context.Background(), false)); got != "Intel(R) Xeon(R) E-2288G CPU @ 3.70GHz (8 cores, 16 threads)" {
+		t.Errorf("GetCpuInfo from lscpu = %q", got)
+	}
+
+	useFakeCommands(t, nil)
+	if got := ansi.Strip(GetCpuInfo(context.Background(), false)); got != "Not available" {
+		t.Errorf("GetCpuInfo without sources = %q, want Not available", got)
+	}
+}
+
+func TestGetNetworkThroughputFromFixture(t *testing.T) {
+	useFakeHostRoot(t, map[string]string{
+		"proc/net/route": "Iface\tDestination\tGateway \tFlags\tRefCnt\tUse\tMetric\tMask\t\tMTU\tWindow\tIRTT\neth0\t00000000\t0100A8C0\t0003\t0\t0\t100\t00000000\t0\t0\t0\n",
+		"proc/net/dev":   "Inter-|   Receive\n face |bytes    packets\n  eth0: 1000 5 0 0 0 0 0 0 2000 4 0 0 0 0 0 0\n",
+	})
+
+	// The counters don't change between samples, so both rates are zero
+	if got, want := ansi.Strip(GetNetworkThroughput(context.Background(), false)), "↓ 0 B/s ↑ 0 B/s (eth0)"; got != want {
+		t.Errorf("GetNetworkThroughput = %q, want %q", got, want)
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"time"
 
 	"github.com/saltyorg/sb-go/internal/config"
-	"github.com/saltyorg/sb-go/internal/constants"
 
 	"github.com/autobrr/go-qbittorrent"
 )
@@ -30,7 +29,7 @@ type qbittorrentInfo struct {
 
 // GetQbittorrentInfo fetches and formats qBittorrent information.
 func GetQbittorrentInfo(ctx context.Context, verbose bool) string {
-	configPath := constants.SaltboxMOTDConfigPath
+	configPath := config.SB().MOTDConfig
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		if verbose {
 			fmt.Printf("DEBUG: Config file %s does not exist\n", configPath)
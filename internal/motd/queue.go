@@ -10,7 +10,6 @@ import (
 	"time"
 
 	"github.com/saltyorg/sb-go/internal/config"
-	"github.com/saltyorg/sb-go/internal/constants"
 
 	"golift.io/starr"
 	"golift.io/starr/lidarr"
@@ -34,7 +33,7 @@ type QueueInfo struct {
 // GetQueueInfo fetches queue information from configured applications
 func GetQueueInfo(ctx context.Context, verbose bool) string {
 	// Check if the configuration file exists
-	configPath := constants.SaltboxMOTDConfigPath
+	configPath := config.SB().MOTDConfig
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		if verbose {
 			fmt.Printf("DEBUG: Config file %s does not exist\n", configPath)
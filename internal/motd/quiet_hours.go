@@ -0,0 +1,47 @@
+package motd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/config"
+)
+
+// loadQuietHours returns the quiet hours from the MOTD config, or nil if none are configured
+func loadQuietHours() *config.QuietHoursConfig {
+	cfg := loadMOTDConfig()
+	if cfg == nil {
+		return nil
+	}
+	return cfg.QuietHours
+}
+
+// inQuietHours reports whether network requests are paused at now. Invalid quiet hours
+// never pause them, so a typo in the config doesn't hide sections.
+func inQuietHours(quiet *config.QuietHoursConfig, now time.Time) bool {
+	if quiet == nil {
+		return false
+	}
+	active, err := quiet.Contains(now)
+	return err == nil && active
+}
+
+// QuietHoursActive reports whether the quiet hours of the MOTD config cover the current time
+func QuietHoursActive() bool {
+	return inQuietHours(loadQuietHours(), time.Now())
+}
+
+// SectionProvider returns the provider to run for a section. Sections that make network
+// requests report their checks as paused instead while quietHours is set.
+func SectionProvider(section Section, quietHours bool) InfoProvider {
+	if !section.Network || !quietHours {
+		return section.Provider
+	}
+	return func(ctx context.Context, verbose bool) string {
+		if verbose {
+			fmt.Printf("DEBUG: Skipping %s during quiet hours\n", section.Name)
+		}
+		return DimStyle.Render("Checks paused during quiet hours")
+	}
+}
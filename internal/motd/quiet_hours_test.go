@@ -0,0 +1,67 @@
+package motd
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/config"
+)
+
+func TestInQuietHours(t *testing.T) {
+	at := func(clock string) time.Time {
+		parsed, err := time.Parse("15:04", clock)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return time.Date(2025, 6, 1, parsed.Hour(), parsed.Minute(), 30, 0, time.Local)
+	}
+	overnight := &config.QuietHoursConfig{Start: "22:00", End: "07:00"}
+	daytime := &config.QuietHoursConfig{Start: "09:30", End: "17:00"}
+
+	tests := []struct {
+		name  string
+		quiet *config.QuietHoursConfig
+		now   string
+		want  bool
+	}{
+		{"not configured", nil, "23:00", false},
+		{"overnight before midnight", overnight, "23:15", true},
+		{"overnight after midnight", overnight, "03:00", true},
+		{"overnight start is included", overnight, "22:00", true},
+		{"overnight end is excluded", overnight, "07:00", false},
+		{"overnight outside", overnight, "12:00", false},
+		{"daytime inside", daytime, "12:00", true},
+		{"daytime outside", daytime, "08:00", false},
+		{"empty window", &config.QuietHoursConfig{Start: "10:00", End: "10:00"}, "10:00", false},
+		{"invalid window", &config.QuietHoursConfig{Start: "10pm", End: "07:00"}, "23:00", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inQuietHours(tt.quiet, at(tt.now)); got != tt.want {
+				t.Errorf("inQuietHours(%s) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSectionProviderQuietHours(t *testing.T) {
+	var called bool
+	provider := func(ctx context.Context, verbose bool) string {
+		called = true
+		return "checked"
+	}
+	network := Section{Name: "plex", Provider: provider, Network: true}
+	local := Section{Name: "disk", Provider: provider}
+
+	if got := SectionProvider(network, true)(context.Background(), false); called || !strings.Contains(got, "paused") {
+		t.Errorf("network section during quiet hours = %q, called = %v", got, called)
+	}
+	if got := SectionProvider(local, true)(context.Background(), false); got != "checked" {
+		t.Errorf("local section during quiet hours = %q, want it checked", got)
+	}
+	if got := SectionProvider(network, false)(context.Background(), false); got != "checked" {
+		t.Errorf("network section outside quiet hours = %q, want it checked", got)
+	}
+}
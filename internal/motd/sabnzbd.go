@@ -13,7 +13,6 @@ import (
 	"time"
 
 	"github.com/saltyorg/sb-go/internal/config"
-	"github.com/saltyorg/sb-go/internal/constants"
 )
 
 // SabnzbdInfo holds the processed information for an SABnzbd instance
@@ -43,7 +42,7 @@ type SabnzbdQueue struct {
 
 // GetSabnzbdInfo fetches and formats SABnzbd queue information
 func GetSabnzbdInfo(ctx context.Context, verbose bool) string {
-	configPath := constants.SaltboxMOTDConfigPath
+	configPath := config.SB().MOTDConfig
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		if verbose {
 			fmt.Printf("DEBUG: Config file %s does not exist\n", configPath)
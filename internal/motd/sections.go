@@ -0,0 +1,125 @@
+package motd
+
+import (
+	"os"
+	"slices"
+
+	"github.com/saltyorg/sb-go/internal/config"
+)
+
+// Section describes a MOTD section that can be enabled, disabled and reordered
+type Section struct {
+	Name     string // Name used by flags and the config file (e.g., "docker")
+	Key      string // The label shown in the output (e.g., "Docker:")
+	Provider InfoProvider
+	Network  bool // Makes network requests, so it is paused during quiet hours
+}
+
+// DefaultSections lists all known MOTD sections in their default display order
+var DefaultSections = []Section{
+	{Name: "distro", Key: "Distribution:", Provider: GetDistributionWithContext},
+	{Name: "kernel", Key: "Kernel:", Provider: GetKernelWithContext},
+	{Name: "uptime", Key: "Uptime:", Provider: GetUptimeWithContext},
+	{Name: "cpu", Key: "Load Averages:", Provider: GetCpuAveragesWithContext},
+	{Name: "processes", Key: "Processes:", Provider: GetProcessCountWithContext},
+	{Name: "pressure", Key: "Pressure:", Provider: GetPressureInfoWithContext},
+	{Name: "cpu-info", Key: "CPU:", Provider: GetCpuInfoWithContext},
+	{Name: "gpu", Key: "GPU:", Provider: GetGpuInfoWithContext},
+	{Name: "temps", Key: "Temperatures:", Provider: GetTemperaturesWithContext},
+	{Name: "memory", Key: "Memory Usage:", Provider: GetMemoryInfoWithContext},
+	{Name: "network", Key: "Network:", Provider: GetNetworkThroughputWithContext},
+	{Name: "history", Key: "Recent Usage:", Provider: GetResourceHistoryWithContext},
+	{Name: "apt", Key: "Package Status:", Provider: GetAptStatusWithContext},
+	{Name: "reboot", Key: "Reboot Status:", Provider: GetRebootRequiredWithContext},
+	{Name: "sessions", Key: "User Sessions:", Provider: GetUserSessionsWithContext},
+	{Name: "login", Key: "Last login:", Provider: GetLastLoginWithContext},
+	{Name: "disk", Key: "Disk Usage:", Provider: GetDiskInfoWithContext},
+	{Name: "smart", Key: "Disk Health:", Provider: GetDiskHealthWithContext},
+	{Name: "pools", Key: "Storage Pools:", Provider: GetPoolStatusWithContext},
+	{Name: "mounts", Key: "Rclone Mounts:", Provider: GetMountHealthWithContext},
+	{Name: "systemd", Key: "Services:", Provider: GetSystemdServicesInfoWithContext},
+	{Name: "docker", Key: "Docker:", Provider: GetDockerInfoWithContext},
+	{Name: "traefik", Key: "Traefik:", Provider: GetTraefikInfoWithContext, Network: true},
+	{Name: "queues", Key: "Download Queues:", Provider: GetQueueInfoWithContext, Network: true},
+	{Name: "sabnzbd", Key: "SABnzbd:", Provider: GetSabnzbdInfoWithContext, Network: true},
+	{Name: "nzbget", Key: "NZBGet:", Provider: GetNzbgetInfoWithContext, Network: true},
+	{Name: "qbittorrent", Key: "qBittorrent:", Provider: GetQbittorrentInfoWithContext, Network: true},
+	{Name: "rtorrent", Key: "rTorrent:", Provider: GetRtorrentInfoWithContext, Network: true},
+	{Name: "plex", Key: "Plex:", Provider: GetPlexInfoWithContext, Network: true},
+	{Name: "emby", Key: "Emby:", Provider: GetEmbyInfoWithContext, Network: true},
+	{Name: "jellyfin", Key: "Jellyfin:", Provider: GetJellyfinInfoWithContext, Network: true},
+}
+
+// loadMOTDConfig loads the MOTD config file, returning nil if it doesn't exist or can't be parsed
+func loadMOTDConfig() *config.MOTDConfig {
+	configPath := config.SB().MOTDConfig
+
+	if _, err := os.Stat(configPath); err != nil {
+		return nil
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return nil
+	}
+	return cfg
+}
+
+// LoadSectionsConfig loads the sections layout from the MOTD config file.
+// Returns nil if the config file doesn't exist or has no sections configured.
+func LoadSectionsConfig() *config.SectionsConfig {
+	cfg := loadMOTDConfig()
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Sections
+}
+
+// ResolveSections returns the sections to display in order.
+// Sections listed in cfg.Order come first in the given order, followed by any
+// remaining sections in their default order. Sections listed in cfg.Disabled are removed.
+// Any unknown section names found in the config are returned so the caller can warn about them.
+func ResolveSections(cfg *config.SectionsConfig) ([]Section, []string) {
+	if cfg == nil {
+		return slices.Clone(DefaultSections), nil
+	}
+
+	byName := make(map[string]Section, len(DefaultSections))
+	for _, section := range DefaultSections {
+		byName[section.Name] = section
+	}
+
+	var unknown []string
+	for _, name := range cfg.Disabled {
+		if _, ok := byName[name]; !ok && !slices.Contains(unknown, name) {
+			unknown = append(unknown, name)
+		}
+	}
+
+	var resolved []Section
+	seen := make(map[string]bool, len(DefaultSections))
+	add := func(section Section) {
+		if seen[section.Name] || slices.Contains(cfg.Disabled, section.Name) {
+			return
+		}
+		seen[section.Name] = true
+		resolved = append(resolved, section)
+	}
+
+	for _, name := range cfg.Order {
+		section, ok := byName[name]
+		if !ok {
+			if !slices.Contains(unknown, name) {
+				unknown = append(unknown, name)
+			}
+			continue
+		}
+		add(section)
+	}
+
+	for _, section := range DefaultSections {
+		add(section)
+	}
+
+	return resolved, unknown
+}
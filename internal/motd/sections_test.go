@@ -0,0 +1,48 @@
+package motd
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/saltyorg/sb-go/internal/config"
+)
+
+func sectionNames(sections []Section) []string {
+	names := make([]string, len(sections))
+	for i, section := range sections {
+		names[i] = section.Name
+	}
+	return names
+}
+
+func TestResolveSectionsDefaultsToBuiltInOrder(t *testing.T) {
+	sections, unknown := ResolveSections(nil)
+
+	if len(unknown) != 0 {
+		t.Fatalf("expected no unknown sections, got %v", unknown)
+	}
+	if !slices.Equal(sectionNames(sections), sectionNames(DefaultSections)) {
+		t.Fatalf("expected default order, got %v", sectionNames(sections))
+	}
+}
+
+func TestResolveSectionsAppliesOrderAndDisabled(t *testing.T) {
+	sections, unknown := ResolveSections(&config.SectionsConfig{
+		Order:    []string{"docker", "bogus", "disk"},
+		Disabled: []string{"gpu", "missing"},
+	})
+
+	names := sectionNames(sections)
+	if len(names) < 2 || names[0] != "docker" || names[1] != "disk" {
+		t.Fatalf("expected docker and disk first, got %v", names)
+	}
+	if slices.Contains(names, "gpu") {
+		t.Fatalf("expected gpu to be disabled, got %v", names)
+	}
+	if len(names) != len(DefaultSections)-1 {
+		t.Fatalf("expected %d sections, got %d", len(DefaultSections)-1, len(names))
+	}
+	if !slices.Equal(unknown, []string{"missing", "bogus"}) {
+		t.Fatalf("unexpected unknown sections: %v", unknown)
+	}
+}
@@ -0,0 +1,203 @@
+package motd
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/executor"
+)
+
+// smartDisk represents a disk reported by `smartctl --scan`
+type smartDisk struct {
+	device     string
+	deviceType string
+}
+
+// smartHealth represents the parsed SMART health of a single disk
+type smartHealth struct {
+	name        string
+	status      string // "PASSED", "FAILED" or "" when the health couldn't be determined
+	reallocated int64
+	pending     int64
+}
+
+// GetDiskHealth returns a SMART health summary for each physical disk.
+// The section is opt-in through the disk_health section of the MOTD config since
+// smartctl requires root privileges. Returns an empty string to hide the section
+// when it isn't enabled, smartctl isn't installed or no disks report SMART data.
+func GetDiskHealth(ctx context.Context, verbose bool) string {
+	cfg := loadMOTDConfig()
+	if cfg == nil || cfg.DiskHealth == nil || !cfg.DiskHealth.IsEnabled() {
+		if verbose {
+			fmt.Printf("DEBUG: Disk health section not enabled in MOTD config\n")
+		}
+		return ""
+	}
+
+	if _, err := exec.LookPath("smartctl"); err != nil {
+		if verbose {
+			fmt.Printf("DEBUG: smartctl not found, hiding disk health section\n")
+		}
+		return ""
+	}
+
+	disks := parseSmartScan(runSmartctl(ctx, "--scan"))
+	if verbose {
+		fmt.Printf("DEBUG: smartctl found %d disks\n", len(disks))
+	}
+
+	var results []smartHealth
+	for _, disk := range disks {
+		args := []string{"-H", "-A"}
+		if disk.deviceType != "" {
+			args = append(args, "-d", disk.deviceType)
+		}
+		args = append(args, disk.device)
+
+		health := parseSmartOutput(runSmartctl(ctx, args...))
+		if health.status == "" {
+			if verbose {
+				fmt.Printf("DEBUG: No SMART health reported for %s\n", disk.device)
+			}
+			continue
+		}
+		health.name = filepath.Base(disk.device)
+		results = append(results, health)
+	}
+
+	if len(results) == 0 {
+		return ""
+	}
+
+	return formatDiskHealth(results)
+}
+
+// runSmartctl runs smartctl and returns its output.
+// smartctl uses its exit status as a bitmask (e.g., bit 3 is set for failing disks),
+// so the output is returned regardless of the exit code.
+func runSmartctl(ctx context.Context, args ...string) string {
+	ctx, cancel := applyTimeout(ctx, 10*time.Second)
+	if cancel != nil {
+		defer cancel()
+	}
+
+	result, _ := executor.Run(ctx, "smartctl",
+		executor.WithArgs(args...),
+		executor.WithOutputMode(executor.OutputModeCapture),
+	)
+	if result == nil {
+		return ""
+	}
+	return string(result.Stdout)
+}
+
+// parseSmartScan parses `smartctl --scan` output.
+// Lines look like "/dev/sda -d sat # /dev/sda [SAT], ATA device".
+func parseSmartScan(output string) []smartDisk {
+	var disks []smartDisk
+	seen := make(map[string]bool)
+	for line := range strings.Lines(output) {
+		line, _, _ = strings.Cut(line, "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 || !strings.HasPrefix(fields[0], "/dev/") {
+			continue
+		}
+
+		disk := smartDisk{device: fields[0]}
+		if len(fields) >= 3 && fields[1] == "-d" {
+			disk.deviceType = fields[2]
+		}
+
+		key := disk.device + " " + disk.deviceType
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		disks = append(disks, disk)
+	}
+	return disks
+}
+
+// parseSmartOutput extracts the overall health and sector counts from `smartctl -H -A` output
+func parseSmartOutput(output string) smartHealth {
+	var health smartHealth
+	for line := range strings.Lines(output) {
+		line = strings.TrimSpace(line)
+
+		// ATA and NVMe disks
+		if result, ok := strings.CutPrefix(line, "SMART overall-health self-assessment test result:"); ok {
+			health.status = smartStatus(strings.TrimSpace(result))
+			continue
+		}
+		// SCSI and SAS disks
+		if result, ok := strings.CutPrefix(line, "SMART Health Status:"); ok {
+			health.status = smartStatus(strings.TrimSpace(result))
+			continue
+		}
+
+		// ATA attribute table: ID# ATTRIBUTE_NAME FLAG VALUE WORST THRESH TYPE UPDATED WHEN_FAILED RAW_VALUE
+		fields := strings.Fields(line)
+		if len(fields) < 10 {
+			continue
+		}
+		raw, err := strconv.ParseInt(fields[9], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[1] {
+		case "Reallocated_Sector_Ct":
+			health.reallocated = raw
+		case "Current_Pending_Sector":
+			health.pending = raw
+		}
+	}
+	return health
+}
+
+// smartStatus normalizes the health result reported by smartctl to PASSED or FAILED
+func smartStatus(result string) string {
+	switch {
+	case result == "PASSED" || result == "OK":
+		return "PASSED"
+	case strings.HasPrefix(result, "FAILED"):
+		return "FAILED"
+	default:
+		return ""
+	}
+}
+
+// formatDiskHealth formats disk health results on a single line.
+// Failing disks are shown in red and disks with reallocated or pending sectors in yellow.
+func formatDiskHealth(results []smartHealth) string {
+	parts := make([]string, 0, len(results))
+	for _, r := range results {
+		valueStyle := ValueStyle
+		value := r.status
+
+		var warnings []string
+		if r.reallocated > 0 {
+			warnings = append(warnings, fmt.Sprintf("%d reallocated", r.reallocated))
+		}
+		if r.pending > 0 {
+			warnings = append(warnings, fmt.Sprintf("%d pending", r.pending))
+		}
+		if len(warnings) > 0 {
+			valueStyle = WarningStyle
+			value = fmt.Sprintf("%s (%s)", value, strings.Join(warnings, ", "))
+		}
+		if r.status == "FAILED" {
+			valueStyle = ErrorStyle
+		}
+
+		parts = append(parts, fmt.Sprintf("%s: %s",
+			DefaultStyle.Render(r.name),
+			valueStyle.Render(value)))
+	}
+
+	return strings.Join(parts, " | ")
+}
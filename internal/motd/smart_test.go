@@ -0,0 +1,79 @@
+package motd
+
+import (
+	"testing"
+)
+
+func TestParseSmartScan(t *testing.T) {
+	output := `/dev/sda -d sat # /dev/sda [SAT], ATA device
+/dev/sdb -d scsi # /dev/sdb, SCSI device
+/dev/nvme0 -d nvme # /dev/nvme0, NVMe device
+/dev/sda -d sat # duplicate
+`
+
+	disks := parseSmartScan(output)
+	if len(disks) != 3 {
+		t.Fatalf("expected 3 disks, got %d: %+v", len(disks), disks)
+	}
+	if disks[0].device != "/dev/sda" || disks[0].deviceType != "sat" {
+		t.Fatalf("unexpected first disk: %+v", disks[0])
+	}
+	if disks[2].device != "/dev/nvme0" || disks[2].deviceType != "nvme" {
+		t.Fatalf("unexpected last disk: %+v", disks[2])
+	}
+}
+
+func TestParseSmartOutput(t *testing.T) {
+	tests := []struct {
+		name        string
+		output      string
+		status      string
+		reallocated int64
+		pending     int64
+	}{
+		{
+			name: "ata with sector warnings",
+			output: `=== START OF READ SMART DATA SECTION ===
+SMART overall-health self-assessment test result: PASSED
+
+ID# ATTRIBUTE_NAME          FLAG     VALUE WORST THRESH TYPE      UPDATED  WHEN_FAILED RAW_VALUE
+  5 Reallocated_Sector_Ct   0x0033   100   100   010    Pre-fail  Always       -       8
+  9 Power_On_Hours          0x0032   090   090   000    Old_age   Always       -       8760
+197 Current_Pending_Sector  0x0012   100   100   000    Old_age   Always       -       2
+`,
+			status:      "PASSED",
+			reallocated: 8,
+			pending:     2,
+		},
+		{
+			name:   "ata failing",
+			output: "SMART overall-health self-assessment test result: FAILED!\n",
+			status: "FAILED",
+		},
+		{
+			name:   "scsi ok",
+			output: "SMART Health Status: OK\n",
+			status: "PASSED",
+		},
+		{
+			name:   "no smart support",
+			output: "SMART support is: Unavailable - device lacks SMART capability.\n",
+			status: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := parseSmartOutput(tt.output)
+			if health.status != tt.status {
+				t.Errorf("status = %q, want %q", health.status, tt.status)
+			}
+			if health.reallocated != tt.reallocated {
+				t.Errorf("reallocated = %d, want %d", health.reallocated, tt.reallocated)
+			}
+			if health.pending != tt.pending {
+				t.Errorf("pending = %d, want %d", health.pending, tt.pending)
+			}
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"os"
 
 	"github.com/saltyorg/sb-go/internal/config"
-	"github.com/saltyorg/sb-go/internal/constants"
 	"github.com/saltyorg/sb-go/internal/styles"
 
 	"charm.land/lipgloss/v2"
@@ -60,7 +59,7 @@ func createColorStyle(color string) lipgloss.Style {
 
 // InitializeColors loads custom colors from the MOTD config if available
 func InitializeColors() {
-	configPath := constants.SaltboxMOTDConfigPath
+	configPath := config.SB().MOTDConfig
 
 	// Check if config file exists
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/saltyorg/sb-go/internal/config"
-	"github.com/saltyorg/sb-go/internal/constants"
 	"github.com/saltyorg/sb-go/internal/systemd"
 )
 
@@ -21,6 +20,16 @@ var defaultDisplayNames = map[string]string{
 	"saltbox_managed_mergerfs":                 "Mergerfs",
 }
 
+// LoadSystemdConfig loads the systemd settings from the MOTD config file.
+// Returns nil if the config file doesn't exist or has no systemd section configured.
+func LoadSystemdConfig() *config.SystemdConfig {
+	cfg := loadMOTDConfig()
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Systemd
+}
+
 // GetSystemdServicesInfo returns formatted information about systemd services.
 // It uses the default filters (saltbox_managed_* prefix and docker exact match)
 // plus any additional services specified in the MOTD config file.
@@ -28,7 +37,7 @@ func GetSystemdServicesInfo(ctx context.Context, verbose bool) string {
 	// Load config if available
 	var additionalServices []string
 	var userDisplayNames map[string]string
-	configPath := constants.SaltboxMOTDConfigPath
+	configPath := config.SB().MOTDConfig
 
 	if _, err := os.Stat(configPath); err == nil {
 		cfg, err := config.LoadConfig(configPath)
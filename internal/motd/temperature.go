@@ -0,0 +1,224 @@
+package motd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Temperature thresholds in degrees Celsius
+const (
+	temperatureWarning  = 70.0
+	temperatureCritical = 85.0
+)
+
+// hwmonPath is the sysfs directory containing hardware monitoring devices
+const hwmonPath = "/sys/class/hwmon"
+
+// temperatureReading represents a single named temperature sensor reading
+type temperatureReading struct {
+	name    string
+	celsius float64
+}
+
+// GetTemperatures returns CPU package and drive temperatures.
+// It reads hwmon sensors from sysfs and falls back to `sensors -j` when nothing is found.
+// Returns an empty string when no sensors are available (e.g., virtual machines) to hide the section.
+func GetTemperatures(ctx context.Context, verbose bool) string {
+	readings := readHwmonTemperatures(hostPath(hwmonPath))
+	if len(readings) == 0 {
+		if verbose {
+			fmt.Printf("DEBUG: No hwmon temperature sensors found, falling back to sensors -j\n")
+		}
+		output := ExecCommand(ctx, "sensors", "-j")
+		if output != "Not available" && output != "" {
+			readings = parseSensorsJSON([]byte(output))
+		}
+	}
+
+	if len(readings) == 0 {
+		return ""
+	}
+
+	return formatTemperatures(readings)
+}
+
+// readHwmonTemperatures reads CPU and drive temperatures from the hwmon sysfs tree
+func readHwmonTemperatures(root string) []temperatureReading {
+	devices, err := os.ReadDir(root)
+	if err != nil {
+		return nil
+	}
+
+	var readings []temperatureReading
+	for _, device := range devices {
+		devicePath := filepath.Join(root, device.Name())
+		chip := readSysfsString(filepath.Join(devicePath, "name"))
+		if chip == "" {
+			continue
+		}
+
+		inputs, err := filepath.Glob(filepath.Join(devicePath, "temp*_input"))
+		if err != nil {
+			continue
+		}
+		sort.Strings(inputs)
+
+		for _, input := range inputs {
+			raw := readSysfsString(input)
+			millidegrees, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				continue
+			}
+
+			label := readSysfsString(strings.TrimSuffix(input, "_input") + "_label")
+			name, ok := temperatureSensorName(chip, label, hwmonDeviceName(devicePath))
+			if !ok {
+				continue
+			}
+			readings = append(readings, temperatureReading{name: name, celsius: millidegrees / 1000.0})
+		}
+	}
+
+	return readings
+}
+
+// hwmonDeviceName returns the name of the block or NVMe device backing a hwmon entry, if any
+func hwmonDeviceName(devicePath string) string {
+	// drivetemp exposes the block device under device/block/<name>
+	if entries, err := os.ReadDir(filepath.Join(devicePath, "device", "block")); err == nil && len(entries) > 0 {
+		return entries[0].Name()
+	}
+
+	// nvme hwmon devices link directly to the controller (e.g., nvme0)
+	if target, err := filepath.EvalSymlinks(filepath.Join(devicePath, "device")); err == nil {
+		return filepath.Base(target)
+	}
+
+	return ""
+}
+
+// parseSensorsJSON extracts CPU and drive temperatures from `sensors -j` output
+func parseSensorsJSON(data []byte) []temperatureReading {
+	var chips map[string]map[string]any
+	if err := json.Unmarshal(data, &chips); err != nil {
+		return nil
+	}
+
+	chipNames := make([]string, 0, len(chips))
+	for chipName := range chips {
+		chipNames = append(chipNames, chipName)
+	}
+	sort.Strings(chipNames)
+
+	var readings []temperatureReading
+	for _, chipName := range chipNames {
+		// Chip names look like "coretemp-isa-0000" or "nvme-pci-0100"
+		chip, _, _ := strings.Cut(chipName, "-")
+
+		labels := make([]string, 0, len(chips[chipName]))
+		for label := range chips[chipName] {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+
+		for _, label := range labels {
+			values, ok := chips[chipName][label].(map[string]any)
+			if !ok {
+				continue
+			}
+			for field, value := range values {
+				if !strings.HasPrefix(field, "temp") || !strings.HasSuffix(field, "_input") {
+					continue
+				}
+				celsius, ok := value.(float64)
+				if !ok {
+					continue
+				}
+				name, ok := temperatureSensorName(chip, label, "")
+				if !ok {
+					continue
+				}
+				readings = append(readings, temperatureReading{name: name, celsius: celsius})
+			}
+		}
+	}
+
+	return readings
+}
+
+// temperatureSensorName maps a hwmon chip/label pair to a display name.
+// Only CPU package and drive sensors are reported; everything else is skipped.
+func temperatureSensorName(chip, label, device string) (string, bool) {
+	switch chip {
+	case "coretemp":
+		// Intel: only report package sensors, not individual cores
+		if after, ok := strings.CutPrefix(label, "Package id "); ok {
+			return "CPU " + after, true
+		}
+		return "", false
+	case "k10temp", "zenpower":
+		// AMD: Tctl is the control temperature; Tdie is reported on older models
+		if label == "Tctl" || label == "Tdie" {
+			return "CPU", true
+		}
+		return "", false
+	case "cpu_thermal":
+		return "CPU", true
+	case "nvme":
+		if label == "" || label == "Composite" {
+			if device == "" {
+				return "NVMe", true
+			}
+			return device, true
+		}
+		return "", false
+	case "drivetemp":
+		if device == "" {
+			return "Drive", true
+		}
+		return device, true
+	default:
+		return "", false
+	}
+}
+
+// formatTemperatures formats readings on a single line with threshold coloring
+func formatTemperatures(readings []temperatureReading) string {
+	// Collapse duplicate names (e.g., both Tctl and Tdie) keeping the first reading
+	seen := make(map[string]bool, len(readings))
+	var parts []string
+	for _, r := range readings {
+		if seen[r.name] {
+			continue
+		}
+		seen[r.name] = true
+
+		valueStyle := ValueStyle
+		if r.celsius > temperatureCritical {
+			valueStyle = ErrorStyle
+		} else if r.celsius > temperatureWarning {
+			valueStyle = WarningStyle
+		}
+
+		parts = append(parts, fmt.Sprintf("%s: %s",
+			DefaultStyle.Render(r.name),
+			valueStyle.Render(fmt.Sprintf("%.0f°C", r.celsius))))
+	}
+
+	return strings.Join(parts, " | ")
+}
+
+// readSysfsString reads a sysfs attribute and returns its trimmed content
+func readSysfsString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,67 @@
+package motd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSysfsFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestReadHwmonTemperatures(t *testing.T) {
+	root := t.TempDir()
+
+	writeSysfsFile(t, filepath.Join(root, "hwmon0", "name"), "coretemp\n")
+	writeSysfsFile(t, filepath.Join(root, "hwmon0", "temp1_input"), "45000\n")
+	writeSysfsFile(t, filepath.Join(root, "hwmon0", "temp1_label"), "Package id 0\n")
+	writeSysfsFile(t, filepath.Join(root, "hwmon0", "temp2_input"), "44000\n")
+	writeSysfsFile(t, filepath.Join(root, "hwmon0", "temp2_label"), "Core 0\n")
+
+	writeSysfsFile(t, filepath.Join(root, "hwmon1", "name"), "drivetemp\n")
+	writeSysfsFile(t, filepath.Join(root, "hwmon1", "temp1_input"), "72000\n")
+	writeSysfsFile(t, filepath.Join(root, "hwmon1", "device", "block", "sda", "size"), "0\n")
+
+	writeSysfsFile(t, filepath.Join(root, "hwmon2", "name"), "acpitz\n")
+	writeSysfsFile(t, filepath.Join(root, "hwmon2", "temp1_input"), "27800\n")
+
+	readings := readHwmonTemperatures(root)
+	if len(readings) != 2 {
+		t.Fatalf("expected 2 readings, got %d: %+v", len(readings), readings)
+	}
+	if readings[0].name != "CPU 0" || readings[0].celsius != 45 {
+		t.Fatalf("unexpected CPU reading: %+v", readings[0])
+	}
+	if readings[1].name != "sda" || readings[1].celsius != 72 {
+		t.Fatalf("unexpected drive reading: %+v", readings[1])
+	}
+}
+
+func TestParseSensorsJSON(t *testing.T) {
+	data := []byte(`{
+		"k10temp-pci-00c3": {"Adapter": "PCI adapter", "Tctl": {"temp1_input": 88.6}},
+		"nvme-pci-0100": {"Adapter": "PCI adapter", "Composite": {"temp1_input": 38.85, "temp1_max": 81.85}},
+		"acpitz-acpi-0": {"Adapter": "ACPI interface", "temp1": {"temp1_input": 27.8}}
+	}`)
+
+	readings := parseSensorsJSON(data)
+	if len(readings) != 2 {
+		t.Fatalf("expected 2 readings, got %d: %+v", len(readings), readings)
+	}
+
+	out := formatTemperatures(readings)
+	if !strings.Contains(out, "CPU") || !strings.Contains(out, "89°C") {
+		t.Fatalf("expected CPU reading in output, got %q", out)
+	}
+	if !strings.Contains(out, "NVMe") || !strings.Contains(out, "39°C") {
+		t.Fatalf("expected NVMe reading in output, got %q", out)
+	}
+}
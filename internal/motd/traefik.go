@@ -0,0 +1,125 @@
+package motd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/config"
+)
+
+// defaultTraefikURL is the API address of the Saltbox Traefik container
+const defaultTraefikURL = "http://traefik:8080"
+
+// traefikRoutersPerPage is the page size requested from the routers API
+const traefikRoutersPerPage = 100
+
+// TraefikRouter is a single HTTP router as reported by the Traefik API
+type TraefikRouter struct {
+	Name        string          `json:"name"`
+	Status      string          `json:"status"`
+	Rule        string          `json:"rule"`
+	Service     string          `json:"service"`
+	Provider    string          `json:"provider"`
+	EntryPoints []string        `json:"entryPoints"`
+	Error       json.RawMessage `json:"error,omitempty"`
+}
+
+// Problem returns why the router needs attention, or an empty string if it's healthy
+func (r TraefikRouter) Problem() string {
+	if errMsg := extractTraefikRouterError(r.Error); errMsg != "" {
+		return errMsg
+	}
+	if r.Status == "disabled" {
+		return "router is disabled"
+	}
+	return ""
+}
+
+// traefikAPIConfig returns the traefik section of the MOTD config, with the defaults filled in
+func traefikAPIConfig() config.TraefikAPIConfig {
+	var apiConfig config.TraefikAPIConfig
+	if cfg := loadMOTDConfig(); cfg != nil && cfg.Traefik != nil {
+		apiConfig = *cfg.Traefik
+	}
+	if apiConfig.URL == "" {
+		apiConfig.URL = defaultTraefikURL
+	}
+	if apiConfig.Timeout <= 0 {
+		apiConfig.Timeout = 3
+	}
+	apiConfig.URL = strings.TrimSuffix(apiConfig.URL, "/")
+	return apiConfig
+}
+
+// TraefikAPIURL returns the configured Traefik API base URL, or the Saltbox default
+func TraefikAPIURL() string {
+	return traefikAPIConfig().URL
+}
+
+// FetchTraefikRouters returns all HTTP routers from the Traefik API.
+// The API address, credentials and timeout come from the traefik section of the MOTD config.
+func FetchTraefikRouters(ctx context.Context) ([]TraefikRouter, error) {
+	return fetchTraefikRouters(ctx, traefikAPIConfig())
+}
+
+// fetchTraefikRouters fetches every page of HTTP routers from the Traefik API described by apiConfig
+func fetchTraefikRouters(ctx context.Context, apiConfig config.TraefikAPIConfig) ([]TraefikRouter, error) {
+	client := &http.Client{Timeout: time.Duration(apiConfig.Timeout) * time.Second}
+
+	var routers []TraefikRouter
+	for page := 1; ; {
+		query := url.Values{"page": {strconv.Itoa(page)}, "per_page": {strconv.Itoa(traefikRoutersPerPage)}}
+		pageRouters, nextPage, err := fetchTraefikRoutersPage(ctx, client, apiConfig.URL+"/api/http/routers?"+query.Encode(), apiConfig.User, apiConfig.Password)
+		if err != nil {
+			return nil, err
+		}
+		routers = append(routers, pageRouters...)
+
+		// Traefik points the next page back to the first one after the last page
+		if nextPage <= page {
+			return routers, nil
+		}
+		page = nextPage
+	}
+}
+
+// fetchTraefikRoutersPage fetches a single page of routers, returning the next page number from the X-Next-Page header
+func fetchTraefikRoutersPage(ctx context.Context, client *http.Client, pageURL, user, password string) ([]TraefikRouter, int, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	if user != "" {
+		req.SetBasicAuth(user, password)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to connect to Traefik: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("traefik API returned status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var routers []TraefikRouter
+	if err := json.Unmarshal(body, &routers); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse Traefik router response: %w", err)
+	}
+
+	nextPage, _ := strconv.Atoi(resp.Header.Get("X-Next-Page"))
+	return routers, nextPage, nil
+}
@@ -0,0 +1,65 @@
+package motd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/saltyorg/sb-go/internal/config"
+)
+
+func TestFetchTraefikRoutersFollowsPages(t *testing.T) {
+	pages := map[string][]TraefikRouter{
+		"1": {{Name: "sonarr@docker", Status: "enabled", Rule: "Host(`sonarr.example.com`)", Service: "sonarr"}},
+		"2": {{Name: "radarr@docker", Status: "disabled", Error: json.RawMessage(`["the service \"radarr\" does not exist"]`)}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		page := r.URL.Query().Get("page")
+		if r.URL.Path != "/api/http/routers" || r.URL.Query().Get("per_page") != strconv.Itoa(traefikRoutersPerPage) {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		// Like Traefik, the last page points back to the first one
+		next := "1"
+		if page == "1" {
+			next = "2"
+		}
+		w.Header().Set("X-Next-Page", next)
+		_ = json.NewEncoder(w).Encode(pages[page])
+	}))
+	defer server.Close()
+
+	routers, err := fetchTraefikRouters(context.Background(), config.TraefikAPIConfig{URL: server.URL, User: "admin", Password: "secret", Timeout: 1})
+	if err != nil {
+		t.Fatalf("fetchTraefikRouters unexpected error: %v", err)
+	}
+	if len(routers) != 2 || routers[0].Rule != "Host(`sonarr.example.com`)" || routers[1].Name != "radarr@docker" {
+		t.Fatalf("routers = %+v, want both pages", routers)
+	}
+
+	if problem := routers[0].Problem(); problem != "" {
+		t.Errorf("healthy router problem = %q", problem)
+	}
+	if problem, want := routers[1].Problem(), `the service "radarr" does not exist`; problem != want {
+		t.Errorf("broken router problem = %q, want %q", problem, want)
+	}
+
+	if _, err := fetchTraefikRouters(context.Background(), config.TraefikAPIConfig{URL: server.URL, Timeout: 1}); err == nil {
+		t.Error("fetchTraefikRouters without credentials succeeded")
+	}
+}
+
+func TestTraefikRouterProblem(t *testing.T) {
+	if problem := (TraefikRouter{Status: "disabled"}).Problem(); problem != "router is disabled" {
+		t.Errorf("disabled router problem = %q", problem)
+	}
+	if problem := (TraefikRouter{Status: "enabled", Error: json.RawMessage(`null`)}).Problem(); problem != "" {
+		t.Errorf("enabled router problem = %q", problem)
+	}
+}
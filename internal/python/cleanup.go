@@ -168,18 +168,13 @@ func RemoveDeadsnakesPackages(ctx context.Context, pythonVersion string, verbose
 // ShouldCleanupDeadsnakes checks if the system should clean up deadsnakes packages
 // Returns true if the system is Ubuntu 20.04 or 22.04
 func ShouldCleanupDeadsnakes() (bool, error) {
-	osRelease, err := ubuntu.ParseOSRelease("/etc/os-release")
+	release, err := ubuntu.CurrentRelease()
 	if err != nil {
-		return false, fmt.Errorf("error parsing OS release: %w", err)
+		return false, fmt.Errorf("error detecting Ubuntu release: %w", err)
 	}
 
-	versionID, ok := osRelease["VERSION_ID"]
-	if !ok {
-		return false, nil
-	}
-
-	// Only clean up on Ubuntu 20.04 (focal) and 22.04 (jammy)
-	return versionID == "20.04" || versionID == "22.04", nil
+	// Only clean up on Ubuntu 20.04 (focal) and 22.04 (jammy), including derivatives based on them
+	return release.Version == "20.04" || release.Version == "22.04", nil
 }
 
 // CleanupDeadsnakesIfNeeded checks if cleanup is needed and performs it.
@@ -0,0 +1,112 @@
+// Package retry runs operations that can fail temporarily, like network requests, with
+// bounded retries and exponential backoff.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// Options controls how Do retries an operation
+type Options struct {
+	MaxAttempts int              // Attempts including the first, a single attempt when 0 or less
+	BaseDelay   time.Duration    // Delay before the first retry, doubled for every further retry
+	MaxDelay    time.Duration    // Upper bound of a single delay, unbounded when 0
+	Jitter      float64          // Fraction of each delay that is randomized, e.g. 0.2 for ±20%
+	Retryable   func(error) bool // Reports whether an error is worth retrying, all errors are when nil
+	// RetryAfter returns the delay a server asked for with an error, e.g. in a Retry-After
+	// header, which is waited instead of the backoff
+	RetryAfter func(error) (time.Duration, bool)
+	// OnRetry is called before waiting for the next attempt, e.g. to log the failure
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+// permanentError marks an error that is never retried
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so Do returns it right away, whatever the Retryable predicate says.
+// Do unwraps it again, so callers see err itself.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// wait sleeps for d or until ctx is done, replaced in tests
+var wait = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Do calls fn until it succeeds, returns an error that isn't retryable, or opts.MaxAttempts
+// attempts were made, and returns the last error. Waiting between attempts stops early when
+// ctx is done, in which case the last error is returned along with the cancellation.
+func Do(ctx context.Context, opts Options, fn func(ctx context.Context) error) error {
+	attempts := max(opts.MaxAttempts, 1)
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		if permanent, ok := errors.AsType[permanentError](err); ok {
+			return permanent.err
+		}
+		if attempt == attempts || (opts.Retryable != nil && !opts.Retryable(err)) || ctx.Err() != nil {
+			return err
+		}
+
+		delay := opts.delay(attempt)
+		if opts.RetryAfter != nil {
+			if after, ok := opts.RetryAfter(err); ok {
+				delay = after
+			}
+		}
+		if opts.OnRetry != nil {
+			opts.OnRetry(attempt, err, delay)
+		}
+		if waitErr := wait(ctx, delay); waitErr != nil {
+			return fmt.Errorf("%w (retry cancelled: %v)", err, waitErr)
+		}
+	}
+}
+
+// delay returns the backoff before the retry following attempt, with jitter applied
+func (o Options) delay(attempt int) time.Duration {
+	delay := o.BaseDelay
+	for range attempt - 1 {
+		if o.MaxDelay > 0 && delay >= o.MaxDelay {
+			break
+		}
+		delay *= 2
+	}
+	if o.MaxDelay > 0 {
+		delay = min(delay, o.MaxDelay)
+	}
+	if o.Jitter > 0 {
+		delay += time.Duration((rand.Float64()*2 - 1) * o.Jitter * float64(delay))
+	}
+	return max(delay, 0)
+}
+
+// RetryableStatus reports whether an HTTP response with the given status code is worth
+// retrying: rate limiting and server errors are, client errors like bad credentials aren't
+func RetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code == http.StatusRequestTimeout || code >= 500
+}
@@ -0,0 +1,247 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// recordWaits replaces wait with one that returns right away, recording the requested delays
+func recordWaits(t *testing.T) *[]time.Duration {
+	t.Helper()
+	previous := wait
+	t.Cleanup(func() { wait = previous })
+
+	var delays []time.Duration
+	wait = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return ctx.Err()
+	}
+	return &delays
+}
+
+func TestDoSucceedsAfterRetries(t *testing.T) {
+	delays := recordWaits(t)
+
+	calls := 0
+	var retried []int
+	err := Do(context.Background(), Options{
+		MaxAttempts: 5,
+		BaseDelay:   time.Second,
+		OnRetry:     func(attempt int, err error, delay time.Duration) { retried = append(retried, attempt) },
+	}, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("temporary")
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+	if want := []time.Duration{time.Second, 2 * time.Second}; !equalDurations(*delays, want) {
+		t.Errorf("delays = %v, want %v", *delays, want)
+	}
+	if len(retried) != 2 || retried[0] != 1 || retried[1] != 2 {
+		t.Errorf("OnRetry attempts = %v, want [1 2]", retried)
+	}
+}
+
+func TestDoWaitsRetryAfter(t *testing.T) {
+	delays := recordWaits(t)
+
+	errLimited := errors.New("rate limited")
+	calls := 0
+	err := Do(context.Background(), Options{
+		MaxAttempts: 3,
+		BaseDelay:   time.Second,
+		RetryAfter: func(err error) (time.Duration, bool) {
+			return 30 * time.Second, errors.Is(err, errLimited)
+		},
+	}, func(ctx context.Context) error {
+		calls++
+		if calls == 1 {
+			return errLimited
+		}
+		return errors.New("temporary")
+	})
+
+	if err == nil {
+		t.Fatal("Do() = nil, want the last error")
+	}
+	// The server's delay replaces the first backoff, later failures back off as usual
+	if want := []time.Duration{30 * time.Second, 2 * time.Second}; !equalDurations(*delays, want) {
+		t.Errorf("delays = %v, want %v", *delays, want)
+	}
+}
+
+func TestDoReturnsLastErrorAfterMaxAttempts(t *testing.T) {
+	recordWaits(t)
+
+	calls := 0
+	err := Do(context.Background(), Options{MaxAttempts: 3}, func(ctx context.Context) error {
+		calls++
+		return errors.New("attempt failed")
+	})
+
+	if err == nil || err.Error() != "attempt failed" {
+		t.Errorf("Do() = %v, want the last error", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+}
+
+func TestDoSingleAttemptByDefault(t *testing.T) {
+	delays := recordWaits(t)
+
+	calls := 0
+	_ = Do(context.Background(), Options{}, func(ctx context.Context) error {
+		calls++
+		return errors.New("failed")
+	})
+
+	if calls != 1 || len(*delays) != 0 {
+		t.Errorf("calls = %d, waits = %d, want 1 call and no waits", calls, len(*delays))
+	}
+}
+
+func TestDoStopsOnNonRetryableError(t *testing.T) {
+	recordWaits(t)
+
+	errFatal := errors.New("fatal")
+	calls := 0
+	err := Do(context.Background(), Options{
+		MaxAttempts: 5,
+		Retryable:   func(err error) bool { return !errors.Is(err, errFatal) },
+	}, func(ctx context.Context) error {
+		calls++
+		if calls == 2 {
+			return errFatal
+		}
+		return errors.New("temporary")
+	})
+
+	if !errors.Is(err, errFatal) {
+		t.Errorf("Do() = %v, want %v", err, errFatal)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestDoStopsOnPermanentError(t *testing.T) {
+	recordWaits(t)
+
+	errRejected := errors.New("rejected")
+	calls := 0
+	err := Do(context.Background(), Options{MaxAttempts: 5}, func(ctx context.Context) error {
+		calls++
+		return Permanent(errRejected)
+	})
+
+	if err != errRejected {
+		t.Errorf("Do() = %v, want the unwrapped error", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+	if Permanent(nil) != nil {
+		t.Error("Permanent(nil) != nil")
+	}
+}
+
+func TestDoStopsWhenContextIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errTemporary := errors.New("temporary")
+	calls := 0
+	err := Do(ctx, Options{MaxAttempts: 5, BaseDelay: time.Hour}, func(ctx context.Context) error {
+		calls++
+		cancel()
+		return errTemporary
+	})
+
+	if !errors.Is(err, errTemporary) {
+		t.Errorf("Do() = %v, want it to wrap the last error", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestDoCancelledWhileWaiting(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	errTemporary := errors.New("temporary")
+	err := Do(ctx, Options{MaxAttempts: 5, BaseDelay: time.Hour}, func(ctx context.Context) error {
+		return errTemporary
+	})
+
+	if !errors.Is(err, errTemporary) {
+		t.Errorf("Do() = %v, want it to wrap the last error", err)
+	}
+}
+
+func TestDelay(t *testing.T) {
+	opts := Options{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := opts.delay(i + 1); got != w {
+			t.Errorf("delay(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+
+	// A long run of retries must not overflow past the cap
+	if got := opts.delay(100); got != 5*time.Second {
+		t.Errorf("delay(100) = %v, want %v", got, 5*time.Second)
+	}
+}
+
+func TestDelayJitter(t *testing.T) {
+	opts := Options{BaseDelay: time.Second, Jitter: 0.2}
+	for range 100 {
+		if got := opts.delay(2); got < 1600*time.Millisecond || got > 2400*time.Millisecond {
+			t.Fatalf("delay(2) = %v, want within 20%% of 2s", got)
+		}
+	}
+}
+
+func TestRetryableStatus(t *testing.T) {
+	tests := map[int]bool{
+		200: false,
+		400: false,
+		401: false,
+		403: false,
+		404: false,
+		408: true,
+		429: true,
+		500: true,
+		502: true,
+		503: true,
+	}
+	for code, want := range tests {
+		if got := RetryableStatus(code); got != want {
+			t.Errorf("RetryableStatus(%d) = %v, want %v", code, got, want)
+		}
+	}
+}
+
+func equalDurations(a, b []time.Duration) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,177 @@
+package setup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfigFile pairs a .default template with the config file created from it
+type defaultConfigFile struct {
+	defaultPath string
+	configPath  string
+}
+
+// defaultConfigFiles lists the .default templates in the defaults folder of saltboxPath
+func defaultConfigFiles(saltboxPath string) ([]defaultConfigFile, error) {
+	matches, err := filepath.Glob(filepath.Join(saltboxPath, "defaults", "*.default"))
+	if err != nil {
+		return nil, fmt.Errorf("error listing default config files: %w", err)
+	}
+
+	files := make([]defaultConfigFile, 0, len(matches))
+	for _, match := range matches {
+		files = append(files, defaultConfigFile{
+			defaultPath: match,
+			configPath:  filepath.Join(saltboxPath, strings.TrimSuffix(filepath.Base(match), ".default")),
+		})
+	}
+	return files, nil
+}
+
+// ConfigKey is a setting in a config file, identified by its dotted key path
+type ConfigKey struct {
+	Path string
+	// Value is the YAML rendering of the setting's value
+	Value string
+}
+
+// ConfigDiff lists the settings in which a config file differs from its .default template.
+// Values are not compared, so settings the user has changed are not reported.
+type ConfigDiff struct {
+	// Path is the path of the config file
+	Path string
+	// Added lists the settings present in the template but missing from the config file
+	Added []ConfigKey
+	// Removed lists the settings present in the config file but no longer in the template
+	Removed []ConfigKey
+}
+
+// InSync reports whether the config file has the same settings as its template
+func (d ConfigDiff) InSync() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// DiffDefaultConfigFiles compares the existing config files in saltboxPath with their .default templates.
+// Config files that haven't been created yet are skipped, since CopyDefaultConfigFiles creates them
+// from the template.
+func DiffDefaultConfigFiles(saltboxPath string) ([]ConfigDiff, error) {
+	files, err := defaultConfigFiles(saltboxPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []ConfigDiff
+	for _, file := range files {
+		current, err := os.ReadFile(file.configPath)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", file.configPath, err)
+		}
+
+		defaults, err := os.ReadFile(file.defaultPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", file.defaultPath, err)
+		}
+
+		diff, err := diffConfig(current, defaults)
+		if err != nil {
+			return nil, fmt.Errorf("error comparing %s with %s: %w", file.configPath, filepath.Base(file.defaultPath), err)
+		}
+		diff.Path = file.configPath
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
+
+// diffConfig compares the keys of a config file with those of its template
+func diffConfig(current, defaults []byte) (ConfigDiff, error) {
+	currentRoot, err := parseConfigMapping(current)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+	defaultRoot, err := parseConfigMapping(defaults)
+	if err != nil {
+		return ConfigDiff{}, err
+	}
+
+	var diff ConfigDiff
+	diffMappings(currentRoot, defaultRoot, "", &diff)
+	return diff, nil
+}
+
+// parseConfigMapping parses a YAML document and returns its top-level mapping.
+// An empty document is treated as an empty mapping.
+func parseConfigMapping(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.MappingNode}, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("expected a mapping at the top level")
+	}
+	return root, nil
+}
+
+// diffMappings records the keys missing from either mapping, descending into mappings present in both.
+// Other values, including lists, are user settings and only compared by key.
+func diffMappings(current, defaults *yaml.Node, prefix string, diff *ConfigDiff) {
+	currentValues := mappingValues(current)
+	defaultValues := mappingValues(defaults)
+
+	for i := 0; i+1 < len(defaults.Content); i += 2 {
+		key := defaults.Content[i].Value
+		path := joinKeyPath(prefix, key)
+		defaultValue := defaults.Content[i+1]
+
+		currentValue, ok := currentValues[key]
+		if !ok {
+			diff.Added = append(diff.Added, ConfigKey{Path: path, Value: renderConfigValue(defaultValue)})
+			continue
+		}
+		if currentValue.Kind == yaml.MappingNode && defaultValue.Kind == yaml.MappingNode {
+			diffMappings(currentValue, defaultValue, path, diff)
+		}
+	}
+
+	for i := 0; i+1 < len(current.Content); i += 2 {
+		key := current.Content[i].Value
+		if _, ok := defaultValues[key]; !ok {
+			diff.Removed = append(diff.Removed, ConfigKey{Path: joinKeyPath(prefix, key), Value: renderConfigValue(current.Content[i+1])})
+		}
+	}
+}
+
+// mappingValues indexes the values of a mapping node by key
+func mappingValues(node *yaml.Node) map[string]*yaml.Node {
+	values := make(map[string]*yaml.Node, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		values[node.Content[i].Value] = node.Content[i+1]
+	}
+	return values
+}
+
+func joinKeyPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// renderConfigValue renders a value as YAML without the trailing newline
+func renderConfigValue(node *yaml.Node) string {
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return node.Value
+	}
+	return strings.TrimSuffix(string(data), "\n")
+}
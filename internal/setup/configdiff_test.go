@@ -0,0 +1,93 @@
+package setup
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestDiffConfig(t *testing.T) {
+	current := []byte(`
+plex:
+  user: seed
+  pass: secret
+apps:
+  - sonarr
+legacy_option: yes
+`)
+	defaults := []byte(`
+plex:
+  user: user
+  pass: password
+  tag: latest
+apps:
+  - radarr
+  - lidarr
+dns:
+  ipv6: no
+  proxied: yes
+`)
+
+	diff, err := diffConfig(current, defaults)
+	if err != nil {
+		t.Fatalf("diffConfig unexpected error: %v", err)
+	}
+
+	wantAdded := []ConfigKey{
+		{Path: "plex.tag", Value: "latest"},
+		{Path: "dns", Value: "ipv6: no\nproxied: yes"},
+	}
+	if !slices.Equal(diff.Added, wantAdded) {
+		t.Errorf("Added = %q, want %q", diff.Added, wantAdded)
+	}
+
+	wantRemoved := []ConfigKey{{Path: "legacy_option", Value: "yes"}}
+	if !slices.Equal(diff.Removed, wantRemoved) {
+		t.Errorf("Removed = %q, want %q", diff.Removed, wantRemoved)
+	}
+}
+
+func TestDiffConfigInSync(t *testing.T) {
+	diff, err := diffConfig([]byte("a:\n  b: 1\n"), []byte("a:\n  b: 2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.InSync() {
+		t.Errorf("changed values reported as differences: %+v", diff)
+	}
+
+	if _, err := diffConfig([]byte("- a\n"), []byte("a: 1\n")); err == nil {
+		t.Error("expected error for a config that isn't a mapping")
+	}
+}
+
+func TestDiffDefaultConfigFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"defaults/settings.yml.default":      "a: 1\nb: 2\n",
+		"defaults/backup_config.yml.default": "c: 3\n",
+		"settings.yml":                       "a: 5\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	diffs, err := DiffDefaultConfigFiles(dir)
+	if err != nil {
+		t.Fatalf("DiffDefaultConfigFiles unexpected error: %v", err)
+	}
+	// backup_config.yml doesn't exist yet, so only settings.yml is compared
+	if len(diffs) != 1 || diffs[0].Path != filepath.Join(dir, "settings.yml") {
+		t.Fatalf("DiffDefaultConfigFiles = %+v, want a single diff for settings.yml", diffs)
+	}
+	if want := []ConfigKey{{Path: "b", Value: "2"}}; !slices.Equal(diffs[0].Added, want) {
+		t.Errorf("Added = %q, want %q", diffs[0].Added, want)
+	}
+}
@@ -0,0 +1,97 @@
+package setup
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// pipProgress turns pip install output into a short status naming the package pip is working on.
+// pip doesn't announce how many packages it will collect, so the total is estimated from the
+// requirements file and dropped once dependencies push the count past it.
+type pipProgress struct {
+	total     int // Estimated number of packages, 0 if unknown
+	collected int
+}
+
+// countRequirements returns the number of requirements in a pip requirements file,
+// skipping blank lines, comments and options. Returns 0 if the file can't be read.
+func countRequirements(path string) int {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer func() { _ = file.Close() }()
+
+	count := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+// parseLine returns the status for a line of pip output. Recognizes collecting, downloading,
+// wheel building and installing lines, and returns false for any other line.
+func (p *pipProgress) parseLine(line string) (string, bool) {
+	line = strings.TrimSpace(ansi.Strip(line))
+
+	// Collecting ansible==10.7.0 (from -r requirements-saltbox.txt (line 1))
+	// Requirement already satisfied: jinja2>=3.0.0 in /srv/ansible/venv/lib/python3.12/site-packages
+	for _, prefix := range []string{"Collecting ", "Requirement already satisfied: "} {
+		rest, ok := strings.CutPrefix(line, prefix)
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			return "", false
+		}
+		p.collected++
+		return fmt.Sprintf("Collecting %s (%s)", requirementName(fields[0]), p.count()), true
+	}
+
+	// Downloading ansible-10.7.0-py3-none-any.whl (51.6 MB)
+	if rest, ok := strings.CutPrefix(line, "Downloading "); ok && rest != "" {
+		return fmt.Sprintf("Downloading %s (%s)", rest, p.count()), true
+	}
+
+	// Building wheel for pyyaml (pyproject.toml): started
+	if rest, ok := strings.CutPrefix(line, "Building wheel for "); ok {
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			return "", false
+		}
+		return "Building wheel for " + fields[0], true
+	}
+
+	// Installing collected packages: MarkupSafe, PyYAML, jinja2, ansible-core, ansible
+	if rest, ok := strings.CutPrefix(line, "Installing collected packages: "); ok {
+		return fmt.Sprintf("Installing %d collected packages", len(strings.Split(rest, ","))), true
+	}
+
+	return "", false
+}
+
+// count formats the number of collected packages against the estimated total
+func (p *pipProgress) count() string {
+	if p.total > 0 && p.collected <= p.total {
+		return fmt.Sprintf("package %d/%d", p.collected, p.total)
+	}
+	return fmt.Sprintf("package %d", p.collected)
+}
+
+// requirementName strips the version specifier, extras and markers from a requirement
+func requirementName(requirement string) string {
+	if i := strings.IndexAny(requirement, "=<>!~[;@ "); i > 0 {
+		return requirement[:i]
+	}
+	return requirement
+}
@@ -0,0 +1,46 @@
+package setup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCountRequirements(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "requirements.txt")
+	content := "# Saltbox requirements\nansible==10.7.0\n\n--extra-index-url https://example.com\njmespath\n  docker>=7.0 ; python_version >= '3.8'\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := countRequirements(path); got != 3 {
+		t.Errorf("countRequirements() = %d, want 3", got)
+	}
+	if got := countRequirements(filepath.Join(t.TempDir(), "missing.txt")); got != 0 {
+		t.Errorf("countRequirements(missing) = %d, want 0", got)
+	}
+}
+
+func TestPipProgressParseLine(t *testing.T) {
+	progress := &pipProgress{total: 2}
+	steps := []struct {
+		line string
+		want string
+		ok   bool
+	}{
+		{"Collecting ansible==10.7.0 (from -r requirements-saltbox.txt (line 1))", "Collecting ansible (package 1/2)", true},
+		{"  Downloading ansible-10.7.0-py3-none-any.whl (51.6 MB)", "Downloading ansible-10.7.0-py3-none-any.whl (51.6 MB) (package 1/2)", true},
+		{"     \x1b[38;5;197m━━━━━━━━━━━━\x1b[0m 51.6/51.6 MB 20.1 MB/s eta 0:00:00", "", false},
+		{"Requirement already satisfied: jinja2>=3.0.0 in /srv/ansible/venv/lib/python3.12/site-packages", "Collecting jinja2 (package 2/2)", true},
+		{"Collecting PyYAML[libyaml]>=5.1 (from ansible-core)", "Collecting PyYAML (package 3)", true},
+		{"  Building wheel for pyyaml (pyproject.toml): started", "Building wheel for pyyaml", true},
+		{"Installing collected packages: PyYAML, jinja2, ansible", "Installing 3 collected packages", true},
+		{"Successfully installed PyYAML-6.0.2 ansible-10.7.0 jinja2-3.1.4", "", false},
+	}
+
+	for _, step := range steps {
+		got, ok := progress.parseLine(step.line)
+		if got != step.want || ok != step.ok {
+			t.Errorf("parseLine(%q) = %q, %v, want %q, %v", step.line, got, ok, step.want, step.ok)
+		}
+	}
+}
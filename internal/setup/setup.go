@@ -18,10 +18,32 @@ import (
 	"github.com/saltyorg/sb-go/internal/uv"
 )
 
+// initialPackages are the apt packages installed by InitialSetup.
+// git and curl are needed by the later setup phases, software-properties-common provides
+// add-apt-repository and the rest are build and runtime dependencies of Saltbox.
+var initialPackages = []string{
+	"git", "curl", "software-properties-common", "apt-transport-https",
+	"locales", "nano", "wget", "jq", "file", "gpg-agent", "libpq-dev",
+	"build-essential", "libssl-dev", "libffi-dev", "python3-dev",
+	"python3-testresources", "python3-apt", "python3-venv", "python3-pip",
+}
+
 // InitialSetup performs the initial setup tasks.
 // The context parameter allows for cancellation of long-running operations.
+//
+// Ordering constraints: the apt repositories must be configured and the package cache updated
+// before any install, since some packages come from universe. Configuring the repositories only
+// writes files, so it doesn't depend on any package being installed, which lets all packages be
+// installed in a single apt-get run after a single cache update.
 func InitialSetup(ctx context.Context, task *spinners.Task, verbose bool) error {
-	// Update apt cache
+	// Add apt repos
+	if err := task.RunStreaming(ctx, spinners.TaskSpec{Running: "Adding apt repositories"}, func(taskCtx context.Context) error {
+		return apt.AddAptRepositories(taskCtx, verbose)
+	}); err != nil {
+		return fmt.Errorf("error adding apt repositories: %w", err)
+	}
+
+	// Update apt cache with the configured repositories
 	if err := task.RunStreaming(ctx, spinners.TaskSpec{Running: "Updating apt package cache"}, func(taskCtx context.Context) error {
 		updateCache := apt.UpdatePackageLists(taskCtx, verbose)
 		return updateCache()
@@ -29,14 +51,6 @@ func InitialSetup(ctx context.Context, task *spinners.Task, verbose bool) error
 		return fmt.Errorf("error updating apt cache: %w", err)
 	}
 
-	// Install git and curl
-	if err := task.RunStreaming(ctx, spinners.TaskSpec{Running: "Installing git and curl"}, func(taskCtx context.Context) error {
-		installGitCurl := apt.InstallPackage(taskCtx, []string{"git", "curl"}, verbose)
-		return installGitCurl()
-	}); err != nil {
-		return fmt.Errorf("error installing git and curl: %w", err)
-	}
-
 	// Create /srv/git directory
 	dir := constants.SaltboxGitPath
 	if err := task.Run(ctx, spinners.TaskSpec{Running: fmt.Sprintf("Creating directory %s", dir)}, func(context.Context, *spinners.Task) error {
@@ -53,40 +67,12 @@ func InitialSetup(ctx context.Context, task *spinners.Task, verbose bool) error
 		return fmt.Errorf("error creating %s: %w", dir, err)
 	}
 
-	// Install software-properties-common and apt-transport-https
-	if err := task.RunStreaming(ctx, spinners.TaskSpec{Running: "Installing software-properties-common and apt-transport-https"}, func(taskCtx context.Context) error {
-		installPropsTransport := apt.InstallPackage(taskCtx, []string{"software-properties-common", "apt-transport-https"}, verbose)
-		return installPropsTransport()
-	}); err != nil {
-		return fmt.Errorf("error installing software-properties-common and apt-transport-https: %w", err)
-	}
-
-	// Add apt repos
-	if err := task.RunStreaming(ctx, spinners.TaskSpec{Running: "Adding apt repositories"}, func(taskCtx context.Context) error {
-		return apt.AddAptRepositories(taskCtx, verbose)
-	}); err != nil {
-		return fmt.Errorf("error adding apt repositories: %w", err)
-	}
-
-	// Update apt cache again after adding repositories
-	if err := task.RunStreaming(ctx, spinners.TaskSpec{Running: "Updating apt package cache again"}, func(taskCtx context.Context) error {
-		updateCacheAgain := apt.UpdatePackageLists(taskCtx, verbose)
-		return updateCacheAgain()
-	}); err != nil {
-		return fmt.Errorf("error updating apt cache: %w", err)
-	}
-
-	// Install additional required packages.
-	if err := task.RunStreaming(ctx, spinners.TaskSpec{Running: "Installing additional required packages"}, func(taskCtx context.Context) error {
-		packages := []string{
-			"locales", "nano", "wget", "jq", "file", "gpg-agent", "libpq-dev",
-			"build-essential", "libssl-dev", "libffi-dev", "python3-dev",
-			"python3-testresources", "python3-apt", "python3-venv", "python3-pip",
-		}
-		installPackages := apt.InstallPackage(taskCtx, packages, verbose)
+	// Install all required packages in one apt-get run
+	if err := task.RunStreaming(ctx, spinners.TaskSpec{Running: "Installing required packages"}, func(taskCtx context.Context) error {
+		installPackages := apt.InstallPackage(taskCtx, initialPackages, verbose)
 		return installPackages()
 	}); err != nil {
-		return fmt.Errorf("error installing additional packages: %w", err)
+		return fmt.Errorf("error installing required packages: %w", err)
 	}
 	return nil
 }
@@ -107,32 +93,16 @@ func ConfigureLocale(ctx context.Context, task *spinners.Task) error {
 
 	// Generate locale if not already installed.
 	if !localeInstalled {
-		if err := task.Run(ctx, spinners.TaskSpec{Running: fmt.Sprintf("Generating locale %s", targetLocale)}, func(context.Context, *spinners.Task) error {
-			result, err := executor.Run(ctx, "locale-gen",
-				executor.WithArgs(targetLocale),
-				executor.WithOutputMode(executor.OutputModeCombined),
-			)
-			if err != nil {
-				return fmt.Errorf("%w: %s", err, string(result.Combined))
-			}
-			return nil
-		}); err != nil {
+		if err := task.RunCommand(ctx, spinners.TaskSpec{Running: fmt.Sprintf("Generating locale %s", targetLocale)},
+			"locale-gen", []string{targetLocale}); err != nil {
 			// Return the error instead of exiting.
 			return fmt.Errorf("error generating locale: %w", err)
 		}
 	}
 
 	// Use update-locale to set both LANG and LC_ALL system-wide locale variables
-	if err := task.Run(ctx, spinners.TaskSpec{Running: fmt.Sprintf("Setting system-wide locale (LC_ALL and LANG) to %s", targetLocale)}, func(context.Context, *spinners.Task) error {
-		result, err := executor.Run(ctx, "update-locale",
-			executor.WithArgs("LC_ALL="+targetLocale, "LANG="+targetLocale),
-			executor.WithOutputMode(executor.OutputModeCombined),
-		)
-		if err != nil {
-			return fmt.Errorf("%w: %s", err, string(result.Combined))
-		}
-		return nil
-	}); err != nil {
+	if err := task.RunCommand(ctx, spinners.TaskSpec{Running: fmt.Sprintf("Setting system-wide locale (LC_ALL and LANG) to %s", targetLocale)},
+		"update-locale", []string{"LC_ALL=" + targetLocale, "LANG=" + targetLocale}); err != nil {
 		// Don't treat this as fatal; just log it and let dpkg-reconfigure try to fix it.
 		task.Info(fmt.Sprintf("update-locale failed, attempting fallback: %v", err))
 	}
@@ -149,17 +119,10 @@ func ConfigureLocale(ctx context.Context, task *spinners.Task) error {
 
 	if !lcAllSet || !langSet {
 		// Use a spinner for dpkg-reconfigure.
-		if err := task.Run(ctx, spinners.TaskSpec{Running: "Locale not set correctly, reconfiguring locales..."}, func(context.Context, *spinners.Task) error {
-			result, err := executor.Run(ctx, "dpkg-reconfigure",
-				executor.WithArgs("locales"),
-				executor.WithInheritEnv("DEBIAN_FRONTEND=noninteractive"),
-				executor.WithOutputMode(executor.OutputModeCombined),
-			)
-			if err != nil {
-				return fmt.Errorf("%w: %s", err, string(result.Combined))
-			}
-			return nil
-		}); err != nil {
+		if err := task.RunCommand(ctx, spinners.TaskSpec{Running: "Locale not set correctly, reconfiguring locales..."},
+			"dpkg-reconfigure", []string{"locales"},
+			executor.WithInheritEnv("DEBIAN_FRONTEND=noninteractive"),
+		); err != nil {
 			// Return the error instead of exiting.
 			return fmt.Errorf("error reconfiguring locales: %w", err)
 		}
@@ -214,7 +177,7 @@ func PythonVenv(ctx context.Context, task *spinners.Task, verbose bool) error {
 
 	// Create venv using uv
 	venvPath := filepath.Join(constants.AnsibleVenvPath, "venv")
-	if err := task.Run(ctx, spinners.TaskSpec{Running: "Creating venv"}, func(taskCtx context.Context, _ *spinners.Task) error {
+	if err := task.RunStreaming(ctx, spinners.TaskSpec{Running: "Creating venv"}, func(taskCtx context.Context) error {
 		return uv.CreateVenv(taskCtx, venvPath, constants.AnsibleVenvPythonVersion, verbose)
 	}); err != nil {
 		return fmt.Errorf("error creating venv: %w", err)
@@ -233,7 +196,7 @@ func PythonVenv(ctx context.Context, task *spinners.Task, verbose bool) error {
 			time.Sleep(1 * time.Second)
 		}
 
-		return fmt.Errorf("virtual environment Python still not found after waiting")
+		return fmt.Errorf("virtual environment Python %s still not found after waiting %s", venvPythonPath, maxWait)
 	}); err != nil {
 		return fmt.Errorf("error checking for venv Python: %w", err)
 	}
@@ -262,14 +225,10 @@ func SaltboxRepo(ctx context.Context, task *spinners.Task, verbose bool, branch
 		}
 
 		// Run submodule update after cloning.
-		if err := task.RunStreaming(ctx, spinners.TaskSpec{Running: "Updating git submodules"}, func(taskCtx context.Context) error {
-			_, err := executor.Run(taskCtx, "git",
-				executor.WithArgs("submodule", "update", "--progress", "--init", "--recursive"),
-				executor.WithWorkingDir(saltboxPath),
-				executor.WithOutputMode(executor.OutputModeDiscard),
-			)
-			return err
-		}); err != nil {
+		if err := task.RunCommand(ctx, spinners.TaskSpec{Running: "Updating git submodules"},
+			"git", []string{"submodule", "update", "--progress", "--init", "--recursive"},
+			executor.WithWorkingDir(saltboxPath),
+		); err != nil {
 			return fmt.Errorf("error running git submodule update: %w", err)
 		}
 
@@ -301,14 +260,10 @@ func SaltboxRepo(ctx context.Context, task *spinners.Task, verbose bool, branch
 				ChildDisplay: spinners.CollapseChildTasks,
 			}, func(ctx context.Context, initTask *spinners.Task) error {
 				for _, step := range initSteps {
-					if err := initTask.RunStreaming(ctx, spinners.TaskSpec{Running: step.name}, func(taskCtx context.Context) error {
-						_, err := executor.Run(taskCtx, step.command[0],
-							executor.WithArgs(step.command[1:]...),
-							executor.WithWorkingDir(saltboxPath),
-							executor.WithOutputMode(executor.OutputModeDiscard),
-						)
-						return err
-					}); err != nil {
+					if err := initTask.RunCommand(ctx, spinners.TaskSpec{Running: step.name},
+						step.command[0], step.command[1:],
+						executor.WithWorkingDir(saltboxPath),
+					); err != nil {
 						return fmt.Errorf("error running command %v: %w", step.command, err)
 					}
 				}
@@ -367,17 +322,10 @@ func InitializeGitHooks(ctx context.Context, task *spinners.Task) error {
 		return fmt.Errorf("error checking for init-hooks script: %w", err)
 	}
 
-	if err := task.RunStreaming(ctx, spinners.TaskSpec{Running: "Activating Git hooks"}, func(taskCtx context.Context) error {
-		result, err := executor.Run(taskCtx, "bash",
-			executor.WithArgs(initHooksScript),
-			executor.WithWorkingDir(saltboxPath),
-			executor.WithOutputMode(executor.OutputModeCombined),
-		)
-		if err != nil {
-			return fmt.Errorf("%w: %s", err, string(result.Combined))
-		}
-		return nil
-	}); err != nil {
+	if err := task.RunCommand(ctx, spinners.TaskSpec{Running: "Activating Git hooks"},
+		"bash", []string{initHooksScript},
+		executor.WithWorkingDir(saltboxPath),
+	); err != nil {
 		return fmt.Errorf("error activating Git hooks: %w", err)
 	}
 
@@ -418,13 +366,25 @@ func InstallPipDependencies(ctx context.Context, task *spinners.Task, verbose bo
 		if verbose {
 			fmt.Println("Running command:", installRequirements)
 		}
-		_, err := executor.Run(ctx, installRequirements[0],
+		options := []executor.Option{
 			executor.WithArgs(installRequirements[1:]...),
 			executor.WithOutputMode(executor.OutputModeStream),
 			executor.WithStdout(stdout),
 			executor.WithStderr(stderr),
 			executor.WithPseudoTerminal(),
-		)
+		}
+
+		// Behind a spinner, show the package pip is working on, as this is the longest setup step
+		if !verbose && executor.ReportStatus(ctx, "collecting packages...") {
+			progress := &pipProgress{total: countRequirements(requirementsPath)}
+			options = append(options, executor.WithLineCallback(func(line string) {
+				if status, ok := progress.parseLine(line); ok {
+					executor.ReportStatus(ctx, status)
+				}
+			}))
+		}
+
+		_, err := executor.Run(ctx, installRequirements[0], options...)
 		if err != nil {
 			return fmt.Errorf("command failed: %w", err)
 		}
@@ -527,24 +487,22 @@ func copyConfigFile(srcPath, destPath string) error {
 }
 
 // CopyDefaultConfigFiles copies default config files into the Saltbox folder.
+// Existing config files are left untouched; DiffDefaultConfigFiles reports how they differ from the defaults.
 func CopyDefaultConfigFiles(ctx context.Context, task *spinners.Task) error {
-	saltboxPath := constants.SaltboxRepoPath
-	defaultsDir := filepath.Join(saltboxPath, "defaults")
-	files, err := filepath.Glob(filepath.Join(defaultsDir, "*.default"))
+	files, err := defaultConfigFiles(constants.SaltboxRepoPath)
 	if err != nil {
-		return fmt.Errorf("error listing default config files: %w", err)
+		return err
 	}
 
-	for _, srcPath := range files {
-		baseName := filepath.Base(srcPath)
-		destName := strings.TrimSuffix(baseName, ".default")
-		destPath := filepath.Join(saltboxPath, destName)
+	for _, file := range files {
+		baseName := filepath.Base(file.defaultPath)
+		destPath := file.configPath
 
 		// Check if the destination file already exists.
 		if _, err := os.Stat(destPath); os.IsNotExist(err) {
 			// Destination file doesn't exist, proceed with copying.
 			if err := task.Run(ctx, spinners.TaskSpec{Running: fmt.Sprintf("Copying %s", baseName)}, func(context.Context, *spinners.Task) error {
-				return copyConfigFile(srcPath, destPath)
+				return copyConfigFile(file.defaultPath, destPath)
 			}); err != nil {
 				return err
 			}
@@ -43,6 +43,9 @@ type TaskSpec struct {
 // RunnerOptions configures one independent progress renderer.
 type RunnerOptions struct {
 	Verbose bool
+	// Quiet only prints failed tasks with their output, warnings and the final
+	// status of root tasks. Verbose and NoProgress take precedence over it.
+	Quiet bool
 	// NoProgress suppresses task lifecycle output while preserving notices and
 	// output written by the work itself.
 	NoProgress bool
@@ -52,6 +55,7 @@ type RunnerOptions struct {
 // Runner owns one progress session. It contains no process-global state.
 type Runner struct {
 	verbose    bool
+	quiet      bool
 	noProgress bool
 	output     io.Writer
 	mu         sync.Mutex
@@ -63,8 +67,10 @@ func NewRunner(opts RunnerOptions) *Runner {
 	if output == nil {
 		output = os.Stderr
 	}
+	quiet := opts.Quiet && !opts.Verbose && !opts.NoProgress
 	return &Runner{
-		verbose:    opts.Verbose || opts.NoProgress || !tty.IsInteractive(),
+		verbose:    opts.Verbose || opts.NoProgress || quiet || !tty.IsInteractive(),
+		quiet:      quiet,
 		noProgress: opts.NoProgress,
 		output:     output,
 	}
@@ -72,11 +78,14 @@ func NewRunner(opts RunnerOptions) *Runner {
 
 // Verbose reports whether this runner uses plain text output.
 func (r *Runner) Verbose() bool {
-	return r.verbose
+	return r.verbose && !r.quiet
 }
 
-// Info prints an informational message outside a task scope.
+// Info prints an informational message outside a task scope. Quiet runners drop it.
 func (r *Runner) Info(message string) {
+	if r.quiet {
+		return
+	}
 	r.printMessage(message, styles.ColorLightBlue)
 }
 
@@ -94,7 +103,7 @@ type Task struct {
 
 // Verbose reports whether this task's runner uses plain text output.
 func (t *Task) Verbose() bool {
-	return t.run.runner.verbose
+	return t.run.runner.Verbose()
 }
 
 type taskRun struct {
@@ -125,6 +134,16 @@ func (r *Runner) Run(
 		return fn(taskCtx, root)
 	}
 
+	if r.quiet {
+		err := fn(taskCtx, root)
+		if err != nil {
+			r.printPlain(0, spec.Failure+": Failed")
+			return err
+		}
+		r.printPlain(0, spec.Success)
+		return nil
+	}
+
 	if r.verbose {
 		r.printPlain(0, spec.Running+"...")
 		err := fn(taskCtx, root)
@@ -177,11 +196,19 @@ func (r *Runner) RunOutput(
 		return fmt.Errorf("root output task function is required")
 	}
 	return r.Run(ctx, spec, func(ctx context.Context, root *Task) error {
+		if r.quiet {
+			output := &outputCapture{}
+			err := fn(ctx, output, output)
+			if err != nil {
+				r.printOutput(1, output.String())
+			}
+			return err
+		}
 		if r.verbose {
 			return fn(ctx, r.output, r.output)
 		}
 		writer := &progressOutputWriter{program: root.run.program, id: root.id}
-		return fn(ctx, writer, writer)
+		return fn(executor.WithStatusReporter(ctx, writer.status), writer, writer)
 	})
 }
 
@@ -195,6 +222,7 @@ func (t *Task) Run(
 }
 
 // RunOutput executes a child task with task-scoped stdout and stderr writers.
+// Behind the live renderer, executor.ReportStatus sets the task's status line.
 func (t *Task) RunOutput(
 	ctx context.Context,
 	spec TaskSpec,
@@ -214,6 +242,32 @@ func (t *Task) RunStreaming(
 	})
 }
 
+// RunCommand executes a command as a child task. Its output is shown through the
+// task like RunStreaming, and a failure's error includes the command's stderr so
+// it stays meaningful once the progress display is gone. Options may set e.g. the
+// working directory or environment.
+func (t *Task) RunCommand(
+	ctx context.Context,
+	spec TaskSpec,
+	command string,
+	args []string,
+	options ...executor.Option,
+) error {
+	return t.RunStreaming(ctx, spec, func(taskCtx context.Context) error {
+		runOptions := append([]executor.Option{
+			executor.WithArgs(args...),
+			executor.WithOutputMode(executor.OutputModeDiscard),
+		}, options...)
+		result, err := executor.Run(taskCtx, command, runOptions...)
+		if err != nil && result != nil {
+			if stderr := strings.TrimSpace(string(result.Stderr)); stderr != "" {
+				return fmt.Errorf("%w: %s", err, stderr)
+			}
+		}
+		return err
+	})
+}
+
 func (t *Task) runTask(
 	ctx context.Context,
 	spec TaskSpec,
@@ -237,6 +291,22 @@ func (t *Task) runTask(
 		return fn(ctx, child)
 	}
 
+	if t.run.runner.quiet {
+		// Only failures are shown, together with the output that explains them
+		var err error
+		output := &outputCapture{}
+		if outputFn != nil {
+			err = outputFn(ctx, output, output)
+		} else {
+			err = fn(ctx, child)
+		}
+		if err != nil {
+			t.run.runner.printPlain(child.depth, spec.Failure+": Failed")
+			t.run.runner.printOutput(child.depth+1, output.String())
+		}
+		return err
+	}
+
 	if t.run.runner.verbose {
 		depth := child.depth
 		t.run.runner.printPlain(depth, spec.Running+"...")
@@ -260,8 +330,9 @@ func (t *Task) runTask(
 	if outputFn != nil {
 		stdout := &outputCapture{}
 		stderr := &outputCapture{}
-		err = outputFn(ctx,
-			&progressOutputWriter{program: t.run.program, id: id, capture: stdout},
+		writer := &progressOutputWriter{program: t.run.program, id: id, capture: stdout}
+		err = outputFn(executor.WithStatusReporter(ctx, writer.status),
+			writer,
 			&progressOutputWriter{program: t.run.program, id: id, capture: stderr},
 		)
 		if err != nil {
@@ -281,7 +352,11 @@ func (t *Task) runTask(
 }
 
 // Info prints an informational message without disturbing the live renderer.
+// Quiet runners drop it.
 func (t *Task) Info(message string) {
+	if t.run.runner.quiet {
+		return
+	}
 	t.message(message, styles.ColorLightBlue)
 }
 
@@ -305,6 +380,15 @@ func (r *Runner) printPlain(depth int, message string) {
 	fmt.Fprintln(r.output, strings.Repeat("  ", depth)+message)
 }
 
+// printOutput prints the non-empty lines of a task's output below it.
+func (r *Runner) printOutput(depth int, output string) {
+	for line := range strings.SplitSeq(output, "\n") {
+		if line = strings.TrimRight(line, "\r "); line != "" {
+			r.printPlain(depth, line)
+		}
+	}
+}
+
 func (r *Runner) printMessage(message, color string) {
 	if r.verbose {
 		r.printPlain(0, message)
@@ -349,6 +433,7 @@ type progressNode struct {
 	order    uint64
 	spec     TaskSpec
 	state    progressTaskState
+	status   string // Short status shown after the running message, e.g., the current package
 	err      error
 	output   taskOutputBuffer
 	notices  []progressNotice
@@ -386,6 +471,11 @@ type progressOutputMsg struct {
 	output string
 }
 
+type progressStatusMsg struct {
+	id     uint64
+	status string
+}
+
 type progressNoticeMsg struct {
 	id      uint64
 	message string
@@ -484,6 +574,10 @@ func (m progressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if node, ok := m.nodes[msg.id]; ok {
 			node.output.WriteString(msg.output)
 		}
+	case progressStatusMsg:
+		if node, ok := m.nodes[msg.id]; ok {
+			node.status = msg.status
+		}
 	case progressNoticeMsg:
 		if node, ok := m.nodes[msg.id]; ok {
 			node.notices = append(node.notices, progressNotice{
@@ -543,6 +637,9 @@ func (m progressModel) renderNode(id uint64, depth int, forceVisible bool) []str
 	switch node.state {
 	case progressRunning:
 		marker = m.spinner.View()
+		if node.status != "" {
+			message += ": " + node.status
+		}
 	case progressSucceeded:
 		message = node.spec.Success
 		color = styles.ColorMediumGreen
@@ -641,6 +738,11 @@ func (w *progressOutputWriter) Write(output []byte) (int, error) {
 	return len(output), nil
 }
 
+// status shows a short status for the task, replacing the previous one
+func (w *progressOutputWriter) status(status string) {
+	w.program.Send(progressStatusMsg{id: w.id, status: status})
+}
+
 type outputCapture struct {
 	mu     sync.Mutex
 	output strings.Builder
@@ -9,6 +9,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/saltyorg/sb-go/internal/executor"
 	"github.com/saltyorg/sb-go/internal/styles"
 )
 
@@ -196,6 +197,24 @@ func TestTaskNoticeRemainsAttachedToCompletedTask(t *testing.T) {
 	}
 }
 
+func TestTaskStatusFollowsRunningMessageUntilFinished(t *testing.T) {
+	model := newProgressModel(TaskSpec{Running: "root"}, func() error { return nil })
+	updated, _ := model.Update(progressStartMsg{id: 1, parentID: 0, spec: TaskSpec{Running: "Installing packages", Success: "Installed packages"}})
+	model = updated.(progressModel)
+	updated, _ = model.Update(progressStatusMsg{id: 1, status: "Unpacking jq"})
+	model = updated.(progressModel)
+
+	if view := model.View().Content; !strings.Contains(view, "Installing packages: Unpacking jq") {
+		t.Fatalf("status missing from running task: %q", view)
+	}
+
+	updated, _ = model.Update(progressFinishMsg{id: 1})
+	model = updated.(progressModel)
+	if view := model.View().Content; strings.Contains(view, "Unpacking jq") || !strings.Contains(view, "Installed packages") {
+		t.Fatalf("status retained after success: %q", view)
+	}
+}
+
 func TestPrintChildrenDetachesSuccessfulChild(t *testing.T) {
 	model := newProgressModel(TaskSpec{Running: "root", ChildDisplay: PrintChildTasks}, func() error { return nil })
 	updated, _ := model.Update(progressStartMsg{id: 1, parentID: 0, spec: TaskSpec{Running: "child", Success: "child done"}})
@@ -298,6 +317,70 @@ func TestNoProgressRunnerSuppressesTaskLifecycleButStreamsOutput(t *testing.T) {
 	}
 }
 
+func TestQuietRunnerShowsOnlyFailuresWarningsAndFinalStatus(t *testing.T) {
+	var output bytes.Buffer
+	runner := NewRunner(RunnerOptions{Quiet: true, Output: &output})
+	if runner.Verbose() {
+		t.Fatal("quiet runner reported verbose output")
+	}
+	childErr := errors.New("child broke")
+	err := runner.Run(context.Background(), TaskSpec{
+		Running: "root running",
+		Success: "root done",
+		Failure: "root failed",
+	}, func(ctx context.Context, root *Task) error {
+		runner.Info("chatty notice")
+		root.Info("chatty task notice")
+		root.Warning("important warning")
+		if err := root.RunOutput(ctx, TaskSpec{Running: "quiet child"}, func(_ context.Context, stdout, _ io.Writer) error {
+			_, err := io.WriteString(stdout, "routine output\n")
+			return err
+		}); err != nil {
+			return err
+		}
+		return root.RunOutput(ctx, TaskSpec{
+			Running: "failing child",
+			Failure: "failing child step",
+		}, func(_ context.Context, _, stderr io.Writer) error {
+			_, _ = io.WriteString(stderr, "diagnostic line\n")
+			return childErr
+		})
+	})
+	if !errors.Is(err, childErr) {
+		t.Fatalf("expected child error, got %v", err)
+	}
+
+	rendered := output.String()
+	for _, hidden := range []string{"root running", "chatty", "quiet child", "routine output", "root done"} {
+		if strings.Contains(rendered, hidden) {
+			t.Fatalf("quiet runner emitted %q: %q", hidden, rendered)
+		}
+	}
+	for _, shown := range []string{"important warning", "  failing child step: Failed", "    diagnostic line", "root failed: Failed"} {
+		if !strings.Contains(rendered, shown) {
+			t.Fatalf("quiet runner dropped %q: %q", shown, rendered)
+		}
+	}
+}
+
+func TestQuietRunnerPrintsFinalStatusAndYieldsToVerbose(t *testing.T) {
+	var output bytes.Buffer
+	runner := NewRunner(RunnerOptions{Quiet: true, Output: &output})
+	err := runner.Run(context.Background(), TaskSpec{Running: "root running", Success: "root done"}, func(ctx context.Context, root *Task) error {
+		return root.Run(ctx, TaskSpec{Running: "child"}, func(context.Context, *Task) error { return nil })
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output.String() != "root done\n" {
+		t.Fatalf("quiet output = %q, want only the final status", output.String())
+	}
+
+	if !NewRunner(RunnerOptions{Quiet: true, Verbose: true, Output: io.Discard}).Verbose() {
+		t.Fatal("verbose should take precedence over quiet")
+	}
+}
+
 func TestRunnerSupportsConcurrentExplicitSiblings(t *testing.T) {
 	runner := NewRunner(RunnerOptions{Verbose: true, Output: io.Discard})
 	err := runner.Run(context.Background(), TaskSpec{Running: "root"}, func(ctx context.Context, root *Task) error {
@@ -345,6 +428,28 @@ func TestRunnerPropagatesCancellation(t *testing.T) {
 	}
 }
 
+func TestRunCommandIncludesStderrInError(t *testing.T) {
+	var output bytes.Buffer
+	runner := NewRunner(RunnerOptions{Verbose: true, Output: &output})
+	err := runner.Run(context.Background(), TaskSpec{Running: "root"}, func(ctx context.Context, root *Task) error {
+		if err := root.RunCommand(ctx, TaskSpec{Running: "succeeding"}, "sh", []string{"-c", "echo fine"}); err != nil {
+			return err
+		}
+		return root.RunCommand(ctx, TaskSpec{Running: "failing"}, "sh", []string{"-c", "echo progress; echo 'fatal: broken' >&2; exit 3"},
+			executor.WithWorkingDir(t.TempDir()))
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "exit status 3: fatal: broken") {
+		t.Fatalf("RunCommand error = %v, want the exit status and stderr", err)
+	}
+	if cmdErr, ok := errors.AsType[*executor.CommandError](err); !ok || cmdErr.ExitCode != 3 {
+		t.Fatalf("RunCommand error doesn't wrap the command error: %v", err)
+	}
+	if rendered := output.String(); !strings.Contains(rendered, "fine") || !strings.Contains(rendered, "progress") {
+		t.Fatalf("RunCommand output was not shown through the task: %q", rendered)
+	}
+}
+
 func TestTaskOutputBufferRewritesCarriageReturnProgress(t *testing.T) {
 	var output taskOutputBuffer
 	output.WriteString("Downloading 10%\rDownloading 80%\rDownloading 100%")
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -351,6 +352,7 @@ func parseSystemdTimestamp(timestamp string) (time.Time, error) {
 
 type listTimersEntry struct {
 	Next      *int64  `json:"next"`
+	Last      *int64  `json:"last"`
 	Unit      string  `json:"unit"`
 	Activates *string `json:"activates"`
 }
@@ -442,3 +444,23 @@ func FiltersWithAdditional(additionalServices []string) []ServiceFilter {
 
 	return filters
 }
+
+// FiltersWithPrefixes returns the default filters plus additional prefix-match services.
+// Prefixes already covered by the default filters are skipped.
+func FiltersWithPrefixes(prefixes []string) []ServiceFilter {
+	filters := make([]ServiceFilter, len(DefaultFilters))
+	copy(filters, DefaultFilters)
+
+	for _, prefix := range prefixes {
+		prefix = strings.TrimSpace(prefix)
+		if prefix == "" || slices.ContainsFunc(filters, func(f ServiceFilter) bool { return f.IsPrefix && f.Pattern == prefix }) {
+			continue
+		}
+		filters = append(filters, ServiceFilter{
+			Pattern:  prefix,
+			IsPrefix: true,
+		})
+	}
+
+	return filters
+}
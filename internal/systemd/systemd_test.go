@@ -114,3 +114,25 @@ func TestParseListTimersJSON(t *testing.T) {
 		t.Fatalf("expected 5h 30m, got %q", got)
 	}
 }
+
+func TestFiltersWithPrefixes(t *testing.T) {
+	filters := FiltersWithPrefixes([]string{"community_", "", "saltbox_managed_", "community_", " custom_ "})
+
+	want := append(append([]ServiceFilter{}, DefaultFilters...),
+		ServiceFilter{Pattern: "community_", IsPrefix: true},
+		ServiceFilter{Pattern: "custom_", IsPrefix: true},
+	)
+	if len(filters) != len(want) {
+		t.Fatalf("FiltersWithPrefixes() = %+v, want %+v", filters, want)
+	}
+	for i := range want {
+		if filters[i] != want[i] {
+			t.Fatalf("FiltersWithPrefixes()[%d] = %+v, want %+v", i, filters[i], want[i])
+		}
+	}
+
+	// The defaults must not be modified
+	if len(DefaultFilters) != 2 {
+		t.Fatalf("DefaultFilters was modified: %+v", DefaultFilters)
+	}
+}
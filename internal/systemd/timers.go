@@ -0,0 +1,104 @@
+package systemd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/saltyorg/sb-go/internal/executor"
+)
+
+// timerOverdueGrace is how long past its next elapse a timer may be before it's considered overdue
+const timerOverdueGrace = time.Minute
+
+// TimerInfo holds information about a systemd timer and the service it activates.
+type TimerInfo struct {
+	Name          string    // Timer name without .timer suffix
+	Service       string    // Activated service name without .service suffix
+	Next          time.Time // Next trigger, zero if the timer isn't scheduled
+	Last          time.Time // Last trigger, zero if the timer never ran
+	ServiceActive string    // Active state of the activated service, e.g. failed
+}
+
+// Failed reports whether the last run of the activated service failed.
+func (t TimerInfo) Failed() bool {
+	return t.ServiceActive == "failed"
+}
+
+// Overdue reports whether the timer should have triggered by now but hasn't.
+func (t TimerInfo) Overdue(now time.Time) bool {
+	return !t.Next.IsZero() && now.Sub(t.Next) > timerOverdueGrace
+}
+
+// GetFilteredTimers retrieves the systemd timers whose timer or activated service matches the given filters,
+// along with the active state of the activated service.
+func GetFilteredTimers(ctx context.Context, filters []ServiceFilter) ([]TimerInfo, error) {
+	result, err := executor.Run(ctx, "systemctl",
+		executor.WithArgs("list-timers", "--all", "--no-pager", "--output=json"),
+		executor.WithOutputMode(executor.OutputModeCapture),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list systemd timers: %w", err)
+	}
+
+	timers, err := parseTimers(string(result.Stdout), filters)
+	if err != nil {
+		return nil, err
+	}
+	if len(timers) == 0 {
+		return timers, nil
+	}
+
+	// The activated services match the same filters, so their states come from a single lookup
+	services, err := GetFilteredServices(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the states of the timer services: %w", err)
+	}
+	activeByService := make(map[string]string, len(services))
+	for _, service := range services {
+		activeByService[service.Name] = service.Active
+	}
+	for i := range timers {
+		timers[i].ServiceActive = activeByService[timers[i].Service]
+	}
+	return timers, nil
+}
+
+// parseTimers parses the JSON output of systemctl list-timers, keeping the timers that match any filter
+func parseTimers(output string, filters []ServiceFilter) ([]TimerInfo, error) {
+	var entries []listTimersEntry
+	if err := json.Unmarshal([]byte(output), &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse systemd timers: %w", err)
+	}
+
+	var timers []TimerInfo
+	for _, entry := range entries {
+		timer := TimerInfo{Name: strings.TrimSuffix(entry.Unit, ".timer")}
+		if entry.Activates != nil {
+			timer.Service = strings.TrimSuffix(*entry.Activates, ".service")
+		}
+
+		matches := false
+		for _, filter := range filters {
+			if matchesFilter(timer.Name, filter) || (timer.Service != "" && matchesFilter(timer.Service, filter)) {
+				matches = true
+				break
+			}
+		}
+		if !matches {
+			continue
+		}
+
+		// systemd reports unset timestamps as null or 0
+		if entry.Next != nil && *entry.Next > 0 {
+			timer.Next = time.UnixMicro(*entry.Next)
+		}
+		if entry.Last != nil && *entry.Last > 0 {
+			timer.Last = time.UnixMicro(*entry.Last)
+		}
+		timers = append(timers, timer)
+	}
+	return timers, nil
+}
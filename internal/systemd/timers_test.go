@@ -0,0 +1,57 @@
+package systemd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimers(t *testing.T) {
+	output := `[
+  {"next": 1773423000000000, "left": 19800000000, "last": 1773333000000000, "passed": 70200000000, "unit": "saltbox_managed_backup.timer", "activates": "saltbox_managed_backup.service"},
+  {"next": 0, "left": 0, "last": null, "passed": null, "unit": "plex_cleanup.timer", "activates": "saltbox_managed_plex_cleanup.service"},
+  {"next": 1773423000000000, "unit": "motd-news.timer", "activates": "motd-news.service"}
+]`
+
+	timers, err := parseTimers(output, DefaultFilters)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if len(timers) != 2 {
+		t.Fatalf("timers = %+v, want the two Saltbox managed timers", timers)
+	}
+
+	backup := timers[0]
+	if backup.Name != "saltbox_managed_backup" || backup.Service != "saltbox_managed_backup" {
+		t.Errorf("backup timer = %+v", backup)
+	}
+	if !backup.Next.Equal(time.UnixMicro(1773423000000000)) || !backup.Last.Equal(time.UnixMicro(1773333000000000)) {
+		t.Errorf("backup timestamps = %v, %v", backup.Next, backup.Last)
+	}
+
+	// Matched through the activated service, without any timestamps
+	cleanup := timers[1]
+	if cleanup.Name != "plex_cleanup" || !cleanup.Next.IsZero() || !cleanup.Last.IsZero() {
+		t.Errorf("cleanup timer = %+v", cleanup)
+	}
+
+	if _, err := parseTimers("not json", DefaultFilters); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+func TestTimerInfoStatus(t *testing.T) {
+	now := time.Date(2026, 3, 13, 12, 0, 0, 0, time.UTC)
+
+	if (TimerInfo{Next: now.Add(-30 * time.Second)}).Overdue(now) {
+		t.Error("timer within the grace period is overdue")
+	}
+	if !(TimerInfo{Next: now.Add(-time.Hour)}).Overdue(now) {
+		t.Error("timer an hour past its next run is not overdue")
+	}
+	if (TimerInfo{}).Overdue(now) {
+		t.Error("unscheduled timer is overdue")
+	}
+	if !(TimerInfo{ServiceActive: "failed"}).Failed() || (TimerInfo{ServiceActive: "inactive"}).Failed() {
+		t.Error("Failed does not follow the service active state")
+	}
+}
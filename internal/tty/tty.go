@@ -1,7 +1,12 @@
 package tty
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/mattn/go-isatty"
 )
@@ -20,3 +25,33 @@ func init() {
 func IsInteractive() bool {
 	return isInteractive
 }
+
+// ErrConfirmationRequired is returned by Confirm when confirmation can't be asked for
+// because stdin is not a terminal
+var ErrConfirmationRequired = errors.New("confirmation required but no terminal is available to ask for it, pass --yes to confirm")
+
+// Confirm asks the user to confirm a destructive action with a y/N prompt on stdin.
+// Any answer other than y or yes declines. assumeYes, typically set by a --yes flag,
+// confirms without asking. Without it, ErrConfirmationRequired is returned when stdin
+// is not a terminal, so non-interactive runs abort instead of acting unconfirmed.
+func Confirm(prompt string, assumeYes bool) (bool, error) {
+	return confirm(os.Stdin, os.Stdout, isatty.IsTerminal(os.Stdin.Fd()), prompt, assumeYes)
+}
+
+func confirm(in io.Reader, out io.Writer, interactive bool, prompt string, assumeYes bool) (bool, error) {
+	if assumeYes {
+		return true, nil
+	}
+	if !interactive {
+		return false, ErrConfirmationRequired
+	}
+
+	_, _ = fmt.Fprintf(out, "%s [y/N]: ", prompt)
+	response, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("error reading input: %w", err)
+	}
+
+	response = strings.ToLower(strings.TrimSpace(response))
+	return response == "y" || response == "yes", nil
+}
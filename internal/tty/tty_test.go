@@ -0,0 +1,46 @@
+package tty
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		interactive bool
+		assumeYes   bool
+		want        bool
+		wantErr     error
+	}{
+		{name: "yes", input: "y\n", interactive: true, want: true},
+		{name: "yes in full", input: " YES \n", interactive: true, want: true},
+		{name: "no", input: "n\n", interactive: true},
+		{name: "empty answer declines", input: "\n", interactive: true},
+		{name: "end of input declines", input: "", interactive: true},
+		{name: "assume yes", assumeYes: true, want: true},
+		{name: "assume yes without terminal", assumeYes: true, want: true},
+		{name: "no terminal", input: "y\n", wantErr: ErrConfirmationRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := confirm(strings.NewReader(tt.input), &out, tt.interactive, "Reset the repository?", tt.assumeYes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("confirm() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("confirm() = %v, want %v", got, tt.want)
+			}
+
+			prompted := strings.Contains(out.String(), "Reset the repository? [y/N]: ")
+			if wantPrompt := tt.interactive && !tt.assumeYes; prompted != wantPrompt {
+				t.Errorf("prompt shown = %v, want %v (output %q)", prompted, wantPrompt, out.String())
+			}
+		})
+	}
+}
@@ -5,10 +5,64 @@ import (
 	"fmt"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
 )
 
-// CheckSupport checks if the OS is Ubuntu and if it is one of the supported versions.
+const (
+	// AllowUnsupportedFlag continues on unsupported releases with a warning instead of exiting
+	AllowUnsupportedFlag = "allow-unsupported"
+	// AllowUnsupportedEnv enables AllowUnsupportedFlag from the environment (e.g., SB_ALLOW_UNSUPPORTED=1)
+	AllowUnsupportedEnv = "SB_ALLOW_UNSUPPORTED"
+)
+
+// AllowUnsupported reports whether the --allow-unsupported flag is among args or enabled through
+// the environment. The flag is checked before command line parsing since the release check runs first.
+func AllowUnsupported(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		value, ok := strings.CutPrefix(arg, "--"+AllowUnsupportedFlag)
+		if !ok {
+			continue
+		}
+		if value == "" {
+			return true
+		}
+		if value, ok = strings.CutPrefix(value, "="); ok {
+			if allowed, err := strconv.ParseBool(value); err == nil {
+				return allowed
+			}
+		}
+	}
+
+	allowed, err := strconv.ParseBool(os.Getenv(AllowUnsupportedEnv))
+	return err == nil && allowed
+}
+
+// releaseVersions maps Ubuntu codenames to versions, so derivatives reporting only the codename
+// of their Ubuntu base through UBUNTU_CODENAME can be matched against supported versions
+var releaseVersions = map[string]string{
+	"bionic":   "18.04",
+	"focal":    "20.04",
+	"jammy":    "22.04",
+	"noble":    "24.04",
+	"oracular": "24.10",
+	"plucky":   "25.04",
+	"questing": "25.10",
+}
+
+// Release describes the Ubuntu release the system is, or is based on
+type Release struct {
+	Name       string // Distribution name (e.g., "Linux Mint 22")
+	Version    string // Ubuntu version (e.g., "24.04")
+	Codename   string // Ubuntu codename (e.g., "noble")
+	Derivative bool   // Whether the system is an Ubuntu derivative rather than Ubuntu itself
+}
+
+// CheckSupport checks if the OS is Ubuntu, or a derivative based on Ubuntu, and if the
+// Ubuntu release is one of the supported versions.
 // Returns an error message if not supported, or nil if supported.
 func CheckSupport(supportedVersions []string) error {
 	// Check if OS is Linux
@@ -20,29 +74,66 @@ func CheckSupport(supportedVersions []string) error {
 		return fmt.Errorf("not running on Linux (detected OS: %s)", osName)
 	}
 
-	// Parse /etc/os-release
+	release, err := CurrentRelease()
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(supportedVersions, release.Version) {
+		return nil // Supported version
+	}
+
+	if release.Derivative {
+		return fmt.Errorf("unsupported Ubuntu version (detected %s based on Ubuntu %s, supported versions: %s)",
+			release.Name, release.Version, strings.Join(supportedVersions, ", "))
+	}
+	return fmt.Errorf("unsupported Ubuntu version (detected version: %s, supported versions: %s)",
+		release.Version, strings.Join(supportedVersions, ", "))
+}
+
+// CurrentRelease returns the Ubuntu release of the system from /etc/os-release
+func CurrentRelease() (Release, error) {
 	osRelease, err := ParseOSRelease("/etc/os-release")
 	if err != nil {
-		return fmt.Errorf("error parsing /etc/os-release: %w", err)
+		return Release{}, fmt.Errorf("error parsing /etc/os-release: %w", err)
 	}
+	return DetectRelease(osRelease)
+}
 
-	// Check if ID is ubuntu
-	if osRelease["ID"] != "ubuntu" {
-		return fmt.Errorf("not an Ubuntu distribution (detected ID: %s)", osRelease["ID"])
+// DetectRelease determines the Ubuntu release from parsed os-release fields.
+// Derivatives are recognized by "ubuntu" in ID_LIKE and mapped to their Ubuntu base through
+// UBUNTU_CODENAME, since their own VERSION_ID and VERSION_CODENAME follow the derivative's numbering.
+func DetectRelease(osRelease map[string]string) (Release, error) {
+	name := osRelease["PRETTY_NAME"]
+	if name == "" {
+		name = osRelease["ID"]
 	}
 
-	// Check if VERSION_ID is supported
-	versionID, ok := osRelease["VERSION_ID"]
-	if !ok {
-		return fmt.Errorf("a Ubuntu version ID not found in /etc/os-release")
+	if osRelease["ID"] == "ubuntu" {
+		versionID, ok := osRelease["VERSION_ID"]
+		if !ok {
+			return Release{}, fmt.Errorf("a Ubuntu version ID not found in /etc/os-release")
+		}
+		codename := osRelease["VERSION_CODENAME"]
+		if codename == "" {
+			codename = osRelease["UBUNTU_CODENAME"]
+		}
+		return Release{Name: name, Version: versionID, Codename: codename}, nil
 	}
 
-	if slices.Contains(supportedVersions, versionID) {
-		return nil // Supported version
+	if !slices.Contains(strings.Fields(osRelease["ID_LIKE"]), "ubuntu") {
+		return Release{}, fmt.Errorf("not an Ubuntu distribution (detected ID: %s)", osRelease["ID"])
 	}
 
-	return fmt.Errorf("unsupported Ubuntu version (detected version: %s, supported versions: %s)",
-		versionID, strings.Join(supportedVersions, ", "))
+	codename := osRelease["UBUNTU_CODENAME"]
+	if codename == "" {
+		return Release{}, fmt.Errorf("an Ubuntu codename not found in /etc/os-release (detected %s)", name)
+	}
+	version, ok := releaseVersions[codename]
+	if !ok {
+		return Release{}, fmt.Errorf("unknown Ubuntu codename %q (detected %s)", codename, name)
+	}
+	return Release{Name: name, Version: version, Codename: codename, Derivative: true}, nil
 }
 
 // getOSName returns the lowercase OS name.
@@ -69,12 +160,12 @@ func ParseOSRelease(path string) (map[string]string, error) {
 	scanner := bufio.NewScanner(file)
 	osRelease := make(map[string]string)
 	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.Contains(line, "=") {
-			parts := strings.SplitN(line, "=", 2)
-			key := parts[0]
-			value := strings.Trim(parts[1], "\"") // Remove quotes
-			osRelease[key] = value
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, "="); ok {
+			osRelease[key] = strings.Trim(value, `"'`) // Remove quotes
 		}
 	}
 	if err := scanner.Err(); err != nil {
@@ -0,0 +1,156 @@
+package ubuntu
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAllowUnsupported(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		env  string
+		want bool
+	}{
+		{name: "default", args: []string{"install", "core"}, want: false},
+		{name: "flag", args: []string{"--allow-unsupported", "install", "core"}, want: true},
+		{name: "flag after command", args: []string{"install", "core", "--allow-unsupported"}, want: true},
+		{name: "flag with value", args: []string{"--allow-unsupported=true"}, want: true},
+		{name: "flag disabled", args: []string{"--allow-unsupported=false"}, env: "1", want: false},
+		{name: "flag after terminator", args: []string{"install", "--", "--allow-unsupported"}, want: false},
+		{name: "similar flag", args: []string{"--allow-unsupported-extra"}, want: false},
+		{name: "env", env: "1", want: true},
+		{name: "env true", env: "true", want: true},
+		{name: "env disabled", env: "0", want: false},
+		{name: "env invalid", env: "yes", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(AllowUnsupportedEnv, tt.env)
+			if got := AllowUnsupported(tt.args); got != tt.want {
+				t.Errorf("AllowUnsupported(%q) with %s=%q = %v, want %v", tt.args, AllowUnsupportedEnv, tt.env, got, tt.want)
+			}
+		})
+	}
+}
+
+func writeOSRelease(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "os-release")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDetectRelease(t *testing.T) {
+	tests := []struct {
+		name      string
+		osRelease string
+		want      Release
+		wantErr   bool
+	}{
+		{
+			name: "ubuntu",
+			osRelease: `PRETTY_NAME="Ubuntu 24.04.1 LTS"
+NAME="Ubuntu"
+VERSION_ID="24.04"
+VERSION="24.04.1 LTS (Noble Numbat)"
+VERSION_CODENAME=noble
+ID=ubuntu
+ID_LIKE=debian
+UBUNTU_CODENAME=noble
+`,
+			want: Release{Name: "Ubuntu 24.04.1 LTS", Version: "24.04", Codename: "noble"},
+		},
+		{
+			name: "linux mint",
+			osRelease: `NAME="Linux Mint"
+VERSION="22 (Wilma)"
+ID=linuxmint
+ID_LIKE="ubuntu debian"
+PRETTY_NAME="Linux Mint 22"
+VERSION_ID="22"
+VERSION_CODENAME=wilma
+UBUNTU_CODENAME=noble
+`,
+			want: Release{Name: "Linux Mint 22", Version: "24.04", Codename: "noble", Derivative: true},
+		},
+		{
+			name: "pop os",
+			osRelease: `NAME="Pop!_OS"
+VERSION="22.04 LTS"
+ID=pop
+ID_LIKE="ubuntu debian"
+PRETTY_NAME="Pop!_OS 22.04 LTS"
+VERSION_ID="22.04"
+VERSION_CODENAME=jammy
+UBUNTU_CODENAME=jammy
+`,
+			want: Release{Name: "Pop!_OS 22.04 LTS", Version: "22.04", Codename: "jammy", Derivative: true},
+		},
+		{
+			name: "debian",
+			osRelease: `PRETTY_NAME="Debian GNU/Linux 12 (bookworm)"
+VERSION_ID="12"
+VERSION_CODENAME=bookworm
+ID=debian
+`,
+			wantErr: true,
+		},
+		{
+			name: "derivative with unknown base",
+			osRelease: `ID=example
+ID_LIKE=ubuntu
+UBUNTU_CODENAME=future
+`,
+			wantErr: true,
+		},
+		{
+			name: "derivative without base codename",
+			osRelease: `ID=example
+ID_LIKE=ubuntu
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			osRelease, err := ParseOSRelease(writeOSRelease(t, tt.osRelease))
+			if err != nil {
+				t.Fatalf("ParseOSRelease unexpected error: %v", err)
+			}
+
+			got, err := DetectRelease(osRelease)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("DetectRelease expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DetectRelease unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectRelease = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOSReleaseSkipsCommentsAndQuotes(t *testing.T) {
+	path := writeOSRelease(t, "# comment\nID='ubuntu'\nVERSION_ID=\"24.04\"\n\nEMPTY=\n")
+
+	got, err := ParseOSRelease(path)
+	if err != nil {
+		t.Fatalf("ParseOSRelease unexpected error: %v", err)
+	}
+	want := map[string]string{"ID": "ubuntu", "VERSION_ID": "24.04", "EMPTY": ""}
+	if !maps.Equal(got, want) {
+		t.Errorf("ParseOSRelease = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+)
+
+// ErrNoInvokingUser is returned by LookupInvokingUser when sb was started by root itself,
+// e.g. from a root shell or cron, rather than by a user through sudo or pkexec
+var ErrNoInvokingUser = errors.New("sb was run as root without sudo, so files it creates are owned by root")
+
+// unsetLoginUID is the value of /proc/self/loginuid for processes not started from a login session
+const unsetLoginUID = "4294967295"
+
+// InvokingUser is the non-root user on whose behalf sb runs as root
+type InvokingUser struct {
+	Name string
+	UID  int
+	GID  int
+	Home string
+}
+
+// invokingUserSources are the process details LookupInvokingUser reads, replaced in tests
+type invokingUserSources struct {
+	getenv       func(string) string
+	lookupName   func(string) (*user.User, error)
+	lookupID     func(string) (*user.User, error)
+	readLoginUID func() (string, error)
+}
+
+// systemInvokingUserSources reads the details of the running process
+var systemInvokingUserSources = invokingUserSources{
+	getenv:     os.Getenv,
+	lookupName: user.Lookup,
+	lookupID:   user.LookupId,
+	readLoginUID: func() (string, error) {
+		data, err := os.ReadFile("/proc/self/loginuid")
+		return string(data), err
+	},
+}
+
+// LookupInvokingUser returns the non-root user sb was started by, taken from SUDO_USER,
+// then PKEXEC_UID, then the login session (which survives su). ErrNoInvokingUser is returned
+// when none of them names a user other than root.
+func LookupInvokingUser() (*InvokingUser, error) {
+	return lookupInvokingUser(systemInvokingUserSources)
+}
+
+func lookupInvokingUser(sources invokingUserSources) (*InvokingUser, error) {
+	if name := sources.getenv("SUDO_USER"); name != "" && name != "root" {
+		u, err := sources.lookupName(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up sudo user %s: %w", name, err)
+		}
+		return newInvokingUser(u)
+	}
+
+	uids := []string{sources.getenv("PKEXEC_UID")}
+	if loginUID, err := sources.readLoginUID(); err == nil {
+		uids = append(uids, strings.TrimSpace(loginUID))
+	}
+	for _, uid := range uids {
+		if uid == "" || uid == "0" || uid == unsetLoginUID {
+			continue
+		}
+		u, err := sources.lookupID(uid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up invoking user %s: %w", uid, err)
+		}
+		return newInvokingUser(u)
+	}
+
+	return nil, ErrNoInvokingUser
+}
+
+// newInvokingUser converts a user account, whose IDs are numeric on Linux
+func newInvokingUser(u *user.User) (*InvokingUser, error) {
+	uid, err := strconv.Atoi(u.Uid)
+	if err != nil {
+		return nil, fmt.Errorf("invalid uid %q for user %s", u.Uid, u.Username)
+	}
+	gid, err := strconv.Atoi(u.Gid)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gid %q for user %s", u.Gid, u.Username)
+	}
+	return &InvokingUser{Name: u.Username, UID: uid, GID: gid, Home: u.HomeDir}, nil
+}
+
+// ChownToInvokingUser gives a file sb created as root to the user who invoked sb, so it doesn't
+// end up root-owned in their hands. Nothing is changed when sb doesn't run as root. When root ran
+// sb itself, ErrNoInvokingUser is returned so the caller can warn that the file is owned by root.
+func ChownToInvokingUser(path string) error {
+	if os.Geteuid() != 0 {
+		return nil
+	}
+	u, err := LookupInvokingUser()
+	if err != nil {
+		return err
+	}
+	if err := os.Lchown(path, u.UID, u.GID); err != nil {
+		return fmt.Errorf("failed to give %s to %s: %w", path, u.Name, err)
+	}
+	return nil
+}
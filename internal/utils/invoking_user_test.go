@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os/user"
+	"testing"
+)
+
+func TestLookupInvokingUser(t *testing.T) {
+	accounts := []*user.User{
+		{Username: "seed", Uid: "1000", Gid: "1000", HomeDir: "/home/seed"},
+		{Username: "alice", Uid: "1001", Gid: "1001", HomeDir: "/home/alice"},
+	}
+	sources := func(env map[string]string, loginUID string) invokingUserSources {
+		return invokingUserSources{
+			getenv: func(key string) string { return env[key] },
+			lookupName: func(name string) (*user.User, error) {
+				for _, u := range accounts {
+					if u.Username == name {
+						return u, nil
+					}
+				}
+				return nil, user.UnknownUserError(name)
+			},
+			lookupID: func(uid string) (*user.User, error) {
+				for _, u := range accounts {
+					if u.Uid == uid {
+						return u, nil
+					}
+				}
+				return nil, fmt.Errorf("unknown uid %s", uid)
+			},
+			readLoginUID: func() (string, error) {
+				if loginUID == "" {
+					return "", errors.New("no loginuid")
+				}
+				return loginUID + "\n", nil
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		loginUID string
+		want     string
+		wantErr  error
+	}{
+		{"sudo", map[string]string{"SUDO_USER": "seed", "PKEXEC_UID": "1001"}, "1001", "seed", nil},
+		{"pkexec", map[string]string{"PKEXEC_UID": "1001"}, "", "alice", nil},
+		{"su from a login session", map[string]string{"SUDO_USER": "root"}, "1000", "seed", nil},
+		{"root login", nil, "0", "", ErrNoInvokingUser},
+		{"cron", nil, unsetLoginUID, "", ErrNoInvokingUser},
+		{"no loginuid", nil, "", "", ErrNoInvokingUser},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := lookupInvokingUser(sources(tt.env, tt.loginUID))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("lookupInvokingUser() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("lookupInvokingUser() error = %v", err)
+			}
+			if u.Name != tt.want {
+				t.Errorf("lookupInvokingUser() = %s, want %s", u.Name, tt.want)
+			}
+		})
+	}
+
+	if _, err := lookupInvokingUser(sources(map[string]string{"SUDO_USER": "ghost"}, "")); err == nil {
+		t.Error("expected an error for an unknown sudo user")
+	}
+	u, err := lookupInvokingUser(sources(map[string]string{"SUDO_USER": "seed"}, ""))
+	if err != nil || u.UID != 1000 || u.GID != 1000 || u.Home != "/home/seed" {
+		t.Errorf("lookupInvokingUser() = %+v, %v", u, err)
+	}
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseDefaultRouteInterface returns the interface of the IPv4 default route in /proc/net/route content.
+// When several default routes exist, the one with the lowest metric wins.
+func ParseDefaultRouteInterface(data string) string {
+	iface := ""
+	bestMetric := -1
+	for line := range strings.Lines(data) {
+		// Iface Destination Gateway Flags RefCnt Use Metric Mask ...
+		fields := strings.Fields(line)
+		if len(fields) < 8 || fields[1] != "00000000" || fields[7] != "00000000" {
+			continue
+		}
+		metric, err := strconv.Atoi(fields[6])
+		if err != nil {
+			continue
+		}
+		if bestMetric == -1 || metric < bestMetric {
+			iface = fields[0]
+			bestMetric = metric
+		}
+	}
+	return iface
+}
@@ -0,0 +1,43 @@
+package utils
+
+import "testing"
+
+func TestParseDefaultRouteInterface(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "lowest metric wins",
+			data: `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+docker0	000011AC	00000000	0001	0	0	0	0000FFFF	0	0	0
+wlan0	00000000	0101A8C0	0003	0	0	600	00000000	0	0	0
+eth0	00000000	0100A8C0	0003	0	0	100	00000000	0	0	0
+eth0	0000A8C0	00000000	0001	0	0	100	00FFFFFF	0	0	0
+`,
+			want: "eth0",
+		},
+		{
+			// A destination of 0.0.0.0 with a non-zero mask isn't the default route
+			name: "masked zero destination",
+			data: `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+tun0	00000000	00000000	0001	0	0	0	00000080	0	0	0
+eth0	00000000	0100A8C0	0003	0	0	100	00000000	0	0	0
+`,
+			want: "eth0",
+		},
+		{
+			name: "no default route",
+			data: "Iface\tDestination\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseDefaultRouteInterface(tt.data); got != tt.want {
+				t.Errorf("ParseDefaultRouteInterface() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
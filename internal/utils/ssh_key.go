@@ -1,6 +1,12 @@
 package utils
 
-import "strings"
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode"
+)
 
 var authorizedKeyTypes = map[string]struct{}{
 	"sk-ecdsa-sha2-nistp256@openssh.com":          {},
@@ -28,25 +34,110 @@ var authorizedKeyTypes = map[string]struct{}{
 	"ssh-dss-cert-v01@openssh.com":                {},
 }
 
+// authorizedKeyBlobTypes maps the key types whose encoded key data names a different type.
+// RSA SHA-2 keys are plain ssh-rsa keys and webauthn keys are sk-ecdsa keys.
+var authorizedKeyBlobTypes = map[string]string{
+	"rsa-sha2-256":                                "ssh-rsa",
+	"rsa-sha2-512":                                "ssh-rsa",
+	"rsa-sha2-256-cert-v01@openssh.com":           "ssh-rsa-cert-v01@openssh.com",
+	"rsa-sha2-512-cert-v01@openssh.com":           "ssh-rsa-cert-v01@openssh.com",
+	"webauthn-sk-ecdsa-sha2-nistp256@openssh.com": "sk-ecdsa-sha2-nistp256@openssh.com",
+}
+
 // IsValidAuthorizedKeyLine checks a single authorized_keys line for a supported key type.
 func IsValidAuthorizedKeyLine(line string) bool {
-	trimmed := strings.TrimSpace(line)
-	if trimmed == "" {
-		return false
-	}
+	return ValidateAuthorizedKeyLine(line) == nil
+}
 
-	fields := strings.Fields(trimmed)
-	if len(fields) < 2 {
-		return false
+// ValidateAuthorizedKeyLine checks a single authorized_keys line. The line may start with
+// options and must hold a supported key type followed by base64 key data of that type.
+// The comment after the key data is optional but may not hold control characters.
+func ValidateAuthorizedKeyLine(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return fmt.Errorf("empty key")
 	}
 
 	for i, field := range fields {
-		if _, ok := authorizedKeyTypes[field]; ok {
-			return i+1 < len(fields)
+		if _, ok := authorizedKeyTypes[field]; !ok {
+			continue
+		}
+		if i+1 >= len(fields) {
+			return fmt.Errorf("missing key data after %s", field)
+		}
+		if err := validateAuthorizedKeyData(field, fields[i+1]); err != nil {
+			return err
+		}
+		for _, comment := range fields[i+2:] {
+			if strings.IndexFunc(comment, unicode.IsControl) >= 0 {
+				return fmt.Errorf("key comment contains control characters")
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("no supported key type found")
+}
+
+// validateAuthorizedKeyData checks that data is base64 encoded key data of keyType.
+// The key data starts with the length prefixed name of its key type.
+func validateAuthorizedKeyData(keyType, data string) error {
+	blob, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return fmt.Errorf("%s key data is not valid base64", keyType)
+	}
+
+	if len(blob) < 4 {
+		return fmt.Errorf("%s key data is truncated", keyType)
+	}
+	nameLen := binary.BigEndian.Uint32(blob)
+	if uint64(nameLen) > uint64(len(blob)-4) {
+		return fmt.Errorf("%s key data is truncated", keyType)
+	}
+	name := string(blob[4 : 4+nameLen])
+
+	want := keyType
+	if blobType, ok := authorizedKeyBlobTypes[keyType]; ok {
+		want = blobType
+	}
+	if name != want {
+		return fmt.Errorf("key data holds a %q key, not %s", name, keyType)
+	}
+	if len(blob) == int(4+nameLen) {
+		return fmt.Errorf("%s key data is truncated", keyType)
+	}
+
+	return nil
+}
+
+// ValidateAuthorizedKeys validates an authorized_keys style value with one SSH public key
+// or key source URL per line. Blank lines and # comments are skipped. The error names the
+// first invalid line, counted from 1.
+func ValidateAuthorizedKeys(value string) error {
+	foundKey := false
+	for i, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		foundKey = true
+		if isAuthorizedKeyURL(line) {
+			continue
 		}
+		if err := ValidateAuthorizedKeyLine(line); err != nil {
+			return fmt.Errorf("line %d: %w", i+1, err)
+		}
+	}
+
+	if !foundKey {
+		return fmt.Errorf("no SSH public keys or URLs found")
 	}
+	return nil
+}
 
-	return false
+// isAuthorizedKeyURL reports whether value is a key source URL rather than a key
+func isAuthorizedKeyURL(value string) bool {
+	return strings.HasPrefix(value, "http") || strings.HasPrefix(value, "file")
 }
 
 // IsValidAuthorizedKeyOrURL validates a key string or supported key source URL.
@@ -56,7 +147,7 @@ func IsValidAuthorizedKeyOrURL(value string) bool {
 		return true
 	}
 
-	if strings.HasPrefix(trimmed, "http") || strings.HasPrefix(trimmed, "file") {
+	if isAuthorizedKeyURL(trimmed) {
 		return true
 	}
 
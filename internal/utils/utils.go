@@ -218,7 +218,7 @@ func CheckDiskSpace(paths []string, verbosity int) error {
 		}
 
 		logging.Debug(verbosity, "Disk usage for %s: total=%s, available=%s, used=%.1f%%",
-			usage.path, formatBytes(usage.totalBytes), formatBytes(usage.availableBytes), usage.usedPercent)
+			usage.path, FormatBytes(usage.totalBytes), FormatBytes(usage.availableBytes), usage.usedPercent)
 
 		if usage.availableBytes < diskSpaceMinFreeBytes {
 			return diskSpaceError(usage.path, usage.usedPercent, usage.availableBytes)
@@ -268,7 +268,8 @@ func nearestExistingPath(path string) string {
 	}
 }
 
-func formatBytes(b uint64) string {
+// FormatBytes formats a size in bytes with binary units, e.g. "1.5 GiB"
+func FormatBytes(b uint64) string {
 	const unit = 1024
 	if b < unit {
 		return fmt.Sprintf("%d B", b)
@@ -284,7 +285,7 @@ func formatBytes(b uint64) string {
 
 func diskSpaceError(path string, usedPercent float64, availableBytes uint64) error {
 	return fmt.Errorf("INSUFFICIENT DISK SPACE - Install cancelled: %s is %.1f%% full (%s free). Free up space on %s before continuing.",
-		path, usedPercent, formatBytes(availableBytes), path)
+		path, usedPercent, FormatBytes(availableBytes), path)
 }
 
 // DiskSpaceError exposes the standard disk space error format for callers that need to force a failure.
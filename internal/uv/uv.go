@@ -166,13 +166,14 @@ func InstallPython(ctx context.Context, version string, verbose bool) error {
 func ListInstalledPythons(ctx context.Context) ([]string, error) {
 	result, err := executor.Run(ctx, UVBinaryPath,
 		executor.WithArgs("python", "list", "--only-installed"),
-		executor.WithInheritEnv(fmt.Sprintf("UV_PYTHON_INSTALL_DIR=%s", constants.PythonInstallDir)))
+		executor.WithInheritEnv(fmt.Sprintf("UV_PYTHON_INSTALL_DIR=%s", constants.PythonInstallDir)),
+		executor.WithOutputMode(executor.OutputModeCapture))
 
 	if err != nil {
-		return nil, fmt.Errorf("error listing installed Pythons: %w", err)
+		return nil, fmt.Errorf("error listing installed Pythons: %w", withStderr(err, result))
 	}
 
-	lines := strings.Split(strings.TrimSpace(string(result.Combined)), "\n")
+	lines := strings.Split(strings.TrimSpace(string(result.Stdout)), "\n")
 	var versions []string
 	for _, line := range lines {
 		if line != "" {
@@ -187,13 +188,27 @@ func ListInstalledPythons(ctx context.Context) ([]string, error) {
 func FindPythonBinary(ctx context.Context, version string) (string, error) {
 	result, err := executor.Run(ctx, UVBinaryPath,
 		executor.WithArgs("python", "find", version),
-		executor.WithInheritEnv(fmt.Sprintf("UV_PYTHON_INSTALL_DIR=%s", constants.PythonInstallDir)))
+		executor.WithInheritEnv(fmt.Sprintf("UV_PYTHON_INSTALL_DIR=%s", constants.PythonInstallDir)),
+		executor.WithOutputMode(executor.OutputModeCapture))
 
 	if err != nil {
-		return "", fmt.Errorf("error finding Python %s: %w", version, err)
+		return "", fmt.Errorf("error finding Python %s: %w", version, withStderr(err, result))
 	}
 
-	return strings.TrimSpace(string(result.Combined)), nil
+	// Only stdout holds the path, uv prints warnings to stderr
+	return strings.TrimSpace(string(result.Stdout)), nil
+}
+
+// withStderr appends the stderr of a failed command to its error, so failures are debuggable
+// without rerunning the command
+func withStderr(err error, result *executor.Result) error {
+	if result == nil {
+		return err
+	}
+	if stderr := strings.TrimSpace(string(result.Stderr)); stderr != "" {
+		return fmt.Errorf("%w: %s", err, stderr)
+	}
+	return err
 }
 
 // CreateVenv creates a virtual environment using Python's built-in venv module
@@ -2,8 +2,11 @@ package uv
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
+
+	"github.com/saltyorg/sb-go/internal/executor"
 )
 
 // TestUVBinaryPath tests that the UV binary path constant is correct
@@ -45,3 +48,23 @@ func TestDownloadAndInstallUV(t *testing.T) {
 		t.Errorf("Expected uv binary to exist at %s after installation", UVBinaryPath)
 	}
 }
+
+// TestWithStderr tests that the stderr of a failed command is added to its error
+func TestWithStderr(t *testing.T) {
+	base := errors.New("exit status 1")
+
+	err := withStderr(base, &executor.Result{Stderr: []byte("error: No interpreter found\n")})
+	if err.Error() != "exit status 1: error: No interpreter found" {
+		t.Errorf("Expected stderr in the error, got %q", err)
+	}
+	if !errors.Is(err, base) {
+		t.Error("Expected the original error to be wrapped")
+	}
+
+	if err := withStderr(base, &executor.Result{}); err != base {
+		t.Errorf("Expected the error unchanged without stderr, got %q", err)
+	}
+	if err := withStderr(base, nil); err != base {
+		t.Errorf("Expected the error unchanged without a result, got %q", err)
+	}
+}
@@ -5,23 +5,29 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
+	"net"
 	"net/http"
 	"os"
+	osuser "os/user"
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	sbconfig "github.com/saltyorg/sb-go/internal/config"
 	"github.com/saltyorg/sb-go/internal/logging"
+	"github.com/saltyorg/sb-go/internal/retry"
 	"github.com/saltyorg/sb-go/internal/spinners"
 	"github.com/saltyorg/sb-go/internal/utils"
 
 	"github.com/cloudflare/cloudflare-go/v7"
-	"github.com/cloudflare/cloudflare-go/v7/option"
+	"github.com/cloudflare/cloudflare-go/v7/packages/pagination"
 	"github.com/cloudflare/cloudflare-go/v7/zones"
 	"golang.org/x/net/publicsuffix"
 	"golang.org/x/sync/errgroup"
@@ -113,6 +119,7 @@ func (ctx *AsyncValidationContext) Wait() []error {
 // customValidators registry of all available custom validators
 var customValidators = map[string]CustomValidator{
 	"validate_ssh_key_or_url":    validateSSHKeyOrURL,
+	"validate_authorized_keys":   validateAuthorizedKeys,
 	"validate_password_strength": validatePasswordStrength,
 	"validate_cloudflare_config": validateCloudflareConfigSync,
 	"validate_dockerhub_config":  validateDockerhubConfigSync,
@@ -127,6 +134,22 @@ var customValidators = map[string]CustomValidator{
 	"validate_positive_number":   validatePositiveNumber,
 	"validate_subdomain":         validateSubdomain,
 	"validate_hostname":          validateHostnameStrict,
+	"validate_dns_resolves":      validateDNSResolves,
+}
+
+// DefaultAPIRequestTimeout is the default timeout of each request made by the API validators
+const DefaultAPIRequestTimeout = 10 * time.Second
+
+// apiHTTPClient is shared by the API validators so their requests reuse connections
+var apiHTTPClient = &http.Client{Timeout: DefaultAPIRequestTimeout}
+
+// SetAPIRequestTimeout sets the timeout of each request made by the API validators.
+// A timeout of zero or less restores DefaultAPIRequestTimeout.
+func SetAPIRequestTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultAPIRequestTimeout
+	}
+	apiHTTPClient = &http.Client{Timeout: timeout}
 }
 
 // asyncAPIValidators registry of all available async API validators
@@ -174,6 +197,31 @@ func validateSSHKeyOrURL(value any, _ map[string]any) error {
 	return fmt.Errorf("must be a valid SSH public key or URL")
 }
 
+// validateAuthorizedKeys validates an authorized_keys style value holding one SSH public key
+// or URL per line, reporting the first invalid line
+func validateAuthorizedKeys(value any, _ map[string]any) error {
+	if value == nil {
+		return nil // Optional field
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("must be a string")
+	}
+
+	if strings.TrimSpace(str) == "" {
+		return nil // Optional field
+	}
+
+	logging.DebugBool(verboseMode, "validateAuthorizedKeys called with value: '%s'", str)
+
+	if err := utils.ValidateAuthorizedKeys(str); err != nil {
+		return fmt.Errorf("must hold one valid SSH public key or URL per line: %w", err)
+	}
+
+	return nil
+}
+
 // validatePasswordStrength validates password strength and warns about weak passwords
 func validatePasswordStrength(value any, _ map[string]any) error {
 	str, ok := value.(string)
@@ -270,7 +318,7 @@ func validateCloudflareConfigAsync(ctx context.Context, value any, config map[st
 
 	// Perform actual Cloudflare API validation
 	logging.DebugBool(verboseMode, "validateCloudflareConfigAsync starting API calls for domain: %s", domain)
-	err := validateCloudflareCredentials(ctx, api, email, domain)
+	err := validateCloudflareCredentials(ctx, newCloudflareClient(api, email), domain)
 	duration := time.Since(startTime)
 
 	if err != nil {
@@ -333,7 +381,7 @@ func validateDockerhubConfigAsync(ctx context.Context, value any, _ map[string]a
 
 	// Perform actual Docker Hub authentication test
 	logging.DebugBool(verboseMode, "validateDockerhubConfigAsync starting API call for user: %s", username)
-	err := validateDockerhubCredentials(ctx, username, token)
+	err := validateDockerhubCredentials(ctx, dockerhubHTTPClient(), username, token)
 	duration := time.Since(startTime)
 
 	if err != nil {
@@ -387,7 +435,17 @@ func validateTimezone(value any, _ map[string]any) error {
 
 	logging.DebugBool(verboseMode, "validateTimezone called with value: '%s'", str)
 
-	if strings.ToLower(str) == "auto" {
+	if sbconfig.IsTimezoneAuto(str) {
+		// The resolved zone is shown by sb env, only detect it here when debugging
+		if verboseMode {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if zone, err := sbconfig.ResolveTimezone(ctx, str); err != nil {
+				logging.DebugBool(verboseMode, "validateTimezone - 'auto' could not be resolved: %v", err)
+			} else {
+				logging.DebugBool(verboseMode, "validateTimezone - 'auto' resolves to %s", zone)
+			}
+		}
 		return nil
 	}
 
@@ -417,8 +475,10 @@ func validateCronTime(value any, _ map[string]any) error {
 	}
 }
 
-// validateDirectoryPath validates directory paths
-func validateDirectoryPath(value any, _ map[string]any) error {
+// validateDirectoryPath validates directory path format. The path is only checked on disk
+// when asked for through the validator config: must_exist requires an existing directory and
+// must_be_writable requires the Saltbox user to be able to write to it, or to create it.
+func validateDirectoryPath(value any, config map[string]any) error {
 	str, ok := value.(string)
 	if !ok {
 		return fmt.Errorf("directory path must be a string")
@@ -441,9 +501,102 @@ func validateDirectoryPath(value any, _ map[string]any) error {
 		return fmt.Errorf("invalid directory path format: %s", str)
 	}
 
+	mustExist, _ := config["must_exist"].(bool)
+	mustBeWritable, _ := config["must_be_writable"].(bool)
+
+	if mustExist {
+		info, err := os.Stat(dirPath)
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("directory %s does not exist, create it or correct the path", dirPath)
+		}
+		if err != nil {
+			return fmt.Errorf("cannot access directory %s: %w", dirPath, err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("%s is not a directory", dirPath)
+		}
+	}
+
+	if mustBeWritable {
+		return validateDirectoryWritable(dirPath)
+	}
+
 	return nil
 }
 
+// validateDirectoryWritable checks that the Saltbox user can write to dirPath, or create it when
+// it doesn't exist yet by writing to its nearest existing parent. Only the permission bits are
+// checked, so ACLs and read-only mounts are not taken into account.
+func validateDirectoryWritable(dirPath string) error {
+	userName, err := lookupSaltboxUser()
+	if err != nil {
+		fmt.Printf("Warning: directory write check skipped for %s: %v\n", dirPath, err)
+		return nil
+	}
+	account, err := osuser.Lookup(userName)
+	if err != nil {
+		return fmt.Errorf("cannot check write access to %s: %w", dirPath, err)
+	}
+
+	// Walk up to the nearest existing directory, which is where a missing path would be created
+	target := dirPath
+	info, err := os.Stat(target)
+	for errors.Is(err, os.ErrNotExist) && filepath.Dir(target) != target {
+		target = filepath.Dir(target)
+		info, err = os.Stat(target)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot access %s: %w", target, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", target)
+	}
+
+	writable, err := dirWritableBy(info, account)
+	if err != nil {
+		return fmt.Errorf("cannot check write access to %s: %w", target, err)
+	}
+	if writable {
+		return nil
+	}
+	if target != dirPath {
+		return fmt.Errorf("%s cannot be created: %s is not writable by user '%s'", dirPath, target, userName)
+	}
+	return fmt.Errorf("%s is not writable by user '%s', fix its ownership (chown %s) or permissions", dirPath, userName, userName)
+}
+
+// dirWritableBy reports whether the permission bits of a directory let account create entries in it
+func dirWritableBy(info fs.FileInfo, account *osuser.User) (bool, error) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return false, fmt.Errorf("file ownership is not available")
+	}
+	if account.Uid == "0" {
+		return true, nil
+	}
+
+	owner := strconv.FormatUint(uint64(stat.Uid), 10)
+	group := strconv.FormatUint(uint64(stat.Gid), 10)
+	inGroup := account.Gid == group
+	if !inGroup {
+		// Supplementary groups can't be listed for every account, so a failed lookup only checks the primary group
+		if groups, err := account.GroupIds(); err == nil {
+			inGroup = slices.Contains(groups, group)
+		}
+	}
+
+	// Creating an entry needs both write and search permission
+	const write, search = 0o2, 0o1
+	bits := uint32(info.Mode().Perm())
+	switch {
+	case account.Uid == owner:
+		bits >>= 6
+	case inGroup:
+		bits >>= 3
+	}
+	return bits&write != 0 && bits&search != 0, nil
+}
+
 // validateRcloneTemplate validates rclone template types
 func validateRcloneTemplate(value any, _ map[string]any) error {
 	str, ok := value.(string)
@@ -487,13 +640,13 @@ func validateRcloneRemote(value any, _ map[string]any) error {
 	}
 
 	logging.DebugBool(verboseMode, "validateRcloneRemote - checking remote name: '%s'", remoteName)
-	if err := sbconfig.ValidateRcloneRemote(remoteName, verboseMode); err != nil {
+	if err := checkRcloneRemote(remoteName, verboseMode); err != nil {
 		switch {
 		case errors.Is(err, sbconfig.ErrRcloneNotInstalled):
-			fmt.Printf("Warning: rclone remote validation skipped: rclone is not installed")
+			fmt.Println("Warning: rclone remote validation skipped: rclone is not installed")
 			return nil
 		case errors.Is(err, sbconfig.ErrSystemUserNotFound), errors.Is(err, sbconfig.ErrRcloneConfigNotFound):
-			fmt.Printf("Warning: rclone remote validation skipped: %v", err)
+			fmt.Printf("Warning: rclone remote validation skipped: %v\n", err)
 			return nil
 		default:
 			return err
@@ -511,21 +664,15 @@ func isValidSSHKey(key string) bool {
 }
 
 // validateCloudflareCredentials performs actual Cloudflare API validation
-func validateCloudflareCredentials(ctx context.Context, apiKey, email, domain string) error {
+func validateCloudflareCredentials(ctx context.Context, api CloudflareClient, domain string) error {
 	logging.DebugBool(verboseMode, "validateCloudflareCredentials called for domain: %s", domain)
 
-	// Create Cloudflare API client with timeout
-	api := cloudflare.NewClient(
-		option.WithAPIKey(apiKey),
-		option.WithAPIEmail(email),
-		option.WithHTTPClient(&http.Client{
-			Timeout: 10 * time.Second, // 10 second timeout per request
-		}),
-	)
-
 	// Verify API key
 	logging.DebugBool(verboseMode, "validateCloudflareCredentials - verifying API key")
-	_, err := api.User.Get(ctx)
+	err := retry.Do(ctx, cloudflareRetry, func(ctx context.Context) error {
+		_, err := api.GetUser(ctx)
+		return limitCloudflareWait(err)
+	})
 	if err != nil {
 		return fmt.Errorf("cloudflare API key verification failed: %w", err)
 	}
@@ -540,8 +687,13 @@ func validateCloudflareCredentials(ctx context.Context, apiKey, email, domain st
 	// Verify domain ownership
 	logging.DebugBool(verboseMode, "validateCloudflareCredentials - checking domain ownership for %s", rootDomain)
 	domainStart := time.Now()
-	zonesList, err := api.Zones.List(ctx, zones.ZoneListParams{
-		Name: cloudflare.F(rootDomain),
+	var zonesList *pagination.V4PagePaginationArray[zones.Zone]
+	err = retry.Do(ctx, cloudflareRetry, func(ctx context.Context) error {
+		var err error
+		zonesList, err = api.ListZones(ctx, zones.ZoneListParams{
+			Name: cloudflare.F(rootDomain),
+		})
+		return limitCloudflareWait(err)
 	})
 
 	if err != nil {
@@ -560,8 +712,13 @@ func validateCloudflareCredentials(ctx context.Context, apiKey, email, domain st
 	// Check SSL settings directly (most efficient approach)
 	logging.DebugBool(verboseMode, "validateCloudflareCredentials - checking SSL settings")
 	sslStart := time.Now()
-	sslSettings, err := api.Zones.Settings.Get(ctx, "ssl", zones.SettingGetParams{
-		ZoneID: cloudflare.F(zoneID),
+	var sslSettings *zones.SettingGetResponse
+	err = retry.Do(ctx, cloudflareRetry, func(ctx context.Context) error {
+		var err error
+		sslSettings, err = api.GetZoneSetting(ctx, "ssl", zones.SettingGetParams{
+			ZoneID: cloudflare.F(zoneID),
+		})
+		return limitCloudflareWait(err)
 	})
 	if err != nil {
 		return fmt.Errorf("failed to get zone SSL settings: %w", err)
@@ -588,44 +745,137 @@ func validateCloudflareCredentials(ctx context.Context, apiKey, email, domain st
 	return nil
 }
 
+// cloudflareRetry retries Cloudflare calls that failed to connect, timed out, were rate limited
+// or hit a server error, up to twice. Each wait follows the reset time sent by Cloudflare, with
+// an exponential delay when none was sent.
+var cloudflareRetry = retry.Options{
+	MaxAttempts: 3,
+	BaseDelay:   2 * time.Second,
+	Retryable:   cloudflareRetryable,
+	RetryAfter:  cloudflareRetryAfter,
+	OnRetry: func(attempt int, err error, delay time.Duration) {
+		logging.DebugBool(verboseMode, "validateCloudflareCredentials - attempt %d failed: %v, retrying in %v", attempt, err, delay)
+	},
+}
+
+// cloudflareRetryable reports whether a failed Cloudflare call is worth retrying: network errors
+// and responses with a retryable status are, rejected credentials and other client errors aren't
+func cloudflareRetryable(err error) bool {
+	if apiErr, ok := errors.AsType[*cloudflare.Error](err); ok {
+		return retry.RetryableStatus(apiErr.StatusCode)
+	}
+	_, ok := errors.AsType[net.Error](err)
+	return ok
+}
+
+// cloudflareRetryAfter returns the wait before retrying a rate limited or unavailable call,
+// if Cloudflare sent one
+func cloudflareRetryAfter(err error) (time.Duration, bool) {
+	apiErr, ok := errors.AsType[*cloudflare.Error](err)
+	if !ok || !retry.RetryableStatus(apiErr.StatusCode) || apiErr.Response == nil {
+		return 0, false
+	}
+	return parseRetryAfter(apiErr.Response.Header)
+}
+
+// limitCloudflareWait stops retrying a call when Cloudflare asks for a wait longer than the
+// API request timeout, so validation can't hang
+func limitCloudflareWait(err error) error {
+	if delay, ok := cloudflareRetryAfter(err); ok && delay > apiHTTPClient.Timeout {
+		return retry.Permanent(fmt.Errorf("cloudflare asked to retry after %s: %w", delay.Round(time.Second), err))
+	}
+	return err
+}
+
+// parseRetryAfter reads the wait time from a Retry-After header, in seconds or as an HTTP date,
+// or from the reset parameter of a RateLimit header such as `"default";r=0;t=30`
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return max(0, time.Until(at)), true
+		}
+	}
+	if value := header.Get("Ratelimit"); value != "" {
+		for param := range strings.SplitSeq(value, ";") {
+			if reset, ok := strings.CutPrefix(strings.TrimSpace(param), "t="); ok {
+				if seconds, err := strconv.Atoi(reset); err == nil && seconds >= 0 {
+					return time.Duration(seconds) * time.Second, true
+				}
+			}
+		}
+	}
+	return 0, false
+}
+
+// dockerhubRetry retries the Docker Hub login when the request fails or Docker Hub is rate
+// limiting or having problems, so a network hiccup isn't reported as bad credentials
+var dockerhubRetry = retry.Options{
+	MaxAttempts: 3,
+	BaseDelay:   time.Second,
+	MaxDelay:    5 * time.Second,
+	Jitter:      0.2,
+	OnRetry: func(attempt int, err error, delay time.Duration) {
+		logging.DebugBool(verboseMode, "validateDockerhubCredentials - attempt %d failed: %v, retrying in %v", attempt, err, delay)
+	},
+}
+
 // validateDockerhubCredentials performs actual Docker Hub authentication
-func validateDockerhubCredentials(ctx context.Context, username, token string) error {
+func validateDockerhubCredentials(ctx context.Context, client HTTPDoer, username, token string) error {
 	logging.DebugBool(verboseMode, "validateDockerhubCredentials called for username: %s", username)
 
+	return retry.Do(ctx, dockerhubRetry, func(ctx context.Context) error {
+		return loginDockerhub(ctx, client, username, token)
+	})
+}
+
+// loginDockerhub makes a single Docker Hub login attempt. Failures that retrying can't fix,
+// like rejected credentials, are wrapped with retry.Permanent.
+func loginDockerhub(ctx context.Context, client HTTPDoer, username, token string) error {
 	dockerhubLoginUrl := "https://hub.docker.com/v2/users/login/"
 	payload := strings.NewReader(fmt.Sprintf(`{"username": "%s", "password": "%s"}`, username, token))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dockerhubLoginUrl, payload)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return retry.Permanent(fmt.Errorf("failed to create request: %w", err))
 	}
 
 	req.Header.Add("Content-Type", "application/json")
 
-	// Use client with timeout
-	client := &http.Client{
-		Timeout: 10 * time.Second, // 10 second timeout
-	}
 	res, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to make request: %w", err)
 	}
 	defer func() { _ = res.Body.Close() }()
 
-	if res.StatusCode != http.StatusOK {
-		var respBody map[string]any
-		if json.NewDecoder(res.Body).Decode(&respBody) == nil {
-			if message, ok := respBody["message"].(string); ok {
-				return fmt.Errorf("docker hub authentication failed (HTTP %d): %s", res.StatusCode, message)
-			}
-			if details, ok := respBody["details"].(string); ok {
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	err = dockerhubLoginError(res)
+	if !retry.RetryableStatus(res.StatusCode) {
+		return retry.Permanent(err)
+	}
+	return err
+}
+
+// dockerhubLoginError describes a failed login response, with the message from its body if any
+func dockerhubLoginError(res *http.Response) error {
+	var respBody map[string]any
+	if json.NewDecoder(res.Body).Decode(&respBody) == nil {
+		if message, ok := respBody["message"].(string); ok {
+			return fmt.Errorf("docker hub authentication failed (HTTP %d): %s", res.StatusCode, message)
+		}
+		// Docker Hub reports rejected credentials in "detail"
+		for _, key := range []string{"detail", "details"} {
+			if details, ok := respBody[key].(string); ok {
 				return fmt.Errorf("docker hub authentication failed (HTTP %d): %s", res.StatusCode, details)
 			}
 		}
-		return fmt.Errorf("docker Hub authentication failed (HTTP %d)", res.StatusCode)
 	}
-
-	return nil
+	return fmt.Errorf("docker Hub authentication failed (HTTP %d)", res.StatusCode)
 }
 
 // getRootDomain extracts the root domain from a FQDN
@@ -782,6 +1032,82 @@ func validateHostnameStrict(value any, _ map[string]any) error {
 	return nil
 }
 
+// dnsLookupTimeout bounds the lookup made by validateDNSResolves
+const dnsLookupTimeout = 5 * time.Second
+
+// hostResolver looks up the addresses of a hostname, implemented by *net.Resolver
+type hostResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// dnsResolver is the resolver used by validateDNSResolves, replaced in tests
+var dnsResolver hostResolver = net.DefaultResolver
+
+// dnsFailuresFatal makes validateDNSResolves fail validation instead of printing a warning
+var dnsFailuresFatal bool
+
+// SetDNSFailuresFatal makes hostnames that don't resolve fail validation instead of only warning
+func SetDNSFailuresFatal(fatal bool) {
+	dnsFailuresFatal = fatal
+}
+
+// validateDNSResolves checks that a hostname resolves to at least one address, so a domain
+// whose DNS isn't set up yet is caught before an install fails at the TLS or proxy stage.
+// Failures only print a warning unless SetDNSFailuresFatal was called, and offline the check is skipped.
+func validateDNSResolves(value any, _ map[string]any) error {
+	str, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("hostname must be a string")
+	}
+
+	logging.DebugBool(verboseMode, "validateDNSResolves called with value: '%s'", str)
+
+	if str == "" || offlineMode {
+		logging.DebugBool(verboseMode, "validateDNSResolves - skipped (empty value or offline)")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dnsLookupTimeout)
+	defer cancel()
+
+	err := lookupHostError(ctx, str)
+	if err == nil {
+		logging.DebugBool(verboseMode, "validateDNSResolves - %s resolves", str)
+		return nil
+	}
+	if dnsFailuresFatal {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "WARNING: %v. Make sure its DNS records are set up before installing.\n", err)
+	return nil
+}
+
+// lookupHostError resolves host and describes why it doesn't resolve, telling a missing
+// record (NXDOMAIN) apart from a lookup that timed out
+func lookupHostError(ctx context.Context, host string) error {
+	addrs, err := dnsResolver.LookupHost(ctx, host)
+	if err == nil {
+		if len(addrs) == 0 {
+			return fmt.Errorf("%s has no A or AAAA records", host)
+		}
+		return nil
+	}
+
+	if dnsErr, ok := errors.AsType[*net.DNSError](err); ok {
+		switch {
+		case dnsErr.IsNotFound:
+			return fmt.Errorf("%s does not resolve (NXDOMAIN)", host)
+		case dnsErr.IsTimeout:
+			return fmt.Errorf("DNS lookup of %s timed out", host)
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("DNS lookup of %s timed out", host)
+	}
+	return fmt.Errorf("DNS lookup of %s failed: %w", host, err)
+}
+
 // validateWholeNumber validates that a value is a whole number (integer)
 func validateWholeNumber(value any, _ map[string]any) error {
 	logging.DebugBool(verboseMode, "validateWholeNumber called with value: %v (type: %T)", value, value)
@@ -1,7 +1,30 @@
 package validate
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	osuser "os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
+
+	sbconfig "github.com/saltyorg/sb-go/internal/config"
+	"github.com/saltyorg/sb-go/internal/retry"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/option"
+	"github.com/cloudflare/cloudflare-go/v7/packages/pagination"
+	"github.com/cloudflare/cloudflare-go/v7/user"
+	"github.com/cloudflare/cloudflare-go/v7/zones"
 )
 
 func TestValidateSubdomain(t *testing.T) {
@@ -429,6 +452,15 @@ func TestValidateURL(t *testing.T) {
 	}
 }
 
+// Well-formed key data used by the SSH key tests
+const (
+	testEd25519KeyData = "AAAAC3NzaC1lZDI1NTE5AAAAIDIN8H7CKUHcIFf5wnLlbqwncTTFfya7AJTIm6JzUPzS"
+	testECDSAKeyData   = "AAAAE2VjZHNhLXNoYTItbmlzdHAyNTYAAAAIbmlzdHAyNTYAAABBBJ2pASu3/eJPaN/pOguNl+BON2ncciJdWK6TK7fl2mBmMxzHQ7CcKf6BUSMeiHkNqg/NccF9GETDXTRUNqHhV9U="
+	testRSAKeyData     = "AAAAB3NzaC1yc2EAAAAAAAAAAA=="
+	testSKECDSAKeyData = "AAAAInNrLWVjZHNhLXNoYTItbmlzdHAyNTZAb3BlbnNzaC5jb20AAAAAAAAAAA=="
+	testXMSSKeyData    = "AAAAFHNzaC14bXNzQG9wZW5zc2guY29tAAAAAAAAAAA="
+)
+
 func TestIsValidSSHKey(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -437,37 +469,37 @@ func TestIsValidSSHKey(t *testing.T) {
 	}{
 		{
 			name:  "Valid ssh-rsa key",
-			key:   "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABgQC... user@host",
+			key:   "ssh-rsa " + testRSAKeyData + " user@host",
 			valid: true,
 		},
 		{
 			name:  "Valid sk-ecdsa key",
-			key:   "sk-ecdsa-sha2-nistp256@openssh.com AAAAInNrLWVjZHNh... user@host",
+			key:   "sk-ecdsa-sha2-nistp256@openssh.com " + testSKECDSAKeyData + " user@host",
 			valid: true,
 		},
 		{
 			name:  "Valid ssh-xmss key",
-			key:   "ssh-xmss@openssh.com AAAAB3NzaC1yc2EAAAADAQABAAABgQC... user@host",
+			key:   "ssh-xmss@openssh.com " + testXMSSKeyData + " user@host",
 			valid: true,
 		},
 		{
 			name:  "Valid rsa-sha2-512 key",
-			key:   "rsa-sha2-512 AAAAB3NzaC1yc2EAAAADAQABAAABgQC... user@host",
+			key:   "rsa-sha2-512 " + testRSAKeyData + " user@host",
 			valid: true,
 		},
 		{
 			name:  "Valid key with options",
-			key:   "command=\"echo hello world\" ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAI... user@host",
+			key:   "command=\"echo hello world\" ssh-ed25519 " + testEd25519KeyData + " user@host",
 			valid: true,
 		},
 		{
 			name:  "Valid ssh-ed25519 key",
-			key:   "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAI... user@host",
+			key:   "ssh-ed25519 " + testEd25519KeyData + " user@host",
 			valid: true,
 		},
 		{
 			name:  "Valid ecdsa key",
-			key:   "ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNT... user@host",
+			key:   "ecdsa-sha2-nistp256 " + testECDSAKeyData + " user@host",
 			valid: true,
 		},
 		{
@@ -475,9 +507,29 @@ func TestIsValidSSHKey(t *testing.T) {
 			key:   "ssh-rsa",
 			valid: false,
 		},
+		{
+			name:  "Invalid - key data is not base64",
+			key:   "ssh-ed25519 garbage user@host",
+			valid: false,
+		},
+		{
+			name:  "Invalid - key data of another key type",
+			key:   "ssh-ed25519 " + testRSAKeyData + " user@host",
+			valid: false,
+		},
+		{
+			name:  "Invalid - truncated key data",
+			key:   "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5 user@host",
+			valid: false,
+		},
+		{
+			name:  "Invalid - control characters in comment",
+			key:   "ssh-ed25519 " + testEd25519KeyData + " user\x07@host",
+			valid: false,
+		},
 		{
 			name:  "Invalid - unknown key type",
-			key:   "unknown-type AAAAB3NzaC1yc2EAAAADAQABAAABgQC... user@host",
+			key:   "unknown-type " + testRSAKeyData + " user@host",
 			valid: false,
 		},
 		{
@@ -515,7 +567,7 @@ func TestValidateSSHKeyOrURL(t *testing.T) {
 		},
 		{
 			name:    "Valid multiple keys with comments",
-			value:   "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAI... user@host\n# comment\nssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABgQC... user@host",
+			value:   "ssh-ed25519 " + testEd25519KeyData + " user@host\n# comment\nssh-rsa " + testRSAKeyData + " user@host",
 			wantErr: false,
 		},
 		{
@@ -548,6 +600,58 @@ func TestValidateSSHKeyOrURL(t *testing.T) {
 	}
 }
 
+func TestValidateAuthorizedKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		wantErr string
+	}{
+		{
+			name:  "Valid keys, URLs and comments",
+			value: "# laptop\nssh-ed25519 " + testEd25519KeyData + " user@laptop\n\nhttps://github.com/user.keys\necdsa-sha2-nistp256 " + testECDSAKeyData + "\n",
+		},
+		{
+			name:  "Empty value",
+			value: "",
+		},
+		{
+			name:    "Invalid key data reported with its line",
+			value:   "# keys\nssh-ed25519 " + testEd25519KeyData + " user@laptop\n\nssh-ed25519 garbage user@desktop\nnot-a-key",
+			wantErr: "line 4: ssh-ed25519 key data is not valid base64",
+		},
+		{
+			name:    "Unknown key type reported with its line",
+			value:   "ssh-rsa " + testRSAKeyData + "\nnot-a-key",
+			wantErr: "line 2: no supported key type found",
+		},
+		{
+			name:    "Only comments",
+			value:   "# no keys here\n",
+			wantErr: "no SSH public keys or URLs found",
+		},
+		{
+			name:    "Non-string",
+			value:   123,
+			wantErr: "must be a string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAuthorizedKeys(tt.value, nil)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
 func TestValidateSubdomainCharacters(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -598,3 +702,603 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestSetAPIRequestTimeout(t *testing.T) {
+	t.Cleanup(func() { SetAPIRequestTimeout(DefaultAPIRequestTimeout) })
+
+	SetAPIRequestTimeout(3 * time.Second)
+	if apiHTTPClient.Timeout != 3*time.Second {
+		t.Errorf("timeout = %s, want 3s", apiHTTPClient.Timeout)
+	}
+
+	SetAPIRequestTimeout(0)
+	if apiHTTPClient.Timeout != DefaultAPIRequestTimeout {
+		t.Errorf("timeout = %s, want the default %s", apiHTTPClient.Timeout, DefaultAPIRequestTimeout)
+	}
+}
+
+// rateLimitedCloudflare returns a Cloudflare client for a server that rate limits the first
+// limited requests with the given Retry-After header, and the number of requests it received
+func rateLimitedCloudflare(t *testing.T, limited int, retryAfter string) (*cloudflare.Client, *int) {
+	t.Helper()
+	return failingCloudflare(t, http.StatusTooManyRequests, limited, retryAfter)
+}
+
+// failingCloudflare returns a Cloudflare client for a server that answers the first failures
+// requests with status and the given Retry-After header, and the number of requests it received
+func failingCloudflare(t *testing.T, status, failures int, retryAfter string) (*cloudflare.Client, *int) {
+	t.Helper()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		if requests <= failures {
+			w.Header().Set("Retry-After", retryAfter)
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":971,"message":"Please wait and consider throttling your request speed"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"errors":[],"result":{"id":"user"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return cloudflare.NewClient(
+		option.WithBaseURL(server.URL),
+		option.WithAPIToken("token"),
+		option.WithHTTPClient(apiHTTPClient),
+		option.WithMaxRetries(0),
+	), &requests
+}
+
+// getUserWithRetry gets the Cloudflare user like validateCloudflareCredentials does
+func getUserWithRetry(api *cloudflare.Client) error {
+	return retry.Do(context.Background(), cloudflareRetry, func(ctx context.Context) error {
+		_, err := api.User.Get(ctx)
+		return limitCloudflareWait(err)
+	})
+}
+
+func TestCloudflareRetry(t *testing.T) {
+	t.Run("retries after the rate limit resets", func(t *testing.T) {
+		api, requests := rateLimitedCloudflare(t, 2, "0")
+		if err := getUserWithRetry(api); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *requests != 3 {
+			t.Errorf("requests = %d, want 3", *requests)
+		}
+	})
+
+	t.Run("gives up after the retries", func(t *testing.T) {
+		api, requests := rateLimitedCloudflare(t, 5, "0")
+		if err := getUserWithRetry(api); err == nil {
+			t.Fatal("expected the rate limit error")
+		}
+		if *requests != cloudflareRetry.MaxAttempts {
+			t.Errorf("requests = %d, want %d", *requests, cloudflareRetry.MaxAttempts)
+		}
+	})
+
+	t.Run("retries server errors", func(t *testing.T) {
+		api, requests := failingCloudflare(t, http.StatusServiceUnavailable, 1, "0")
+		if err := getUserWithRetry(api); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *requests != 2 {
+			t.Errorf("requests = %d, want 2", *requests)
+		}
+	})
+
+	t.Run("does not retry rejected credentials", func(t *testing.T) {
+		api, requests := failingCloudflare(t, http.StatusForbidden, 1, "0")
+		if err := getUserWithRetry(api); err == nil {
+			t.Fatal("expected the authentication error")
+		}
+		if *requests != 1 {
+			t.Errorf("requests = %d, want 1", *requests)
+		}
+	})
+
+	t.Run("connection failures are retryable", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		api := cloudflare.NewClient(
+			option.WithBaseURL(server.URL),
+			option.WithAPIToken("token"),
+			option.WithHTTPClient(apiHTTPClient),
+			option.WithMaxRetries(0),
+		)
+		_, err := api.User.Get(context.Background())
+		if err == nil {
+			t.Fatal("expected a connection error")
+		}
+		if !cloudflareRetryable(err) {
+			t.Errorf("connection error %v is not retryable", err)
+		}
+	})
+
+	t.Run("does not wait longer than the request timeout", func(t *testing.T) {
+		api, requests := rateLimitedCloudflare(t, 1, "3600")
+		err := getUserWithRetry(api)
+		if err == nil || !strings.Contains(err.Error(), "retry after 1h0m0s") {
+			t.Fatalf("error = %v, want a retry after error", err)
+		}
+		if *requests != 1 {
+			t.Errorf("requests = %d, want 1", *requests)
+		}
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+		wantOK bool
+	}{
+		{"seconds", http.Header{"Retry-After": {"5"}}, 5 * time.Second, true},
+		{"ratelimit reset", http.Header{"Ratelimit": {`"default";r=0;t=30`}}, 30 * time.Second, true},
+		{"past date", http.Header{"Retry-After": {"Mon, 02 Jan 2006 15:04:05 GMT"}}, 0, true},
+		{"missing", http.Header{}, 0, false},
+		{"invalid", http.Header{"Retry-After": {"soon"}}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.header)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseRetryAfter = %s, %v, want %s, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// fakeResolver returns canned lookup results, keyed by hostname
+type fakeResolver map[string]error
+
+func (r fakeResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	if err, ok := r[host]; ok {
+		return nil, err
+	}
+	return []string{"192.0.2.1"}, nil
+}
+
+func useFakeResolver(t *testing.T, resolver hostResolver) {
+	t.Helper()
+	previous := dnsResolver
+	dnsResolver = resolver
+	t.Cleanup(func() { dnsResolver = previous })
+}
+
+func TestValidateDNSResolves(t *testing.T) {
+	useFakeResolver(t, fakeResolver{
+		"missing.example.com": &net.DNSError{Err: "no such host", Name: "missing.example.com", IsNotFound: true},
+		"slow.example.com":    &net.DNSError{Err: "i/o timeout", Name: "slow.example.com", IsTimeout: true},
+	})
+	t.Cleanup(func() {
+		SetDNSFailuresFatal(false)
+		SetOffline(false)
+	})
+
+	SetDNSFailuresFatal(true)
+	tests := []struct {
+		name    string
+		value   any
+		wantErr string
+	}{
+		{"resolves", "example.com", ""},
+		{"empty", "", ""},
+		{"nxdomain", "missing.example.com", "does not resolve (NXDOMAIN)"},
+		{"timeout", "slow.example.com", "timed out"},
+		{"not a string", 42, "must be a string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDNSResolves(tt.value, nil)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("warning only by default", func(t *testing.T) {
+		SetDNSFailuresFatal(false)
+		if err := validateDNSResolves("missing.example.com", nil); err != nil {
+			t.Errorf("expected only a warning, got error: %v", err)
+		}
+	})
+
+	t.Run("skipped offline", func(t *testing.T) {
+		SetDNSFailuresFatal(true)
+		SetOffline(true)
+		if err := validateDNSResolves("missing.example.com", nil); err != nil {
+			t.Errorf("expected the lookup to be skipped offline, got: %v", err)
+		}
+	})
+}
+
+func TestValidateDNSResolvesIsRegistered(t *testing.T) {
+	if _, ok := customValidators["validate_dns_resolves"]; !ok {
+		t.Error("validate_dns_resolves is not registered")
+	}
+}
+
+// fakeCloudflare answers the Cloudflare calls made while validating credentials
+type fakeCloudflare struct {
+	userErr     error
+	zones       []zones.Zone
+	ssl         zones.SettingGetResponseZonesSchemasSSLValue
+	queriedZone string
+}
+
+func (f *fakeCloudflare) GetUser(context.Context) (*user.UserGetResponse, error) {
+	if f.userErr != nil {
+		return nil, f.userErr
+	}
+	return &user.UserGetResponse{}, nil
+}
+
+func (f *fakeCloudflare) ListZones(_ context.Context, params zones.ZoneListParams) (*pagination.V4PagePaginationArray[zones.Zone], error) {
+	f.queriedZone = params.Name.Value
+	return &pagination.V4PagePaginationArray[zones.Zone]{Result: f.zones}, nil
+}
+
+func (f *fakeCloudflare) GetZoneSetting(context.Context, string, zones.SettingGetParams) (*zones.SettingGetResponse, error) {
+	return &zones.SettingGetResponse{Value: f.ssl}, nil
+}
+
+func TestValidateCloudflareCredentials(t *testing.T) {
+	zone := []zones.Zone{{ID: "zone", Name: "example.co.uk"}}
+	tests := []struct {
+		name    string
+		api     *fakeCloudflare
+		wantErr string
+	}{
+		{
+			name: "valid credentials",
+			api:  &fakeCloudflare{zones: zone, ssl: zones.SettingGetResponseZonesSchemasSSLValueStrict},
+		},
+		{
+			name:    "invalid API key",
+			api:     &fakeCloudflare{userErr: errors.New("unauthorized")},
+			wantErr: "cloudflare API key verification failed",
+		},
+		{
+			name:    "zone not in account",
+			api:     &fakeCloudflare{ssl: zones.SettingGetResponseZonesSchemasSSLValueStrict},
+			wantErr: "example.co.uk not found in Cloudflare account",
+		},
+		{
+			name:    "flexible SSL",
+			api:     &fakeCloudflare{zones: zone, ssl: zones.SettingGetResponseZonesSchemasSSLValueFlexible},
+			wantErr: "incompatible SSL/TLS mode detected: 'flexible'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCloudflareCredentials(context.Background(), tt.api, "sub.example.co.uk")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if tt.api.queriedZone != "example.co.uk" {
+					t.Errorf("queried zone %q, want the root domain example.co.uk", tt.api.queriedZone)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCloudflareConfigAsyncUsesClient(t *testing.T) {
+	var gotKey, gotEmail string
+	previous := newCloudflareClient
+	newCloudflareClient = func(apiKey, email string) CloudflareClient {
+		gotKey, gotEmail = apiKey, email
+		return &fakeCloudflare{zones: []zones.Zone{{ID: "zone"}}, ssl: zones.SettingGetResponseZonesSchemasSSLValueFull}
+	}
+	t.Cleanup(func() { newCloudflareClient = previous })
+
+	err := validateCloudflareConfigAsync(context.Background(),
+		map[string]any{"api": "key", "email": "user@example.com"},
+		map[string]any{"user": map[string]any{"domain": "example.com"}},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotKey != "key" || gotEmail != "user@example.com" {
+		t.Errorf("client created with %q, %q", gotKey, gotEmail)
+	}
+}
+
+// doerFunc adapts a function to the HTTPDoer interface
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func dockerhubResponse(status int, body string) doerFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	}
+}
+
+// fastDockerhubRetry shortens the waits between Docker Hub login attempts
+func fastDockerhubRetry(t *testing.T) {
+	t.Helper()
+	previous := dockerhubRetry
+	t.Cleanup(func() { dockerhubRetry = previous })
+	dockerhubRetry.BaseDelay = time.Millisecond
+	dockerhubRetry.MaxDelay = time.Millisecond
+}
+
+func TestValidateDockerhubCredentials(t *testing.T) {
+	fastDockerhubRetry(t)
+
+	tests := []struct {
+		name    string
+		client  HTTPDoer
+		wantErr string
+	}{
+		{
+			name:   "valid credentials",
+			client: dockerhubResponse(http.StatusOK, `{"token":"jwt"}`),
+		},
+		{
+			name:    "rejected credentials",
+			client:  dockerhubResponse(http.StatusUnauthorized, `{"detail":"Incorrect authentication credentials"}`),
+			wantErr: "docker hub authentication failed (HTTP 401): Incorrect authentication credentials",
+		},
+		{
+			name:    "rejected without details",
+			client:  dockerhubResponse(http.StatusTooManyRequests, `not json`),
+			wantErr: "authentication failed (HTTP 429)",
+		},
+		{
+			name: "network error",
+			client: doerFunc(func(*http.Request) (*http.Response, error) {
+				return nil, errors.New("connection refused")
+			}),
+			wantErr: "failed to make request: connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDockerhubCredentials(context.Background(), tt.client, "user", "token")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateDockerhubCredentialsRetries(t *testing.T) {
+	fastDockerhubRetry(t)
+
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantRequests int
+		wantErr      bool
+	}{
+		{"server error then success", []int{http.StatusServiceUnavailable, http.StatusOK}, 2, false},
+		{"rate limited throughout", []int{http.StatusTooManyRequests}, 3, true},
+		{"rejected credentials are not retried", []int{http.StatusUnauthorized}, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			client := doerFunc(func(req *http.Request) (*http.Response, error) {
+				status := tt.statuses[min(requests, len(tt.statuses)-1)]
+				requests++
+				return dockerhubResponse(status, `{}`)(req)
+			})
+
+			err := validateDockerhubCredentials(context.Background(), client, "user", "token")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, want error: %v", err, tt.wantErr)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestValidateDockerhubCredentialsRequest(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	client := doerFunc(func(req *http.Request) (*http.Response, error) {
+		got = req
+		body, _ = io.ReadAll(req.Body)
+		return dockerhubResponse(http.StatusOK, `{}`)(req)
+	})
+
+	if err := validateDockerhubCredentials(context.Background(), client, "user", "token"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Method != http.MethodPost || got.URL.Host != "hub.docker.com" {
+		t.Errorf("unexpected request: %s %s", got.Method, got.URL)
+	}
+
+	var payload map[string]string
+	if err := json.Unmarshal(body, &payload); err != nil || payload["username"] != "user" || payload["password"] != "token" {
+		t.Errorf("unexpected payload %s (%v)", body, err)
+	}
+}
+
+func TestValidateRcloneRemote(t *testing.T) {
+	tests := []struct {
+		name     string
+		checkErr error
+		wantErr  bool
+	}{
+		{name: "remote exists"},
+		{name: "rclone not installed", checkErr: sbconfig.ErrRcloneNotInstalled},
+		{name: "no saltbox user", checkErr: sbconfig.ErrSystemUserNotFound},
+		{name: "no rclone config", checkErr: sbconfig.ErrRcloneConfigNotFound},
+		{name: "remote missing", checkErr: errors.New("rclone remote 'gdrive' not found in configuration"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var checked string
+			previous := checkRcloneRemote
+			checkRcloneRemote = func(remoteName string, _ bool) error {
+				checked = remoteName
+				return tt.checkErr
+			}
+			t.Cleanup(func() { checkRcloneRemote = previous })
+
+			err := validateRcloneRemote("gdrive:/media", nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if checked != "gdrive" {
+				t.Errorf("checked remote %q, want gdrive", checked)
+			}
+		})
+	}
+}
+
+func TestValidateDirectoryPathOnDisk(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing")
+	mustExist := map[string]any{"must_exist": true}
+
+	if err := validateDirectoryPath(missing, nil); err != nil {
+		t.Errorf("format-only check touched the filesystem: %v", err)
+	}
+	if err := validateDirectoryPath(dir, mustExist); err != nil {
+		t.Errorf("unexpected error for an existing directory: %v", err)
+	}
+	if err := validateDirectoryPath(missing, mustExist); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("error = %v, want does not exist", err)
+	}
+	if err := validateDirectoryPath(file, mustExist); err == nil || !strings.Contains(err.Error(), "is not a directory") {
+		t.Errorf("error = %v, want is not a directory", err)
+	}
+}
+
+func TestValidateDirectoryPathWritable(t *testing.T) {
+	current, err := osuser.Current()
+	if err != nil {
+		t.Skip("current user unavailable")
+	}
+	previous := lookupSaltboxUser
+	lookupSaltboxUser = func() (string, error) { return current.Username, nil }
+	t.Cleanup(func() { lookupSaltboxUser = previous })
+
+	dir := t.TempDir()
+	mustBeWritable := map[string]any{"must_be_writable": true}
+	if err := validateDirectoryPath(dir, mustBeWritable); err != nil {
+		t.Errorf("unexpected error for a writable directory: %v", err)
+	}
+	// A missing directory is checked through its nearest existing parent
+	if err := validateDirectoryPath(filepath.Join(dir, "a", "b"), mustBeWritable); err != nil {
+		t.Errorf("unexpected error for a creatable directory: %v", err)
+	}
+
+	lookupSaltboxUser = func() (string, error) { return "", errors.New("accounts.yml not found") }
+	if err := validateDirectoryPath(dir, mustBeWritable); err != nil {
+		t.Errorf("expected the check to be skipped without a Saltbox user, got: %v", err)
+	}
+}
+
+func TestDirWritableBy(t *testing.T) {
+	dir := t.TempDir()
+	if os.Geteuid() == 0 {
+		// Root passes every check, so hand the directory to an unprivileged owner
+		if err := os.Chown(dir, 4243, 4243); err != nil {
+			t.Fatal(err)
+		}
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stat := info.Sys().(*syscall.Stat_t)
+	owner := strconv.FormatUint(uint64(stat.Uid), 10)
+	group := strconv.FormatUint(uint64(stat.Gid), 10)
+
+	const stranger = "4242424"
+	tests := []struct {
+		name    string
+		mode    os.FileMode
+		account osuser.User
+		want    bool
+	}{
+		{"owner with write", 0o700, osuser.User{Uid: owner, Gid: stranger}, true},
+		{"owner without write", 0o577, osuser.User{Uid: owner, Gid: stranger}, false},
+		{"group with write", 0o770, osuser.User{Uid: stranger, Gid: group}, true},
+		{"group without write", 0o755, osuser.User{Uid: stranger, Gid: group}, false},
+		{"other with write", 0o777, osuser.User{Uid: stranger, Gid: stranger, Username: "sb-test-missing"}, true},
+		{"other without write", 0o775, osuser.User{Uid: stranger, Gid: stranger, Username: "sb-test-missing"}, false},
+		{"root", 0o500, osuser.User{Uid: "0"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.Chmod(dir, tt.mode); err != nil {
+				t.Fatal(err)
+			}
+			info, err := os.Stat(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := dirWritableBy(info, &tt.account)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("dirWritableBy = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	_ = os.Chmod(dir, 0o700)
+}
+
+func TestSchemaRuleValidatorConfig(t *testing.T) {
+	parent := map[string]any{"user": "value"}
+
+	plain := &SchemaRule{}
+	if got := plain.validatorConfig(parent); len(got) != 1 {
+		t.Errorf("expected the parent config without options, got %v", got)
+	}
+
+	withOptions := &SchemaRule{ValidatorConfig: map[string]any{"must_exist": true}}
+	got := withOptions.validatorConfig(parent)
+	if got["must_exist"] != true || got["user"] != "value" {
+		t.Errorf("expected options layered over the parent config, got %v", got)
+	}
+	if _, ok := parent["must_exist"]; ok {
+		t.Error("validatorConfig must not modify the parent config")
+	}
+}
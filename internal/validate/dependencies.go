@@ -0,0 +1,75 @@
+package validate
+
+import (
+	"context"
+	"net/http"
+
+	sbconfig "github.com/saltyorg/sb-go/internal/config"
+	"github.com/saltyorg/sb-go/internal/utils"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/option"
+	"github.com/cloudflare/cloudflare-go/v7/packages/pagination"
+	"github.com/cloudflare/cloudflare-go/v7/user"
+	"github.com/cloudflare/cloudflare-go/v7/zones"
+)
+
+// HTTPDoer sends HTTP requests. It is implemented by *http.Client and allows tests
+// to answer the requests of the networked validators without a network.
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// CloudflareClient is the part of the Cloudflare API used to validate credentials.
+// It allows tests to replace the Cloudflare API with a fake.
+type CloudflareClient interface {
+	// GetUser returns the user the credentials belong to
+	GetUser(ctx context.Context) (*user.UserGetResponse, error)
+	// ListZones returns the zones of the account matching params
+	ListZones(ctx context.Context, params zones.ZoneListParams) (*pagination.V4PagePaginationArray[zones.Zone], error)
+	// GetZoneSetting returns a single setting of a zone
+	GetZoneSetting(ctx context.Context, settingID string, params zones.SettingGetParams) (*zones.SettingGetResponse, error)
+}
+
+// sdkCloudflareClient implements CloudflareClient with the Cloudflare SDK
+type sdkCloudflareClient struct {
+	api *cloudflare.Client
+}
+
+func (c sdkCloudflareClient) GetUser(ctx context.Context) (*user.UserGetResponse, error) {
+	return c.api.User.Get(ctx)
+}
+
+func (c sdkCloudflareClient) ListZones(ctx context.Context, params zones.ZoneListParams) (*pagination.V4PagePaginationArray[zones.Zone], error) {
+	return c.api.Zones.List(ctx, params)
+}
+
+func (c sdkCloudflareClient) GetZoneSetting(ctx context.Context, settingID string, params zones.SettingGetParams) (*zones.SettingGetResponse, error) {
+	return c.api.Zones.Settings.Get(ctx, settingID, params)
+}
+
+// External dependencies of the networked validators, replaced in tests
+var (
+	// newCloudflareClient creates the Cloudflare client for a set of credentials
+	newCloudflareClient = func(apiKey, email string) CloudflareClient {
+		// The SDK's own retries are disabled as they follow Retry-After without a bound;
+		// failed calls are retried with cloudflareRetry instead.
+		return sdkCloudflareClient{api: cloudflare.NewClient(
+			option.WithAPIKey(apiKey),
+			option.WithAPIEmail(email),
+			option.WithHTTPClient(apiHTTPClient),
+			option.WithMaxRetries(0),
+		)}
+	}
+
+	// dockerhubHTTPClient returns the client used for the Docker Hub login request
+	dockerhubHTTPClient = func() HTTPDoer {
+		return apiHTTPClient
+	}
+
+	// lookupSaltboxUser returns the name of the Saltbox user from accounts.yml
+	lookupSaltboxUser = utils.GetSaltboxUser
+
+	// checkRcloneRemote checks that an rclone remote exists in the Saltbox user's rclone config
+	checkRcloneRemote = sbconfig.ValidateRcloneRemote
+)
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/saltyorg/sb-go/internal/config"
 	"github.com/saltyorg/sb-go/internal/constants"
 	"github.com/saltyorg/sb-go/internal/logging"
 	"github.com/saltyorg/sb-go/internal/spinners"
@@ -31,12 +32,42 @@ func AllSaltboxConfigs(
 ) error {
 	// Set verbose mode for both validation and spinners
 	SetVerbose(verbose)
+	SetAPIRequestTimeout(config.SB().APITimeout)
 	return validateAllSaltboxConfigs(ctx, task, verbose)
 }
 
+// SaltboxConfig validates a single Saltbox configuration file, identified by its path.
+// Returns an error if the path isn't a known Saltbox configuration file.
+func SaltboxConfig(
+	ctx context.Context,
+	task *spinners.Task,
+	configPath string,
+	verbose bool,
+) error {
+	SetVerbose(verbose)
+	SetAPIRequestTimeout(config.SB().APITimeout)
+	for _, job := range saltboxConfigJobs() {
+		if job.configPath == configPath {
+			return processValidationJob(ctx, task, job, verbose)
+		}
+	}
+	return fmt.Errorf("no validation defined for %s", configPath)
+}
+
 func validateAllSaltboxConfigs(ctx context.Context, task *spinners.Task, verbose bool) error {
-	// Define all validation jobs
-	jobs := []configValidationJob{
+	// Process each validation job
+	for _, job := range saltboxConfigJobs() {
+		if err := processValidationJob(ctx, task, job, verbose); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// saltboxConfigJobs returns the validation jobs for all Saltbox configuration files
+func saltboxConfigJobs() []configValidationJob {
+	return []configValidationJob{
 		{
 			configPath: constants.SaltboxAccountsConfigPath,
 			schemaPath: "/srv/git/saltbox/schema/accounts.schema.yml",
@@ -68,7 +99,7 @@ func validateAllSaltboxConfigs(ctx context.Context, task *spinners.Task, verbose
 			optional:   false,
 		},
 		{
-			configPath: constants.SaltboxMOTDConfigPath,
+			configPath: config.SB().MOTDConfig,
 			schemaPath: "/srv/git/saltbox/schema/motd.schema.yml",
 			name:       "motd.yml",
 			optional:   true,
@@ -81,15 +112,6 @@ func validateAllSaltboxConfigs(ctx context.Context, task *spinners.Task, verbose
 			duplicatesOnly: true,
 		},
 	}
-
-	// Process each validation job
-	for _, job := range jobs {
-		if err := processValidationJob(ctx, task, job, verbose); err != nil {
-			return err
-		}
-	}
-
-	return nil
 }
 
 // validateDuplicateKeys checks a YAML file for duplicate keys
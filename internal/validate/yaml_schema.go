@@ -3,6 +3,7 @@ package validate
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"reflect"
 	"regexp"
@@ -27,6 +28,7 @@ type SchemaRule struct {
 	RequiredWhenTrue []string               `yaml:"required_when_true"`
 	ValidateWhenTrue []string               `yaml:"validate_when_true"`
 	CustomValidator  string                 `yaml:"custom_validator"`
+	ValidatorConfig  map[string]any         `yaml:"validator_config"` // Options for the field's validators, e.g. must_exist
 	Properties       map[string]*SchemaRule `yaml:"properties"`
 	Items            *SchemaRule            `yaml:"items"` // For array validation
 	// Fields for example config generation
@@ -34,6 +36,18 @@ type SchemaRule struct {
 	Example     any    `yaml:"example"`     // Example value for this field
 }
 
+// validatorConfig returns the config map passed to the rule's validators: the parent
+// config with the rule's validator_config options layered on top
+func (r *SchemaRule) validatorConfig(parentConfig map[string]any) map[string]any {
+	if len(r.ValidatorConfig) == 0 {
+		return parentConfig
+	}
+	config := make(map[string]any, len(parentConfig)+len(r.ValidatorConfig))
+	maps.Copy(config, parentConfig)
+	maps.Copy(config, r.ValidatorConfig)
+	return config
+}
+
 // Schema holds validation rules
 type Schema struct {
 	Rules map[string]*SchemaRule
@@ -41,11 +55,19 @@ type Schema struct {
 
 var verboseMode bool
 
+// offlineMode skips the validators that need network access
+var offlineMode bool
+
 // SetVerbose sets verbose mode for debugging
 func SetVerbose(v bool) {
 	verboseMode = v
 }
 
+// SetOffline skips the API credential checks and DNS lookups, keeping only the structure checks
+func SetOffline(offline bool) {
+	offlineMode = offline
+}
+
 // LoadSchema loads a YAML schema file
 func LoadSchema(schemaPath string) (*Schema, error) {
 	logging.DebugBool(verboseMode, "LoadSchema called with path: %s", schemaPath)
@@ -249,6 +271,7 @@ func (s *Schema) validateFieldWithTypeFlexibility(value any, rule *SchemaRule, p
 		"cron_time":       "validate_cron_time",
 		"rclone_template": "validate_rclone_template",
 		"ssh_key_or_url":  "validate_ssh_key_or_url",
+		"authorized_keys": "validate_authorized_keys",
 		"password":        "validate_password_strength",
 	}
 
@@ -268,7 +291,7 @@ func (s *Schema) validateFieldWithTypeFlexibility(value any, rule *SchemaRule, p
 	if validatorName, isBuiltIn := builtInValidators[rule.Type]; isBuiltIn {
 		logging.DebugBool(verboseMode, "Running built-in %s validator for field '%s'", rule.Type, path)
 		if validator, exists := customValidators[validatorName]; exists {
-			if err := validator(value, parentConfig); err != nil {
+			if err := validator(value, rule.validatorConfig(parentConfig)); err != nil {
 				return fmt.Errorf("field '%s': %w", path, err)
 			}
 		}
@@ -278,13 +301,13 @@ func (s *Schema) validateFieldWithTypeFlexibility(value any, rule *SchemaRule, p
 	if rule.CustomValidator != "" {
 		logging.DebugBool(verboseMode, "Running custom validator '%s' for field '%s'", rule.CustomValidator, path)
 
-		// Check if this is an async API validator
-		if asyncValidator, isAsync := asyncAPIValidators[rule.CustomValidator]; isAsync && asyncCtx != nil {
+		// Check if this is an async API validator; offline only the synchronous structure check runs
+		if asyncValidator, isAsync := asyncAPIValidators[rule.CustomValidator]; isAsync && asyncCtx != nil && !offlineMode {
 			logging.DebugBool(verboseMode, "Adding async API validator '%s' for field '%s'", rule.CustomValidator, path)
 			asyncCtx.AddAPIValidation(path, asyncValidator, value, parentConfig)
 		} else if validator, exists := customValidators[rule.CustomValidator]; exists {
 			// Run synchronous validator
-			if err := validator(value, parentConfig); err != nil {
+			if err := validator(value, rule.validatorConfig(parentConfig)); err != nil {
 				return fmt.Errorf("field '%s': %w", path, err)
 			}
 		} else {
@@ -360,7 +383,7 @@ func (s *Schema) validateField(value any, rule *SchemaRule, path string, parentC
 		} else {
 			logging.DebugBool(verboseMode, "Running built-in subdomain validator for field '%s'", path)
 			if validator, exists := customValidators["validate_subdomain"]; exists {
-				if err := validator(value, parentConfig); err != nil {
+				if err := validator(value, rule.validatorConfig(parentConfig)); err != nil {
 					return fmt.Errorf("field '%s': %w", path, err)
 				}
 			}
@@ -371,7 +394,7 @@ func (s *Schema) validateField(value any, rule *SchemaRule, path string, parentC
 		} else {
 			logging.DebugBool(verboseMode, "Running built-in timezone validator for field '%s'", path)
 			if validator, exists := customValidators["validate_timezone"]; exists {
-				if err := validator(value, parentConfig); err != nil {
+				if err := validator(value, rule.validatorConfig(parentConfig)); err != nil {
 					return fmt.Errorf("field '%s': %w", path, err)
 				}
 			}
@@ -385,6 +408,7 @@ func (s *Schema) validateField(value any, rule *SchemaRule, path string, parentC
 			"cron_time":       "validate_cron_time",
 			"rclone_template": "validate_rclone_template",
 			"ssh_key_or_url":  "validate_ssh_key_or_url",
+			"authorized_keys": "validate_authorized_keys",
 			"password":        "validate_password_strength",
 		}
 
@@ -394,7 +418,7 @@ func (s *Schema) validateField(value any, rule *SchemaRule, path string, parentC
 			} else {
 				logging.DebugBool(verboseMode, "Running built-in %s validator for field '%s'", rule.Type, path)
 				if validator, exists := customValidators[validatorName]; exists {
-					if err := validator(value, parentConfig); err != nil {
+					if err := validator(value, rule.validatorConfig(parentConfig)); err != nil {
 						return fmt.Errorf("field '%s': %w", path, err)
 					}
 				}
@@ -406,7 +430,7 @@ func (s *Schema) validateField(value any, rule *SchemaRule, path string, parentC
 	if rule.CustomValidator != "" {
 		logging.DebugBool(verboseMode, "Running custom validator '%s' for field '%s'", rule.CustomValidator, path)
 		if validator, exists := customValidators[rule.CustomValidator]; exists {
-			if err := validator(value, parentConfig); err != nil {
+			if err := validator(value, rule.validatorConfig(parentConfig)); err != nil {
 				return fmt.Errorf("field '%s': %w", path, err)
 			}
 		} else {
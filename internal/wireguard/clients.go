@@ -0,0 +1,135 @@
+package wireguard
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// clientNamePattern restricts client names to characters that are safe in file names
+var clientNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// Client is a client peer allocated on an interface
+type Client struct {
+	Name      string     `json:"name"`
+	Address   netip.Addr `json:"address"`
+	PublicKey string     `json:"public_key"`
+}
+
+// State is the allocation ledger of an interface, stored next to its config.
+// It records the server settings so clients can be added without repeating them.
+type State struct {
+	Subnet       netip.Prefix `json:"subnet"`
+	Port         int          `json:"port"`
+	Endpoint     string       `json:"endpoint,omitempty"`
+	WANInterface string       `json:"wan_interface"`
+	Clients      []Client     `json:"clients"`
+}
+
+// StatePath returns the path of the allocation ledger of an interface
+func StatePath(iface string) string {
+	return filepath.Join(ConfigDir, iface+".json")
+}
+
+// LoadState reads the ledger at path.
+// Returns an error wrapping os.ErrNotExist if the server hasn't been deployed yet.
+func LoadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// Save writes the ledger to path with owner-only permissions
+func (s *State) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	_, err = WriteConfig(path, string(data)+"\n")
+	return err
+}
+
+// Client returns the client with the given name
+func (s *State) Client(name string) (Client, bool) {
+	i := slices.IndexFunc(s.Clients, func(c Client) bool { return c.Name == name })
+	if i < 0 {
+		return Client{}, false
+	}
+	return s.Clients[i], true
+}
+
+// AddClient allocates the next free address in the subnet to a new client
+func (s *State) AddClient(name, publicKey string) (Client, error) {
+	if _, exists := s.Client(name); exists {
+		return Client{}, fmt.Errorf("client %q already exists", name)
+	}
+
+	address, err := NextFreeAddress(s.Subnet, s.Clients)
+	if err != nil {
+		return Client{}, err
+	}
+
+	client := Client{Name: name, Address: address, PublicKey: publicKey}
+	s.Clients = append(s.Clients, client)
+	return client, nil
+}
+
+// RemoveClient removes a client, freeing its address.
+// Returns false if no client has the given name.
+func (s *State) RemoveClient(name string) bool {
+	before := len(s.Clients)
+	s.Clients = slices.DeleteFunc(s.Clients, func(c Client) bool { return c.Name == name })
+	return len(s.Clients) != before
+}
+
+// NextFreeAddress returns the lowest host address in the subnet not used by the server or a client
+func NextFreeAddress(subnet netip.Prefix, clients []Client) (netip.Addr, error) {
+	for addr := ServerAddress(subnet).Next(); subnet.Contains(addr.Next()); addr = addr.Next() {
+		if !slices.ContainsFunc(clients, func(c Client) bool { return c.Address == addr }) {
+			return addr, nil
+		}
+	}
+	return netip.Addr{}, fmt.Errorf("no free addresses left in %s", subnet)
+}
+
+// ValidateClientName checks that a client name is usable as a file name
+func ValidateClientName(name string) error {
+	if !clientNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid client name %q: use up to 32 letters, digits, '-' or '_'", name)
+	}
+	return nil
+}
+
+// RemoveKeyPair deletes the key pair stored as <name>.key and <name>.pub in dir
+func RemoveKeyPair(dir, name string) error {
+	for _, path := range []string{filepath.Join(dir, name+".key"), filepath.Join(dir, name+".pub")} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// RenderQRCode renders content as a QR code for the terminal, so mobile clients can import a config
+// by scanning it. Two rows of modules share a line using half block characters.
+func RenderQRCode(content string) (string, error) {
+	code, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return "", fmt.Errorf("failed to render QR code: %w", err)
+	}
+	return code.ToSmallString(false), nil
+}
@@ -0,0 +1,141 @@
+package wireguard
+
+import (
+	"errors"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestStateAddClient(t *testing.T) {
+	state := &State{Subnet: netip.MustParsePrefix("10.8.0.0/29")}
+
+	want := []string{"10.8.0.2", "10.8.0.3", "10.8.0.4", "10.8.0.5", "10.8.0.6"}
+	for i, address := range want {
+		client, err := state.AddClient(string(rune('a'+i)), "key")
+		if err != nil {
+			t.Fatalf("AddClient #%d unexpected error: %v", i, err)
+		}
+		if client.Address.String() != address {
+			t.Errorf("AddClient #%d address = %s, want %s", i, client.Address, address)
+		}
+	}
+
+	if _, err := state.AddClient("full", "key"); err == nil {
+		t.Error("expected error when the subnet is exhausted")
+	}
+	if _, err := state.AddClient("a", "key"); err == nil {
+		t.Error("expected error for duplicate client name")
+	}
+}
+
+func TestStateRemoveClientFreesAddress(t *testing.T) {
+	state := &State{Subnet: netip.MustParsePrefix("10.8.0.0/24")}
+	for _, name := range []string{"phone", "laptop", "tablet"} {
+		if _, err := state.AddClient(name, name+"-key"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !state.RemoveClient("laptop") {
+		t.Fatal("RemoveClient(laptop) = false, want true")
+	}
+	if state.RemoveClient("laptop") {
+		t.Error("RemoveClient of a removed client = true, want false")
+	}
+
+	client, err := state.AddClient("desktop", "desktop-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.Address.String() != "10.8.0.3" {
+		t.Errorf("freed address not reused: got %s, want 10.8.0.3", client.Address)
+	}
+}
+
+func TestStateSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wg0.json")
+	state := &State{
+		Subnet:       netip.MustParsePrefix("10.8.0.0/24"),
+		Port:         51820,
+		Endpoint:     "vpn.example.com",
+		WANInterface: "eth0",
+	}
+	if _, err := state.AddClient("phone", "phone-key"); err != nil {
+		t.Fatal(err)
+	}
+	if err := state.Save(path); err != nil {
+		t.Fatalf("Save unexpected error: %v", err)
+	}
+
+	loaded, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState unexpected error: %v", err)
+	}
+	if loaded.Subnet != state.Subnet || loaded.Port != state.Port || loaded.Endpoint != state.Endpoint || loaded.WANInterface != state.WANInterface {
+		t.Errorf("LoadState = %+v, want %+v", loaded, state)
+	}
+	if client, ok := loaded.Client("phone"); !ok || client != state.Clients[0] {
+		t.Errorf("loaded client = %+v, want %+v", client, state.Clients[0])
+	}
+
+	if _, err := LoadState(filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("LoadState of missing file error = %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestValidateClientName(t *testing.T) {
+	for _, name := range []string{"phone", "laptop-1", "my_tablet"} {
+		if err := ValidateClientName(name); err != nil {
+			t.Errorf("ValidateClientName(%q) unexpected error: %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "../etc", "my phone", "a/b"} {
+		if err := ValidateClientName(name); err == nil {
+			t.Errorf("ValidateClientName(%q) expected error", name)
+		}
+	}
+}
+
+func TestRemoveKeyPair(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{"phone.key", "phone.pub"} {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte("key\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := RemoveKeyPair(dir, "phone"); err != nil {
+		t.Fatalf("RemoveKeyPair unexpected error: %v", err)
+	}
+	if _, err := LoadKeyPair(dir, "phone"); err == nil {
+		t.Error("keys still present after RemoveKeyPair")
+	}
+	if err := RemoveKeyPair(dir, "phone"); err != nil {
+		t.Errorf("RemoveKeyPair of missing keys unexpected error: %v", err)
+	}
+}
+
+func TestRenderQRCode(t *testing.T) {
+	code, err := RenderQRCode("[Interface]\nPrivateKey = abc\n")
+	if err != nil {
+		t.Fatalf("RenderQRCode() unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(code, "\n"), "\n")
+	width := utf8.RuneCountInString(lines[0])
+	// Two rows of modules per line, so about half as many lines as columns
+	if len(lines) < width/2 || len(lines) > width/2+1 {
+		t.Errorf("got %d lines of %d columns, want about half as many lines", len(lines), width)
+	}
+	for _, line := range lines {
+		if utf8.RuneCountInString(line) != width {
+			t.Fatalf("line %q has a different width than %d", line, width)
+		}
+		if strings.Trim(line, " █▀▄") != "" {
+			t.Fatalf("line %q contains characters other than half blocks", line)
+		}
+	}
+}
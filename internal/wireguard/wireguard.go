@@ -0,0 +1,289 @@
+package wireguard
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/saltyorg/sb-go/internal/executor"
+	"github.com/saltyorg/sb-go/internal/utils"
+)
+
+const (
+	// ConfigDir is where wg-quick looks for interface configs
+	ConfigDir = "/etc/wireguard"
+	// SysctlConfigPath persists IP forwarding across reboots
+	SysctlConfigPath = "/etc/sysctl.d/99-saltbox-wireguard.conf"
+	// DefaultInterface is the default WireGuard interface name
+	DefaultInterface = "wg0"
+	// DefaultSubnet is the default tunnel subnet
+	DefaultSubnet = "10.8.0.0/24"
+	// DefaultPort is the default UDP listen port
+	DefaultPort = 51820
+)
+
+// KeyPair is a WireGuard private and public key
+type KeyPair struct {
+	PrivateKey string
+	PublicKey  string
+}
+
+// ServerConfig describes the server side of a tunnel and its client peers
+type ServerConfig struct {
+	Interface    string // WireGuard interface name (e.g., "wg0")
+	Subnet       netip.Prefix
+	Port         int
+	WANInterface string // Interface used for NAT of tunnel traffic
+	Server       KeyPair
+	Peers        []Client
+}
+
+// ConfigPath returns the path of the wg-quick config of an interface
+func ConfigPath(iface string) string {
+	return filepath.Join(ConfigDir, iface+".conf")
+}
+
+// ClientKeyDir returns the directory holding the client key pairs of an interface
+func ClientKeyDir(iface string) string {
+	return filepath.Join(ConfigDir, iface+"-clients")
+}
+
+// generateKeyPair creates a new key pair, replaceable in tests
+var generateKeyPair = GenerateKeyPair
+
+// GenerateKeyPair creates a new key pair using wg genkey and wg pubkey
+func GenerateKeyPair(ctx context.Context) (KeyPair, error) {
+	result, err := executor.Run(ctx, "wg",
+		executor.WithArgs("genkey"),
+		executor.WithOutputMode(executor.OutputModeCapture))
+	if err != nil {
+		return KeyPair{}, fmt.Errorf("failed to generate private key: %w", err)
+	}
+	privateKey := strings.TrimSpace(string(result.Stdout))
+
+	result, err = executor.Run(ctx, "wg",
+		executor.WithArgs("pubkey"),
+		executor.WithStdin(strings.NewReader(privateKey+"\n")),
+		executor.WithOutputMode(executor.OutputModeCapture))
+	if err != nil {
+		return KeyPair{}, fmt.Errorf("failed to derive public key: %w", err)
+	}
+
+	return KeyPair{PrivateKey: privateKey, PublicKey: strings.TrimSpace(string(result.Stdout))}, nil
+}
+
+// LoadKeyPair loads the key pair stored as <name>.key and <name>.pub in dir
+func LoadKeyPair(dir, name string) (KeyPair, error) {
+	privateKey, err := os.ReadFile(filepath.Join(dir, name+".key"))
+	if err != nil {
+		return KeyPair{}, fmt.Errorf("failed to read private key: %w", err)
+	}
+	publicKey, err := os.ReadFile(filepath.Join(dir, name+".pub"))
+	if err != nil {
+		return KeyPair{}, fmt.Errorf("failed to read public key: %w", err)
+	}
+	return KeyPair{
+		PrivateKey: strings.TrimSpace(string(privateKey)),
+		PublicKey:  strings.TrimSpace(string(publicKey)),
+	}, nil
+}
+
+// EnsureKeyPair loads the key pair stored as <name>.key and <name>.pub in dir, generating and
+// storing a new one if either file is missing or force is set.
+// Returns whether a new key pair was generated.
+func EnsureKeyPair(ctx context.Context, dir, name string, force bool) (KeyPair, bool, error) {
+	privatePath := filepath.Join(dir, name+".key")
+	publicPath := filepath.Join(dir, name+".pub")
+
+	if !force {
+		if keys, err := LoadKeyPair(dir, name); err == nil {
+			return keys, false, nil
+		}
+	}
+
+	keys, err := generateKeyPair(ctx)
+	if err != nil {
+		return KeyPair{}, false, err
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return KeyPair{}, false, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	if err := os.WriteFile(privatePath, []byte(keys.PrivateKey+"\n"), 0600); err != nil {
+		return KeyPair{}, false, fmt.Errorf("failed to write %s: %w", privatePath, err)
+	}
+	if err := os.WriteFile(publicPath, []byte(keys.PublicKey+"\n"), 0600); err != nil {
+		return KeyPair{}, false, fmt.Errorf("failed to write %s: %w", publicPath, err)
+	}
+
+	return keys, true, nil
+}
+
+// ParseSubnet parses an IPv4 tunnel subnet with room for at least the server and one client
+func ParseSubnet(subnet string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(subnet)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid subnet %q: %w", subnet, err)
+	}
+	if !prefix.Addr().Is4() {
+		return netip.Prefix{}, fmt.Errorf("invalid subnet %q: only IPv4 subnets are supported", subnet)
+	}
+	if prefix.Bits() > 30 {
+		return netip.Prefix{}, fmt.Errorf("invalid subnet %q: prefix must be /30 or larger", subnet)
+	}
+	return prefix.Masked(), nil
+}
+
+// ServerAddress returns the tunnel address of the server, the first host of the subnet
+func ServerAddress(subnet netip.Prefix) netip.Addr {
+	return subnet.Masked().Addr().Next()
+}
+
+// RenderServerConfig renders the wg-quick config of the server.
+// Tunnel traffic is forwarded and masqueraded through the WAN interface.
+func RenderServerConfig(cfg ServerConfig) string {
+	var b strings.Builder
+	forward := fmt.Sprintf("iptables -%%s FORWARD -i %%%%i -j ACCEPT; iptables -%%s FORWARD -o %%%%i -j ACCEPT; iptables -t nat -%%s POSTROUTING -o %s -j MASQUERADE", cfg.WANInterface)
+
+	b.WriteString("# Managed by sb wireguard server\n")
+	b.WriteString("[Interface]\n")
+	fmt.Fprintf(&b, "Address = %s/%d\n", ServerAddress(cfg.Subnet), cfg.Subnet.Bits())
+	fmt.Fprintf(&b, "ListenPort = %d\n", cfg.Port)
+	fmt.Fprintf(&b, "PrivateKey = %s\n", cfg.Server.PrivateKey)
+	fmt.Fprintf(&b, "PostUp = %s\n", fmt.Sprintf(forward, "A", "A", "A"))
+	fmt.Fprintf(&b, "PostDown = %s\n", fmt.Sprintf(forward, "D", "D", "D"))
+	for _, peer := range cfg.Peers {
+		b.WriteString("\n[Peer]\n")
+		fmt.Fprintf(&b, "# %s\n", peer.Name)
+		fmt.Fprintf(&b, "PublicKey = %s\n", peer.PublicKey)
+		fmt.Fprintf(&b, "AllowedIPs = %s/32\n", peer.Address)
+	}
+
+	return b.String()
+}
+
+// RenderClientConfig renders a wg-quick config for a client at address connecting to endpoint (host or IP).
+// The keepalive keeps the tunnel open from behind CGNAT.
+func RenderClientConfig(cfg ServerConfig, client KeyPair, address netip.Addr, endpoint string) string {
+	var b strings.Builder
+
+	b.WriteString("[Interface]\n")
+	fmt.Fprintf(&b, "PrivateKey = %s\n", client.PrivateKey)
+	fmt.Fprintf(&b, "Address = %s/32\n", address)
+	b.WriteString("\n[Peer]\n")
+	fmt.Fprintf(&b, "PublicKey = %s\n", cfg.Server.PublicKey)
+	fmt.Fprintf(&b, "Endpoint = %s\n", endpointWithPort(endpoint, cfg.Port))
+	fmt.Fprintf(&b, "AllowedIPs = %s/32\n", ServerAddress(cfg.Subnet))
+	b.WriteString("PersistentKeepalive = 25\n")
+
+	return b.String()
+}
+
+// endpointWithPort joins an endpoint host with the listen port, bracketing IPv6 addresses
+func endpointWithPort(host string, port int) string {
+	if addr, err := netip.ParseAddr(host); err == nil && addr.Is6() {
+		return "[" + host + "]:" + strconv.Itoa(port)
+	}
+	return host + ":" + strconv.Itoa(port)
+}
+
+// WriteConfig writes content to path with owner-only permissions.
+// Returns whether the file changed; an identical existing file is left untouched.
+func WriteConfig(path, content string) (bool, error) {
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, []byte(content)) {
+		return false, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return false, fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// EnableIPForwarding enables IPv4 forwarding now and persists it across reboots
+func EnableIPForwarding(ctx context.Context) error {
+	if err := os.WriteFile(SysctlConfigPath, []byte("net.ipv4.ip_forward = 1\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", SysctlConfigPath, err)
+	}
+
+	result, err := executor.Run(ctx, "sysctl",
+		executor.WithArgs("-w", "net.ipv4.ip_forward=1"),
+		executor.WithOutputMode(executor.OutputModeCapture))
+	if err != nil {
+		return result.FormatError("enable IPv4 forwarding")
+	}
+	return nil
+}
+
+// IsInterfaceUp reports whether the network interface exists
+func IsInterfaceUp(name string) bool {
+	_, err := os.Stat(filepath.Join("/sys/class/net", name))
+	return err == nil
+}
+
+// BringUp brings the interface up with wg-quick, restarting it first when restart is set
+// (e.g., after the config changed), and enables the wg-quick service so it comes up at boot.
+func BringUp(ctx context.Context, name string, restart bool, verbose bool) error {
+	if IsInterfaceUp(name) {
+		if !restart {
+			return enableService(ctx, name, verbose)
+		}
+		if err := executor.RunVerbose(ctx, "wg-quick", []string{"down", name}, verbose); err != nil {
+			return fmt.Errorf("failed to bring down %s: %w", name, err)
+		}
+	}
+
+	if err := executor.RunVerbose(ctx, "wg-quick", []string{"up", name}, verbose); err != nil {
+		return fmt.Errorf("failed to bring up %s: %w", name, err)
+	}
+	return enableService(ctx, name, verbose)
+}
+
+// Reload applies the config of a running interface without disrupting connected peers,
+// bringing the interface up instead when it isn't running.
+func Reload(ctx context.Context, name string, verbose bool) error {
+	if !IsInterfaceUp(name) {
+		return BringUp(ctx, name, false, verbose)
+	}
+
+	result, err := executor.Run(ctx, "wg-quick",
+		executor.WithArgs("strip", name),
+		executor.WithOutputMode(executor.OutputModeCapture))
+	if err != nil {
+		return result.FormatError(fmt.Sprintf("read config of %s", name))
+	}
+
+	if err := executor.RunVerbose(ctx, "wg", []string{"syncconf", name, "/dev/stdin"}, verbose,
+		executor.WithStdin(bytes.NewReader(result.Stdout))); err != nil {
+		return fmt.Errorf("failed to reload %s: %w", name, err)
+	}
+	return nil
+}
+
+// enableService enables the wg-quick systemd service of the interface at boot
+func enableService(ctx context.Context, name string, verbose bool) error {
+	if err := executor.RunVerbose(ctx, "systemctl", []string{"enable", "wg-quick@" + name}, verbose); err != nil {
+		return fmt.Errorf("failed to enable wg-quick@%s: %w", name, err)
+	}
+	return nil
+}
+
+// DefaultRouteInterface returns the interface of the IPv4 default route with the lowest metric
+func DefaultRouteInterface() (string, error) {
+	data, err := os.ReadFile("/proc/net/route")
+	if err != nil {
+		return "", fmt.Errorf("failed to read routing table: %w", err)
+	}
+	if iface := utils.ParseDefaultRouteInterface(string(data)); iface != "" {
+		return iface, nil
+	}
+	return "", fmt.Errorf("no default route found")
+}
@@ -0,0 +1,181 @@
+package wireguard
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseSubnet(t *testing.T) {
+	tests := []struct {
+		name    string
+		subnet  string
+		want    string
+		wantErr bool
+	}{
+		{name: "default", subnet: "10.8.0.0/24", want: "10.8.0.0/24"},
+		{name: "host bits masked", subnet: "10.8.0.5/24", want: "10.8.0.0/24"},
+		{name: "smallest", subnet: "192.168.9.0/30", want: "192.168.9.0/30"},
+		{name: "too small", subnet: "10.8.0.0/31", wantErr: true},
+		{name: "ipv6", subnet: "fd00::/64", wantErr: true},
+		{name: "invalid", subnet: "10.8.0.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSubnet(tt.subnet)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseSubnet(%q) expected error, got %s", tt.subnet, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSubnet(%q) unexpected error: %v", tt.subnet, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("ParseSubnet(%q) = %s, want %s", tt.subnet, got, tt.want)
+			}
+		})
+	}
+}
+
+func testServerConfig(t *testing.T) ServerConfig {
+	t.Helper()
+	subnet, err := ParseSubnet("10.8.0.0/24")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ServerConfig{
+		Interface:    "wg0",
+		Subnet:       subnet,
+		Port:         51820,
+		WANInterface: "eth0",
+		Server:       KeyPair{PrivateKey: "server-private", PublicKey: "server-public"},
+		Peers: []Client{
+			{Name: "phone", Address: netip.MustParseAddr("10.8.0.2"), PublicKey: "phone-public"},
+			{Name: "laptop", Address: netip.MustParseAddr("10.8.0.3"), PublicKey: "laptop-public"},
+		},
+	}
+}
+
+func TestRenderServerConfig(t *testing.T) {
+	got := RenderServerConfig(testServerConfig(t))
+
+	for _, want := range []string{
+		"Address = 10.8.0.1/24\n",
+		"ListenPort = 51820\n",
+		"PrivateKey = server-private\n",
+		"PostUp = iptables -A FORWARD -i %i -j ACCEPT; iptables -A FORWARD -o %i -j ACCEPT; iptables -t nat -A POSTROUTING -o eth0 -j MASQUERADE\n",
+		"PostDown = iptables -D FORWARD -i %i -j ACCEPT; iptables -D FORWARD -o %i -j ACCEPT; iptables -t nat -D POSTROUTING -o eth0 -j MASQUERADE\n",
+		"[Peer]\n# phone\nPublicKey = phone-public\nAllowedIPs = 10.8.0.2/32\n",
+		"[Peer]\n# laptop\nPublicKey = laptop-public\nAllowedIPs = 10.8.0.3/32\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("server config missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderClientConfig(t *testing.T) {
+	cfg := testServerConfig(t)
+	client := KeyPair{PrivateKey: "client-private", PublicKey: "client-public"}
+	address := netip.MustParseAddr("10.8.0.2")
+
+	got := RenderClientConfig(cfg, client, address, "vpn.example.com")
+	for _, want := range []string{
+		"PrivateKey = client-private\n",
+		"Address = 10.8.0.2/32\n",
+		"PublicKey = server-public\n",
+		"Endpoint = vpn.example.com:51820\n",
+		"AllowedIPs = 10.8.0.1/32\n",
+		"PersistentKeepalive = 25\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("client config missing %q:\n%s", want, got)
+		}
+	}
+
+	if got := RenderClientConfig(cfg, client, address, "2001:db8::1"); !strings.Contains(got, "Endpoint = [2001:db8::1]:51820\n") {
+		t.Errorf("IPv6 endpoint not bracketed:\n%s", got)
+	}
+}
+
+func TestEnsureKeyPair(t *testing.T) {
+	generated := 0
+	original := generateKeyPair
+	generateKeyPair = func(context.Context) (KeyPair, error) {
+		generated++
+		return KeyPair{PrivateKey: "private" + string(rune('0'+generated)), PublicKey: "public" + string(rune('0'+generated))}, nil
+	}
+	t.Cleanup(func() { generateKeyPair = original })
+
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	first, created, err := EnsureKeyPair(ctx, dir, "wg0-server", false)
+	if err != nil || !created {
+		t.Fatalf("first EnsureKeyPair: created=%v err=%v", created, err)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, "wg0-server.key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("private key permissions = %o, want 600", perm)
+	}
+
+	second, created, err := EnsureKeyPair(ctx, dir, "wg0-server", false)
+	if err != nil || created {
+		t.Fatalf("second EnsureKeyPair: created=%v err=%v", created, err)
+	}
+	if second != first {
+		t.Errorf("existing keys not reused: got %+v, want %+v", second, first)
+	}
+
+	forced, created, err := EnsureKeyPair(ctx, dir, "wg0-server", true)
+	if err != nil || !created {
+		t.Fatalf("forced EnsureKeyPair: created=%v err=%v", created, err)
+	}
+	if forced == first {
+		t.Error("force did not regenerate keys")
+	}
+}
+
+func TestEnsureKeyPairGenerateError(t *testing.T) {
+	original := generateKeyPair
+	generateKeyPair = func(context.Context) (KeyPair, error) {
+		return KeyPair{}, errors.New("wg not found")
+	}
+	t.Cleanup(func() { generateKeyPair = original })
+
+	dir := t.TempDir()
+	if _, _, err := EnsureKeyPair(context.Background(), dir, "wg0-client", false); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "wg0-client.key")); !os.IsNotExist(err) {
+		t.Errorf("key file written after failed generation: %v", err)
+	}
+}
+
+func TestWriteConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wireguard", "wg0.conf")
+
+	changed, err := WriteConfig(path, "a")
+	if err != nil || !changed {
+		t.Fatalf("first write: changed=%v err=%v", changed, err)
+	}
+	changed, err = WriteConfig(path, "a")
+	if err != nil || changed {
+		t.Fatalf("identical write: changed=%v err=%v", changed, err)
+	}
+	changed, err = WriteConfig(path, "b")
+	if err != nil || !changed {
+		t.Fatalf("updated write: changed=%v err=%v", changed, err)
+	}
+}
@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/saltyorg/sb-go/cmd"
+	"github.com/saltyorg/sb-go/internal/config"
+	sbErrors "github.com/saltyorg/sb-go/internal/errors"
 	"github.com/saltyorg/sb-go/internal/signals"
 	"github.com/saltyorg/sb-go/internal/ubuntu"
 	"github.com/saltyorg/sb-go/internal/utils"
@@ -22,6 +25,11 @@ import (
 // Unlike the default handler, this respects \n characters in error messages
 // and renders each line separately for better readability.
 func customErrorHandler(w io.Writer, styles fang.Styles, err error) {
+	// The command already printed its outcome
+	if errors.Is(err, sbErrors.ErrSilent) {
+		return
+	}
+
 	if errors.Is(err, context.Canceled) || strings.Contains(err.Error(), "signal: interrupt") {
 		err = fmt.Errorf("interrupted by user (Ctrl+C)")
 	}
@@ -54,8 +62,27 @@ func customErrorHandler(w io.Writer, styles fang.Styles, err error) {
 	}
 }
 
+// relaunchArgs returns the arguments to relaunch sb with as root. sudo resets the environment,
+// so SB_ALLOW_UNSUPPORTED, SB_CONFIG and the sb config overrides are carried over as flags.
+func relaunchArgs(args []string, allowUnsupported bool, getenv func(string) string) []string {
+	var flags []string
+	if allowUnsupported {
+		flags = append(flags, "--"+ubuntu.AllowUnsupportedFlag)
+	}
+	if configPath := getenv(config.SBConfigEnv); configPath != "" {
+		flags = append(flags, "--config="+configPath)
+	}
+	for _, name := range config.EnvOverrideNames() {
+		if value := getenv(name); value != "" {
+			flags = append(flags, "--env="+name+"="+value)
+		}
+	}
+	return slices.Insert(slices.Clone(args), 1, flags...)
+}
+
 func main() {
 	if os.Geteuid() != 0 {
+		os.Args = relaunchArgs(os.Args, ubuntu.AllowUnsupported(nil), os.Getenv)
 		// Relaunch as root with sudo
 		exitCode, err := utils.RelaunchAsRoot()
 		if err != nil {
@@ -68,8 +95,13 @@ func main() {
 	supportedVersions := []string{"20.04", "22.04", "24.04"}
 
 	if err := ubuntu.CheckSupport(supportedVersions); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		// Users of newer or derivative releases can opt in to continuing at their own risk
+		if !ubuntu.AllowUnsupported(os.Args[1:]) {
+			fmt.Println(err)
+			fmt.Printf("Use --%s or %s=1 to continue anyway.\n", ubuntu.AllowUnsupportedFlag, ubuntu.AllowUnsupportedEnv)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %v. Continuing because unsupported releases are allowed.\n", err)
 	}
 
 	// Force truecolor for consistent styling across all commands (process-local only)
@@ -109,11 +141,18 @@ func main() {
 
 	// Execute commands with fang for enhanced CLI UX
 	// Fang provides styled help, formatted errors, and improved presentation
-	if err := fang.Execute(ctx, cmd.GetRootCommand(),
+	err := fang.Execute(ctx, cmd.GetRootCommand(),
 		fang.WithErrorHandler(customErrorHandler),
 		fang.WithoutVersion(), // We have a dedicated 'version' command
-	); err != nil {
-		os.Exit(1)
+	)
+	cmd.ReportResourceUsage(os.Stderr)
+	if err != nil {
+		// Prefer the signal manager's exit code when interrupted (e.g., 130 for ctrl+c)
+		if sigManager.IsShutdown() {
+			os.Exit(sigManager.ExitCode())
+		}
+		// Surface exit codes carried by the error (e.g., from a failed playbook), defaulting to 1
+		os.Exit(sbErrors.ExitCode(err))
 	}
 
 	// Exit with appropriate code if shutdown was triggered
@@ -1,13 +1,20 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"slices"
 	"testing"
 	"time"
 
+	"github.com/saltyorg/sb-go/internal/config"
+	sbErrors "github.com/saltyorg/sb-go/internal/errors"
 	"github.com/saltyorg/sb-go/internal/signals"
+
+	"charm.land/fang/v2"
 )
 
 func TestMainPackageStructure(t *testing.T) {
@@ -373,3 +380,63 @@ func TestRootCheckLogic(t *testing.T) {
 		_ = euid
 	})
 }
+
+// TestCustomErrorHandlerSilent tests that silent errors only set the exit code
+func TestCustomErrorHandlerSilent(t *testing.T) {
+	var out bytes.Buffer
+	err := sbErrors.WithExitCode(fmt.Errorf("%w: 2 issues found", sbErrors.ErrSilent), 1)
+	customErrorHandler(&out, fang.Styles{}, err)
+	if out.Len() != 0 {
+		t.Errorf("silent error printed %q", out.String())
+	}
+	if code := sbErrors.ExitCode(err); code != 1 {
+		t.Errorf("ExitCode() = %d, want 1", code)
+	}
+
+	customErrorHandler(&out, fang.Styles{}, errors.New("failed"))
+	if out.Len() == 0 {
+		t.Error("expected other errors to be printed")
+	}
+}
+
+// TestRelaunchArgs tests that settings in the environment survive the sudo relaunch
+func TestRelaunchArgs(t *testing.T) {
+	env := map[string]string{
+		"SB_CONFIG":           "/home/user/sb.yml",
+		"SB_MOTD_CONFIG":      "/home/user/motd.yml",
+		"SB_FOLLOW_INTERVAL":  "1s",
+		"SB_API_TIMEOUT":      "30s",
+		"SB_APT_LOCK_TIMEOUT": "1m",
+		"HOME":                "/home/user",
+	}
+	args := []string{"sb", "logs", "--dump"}
+
+	got := relaunchArgs(args, true, func(name string) string { return env[name] })
+	want := []string{
+		"sb",
+		"--allow-unsupported",
+		"--config=/home/user/sb.yml",
+		"--env=SB_API_TIMEOUT=30s",
+		"--env=SB_APT_LOCK_TIMEOUT=1m",
+		"--env=SB_FOLLOW_INTERVAL=1s",
+		"--env=SB_MOTD_CONFIG=/home/user/motd.yml",
+		"logs", "--dump",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("relaunchArgs() = %q, want %q", got, want)
+	}
+	if !slices.Equal(args, []string{"sb", "logs", "--dump"}) {
+		t.Errorf("relaunchArgs() modified its arguments: %q", args)
+	}
+
+	// Every override must be forwarded, including ones added later
+	for _, name := range config.EnvOverrideNames() {
+		if !slices.Contains(got, "--env="+name+"="+env[name]) {
+			t.Errorf("override %s is not forwarded", name)
+		}
+	}
+
+	if got := relaunchArgs(args, false, func(string) string { return "" }); !slices.Equal(got, args) {
+		t.Errorf("relaunchArgs() without settings = %q, want %q", got, args)
+	}
+}